@@ -1,12 +1,17 @@
 #!/bin/bash
 
-# Build the WebAssembly binary
-GOOS=js GOARCH=wasm go build -o web/wasm/game.wasm cmd/game/main.go
+# Build the WebAssembly binary; extra arguments such as -tags debug go to go build
+GOOS=js GOARCH=wasm go build "$@" -o web/wasm/game.wasm cmd/game/main.go
 
 # Copy the wasm_exec.js support file from Go installation
 cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" web/
 
+# Copy the icons the web app manifest points at
+mkdir -p web/icons
+cp pkg/assets/icons/icon-192.png pkg/assets/icons/icon-512.png web/icons/
+
 echo "WebAssembly build complete!"
 echo "Files created:"
 echo "  - web/wasm/game.wasm"
-echo "  - web/wasm_exec.js"
\ No newline at end of file
+echo "  - web/wasm_exec.js"
+echo "  - web/icons/"
\ No newline at end of file
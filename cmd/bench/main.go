@@ -0,0 +1,149 @@
+// Command bench checks `go test -bench` output against regression thresholds
+// and fails when a benchmark is slower than its threshold.
+//
+//	go test -run '^$' -bench . ./pkg/island ./pkg/solver | go run ./cmd/bench
+//	go test -tags render -run '^$' -bench . ./pkg/systems | go run ./cmd/bench
+//	go test -run '^$' -bench . ./pkg/island ./pkg/solver | go run ./cmd/bench -write-thresholds
+//
+// Thresholds are generous multiples of a developer machine's timings, so CI
+// machines pass unless an operation gets several times slower.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	thresholdsPath  = flag.String("thresholds", "cmd/bench/thresholds.json", "file with the slowest allowed ns/op per benchmark")
+	writeThresholds = flag.Bool("write-thresholds", false, "write the measured timings times -headroom as the new thresholds")
+	headroom        = flag.Float64("headroom", 3, "multiple of the measured timing written as a threshold")
+)
+
+// minThreshold keeps thresholds of very fast operations above timer noise
+const minThreshold = 100
+
+// errRegression is returned when a benchmark is slower than its threshold
+var errRegression = errors.New("performance regression")
+
+// benchLine matches a result line, e.g.
+// "BenchmarkSolve/25x25-8   846   253176 ns/op", capturing the name without
+// its Benchmark prefix and GOMAXPROCS suffix, and the ns/op
+var benchLine = regexp.MustCompile(`^Benchmark(\S+?)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op`)
+
+// result is one benchmark's timing
+type result struct {
+	name    string
+	nsPerOp int64
+}
+
+// readResults echoes the benchmark output and collects the timings in it
+func readResults(r io.Reader) ([]result, error) {
+	var results []result
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fmt.Println(line)
+		match := benchLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		nsPerOp, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", match[1], err)
+		}
+		results = append(results, result{name: match[1], nsPerOp: int64(nsPerOp)})
+	}
+	return results, scanner.Err()
+}
+
+func loadThresholds(path string) (map[string]int64, error) {
+	thresholds := make(map[string]int64)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return thresholds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &thresholds); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return thresholds, nil
+}
+
+// saveThresholds merges the new timings into the existing thresholds, so
+// benchmarks that were not run keep theirs
+func saveThresholds(path string, thresholds map[string]int64, results []result) error {
+	for _, r := range results {
+		thresholds[r.name] = max(int64(float64(r.nsPerOp)**headroom), minThreshold)
+	}
+	data, err := json.MarshalIndent(thresholds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// checkThresholds reports every benchmark slower than its threshold
+func checkThresholds(thresholds map[string]int64, results []result) error {
+	var slow []string
+	for _, r := range results {
+		limit, ok := thresholds[r.name]
+		if !ok {
+			fmt.Printf("note %s has no threshold\n", r.name)
+			continue
+		}
+		if r.nsPerOp > limit {
+			slow = append(slow, fmt.Sprintf("%s: %d ns/op, threshold %d", r.name, r.nsPerOp, limit))
+		}
+	}
+	if len(slow) == 0 {
+		return nil
+	}
+	sort.Strings(slow)
+	return fmt.Errorf("%w:\n  %s", errRegression, strings.Join(slow, "\n  "))
+}
+
+func main() {
+	flag.Parse()
+
+	thresholds, err := loadThresholds(*thresholdsPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	results, err := readResults(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(os.Stderr, "no benchmark results on stdin")
+		os.Exit(2)
+	}
+	if *writeThresholds {
+		if err := saveThresholds(*thresholdsPath, thresholds, results); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		fmt.Println("wrote", *thresholdsPath)
+		return
+	}
+
+	if err := checkThresholds(thresholds, results); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("ok")
+}
@@ -1,19 +1,79 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"image"
 	"log"
+	"net/url"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/assets"
 	"github.com/ponyo877/island-merge/pkg/core"
+	"github.com/ponyo877/island-merge/pkg/jsapi"
+)
+
+var (
+	dev       = flag.Bool("dev", false, "read assets from -assets and reload levels and theme when they change")
+	assetsDir = flag.String("assets", "pkg/assets", "asset directory for -dev")
+	seed      = flag.Int64("seed", 0, "start every game with this seed, as shown on a results screen")
+	turn      = flag.String("turn", "", "open the correspondence game in a code sent by the other player")
+	race      = flag.String("race", "", "join the online race in a code sent by the other player")
+	edit      = flag.String("edit", "", "co-edit the level in a code sent by another author")
 )
 
 func main() {
+	flag.Parse()
+	if *dev {
+		if err := assets.UseDir(*assetsDir); err != nil {
+			log.Printf("Using embedded assets: %v", err)
+		}
+	}
+	
 	game := core.NewGame()
+	if *seed != 0 {
+		game.SetSeed(*seed)
+	}
+	// Links like ?level=expert_01 open straight into a level in the browser
+	params := jsapi.LaunchParams()
+	if *turn != "" {
+		params = url.Values{"turn": {*turn}}
+	}
+	if *race != "" {
+		params = url.Values{"race": {*race}}
+	}
+	if *edit != "" {
+		params = url.Values{"edit": {*edit}}
+	}
+	if err := game.OpenDeepLink(params); err != nil {
+		log.Printf("Ignoring link: %v", err)
+	}
 	
-	ebiten.SetWindowSize(640, 480)
 	ebiten.SetWindowTitle("Island Merge")
+	ebiten.SetWindowIcon(windowIcons())
+	game.RestoreWindow()
 	
 	if err := ebiten.RunGame(game); err != nil {
+		if errors.Is(err, core.ErrCrashed) {
+			// The crash report is in storage; the next launch offers to restore the auto-save
+			log.Printf("%v; a crash report was saved", err)
+			os.Exit(1)
+		}
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
+
+// windowIcons decodes the embedded icons; the window picks the best fitting size
+func windowIcons() []image.Image {
+	var icons []image.Image
+	for _, size := range assets.IconSizes {
+		icon, err := assets.Icon(size)
+		if err != nil {
+			log.Printf("Missing window icon: %v", err)
+			continue
+		}
+		icons = append(icons, icon)
+	}
+	return icons
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/systems"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// goldenCase renders one board state or UI panel onto a blank screen
+type goldenCase struct {
+	name string
+	draw func(screen *ebiten.Image)
+}
+
+// epoch fixes the clock so time-based effects render the same every run
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newRenderSystem returns a render system without ambient animation, so frames
+// do not depend on when they were drawn
+func newRenderSystem() *systems.RenderSystem {
+	rs := systems.NewRenderSystem(clock.NewManualClock(epoch))
+	rs.SetEffects(false, false)
+	return rs
+}
+
+func level1Board() *island.Board {
+	board := island.NewBoard(5, 5)
+	board.SetupLevel1()
+	return board
+}
+
+func solvedLevel1Board() *island.Board {
+	board := level1Board()
+	for _, p := range []island.Point{{X: 2, Y: 1}, {X: 2, Y: 2}} {
+		board.BuildBridge(p.X, p.Y)
+	}
+	return board
+}
+
+func builtinBoard(id string) *island.Board {
+	level := levels.NewLevelManager().GetLevelByID(id)
+	if level == nil {
+		panic("golden: no built-in level " + id)
+	}
+	return level.NewBoard()
+}
+
+var goldenCases = []goldenCase{
+	{"board_level1", func(screen *ebiten.Image) {
+		newRenderSystem().Draw(screen, level1Board(), false)
+	}},
+	{"board_partial_bridge", func(screen *ebiten.Image) {
+		board := level1Board()
+		board.BuildBridge(2, 1)
+		newRenderSystem().Draw(screen, board, false)
+	}},
+	{"board_won", func(screen *ebiten.Image) {
+		rs := newRenderSystem()
+		rs.Draw(screen, solvedLevel1Board(), true)
+		rs.DrawVictoryStars(screen, 3)
+	}},
+	{"board_expert_01", func(screen *ebiten.Image) {
+		newRenderSystem().Draw(screen, builtinBoard("expert_01"), false)
+	}},
+	{"board_zoomed", func(screen *ebiten.Image) {
+		rs := newRenderSystem()
+		rs.Zoom(1.25)
+		rs.Pan(-20, 10)
+		rs.Draw(screen, builtinBoard("expert_01"), false)
+	}},
+	{"board_highlight", func(screen *ebiten.Image) {
+		rs := newRenderSystem()
+		board := level1Board()
+		rs.Draw(screen, board, false)
+		rs.DrawTileHighlight(screen, 2, 1, color.RGBA{255, 215, 0, 255})
+	}},
+	{"hud", func(screen *ebiten.Image) {
+		rs := newRenderSystem()
+		board := level1Board()
+		rs.Draw(screen, board, false)
+		ui.NewHUD().Draw(screen, rs.BoardBounds(board), ui.HUDData{
+			ModeName: "Classic",
+			Moves:    3,
+			Time:     42 * time.Second,
+			Hints:    []string{"Connect all islands to win!"},
+			Race:     &ui.RaceStatus{PlayerProgress: 0.5, AIProgress: 0.25, AIName: "Easy", AIMoves: 1},
+		})
+	}},
+	{"main_menu", func(screen *ebiten.Image) {
+		ui.NewMainMenu(func(int) {}).Draw(screen)
+	}},
+	{"level_select", func(screen *ebiten.Image) {
+		levelSelect := ui.NewLevelSelectUI(levels.NewLevelManager())
+		levelSelect.Show()
+		levelSelect.Draw(screen)
+	}},
+	{"confirm_dialog", func(screen *ebiten.Image) {
+		dialog := ui.NewConfirmDialog()
+		dialog.Title = "Island Merge closed unexpectedly"
+		dialog.Lines = []string{"A crash report was saved.", "Restore your last auto-save?"}
+		dialog.ConfirmLabel = "Restore"
+		dialog.CancelLabel = "Not now"
+		dialog.Show()
+		dialog.Draw(screen)
+	}},
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"image"
+	"image/color"
+)
+
+// diffColor marks pixels outside the tolerance in diff images
+var diffColor = color.RGBA{255, 0, 255, 255}
+
+// comparison is the result of checking a rendered image against its golden
+type comparison struct {
+	differing int         // Pixels with a channel further than the tolerance from the golden
+	total     int         // Pixels compared
+	diff      *image.RGBA // The golden faded out, with differing pixels in diffColor
+}
+
+// ratio is the fraction of pixels that differ
+func (c comparison) ratio() float64 {
+	if c.total == 0 {
+		return 0
+	}
+	return float64(c.differing) / float64(c.total)
+}
+
+// compareImages counts pixels whose channels differ by more than tolerance.
+// Images of different sizes differ in every pixel.
+func compareImages(got, want image.Image, tolerance uint8) comparison {
+	bounds := want.Bounds()
+	result := comparison{
+		total: bounds.Dx() * bounds.Dy(),
+		diff:  image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy())),
+	}
+	if got.Bounds().Size() != bounds.Size() {
+		result.differing = result.total
+		return result
+	}
+
+	gotMin := got.Bounds().Min
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			w := color.RGBAModel.Convert(want.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.RGBA)
+			g := color.RGBAModel.Convert(got.At(gotMin.X+x, gotMin.Y+y)).(color.RGBA)
+			if channelDiff(w.R, g.R) > tolerance || channelDiff(w.G, g.G) > tolerance ||
+				channelDiff(w.B, g.B) > tolerance || channelDiff(w.A, g.A) > tolerance {
+				result.differing++
+				result.diff.SetRGBA(x, y, diffColor)
+				continue
+			}
+			result.diff.SetRGBA(x, y, color.RGBA{w.R / 4, w.G / 4, w.B / 4, 255})
+		}
+	}
+	return result
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,161 @@
+// Command golden renders board states and UI panels offscreen and compares them
+// against golden PNGs, so render refactors cannot silently change visuals.
+//
+//	go run ./cmd/golden            # compare, exit status 1 on any mismatch
+//	go run ./cmd/golden -update    # rewrite the goldens from the current renderer
+//
+// Rendering needs a graphics context, so the tool opens a small window for the
+// run; on CI use a virtual display such as xvfb-run.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/capture"
+)
+
+const screenWidth, screenHeight = 640, 480
+
+var (
+	update    = flag.Bool("update", false, "write the rendered images as the new goldens")
+	dir       = flag.String("dir", "cmd/golden/testdata", "directory holding the golden PNGs")
+	outDir    = flag.String("out", "golden-out", "directory for the rendered and diff images of failed cases")
+	run       = flag.String("run", "", "only check cases whose name contains this")
+	tolerance = flag.Uint("tolerance", 8, "largest per-channel difference treated as equal")
+	maxDiff   = flag.Float64("max-diff", 0.001, "largest fraction of differing pixels that still passes")
+)
+
+// errMismatch ends the run when some case did not match its golden
+var errMismatch = errors.New("rendering does not match the goldens")
+
+// harness renders every case during the first Draw, where images can be read
+// back, then stops the game loop
+type harness struct {
+	done bool
+	err  error
+}
+
+func (h *harness) Update() error {
+	if h.done {
+		if h.err != nil {
+			return h.err
+		}
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (h *harness) Draw(screen *ebiten.Image) {
+	if h.done {
+		return
+	}
+	h.done = true
+	h.err = checkCases()
+}
+
+func (h *harness) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func checkCases() error {
+	failed := 0
+	for _, c := range goldenCases {
+		if *run != "" && !strings.Contains(c.name, *run) {
+			continue
+		}
+
+		screen := ebiten.NewImage(screenWidth, screenHeight)
+		c.draw(screen)
+		got := capture.Screenshot(screen)
+		screen.Deallocate()
+
+		goldenPath := filepath.Join(*dir, c.name+".png")
+		if *update {
+			if err := writePNG(goldenPath, got); err != nil {
+				return err
+			}
+			fmt.Println("updated", goldenPath)
+			continue
+		}
+
+		want, err := readPNG(goldenPath)
+		if err != nil {
+			fmt.Printf("FAIL %s: %v (run with -update to create it)\n", c.name, err)
+			failed++
+			continue
+		}
+
+		result := compareImages(got, want, uint8(min(*tolerance, 255)))
+		if result.ratio() <= *maxDiff {
+			fmt.Printf("ok   %s\n", c.name)
+			continue
+		}
+
+		failed++
+		fmt.Printf("FAIL %s: %d of %d pixels differ (%.3f%%)\n", c.name, result.differing, result.total, result.ratio()*100)
+		if err := writeFailure(c.name, got, result); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d failed", errMismatch, failed)
+	}
+	return nil
+}
+
+// writeFailure saves what was rendered and where it differs, for review
+func writeFailure(name string, got image.Image, result comparison) error {
+	if err := writePNG(filepath.Join(*outDir, name+".png"), got); err != nil {
+		return err
+	}
+	path := filepath.Join(*outDir, name+".diff.png")
+	if err := writePNG(path, result.diff); err != nil {
+		return err
+	}
+	fmt.Println("     see", path)
+	return nil
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	data, err := capture.EncodePNG(img)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func main() {
+	flag.Parse()
+
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle("Island Merge golden images")
+	if err := ebiten.RunGame(&harness{}); err != nil {
+		if errors.Is(err, errMismatch) {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		log.Fatal(err)
+	}
+}
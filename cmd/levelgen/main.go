@@ -0,0 +1,153 @@
+// Command levelgen generates solvable levels as a level pack, so new difficulty
+// sets can start from generated boards instead of hand-drawn grids.
+//
+//	go run ./cmd/levelgen -n 10 -size 12x12 -density 0.08 -par 10-16 -id archipelago -name "Archipelago" > archipelago.json
+//
+// Every level's par is the solver's move count. The same -seed produces the same pack.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+var (
+	count      = flag.Int("n", 10, "number of levels to generate")
+	size       = flag.String("size", "10x10", "board size as WxH")
+	density    = flag.Float64("density", 0.1, "fraction of tiles that are islands, at most 0.25")
+	par        = flag.String("par", "", "accepted par as N or MIN-MAX; empty accepts any")
+	seed       = flag.Int64("seed", 0, "random seed; 0 uses the current time")
+	attempts   = flag.Int("attempts", 1000, "boards tried per level before giving up")
+	packID     = flag.String("id", "generated", "pack ID; levels are named <id>_01, <id>_02, ...")
+	packName   = flag.String("name", "Generated Levels", "pack name")
+	author     = flag.String("author", "", "pack author")
+	difficulty = flag.Int("difficulty", int(levels.DifficultyBeginner), "difficulty 0 (beginner) to 3 (master)")
+	out        = flag.String("o", "", "output file; empty writes to stdout")
+)
+
+// parseSize reads a WxH board size
+func parseSize(s string) (int, int, error) {
+	w, h, ok := strings.Cut(strings.ToLower(s), "x")
+	if !ok {
+		return 0, 0, fmt.Errorf("size %q is not WxH", s)
+	}
+	width, err := strconv.Atoi(w)
+	if err != nil {
+		return 0, 0, fmt.Errorf("size %q is not WxH", s)
+	}
+	height, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, 0, fmt.Errorf("size %q is not WxH", s)
+	}
+	return width, height, nil
+}
+
+// parsePar reads N or MIN-MAX
+func parsePar(s string) (int, int, error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	lo, hi, isRange := strings.Cut(s, "-")
+	minPar, err := strconv.Atoi(lo)
+	if err != nil || minPar < 0 {
+		return 0, 0, fmt.Errorf("par %q is not N or MIN-MAX", s)
+	}
+	if !isRange {
+		return minPar, minPar, nil
+	}
+	maxPar, err := strconv.Atoi(hi)
+	if err != nil || maxPar < minPar {
+		return 0, 0, fmt.Errorf("par %q is not N or MIN-MAX", s)
+	}
+	return minPar, maxPar, nil
+}
+
+func generatePack() (*levels.LevelPack, error) {
+	width, height, err := parseSize(*size)
+	if err != nil {
+		return nil, err
+	}
+	minPar, maxPar, err := parsePar(*par)
+	if err != nil {
+		return nil, err
+	}
+	if *count <= 0 {
+		return nil, fmt.Errorf("-n must be positive")
+	}
+	if *difficulty < int(levels.DifficultyBeginner) || *difficulty > int(levels.DifficultyMaster) {
+		return nil, fmt.Errorf("difficulty must be 0 to 3")
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+
+	pack := &levels.LevelPack{
+		Format:      levels.PackFormat,
+		Version:     1,
+		ID:          *packID,
+		Name:        *packName,
+		Author:      *author,
+		Description: fmt.Sprintf("Generated %dx%d levels (seed %d)", width, height, *seed),
+		Difficulty:  levels.Difficulty(*difficulty),
+	}
+	opts := levels.GenerateOptions{
+		Width:       width,
+		Height:      height,
+		Density:     *density,
+		MinPar:      minPar,
+		MaxPar:      maxPar,
+		MaxAttempts: *attempts,
+	}
+	for i := 1; i <= *count; i++ {
+		level, err := levels.Generate(rng, opts)
+		if err != nil {
+			return nil, fmt.Errorf("level %d: %w", i, err)
+		}
+		level.ID = fmt.Sprintf("%s_%02d", *packID, i)
+		level.Name = fmt.Sprintf("%s %d", *packName, i)
+		level.Difficulty = pack.Difficulty
+		pack.Levels = append(pack.Levels, level)
+	}
+
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+	return pack, nil
+}
+
+func main() {
+	flag.Parse()
+
+	pack, err := generatePack()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "levelgen:", err)
+		os.Exit(1)
+	}
+
+	data, err := json.MarshalIndent(pack, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "levelgen:", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "levelgen:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d levels to %s (seed %d)\n", len(pack.Levels), *out, *seed)
+}
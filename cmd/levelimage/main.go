@@ -0,0 +1,131 @@
+// Command levelimage converts between levels and PNG images with one pixel per
+// tile, so levels can be drawn in any image editor. Land is green (139,195,74),
+// sea blue (64,164,223), bridges brown (121,85,72) and empty tiles black or
+// transparent; other colors snap to the nearest of these.
+//
+//	go run ./cmd/levelimage -id lagoon -name "Lagoon" lagoon.png > lagoon.json   # image to level
+//	go run ./cmd/levelimage -o lagoon.png lagoon.json                            # level to image
+//	go run ./cmd/levelimage -o images/ pack.json                                 # every level in a pack
+//
+// Imported levels get the solver's move count as par. The web build also
+// imports PNG files picked with Import Pack as custom levels.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+var (
+	levelID   = flag.String("id", "", "ID of an imported level; defaults to the image's file name")
+	levelName = flag.String("name", "", "name of an imported level; defaults to its ID")
+	out       = flag.String("o", "", "output file, or directory for a pack's images; empty writes a level to stdout")
+)
+
+// importImage turns an image into level JSON
+func importImage(path string, data []byte) error {
+	level, err := levels.ParseLevelPNG(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	level.ID = *levelID
+	if level.ID == "" {
+		level.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	level.Name = *levelName
+	if level.Name == "" {
+		level.Name = level.ID
+	}
+	level.OptimalMoves = solver.OptimalMoves(level.NewBoard())
+
+	data, err = json.MarshalIndent(level, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0644)
+}
+
+// exportLevels writes a level, or every level of a pack, as images
+func exportLevels(path string, data []byte) error {
+	var probe struct {
+		Format string `json:"format"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if *out == "" {
+		return fmt.Errorf("-o is required when writing images")
+	}
+
+	if probe.Format == "" {
+		level, err := levels.ParseLevel(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return writeImage(*out, level)
+	}
+
+	pack, err := levels.ParsePack(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		return err
+	}
+	for _, level := range pack.Levels {
+		if err := writeImage(filepath.Join(*out, level.ID+".png"), level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeImage(path string, level *levels.LevelData) error {
+	data, err := levels.EncodeLevelPNG(level)
+	if err != nil {
+		return fmt.Errorf("%s: %w", level.ID, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+	return nil
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: levelimage [flags] level.png | level.json | pack.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path := flag.Arg(0)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if levels.IsPNG(data) {
+			err = importImage(path, data)
+		} else {
+			err = exportLevels(path, data)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "levelimage:", err)
+		os.Exit(1)
+	}
+}
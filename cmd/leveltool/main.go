@@ -0,0 +1,160 @@
+// Command leveltool checks level files before they ship. For every level it
+// prints the island count, whether the solver can connect it and in how many
+// moves, and optionally an ASCII preview.
+//
+//	go run ./cmd/leveltool pack.json level.json   # level packs or single levels
+//	go run ./cmd/leveltool -builtin               # the embedded level sets, e.g. in CI
+//	go run ./cmd/leveltool -preview -solution pack.json
+//	go run ./cmd/leveltool -share pack.json        # share codes for ?code= links
+//
+// It exits with status 1 if any file is invalid or any level cannot be solved.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+var (
+	builtin      = flag.Bool("builtin", false, "check the level sets embedded in the game")
+	showPreview  = flag.Bool("preview", false, "print an ASCII preview of each level (# land, . sea, = bridge)")
+	showSolution = flag.Bool("solution", false, "mark the solver's bridges with + in the preview")
+	showShare    = flag.Bool("share", false, "print each level's share code for ?code= links")
+)
+
+// report is what the tool found out about one level
+type report struct {
+	level    *levels.LevelData
+	islands  int
+	solution *solver.Solution
+}
+
+// ok reports whether the level can ship
+func (r report) ok() bool {
+	return r.solution.Solvable
+}
+
+func (r report) String() string {
+	status := fmt.Sprintf("solvable in %d moves", len(r.solution.Moves))
+	if !r.solution.Solvable {
+		status = "UNSOLVABLE"
+		// The solver keeps to the budget, so its plan needed more bridges than allowed
+		if budget := r.level.BridgeBudget; budget > 0 {
+			status += fmt.Sprintf(" within budget %d", budget)
+		}
+	}
+	line := fmt.Sprintf("%-24s %-24q %3dx%-3d %3d islands  %s", r.level.ID, r.level.Name, r.level.Width, r.level.Height, r.islands, status)
+	if r.level.OptimalMoves > 0 {
+		line += fmt.Sprintf(", par %d", r.level.OptimalMoves)
+		// The solver is a heuristic that never beats the true optimum, so a lower
+		// estimate means the declared par is too generous
+		if r.solution.Solvable && len(r.solution.Moves) < r.level.OptimalMoves {
+			line += " (par is higher than the solver's estimate)"
+		}
+	}
+	return line
+}
+
+func check(level *levels.LevelData) report {
+	board := level.NewBoard()
+	return report{
+		level:    level,
+		islands:  board.IslandGroupCount(),
+		solution: solver.Solve(board),
+	}
+}
+
+// loadLevels reads a level pack, or a single level when the file is not a pack
+func loadLevels(path string) ([]*levels.LevelData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Format string `json:"format"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if probe.Format != "" {
+		pack, err := levels.ParsePack(data)
+		if err != nil {
+			return nil, err
+		}
+		return pack.Levels, nil
+	}
+
+	level, err := levels.ParseLevel(data)
+	if err != nil {
+		return nil, err
+	}
+	return []*levels.LevelData{level}, nil
+}
+
+// checkLevels prints a report for each level and returns how many cannot ship
+func checkLevels(list []*levels.LevelData) int {
+	failed := 0
+	for _, level := range list {
+		r := check(level)
+		fmt.Println(r)
+		if *showPreview {
+			var moves []solver.Move
+			if *showSolution {
+				moves = r.solution.Moves
+			}
+			fmt.Print(preview(level.NewBoard(), moves))
+		}
+		if *showShare {
+			if code, err := levels.EncodeShareCode(level); err == nil {
+				fmt.Printf("  share code: %s\n", code)
+			} else {
+				fmt.Printf("  share code: %v\n", err)
+			}
+		}
+		if !r.ok() {
+			failed++
+		}
+	}
+	return failed
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: leveltool [flags] [level.json ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if !*builtin && flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := 0
+	if *builtin {
+		for _, levelSet := range levels.NewLevelManager().LevelSets {
+			fmt.Printf("== %s (built-in)\n", levelSet.Name)
+			failed += checkLevels(levelSet.Levels)
+		}
+	}
+	for _, path := range flag.Args() {
+		fmt.Printf("== %s\n", path)
+		list, err := loadLevels(path)
+		if err != nil {
+			fmt.Printf("INVALID: %v\n", err)
+			failed++
+			continue
+		}
+		failed += checkLevels(list)
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d problem(s) found\n", failed)
+		os.Exit(1)
+	}
+}
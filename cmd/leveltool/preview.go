@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// previewChars draws each tile type in the ASCII preview
+var previewChars = map[island.TileType]byte{
+	island.TileEmpty:  ' ',
+	island.TileLand:   '#',
+	island.TileSea:    '.',
+	island.TileBridge: '=',
+}
+
+// solutionChar marks the solver's bridges when the solution is shown
+const solutionChar = '+'
+
+// preview renders the board one character per tile, with the given moves marked
+func preview(board *island.Board, moves []solver.Move) string {
+	rows := make([][]byte, board.Height)
+	for y := range rows {
+		rows[y] = make([]byte, board.Width)
+		for x := range rows[y] {
+			rows[y][x] = previewChars[board.GetTile(x, y).Type]
+		}
+	}
+	for _, move := range moves {
+		rows[move.Y][move.X] = solutionChar
+	}
+
+	var sb strings.Builder
+	for _, row := range rows {
+		sb.WriteString("  ")
+		sb.Write(row)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
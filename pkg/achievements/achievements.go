@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
 )
 
 type AchievementType int
@@ -19,6 +21,8 @@ const (
 	AchievementLevelCreator
 	AchievementDedicated
 	AchievementMaster
+	AchievementMutated
+	AchievementAllMutators
 )
 
 type Achievement struct {
@@ -26,6 +30,9 @@ type Achievement struct {
 	Name        string          `json:"name"`
 	Description string          `json:"description"`
 	Icon        string          `json:"icon"`
+	Category    Category        `json:"category"`
+	Points      int             `json:"points"` // Added to the score when unlocked
+	Title       string          `json:"title,omitempty"` // Worn beside the player's name once unlocked
 	Unlocked    bool            `json:"unlocked"`
 	UnlockedAt  *time.Time      `json:"unlocked_at,omitempty"`
 	Progress    int             `json:"progress"`
@@ -37,6 +44,7 @@ type AchievementSystem struct {
 	achievements map[AchievementType]*Achievement
 	statistics   *GameStatistics
 	listeners    []func(*Achievement)
+	clock        clock.Clock
 }
 
 type GameStatistics struct {
@@ -51,11 +59,15 @@ type GameStatistics struct {
 	PerfectGames      int           `json:"perfect_games"`    // Games with minimum moves
 	LevelsCreated     int           `json:"levels_created"`
 	PlayStreak        int           `json:"play_streak"`
+	MutatedWins       int           `json:"mutated_wins,omitempty"` // Games won under at least one mutator
 	LastPlayDate      *time.Time    `json:"last_play_date,omitempty"`
+	Calendar          map[string]DayActivity `json:"calendar,omitempty"` // Activity by local date, e.g. "2026-10-16"
 }
 
-func NewAchievementSystem() *AchievementSystem {
+// NewAchievementSystem creates the achievement set; clk stamps unlocks and play streaks
+func NewAchievementSystem(clk clock.Clock) *AchievementSystem {
 	system := &AchievementSystem{
+		clock:        clk,
 		achievements: make(map[AchievementType]*Achievement),
 		statistics:   &GameStatistics{FewestMoves: 999}, // Initialize with high value
 		listeners:    make([]func(*Achievement), 0),
@@ -65,6 +77,13 @@ func NewAchievementSystem() *AchievementSystem {
 	return system
 }
 
+// Reset clears all progress and statistics; unlock listeners stay registered
+func (as *AchievementSystem) Reset() {
+	as.achievements = make(map[AchievementType]*Achievement)
+	as.statistics = &GameStatistics{FewestMoves: 999}
+	as.initializeAchievements()
+}
+
 func (as *AchievementSystem) initializeAchievements() {
 	achievements := []*Achievement{
 		{
@@ -72,6 +91,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "First Victory",
 			Description: "Win your first game",
 			Icon:        "🏆",
+			Category:    CategoryProgress,
+			Points:      10,
+			Title:       "Castaway",
 			Target:      1,
 		},
 		{
@@ -79,6 +101,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Speed Demon",
 			Description: "Complete a level in under 30 seconds",
 			Icon:        "⚡",
+			Category:    CategorySkill,
+			Points:      25,
+			Title:       "Speed Demon",
 			Target:      1,
 		},
 		{
@@ -86,6 +111,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Efficiency Expert",
 			Description: "Complete a level with minimum moves",
 			Icon:        "🎯",
+			Category:    CategorySkill,
+			Points:      20,
+			Title:       "Minimalist",
 			Target:      1,
 		},
 		{
@@ -93,6 +121,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Time Master",
 			Description: "Win 5 Time Attack games",
 			Icon:        "⏰",
+			Category:    CategorySkill,
+			Points:      30,
+			Title:       "Clockbeater",
 			Target:      5,
 		},
 		{
@@ -100,6 +131,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Perfectionist",
 			Description: "Achieve 10 perfect games",
 			Icon:        "💎",
+			Category:    CategorySkill,
+			Points:      50,
+			Title:       "Perfectionist",
 			Target:      10,
 		},
 		{
@@ -107,6 +141,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Bridge Builder",
 			Description: "Build 100 bridges",
 			Icon:        "🌉",
+			Category:    CategoryProgress,
+			Points:      30,
+			Title:       "Bridge Architect",
 			Target:      100,
 		},
 		{
@@ -114,6 +151,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Island Hopper",
 			Description: "Win 25 games",
 			Icon:        "🏝️",
+			Category:    CategoryProgress,
+			Points:      40,
+			Title:       "Island Hopper",
 			Target:      25,
 		},
 		{
@@ -121,6 +161,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Level Designer",
 			Description: "Create 5 levels in the editor",
 			Icon:        "🎨",
+			Category:    CategoryCreativity,
+			Points:      30,
+			Title:       "Cartographer",
 			Target:      5,
 		},
 		{
@@ -128,6 +171,9 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Dedicated Player",
 			Description: "Play for 7 consecutive days",
 			Icon:        "🔥",
+			Category:    CategoryDedication,
+			Points:      40,
+			Title:       "Regular",
 			Target:      7,
 		},
 		{
@@ -135,9 +181,32 @@ func (as *AchievementSystem) initializeAchievements() {
 			Name:        "Island Master",
 			Description: "Unlock all other achievements",
 			Icon:        "👑",
-			Target:      9,
+			Category:    CategoryDedication,
+			Points:      100,
+			Title:       "Island Master",
+			Target:      11,
 			Hidden:      true,
 		},
+		{
+			ID:          AchievementMutated,
+			Name:        "Thrill Seeker",
+			Description: "Win 3 games with mutators",
+			Icon:        "🌀",
+			Category:    CategorySkill,
+			Points:      25,
+			Title:       "Thrill Seeker",
+			Target:      3,
+		},
+		{
+			ID:          AchievementAllMutators,
+			Name:        "Chaos Theory",
+			Description: "Win a game with every mutator at once",
+			Icon:        "🌪️",
+			Category:    CategorySkill,
+			Points:      50,
+			Title:       "Chaos Tamer",
+			Target:      1,
+		},
 	}
 	
 	for _, achievement := range achievements {
@@ -163,7 +232,7 @@ func (as *AchievementSystem) checkAchievement(id AchievementType) {
 	
 	if achievement.Progress >= achievement.Target {
 		achievement.Unlocked = true
-		now := time.Now()
+		now := as.clock.Now()
 		achievement.UnlockedAt = &now
 		as.notifyListeners(achievement)
 		
@@ -190,9 +259,10 @@ func (as *AchievementSystem) checkMasterAchievement() {
 // Game event handlers
 func (as *AchievementSystem) OnGameStart() {
 	as.statistics.GamesPlayed++
+	as.recordActivity(1, 0)
 	
 	// Update play streak
-	now := time.Now()
+	now := as.clock.Now()
 	if as.statistics.LastPlayDate != nil {
 		daysSince := int(now.Sub(*as.statistics.LastPlayDate).Hours() / 24)
 		if daysSince == 1 {
@@ -212,6 +282,7 @@ func (as *AchievementSystem) OnGameStart() {
 
 func (as *AchievementSystem) OnGameWin(moves int, gameTime time.Duration, isTimeAttack bool, isPerfect bool) {
 	as.statistics.GamesWon++
+	as.recordActivity(0, 1)
 	as.statistics.TotalMoves += moves
 	as.statistics.TotalTime += gameTime
 	
@@ -253,6 +324,21 @@ func (as *AchievementSystem) OnGameWin(moves int, gameTime time.Duration, isTime
 	}
 }
 
+// OnMutatorWin counts a win played under mutators; total is how many mutators exist
+func (as *AchievementSystem) OnMutatorWin(mutators, total int) {
+	if mutators == 0 {
+		return
+	}
+	as.statistics.MutatedWins++
+	as.achievements[AchievementMutated].Progress = as.statistics.MutatedWins
+	as.checkAchievement(AchievementMutated)
+	
+	if mutators >= total {
+		as.achievements[AchievementAllMutators].Progress = 1
+		as.checkAchievement(AchievementAllMutators)
+	}
+}
+
 func (as *AchievementSystem) OnBridgeBuilt() {
 	as.statistics.BridgesBuilt++
 	as.achievements[AchievementBridgeBuilder].Progress = as.statistics.BridgesBuilt
@@ -265,6 +351,46 @@ func (as *AchievementSystem) OnLevelCreated() {
 	as.checkAchievement(AchievementLevelCreator)
 }
 
+// Reevaluate brings every achievement up to date with the statistics, so
+// progress made elsewhere, such as on another device whose save data was
+// imported, unlocks what it earned. Progress never goes down, and newly
+// unlocked achievements notify listeners as usual. It returns how many were
+// unlocked.
+func (as *AchievementSystem) Reevaluate() int {
+	before := as.GetUnlockedCount()
+	stats := as.statistics
+	
+	speedrun := 0
+	if stats.GamesWon > 0 && stats.BestTime > 0 && stats.BestTime < 30*time.Second {
+		speedrun = 1
+	}
+	progress := map[AchievementType]int{
+		AchievementFirstWin:      min(1, stats.GamesWon),
+		AchievementSpeedrun:      speedrun,
+		AchievementEfficient:     min(1, stats.PerfectGames),
+		AchievementTimeAttackWin: stats.TimeAttackWins,
+		AchievementPerfectGame:   stats.PerfectGames,
+		AchievementBridgeBuilder: stats.BridgesBuilt,
+		AchievementIslandHopper:  stats.GamesWon,
+		AchievementLevelCreator:  stats.LevelsCreated,
+		AchievementDedicated:     stats.PlayStreak,
+		AchievementMutated:       stats.MutatedWins,
+	}
+	for id, value := range progress {
+		achievement := as.achievements[id]
+		if achievement == nil {
+			continue
+		}
+		if value > achievement.Progress {
+			achievement.Progress = value
+		}
+		as.checkAchievement(id)
+	}
+	as.checkMasterAchievement()
+	
+	return as.GetUnlockedCount() - before
+}
+
 func (as *AchievementSystem) GetAchievements() []*Achievement {
 	result := make([]*Achievement, 0)
 	for _, achievement := range as.achievements {
@@ -322,8 +448,14 @@ func (as *AchievementSystem) LoadFromJSON(jsonStr string) error {
 		return err
 	}
 	
-	if data.Achievements != nil {
-		as.achievements = data.Achievements
+	// Keep the current definitions, so achievements added since the save was
+	// written are offered, and restore only the player's progress
+	for id, saved := range data.Achievements {
+		if achievement := as.achievements[id]; achievement != nil && saved != nil {
+			achievement.Unlocked = saved.Unlocked
+			achievement.UnlockedAt = saved.UnlockedAt
+			achievement.Progress = saved.Progress
+		}
 	}
 	
 	if data.Statistics != nil {
@@ -0,0 +1,70 @@
+package achievements
+
+import "time"
+
+// calendarDays is how many days of activity the statistics keep, enough for a
+// year of whole weeks
+const calendarDays = 371
+
+// calendarLayout keys the activity calendar by local date
+const calendarLayout = "2006-01-02"
+
+// DayActivity is what was played on one day
+type DayActivity struct {
+	Games int `json:"games"` // Games started
+	Wins  int `json:"wins"`  // Levels completed
+}
+
+// CalendarDay is one day of the activity calendar
+type CalendarDay struct {
+	Date time.Time
+	DayActivity
+}
+
+// recordActivity adds to today's entry in the calendar and forgets days
+// older than the calendar shows
+func (as *AchievementSystem) recordActivity(games, wins int) {
+	stats := as.statistics
+	if stats.Calendar == nil {
+		stats.Calendar = make(map[string]DayActivity)
+	}
+	now := as.clock.Now()
+	today := now.Format(calendarLayout)
+	day := stats.Calendar[today]
+	day.Games += games
+	day.Wins += wins
+	stats.Calendar[today] = day
+
+	oldest := now.AddDate(0, 0, -calendarDays).Format(calendarLayout)
+	for key := range stats.Calendar {
+		if key < oldest {
+			delete(stats.Calendar, key)
+		}
+	}
+}
+
+// Calendar returns the activity of the last days, ending today, oldest first
+func (as *AchievementSystem) Calendar(days int) []CalendarDay {
+	now := as.clock.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	calendar := make([]CalendarDay, days)
+	for i := range calendar {
+		date := today.AddDate(0, 0, i-days+1)
+		calendar[i] = CalendarDay{Date: date, DayActivity: as.statistics.Calendar[date.Format(calendarLayout)]}
+	}
+	return calendar
+}
+
+// LongestStreak is the most consecutive days played that the calendar remembers
+func (as *AchievementSystem) LongestStreak() int {
+	longest, run := 0, 0
+	for _, day := range as.Calendar(calendarDays) {
+		if day.Games > 0 {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
@@ -0,0 +1,56 @@
+package achievements
+
+// Category groups achievements by the kind of play that earns them
+type Category int
+
+const (
+	CategoryProgress Category = iota
+	CategorySkill
+	CategoryCreativity
+	CategoryDedication
+)
+
+// Categories lists every category in display order
+var Categories = []Category{CategoryProgress, CategorySkill, CategoryCreativity, CategoryDedication}
+
+func (c Category) String() string {
+	switch c {
+	case CategoryProgress:
+		return "Progress"
+	case CategorySkill:
+		return "Skill"
+	case CategoryCreativity:
+		return "Creativity"
+	case CategoryDedication:
+		return "Dedication"
+	default:
+		return "Other"
+	}
+}
+
+// GetScore returns the points earned from unlocked achievements and the
+// points all achievements are worth, hidden ones included
+func (as *AchievementSystem) GetScore() (earned, total int) {
+	for _, achievement := range as.achievements {
+		total += achievement.Points
+		if achievement.Unlocked {
+			earned += achievement.Points
+		}
+	}
+	return earned, total
+}
+
+// GetCategoryProgress returns how many achievements in a category are
+// unlocked and how many there are
+func (as *AchievementSystem) GetCategoryProgress(category Category) (unlocked, total int) {
+	for _, achievement := range as.achievements {
+		if achievement.Category != category {
+			continue
+		}
+		total++
+		if achievement.Unlocked {
+			unlocked++
+		}
+	}
+	return unlocked, total
+}
@@ -0,0 +1,23 @@
+package achievements
+
+// HasTitle reports whether an unlocked achievement grants the title
+func (as *AchievementSystem) HasTitle(title string) bool {
+	if title == "" {
+		return false
+	}
+	for _, achievement := range as.achievements {
+		if achievement.Unlocked && achievement.Title == title {
+			return true
+		}
+	}
+	return false
+}
+
+// TitledName is a player's name followed by the title they wear, e.g.
+// "Player 1, Bridge Architect"; without a title it is the name alone
+func TitledName(name, title string) string {
+	if title == "" {
+		return name
+	}
+	return name + ", " + title
+}
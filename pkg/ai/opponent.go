@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+type Skill int
+
+const (
+	SkillOff Skill = iota
+	SkillRandom
+	SkillEasy
+	SkillMedium
+	SkillHard
+)
+
+// skillProfile controls how often the AI follows the solver and how long it thinks
+type skillProfile struct {
+	optimalChance float64
+	thinkTime     time.Duration
+}
+
+var skillProfiles = map[Skill]skillProfile{
+	SkillRandom: {optimalChance: 0.0, thinkTime: time.Millisecond * 1500},
+	SkillEasy:   {optimalChance: 0.4, thinkTime: time.Millisecond * 2500},
+	SkillMedium: {optimalChance: 0.7, thinkTime: time.Millisecond * 1800},
+	SkillHard:   {optimalChance: 0.95, thinkTime: time.Millisecond * 1200},
+}
+
+// Opponent plays its own copy of a board alongside the player
+type Opponent struct {
+	Board         *island.Board
+	Skill         Skill
+	Personality   Personality
+	Moves         int
+	initialGroups int
+	lastMove      time.Time
+	wait          time.Duration // Before the next move; drawn after each move
+	plan          []solver.Move // The Planner's moves still to play
+	clock         clock.Clock
+	rng           *rand.Rand
+}
+
+// NewOpponent creates an AI racer that thinks on clk, so it waits while the game is paused
+func NewOpponent(board *island.Board, skill Skill, clk clock.Clock) *Opponent {
+	aiBoard := board.Clone()
+	return &Opponent{
+		Board:         aiBoard,
+		Skill:         skill,
+		initialGroups: aiBoard.IslandGroupCount(),
+		lastMove:      clk.Now(),
+		clock:         clk,
+		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Seed makes the opponent's choices repeatable, for debugging
+func (o *Opponent) Seed(seed int64) {
+	o.rng = rand.New(rand.NewSource(seed))
+}
+
+func (o *Opponent) Update() {
+	if o.IsFinished() {
+		return
+	}
+
+	profile, ok := skillProfiles[o.Skill]
+	if !ok {
+		return
+	}
+
+	if o.wait == 0 {
+		o.wait = o.thinkTime(profile)
+	}
+	if clock.Since(o.clock, o.lastMove) < o.wait {
+		return
+	}
+	o.lastMove = o.clock.Now()
+	o.wait = 0
+
+	move, ok := o.chooseMove(profile)
+	if !ok {
+		return
+	}
+
+	o.Board.BuildBridge(move.X, move.Y)
+	o.Moves++
+}
+
+// IsFinished reports whether the AI has connected all of its islands
+func (o *Opponent) IsFinished() bool {
+	return o.Board.IsAllConnected()
+}
+
+// Progress returns how far the AI is from connecting everything, from 0 to 1
+func (o *Opponent) Progress() float64 {
+	return ConnectionProgress(o.Board, o.initialGroups)
+}
+
+// ProgressOf measures another board that started from the same layout, e.g. the player's
+func (o *Opponent) ProgressOf(board *island.Board) float64 {
+	return ConnectionProgress(board, o.initialGroups)
+}
+
+// ConnectionProgress measures how many of the initial island groups have been merged
+func ConnectionProgress(board *island.Board, initialGroups int) float64 {
+	if initialGroups <= 1 {
+		return 1.0
+	}
+	merged := initialGroups - board.IslandGroupCount()
+	return float64(merged) / float64(initialGroups-1)
+}
+
+func SkillName(skill Skill) string {
+	switch skill {
+	case SkillOff:
+		return "Off"
+	case SkillRandom:
+		return "Random"
+	case SkillEasy:
+		return "Easy"
+	case SkillMedium:
+		return "Medium"
+	case SkillHard:
+		return "Hard"
+	default:
+		return "Unknown"
+	}
+}
+
+// SkillRating is the fixed rating a skill races at in rated races
+func SkillRating(skill Skill) int {
+	switch skill {
+	case SkillRandom:
+		return 800
+	case SkillEasy:
+		return 1000
+	case SkillMedium:
+		return 1300
+	case SkillHard:
+		return 1600
+	default:
+		return 0
+	}
+}
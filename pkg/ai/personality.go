@@ -0,0 +1,224 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// Personality is how the AI picks among the moves its skill allows. Skill still
+// sets how often it gets a move right; personality sets what it does with the
+// solver's answer and how long it thinks. The Random skill has no personality.
+type Personality int
+
+const (
+	PersonalitySteady  Personality = iota // Follows the solver as often as its skill allows
+	PersonalityPlanner                    // Follows a whole plan, planning again after a mistake, and thinks longer the less skilled it is
+	PersonalityRusher                     // Grabs whatever merges most right now, fast and careless
+	PersonalityChaotic                    // Switches between the others at random, at an erratic pace
+	personalityCount
+)
+
+var personalityNames = []string{"Steady", "Planner", "Rusher", "Chaotic"}
+
+func (p Personality) String() string {
+	if p < 0 || p >= personalityCount {
+		return "Unknown"
+	}
+	return personalityNames[p]
+}
+
+const (
+	rusherThinkScale  = 0.75 // The Rusher thinks this much of its skill's time
+	rusherCarefulness = 0.6  // and gets this share of the moves its skill gets right
+	chaoticGreed      = 0.25 // Share of the Chaotic's wrong moves that grab a merge instead
+)
+
+// PlaysAs is the personality the opponent plays with: its own, or Steady for
+// the Random skill
+func (o *Opponent) PlaysAs() Personality {
+	if o.Skill == SkillRandom || o.Personality < 0 || o.Personality >= personalityCount {
+		return PersonalitySteady
+	}
+	return o.Personality
+}
+
+// thinkTime is how long the opponent waits before its next move
+func (o *Opponent) thinkTime(profile skillProfile) time.Duration {
+	switch o.PlaysAs() {
+	case PersonalityPlanner:
+		// Checking every bridge takes the time the less skilled would spend on mistakes
+		return time.Duration(float64(profile.thinkTime) * (2 - profile.optimalChance))
+	case PersonalityRusher:
+		return time.Duration(float64(profile.thinkTime) * rusherThinkScale)
+	case PersonalityChaotic:
+		return time.Duration(float64(profile.thinkTime) * (0.3 + 1.4*o.rng.Float64()))
+	default:
+		return profile.thinkTime
+	}
+}
+
+// chooseMove picks the opponent's next bridge by its personality
+func (o *Opponent) chooseMove(profile skillProfile) (solver.Move, bool) {
+	switch o.PlaysAs() {
+	case PersonalityPlanner:
+		if o.rng.Float64() < profile.optimalChance {
+			if move, ok := o.plannedMove(); ok {
+				return move, true
+			}
+		}
+		o.plan = nil // A mistake spoils the plan
+	case PersonalityRusher:
+		if o.rng.Float64() < profile.optimalChance*rusherCarefulness {
+			if move, ok := greedyMove(o.Board); ok {
+				return move, true
+			}
+		}
+	case PersonalityChaotic:
+		roll := o.rng.Float64()
+		if roll < profile.optimalChance {
+			if move, ok := solver.NextMove(o.Board); ok {
+				return move, true
+			}
+		} else if roll < profile.optimalChance+(1-profile.optimalChance)*chaoticGreed {
+			if move, ok := greedyMove(o.Board); ok {
+				return move, true
+			}
+		}
+	default:
+		if o.rng.Float64() < profile.optimalChance {
+			if move, ok := solver.NextMove(o.Board); ok {
+				return move, true
+			}
+		}
+	}
+	return o.randomMove()
+}
+
+// plannedMove plays the solver's whole solution in order, planning again only
+// when the next bridge can no longer be built
+func (o *Opponent) plannedMove() (solver.Move, bool) {
+	if len(o.plan) == 0 || !o.Board.CanBuildBridge(o.plan[0].X, o.plan[0].Y) {
+		solution := solver.Solve(o.Board)
+		if !solution.Solvable {
+			return solver.Move{}, false
+		}
+		o.plan = solution.Moves
+	}
+	if len(o.plan) == 0 {
+		return solver.Move{}, false
+	}
+	move := o.plan[0]
+	o.plan = o.plan[1:]
+	return move, true
+}
+
+// randomMove picks any bridge that can be built
+func (o *Opponent) randomMove() (solver.Move, bool) {
+	candidates := make([]solver.Move, 0)
+	for y := 0; y < o.Board.Height; y++ {
+		for x := 0; x < o.Board.Width; x++ {
+			if o.Board.CanBuildBridge(x, y) {
+				candidates = append(candidates, solver.Move{X: x, Y: y})
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return solver.Move{}, false
+	}
+	return candidates[o.rng.Intn(len(candidates))], true
+}
+
+// greedyMove is the bridge that joins the most island groups at once, or the
+// solver's next move when no single bridge joins any
+func greedyMove(board *island.Board) (solver.Move, bool) {
+	var best solver.Move
+	bestJoins := 1
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			if !board.CanBuildBridge(x, y) {
+				continue
+			}
+			if joins := touchingGroups(board, x, y); joins > bestJoins {
+				best, bestJoins = solver.Move{X: x, Y: y}, joins
+			}
+		}
+	}
+	if bestJoins > 1 {
+		return best, true
+	}
+	return solver.NextMove(board)
+}
+
+// touchingGroups counts the island groups next to a tile
+func touchingGroups(board *island.Board, x, y int) int {
+	roots := make(map[int]bool)
+	for _, dir := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		nx, ny := x+dir[0], y+dir[1]
+		tile := board.GetTile(nx, ny)
+		if tile == nil || (tile.Type != island.TileLand && tile.Type != island.TileBridge) {
+			continue
+		}
+		roots[board.UnionFind.Find(ny*board.Width+nx)] = true
+	}
+	return len(roots)
+}
+
+// Taunt is a moment in a race the AI has something to say about
+type Taunt int
+
+const (
+	TauntStart   Taunt = iota // The race begins
+	TauntAhead                // The AI pulls well ahead
+	TauntBehind               // The player pulls well ahead
+	TauntClosing              // The AI nears the finish
+	TauntWon                  // The AI connected everything first
+	TauntLost                 // The player won
+)
+
+// taunts are each personality's lines, by moment
+var taunts = map[Personality][]string{
+	PersonalitySteady: {
+		TauntStart:   "Good luck. Let's see those bridges.",
+		TauntAhead:   "Keeping a steady pace.",
+		TauntBehind:  "You're quick today.",
+		TauntClosing: "Nearly there.",
+		TauntWon:     "Good race.",
+		TauntLost:    "Well played!",
+	},
+	PersonalityPlanner: {
+		TauntStart:   "I've already mapped every crossing.",
+		TauntAhead:   "All according to plan.",
+		TauntBehind:  "Hasty bridges fall down, you know.",
+		TauntClosing: "Final stretch, exactly as calculated.",
+		TauntWon:     "Planning beats panic.",
+		TauntLost:    "An error in my model. Noted.",
+	},
+	PersonalityRusher: {
+		TauntStart:   "Try to keep up!",
+		TauntAhead:   "Too slow! Way too slow!",
+		TauntBehind:  "Hey! Wait for me!",
+		TauntClosing: "Almost done, see ya!",
+		TauntWon:     "Speed wins every time!",
+		TauntLost:    "Rematch. Right now.",
+	},
+	PersonalityChaotic: {
+		TauntStart:   "Bridges? Bridges!",
+		TauntAhead:   "Wheee, I have no idea what I'm doing!",
+		TauntBehind:  "Is this the right ocean?",
+		TauntClosing: "Ooh, they're all touching now!",
+		TauntWon:     "Did I win? I won!",
+		TauntLost:    "Congratulations, or condolences?",
+	},
+}
+
+// Taunt is what the opponent says at a moment in the race
+func (o *Opponent) Taunt(moment Taunt) string {
+	lines, ok := taunts[o.PlaysAs()]
+	if !ok || moment < 0 || int(moment) >= len(lines) {
+		return ""
+	}
+	return lines[moment]
+}
@@ -0,0 +1,226 @@
+// Package analytics records anonymous gameplay events so level designers can find
+// levels that are too hard or too easy. It is opt-in: nothing is recorded or sent
+// unless the player enables it in settings.
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+)
+
+const (
+	uploadTimeout  = 10 * time.Second
+	uploadInterval = 5 * time.Minute // Upload at least this often while events are waiting
+	batchSize      = 50              // Upload early once this many events are waiting
+	maxPending     = 1000            // Oldest events are dropped beyond this, e.g. while offline
+)
+
+// EventType names a recorded gameplay event
+type EventType string
+
+const (
+	EventLevelStarted   EventType = "level_started"
+	EventLevelCompleted EventType = "level_completed"
+	EventLevelFailed    EventType = "level_failed"
+	EventHintUsed       EventType = "hint_used"
+)
+
+// Event is one anonymized record. It carries no player names or profile data;
+// Session only groups the events of one launch of the game.
+type Event struct {
+	Type        EventType `json:"type"`
+	Session     string    `json:"session"`
+	LevelID     string    `json:"level_id,omitempty"`
+	Mode        int       `json:"mode"`
+	Moves       int       `json:"moves,omitempty"`
+	DurationMS  int64     `json:"duration_ms,omitempty"`
+	MoveTimesMS []int64   `json:"move_times_ms,omitempty"` // Thinking time per move, for speedrun analysis
+	Reason      string    `json:"reason,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// Store keeps events that have not been uploaded yet between launches
+type Store interface {
+	SaveAnalytics(data interface{}) error
+	LoadAnalytics(target interface{}) error
+}
+
+type uploadResult struct {
+	count int // Events sent from the front of the queue
+	err   error
+}
+
+// Recorder collects events from the event bus and posts them in batches to a
+// configurable endpoint in the background, so the game loop never waits on the network.
+type Recorder struct {
+	store      Store
+	client     *http.Client
+	enabled    bool
+	endpoint   string
+	session    string
+	pending    []Event
+	uploading  bool
+	results    chan uploadResult
+	lastUpload time.Time
+}
+
+func NewRecorder(store Store) *Recorder {
+	r := &Recorder{
+		store:      store,
+		client:     &http.Client{Timeout: uploadTimeout},
+		session:    newSessionID(),
+		results:    make(chan uploadResult, 1),
+		lastUpload: time.Now(),
+	}
+	if err := store.LoadAnalytics(&r.pending); err != nil {
+		r.pending = nil
+	}
+	return r
+}
+
+func newSessionID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Configure applies the player's choice. Opting out deletes events not yet sent;
+// an empty endpoint keeps events locally until one is configured.
+func (r *Recorder) Configure(enabled bool, endpoint string) {
+	r.enabled = enabled
+	r.endpoint = endpoint
+	if !enabled && len(r.pending) > 0 {
+		r.pending = nil
+		r.save()
+	}
+}
+
+// Enabled reports whether the player opted in
+func (r *Recorder) Enabled() bool {
+	return r.enabled
+}
+
+// Pending returns how many events are waiting to be uploaded
+func (r *Recorder) Pending() int {
+	return len(r.pending)
+}
+
+// Subscribe records the gameplay events level designers need
+func (r *Recorder) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, func(e events.GameStarted) {
+		r.Record(Event{Type: EventLevelStarted, LevelID: e.LevelID, Mode: e.Mode})
+	})
+	events.Subscribe(bus, func(e events.GameWon) {
+		r.Record(Event{Type: EventLevelCompleted, LevelID: e.LevelID, Mode: e.Mode, Moves: e.Moves, DurationMS: e.Time.Milliseconds(), MoveTimesMS: milliseconds(e.MoveTimes)})
+	})
+	events.Subscribe(bus, func(e events.GameLost) {
+		r.Record(Event{Type: EventLevelFailed, LevelID: e.LevelID, Mode: e.Mode, Moves: e.Moves, Reason: e.Reason})
+	})
+	events.Subscribe(bus, func(e events.HintShown) {
+		r.Record(Event{Type: EventHintUsed, LevelID: e.LevelID, Mode: e.Mode, Moves: e.Moves})
+	})
+}
+
+func milliseconds(durations []time.Duration) []int64 {
+	if len(durations) == 0 {
+		return nil
+	}
+	ms := make([]int64, len(durations))
+	for i, d := range durations {
+		ms[i] = d.Milliseconds()
+	}
+	return ms
+}
+
+// Record queues an event when the player has opted in
+func (r *Recorder) Record(e Event) {
+	if !r.enabled {
+		return
+	}
+
+	e.Session = r.session
+	e.Time = time.Now()
+	r.pending = append(r.pending, e)
+	if len(r.pending) > maxPending && !r.uploading {
+		r.pending = r.pending[len(r.pending)-maxPending:]
+	}
+	r.save()
+}
+
+func (r *Recorder) save() {
+	if err := r.store.SaveAnalytics(r.pending); err != nil {
+		fmt.Println("Failed to save analytics:", err)
+	}
+}
+
+// Update picks up a finished upload and starts the next batch when one is due.
+// Call it once per frame.
+func (r *Recorder) Update() {
+	select {
+	case result := <-r.results:
+		r.uploading = false
+		r.lastUpload = time.Now()
+		if result.err != nil {
+			fmt.Println("Analytics upload failed:", result.err)
+		} else if r.enabled {
+			r.pending = r.pending[min(result.count, len(r.pending)):]
+			r.save()
+		}
+	default:
+	}
+
+	if !r.enabled || r.endpoint == "" || r.uploading || len(r.pending) == 0 {
+		return
+	}
+	if len(r.pending) < batchSize && time.Since(r.lastUpload) < uploadInterval {
+		return
+	}
+
+	batch := r.pending
+	if len(batch) > batchSize {
+		batch = batch[:batchSize]
+	}
+	batch = append([]Event(nil), batch...)
+	endpoint := r.endpoint
+	r.uploading = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+		defer cancel()
+		r.results <- uploadResult{count: len(batch), err: r.post(ctx, endpoint, batch)}
+	}()
+}
+
+// post sends a batch as a JSON array
+func (r *Recorder) post(ctx context.Context, endpoint string, batch []Event) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("analytics endpoint returned %s", resp.Status)
+	}
+	return nil
+}
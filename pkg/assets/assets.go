@@ -0,0 +1,71 @@
+// Package assets holds the files bundled into the game binary: the window
+// icons, the built-in level packs and the colour theme. Text uses Ebiten's
+// built-in debug font and sound effects are synthesized, so there are no font
+// or audio files. The web build copies the icons next to index.html for the
+// web app manifest.
+//
+// Release builds read the embedded copies. Dev builds can read the files from
+// a directory instead, so edits show up without recompiling; see UseDir and
+// Watcher.
+package assets
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	"image/png"
+	"io/fs"
+	"os"
+)
+
+//go:embed icons/*.png levels/*.json theme.json
+var embedded embed.FS
+
+// files is where assets are read from: the embedded copies unless UseDir was called
+var files fs.FS = embedded
+
+// dev is set once assets are read from disk
+var dev bool
+
+// UseDir reads assets from a directory laid out like this package, such as
+// pkg/assets in a checkout, instead of the embedded copies
+func UseDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	files = os.DirFS(dir)
+	dev = true
+	return nil
+}
+
+// Dev reports whether assets are read from disk
+func Dev() bool {
+	return dev
+}
+
+// FS returns the asset files, with paths such as "levels/01_beginner.json"
+func FS() fs.FS {
+	return files
+}
+
+// ReadFile reads one asset file
+func ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(files, name)
+}
+
+// IconSizes lists the square icon sizes in pixels, smallest first
+var IconSizes = []int{16, 32, 48, 192, 512}
+
+// Icon decodes the icon of the given size
+func Icon(size int) (image.Image, error) {
+	f, err := files.Open(fmt.Sprintf("icons/icon-%d.png", size))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
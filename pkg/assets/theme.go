@@ -0,0 +1,73 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+)
+
+// ThemeFile is the asset holding the board colours
+const ThemeFile = "theme.json"
+
+// Color is a colour written in JSON as "#rrggbb" or "#rrggbbaa"
+type Color color.RGBA
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	if c.A == 255 {
+		return json.Marshal(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+	}
+	return json.Marshal(fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A))
+}
+
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	hex := s
+	if len(hex) == 7 {
+		hex += "ff" // Opaque
+	}
+	var parsed Color
+	if n, err := fmt.Sscanf(hex, "#%02x%02x%02x%02x", &parsed.R, &parsed.G, &parsed.B, &parsed.A); err != nil || n != 4 || len(hex) != 9 {
+		return fmt.Errorf("invalid colour %q, want #rrggbb or #rrggbbaa", s)
+	}
+	*c = parsed
+	return nil
+}
+
+// RGBA returns the colour for drawing
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return color.RGBA(c).RGBA()
+}
+
+// Theme holds the colours the board is drawn in
+type Theme struct {
+	Sea        Color `json:"sea"`
+	Land       Color `json:"land"`
+	Bridge     Color `json:"bridge"`
+	Background Color `json:"background"`
+	Grid       Color `json:"grid"`
+}
+
+// DefaultTheme is used for any colour theme.json leaves out
+var DefaultTheme = Theme{
+	Sea:        Color{64, 164, 223, 255},
+	Land:       Color{139, 195, 74, 255},
+	Bridge:     Color{121, 85, 72, 255},
+	Background: Color{240, 240, 240, 255},
+	Grid:       Color{200, 200, 200, 255},
+}
+
+// LoadTheme reads theme.json over the default theme
+func LoadTheme() (Theme, error) {
+	theme := DefaultTheme
+	data, err := ReadFile(ThemeFile)
+	if err != nil {
+		return theme, err
+	}
+	if err := json.Unmarshal(data, &theme); err != nil {
+		return DefaultTheme, fmt.Errorf("%s: %w", ThemeFile, err)
+	}
+	return theme, nil
+}
@@ -0,0 +1,66 @@
+package assets
+
+import (
+	"io/fs"
+	"sort"
+	"time"
+)
+
+// watchInterval is how often a Watcher rescans the asset directory
+const watchInterval = 500 * time.Millisecond
+
+// Watcher reports asset files changed on disk so they can be reloaded. It only
+// sees changes in dev mode; with embedded assets Poll never reports anything.
+type Watcher struct {
+	modTimes map[string]time.Time
+	next     time.Time
+}
+
+// NewWatcher starts watching from the files as they are now
+func NewWatcher() *Watcher {
+	w := &Watcher{}
+	if dev {
+		w.modTimes = scan()
+	}
+	return w
+}
+
+// Poll rescans at most every watchInterval and returns the files changed,
+// added or removed since the last scan, sorted
+func (w *Watcher) Poll() []string {
+	if !dev || time.Now().Before(w.next) {
+		return nil
+	}
+	w.next = time.Now().Add(watchInterval)
+
+	current := scan()
+	var changed []string
+	for name, modTime := range current {
+		if previous, ok := w.modTimes[name]; !ok || !previous.Equal(modTime) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range w.modTimes {
+		if _, ok := current[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	w.modTimes = current
+	sort.Strings(changed)
+	return changed
+}
+
+// scan records the modification time of every asset file
+func scan() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	fs.WalkDir(files, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			modTimes[name] = info.ModTime()
+		}
+		return nil
+	})
+	return modTimes
+}
@@ -0,0 +1,50 @@
+// Package benchboards generates the boards the benchmarks measure, so the
+// board, solver and rendering benchmarks all time the same boards.
+package benchboards
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// islandSpacing is the distance between neighbouring islands on generated boards
+const islandSpacing = 5
+
+// Sizes are the board sizes measured: today's largest levels and the planned
+// large boards
+var Sizes = []int{25, 100}
+
+// Name labels a sub-benchmark with its board size, e.g. 25x25
+func Name(size int) string {
+	return fmt.Sprintf("%dx%d", size, size)
+}
+
+// Generate returns a size x size board with single-tile islands on a jittered
+// grid. The seed is fixed so every run measures the same board.
+func Generate(size int) *island.Board {
+	rng := rand.New(rand.NewSource(int64(size)))
+	board := island.NewBoard(size, size)
+	for y := 2; y < size-1; y += islandSpacing {
+		for x := 2; x < size-1; x += islandSpacing {
+			board.SetTile(x+rng.Intn(3)-1, y+rng.Intn(3)-1, island.TileLand)
+		}
+	}
+	return board
+}
+
+// Solved returns a generated board with every island connected, and the moves
+// that connected it
+func Solved(size int) (*island.Board, []solver.Move) {
+	board := Generate(size)
+	solution := solver.Solve(board)
+	if !solution.Solvable {
+		panic(fmt.Sprintf("benchboards: generated %dx%d board is unsolvable", size, size))
+	}
+	for _, move := range solution.Moves {
+		board.BuildBridge(move.X, move.Y)
+	}
+	return board, solution.Moves
+}
@@ -0,0 +1,154 @@
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/replay"
+)
+
+const (
+	maxGIFSize  = 400 // Longest side of the rendered board in pixels
+	minTileSize = 4
+	maxTileSize = 32
+	frameDelay  = 40  // Hundredths of a second between moves
+	finalDelay  = 200 // Hold the solved board before looping
+
+	// Frames follow the player's own pace, sped up and clamped so long pauses don't stall the GIF
+	replaySpeedup = 4
+	maxFrameDelay = 150
+)
+
+// Palette indexes
+const (
+	paletteEmpty = iota
+	paletteLand
+	paletteSea
+	paletteBridge
+	paletteGrid
+	paletteHighlight
+)
+
+var replayPalette = color.Palette{
+	paletteEmpty:     color.RGBA{240, 240, 240, 255},
+	paletteLand:      color.RGBA{139, 195, 74, 255},
+	paletteSea:       color.RGBA{64, 164, 223, 255},
+	paletteBridge:    color.RGBA{121, 85, 72, 255},
+	paletteGrid:      color.RGBA{40, 40, 40, 255},
+	paletteHighlight: color.RGBA{255, 215, 0, 255},
+}
+
+// moveDelay returns how long the frame before move i is shown, in hundredths
+// of a second, following the time the player took over the move
+func moveDelay(r *replay.Replay, i int) int {
+	if r.Moves[i].At == 0 {
+		return frameDelay
+	}
+	var previous time.Duration
+	if i > 0 {
+		previous = r.Moves[i-1].At
+	}
+	delay := int((r.Moves[i].At - previous).Milliseconds() / 10 / replaySpeedup)
+	return min(max(delay, frameDelay), maxFrameDelay)
+}
+
+// gifTileSize fits the board into maxGIFSize
+func gifTileSize(board *island.Board) int {
+	longest := board.Width
+	if board.Height > longest {
+		longest = board.Height
+	}
+	size := maxGIFSize / longest
+	if size < minTileSize {
+		return minTileSize
+	}
+	if size > maxTileSize {
+		return maxTileSize
+	}
+	return size
+}
+
+// EncodeGIF renders a replay as a looping animated GIF: the starting board,
+// then one frame per bridge with the newest bridge highlighted.
+func EncodeGIF(r *replay.Replay) ([]byte, error) {
+	if r.Start == nil {
+		return nil, fmt.Errorf("replay has no starting board")
+	}
+
+	tileSize := gifTileSize(r.Start)
+	board := r.Start.Clone()
+	anim := &gif.GIF{}
+
+	addFrame := func(highlight *replay.Move, delay int) {
+		anim.Image = append(anim.Image, renderFrame(board, tileSize, highlight))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	// Each frame is held for as long as the player thought about the next move
+	delayBefore := func(i int) int {
+		if i < len(r.Moves) {
+			return moveDelay(r, i)
+		}
+		return frameDelay
+	}
+	addFrame(nil, delayBefore(0))
+	for i := range r.Moves {
+		move := r.Moves[i]
+		if move.Removed {
+			board.RemoveBridge(move.X, move.Y)
+			addFrame(nil, delayBefore(i+1))
+			continue
+		}
+		board.BuildBridge(move.X, move.Y)
+		addFrame(&move, delayBefore(i+1))
+	}
+	// Hold the solved network without the highlight
+	addFrame(nil, finalDelay)
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, anim); err != nil {
+		return nil, fmt.Errorf("failed to encode replay: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderFrame draws the board as flat tiles separated by grid lines
+func renderFrame(board *island.Board, tileSize int, highlight *replay.Move) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, board.Width*tileSize+1, board.Height*tileSize+1), replayPalette)
+
+	// Grid lines show through the one-pixel gap left around each tile
+	for i := range img.Pix {
+		img.Pix[i] = paletteGrid
+	}
+
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			index := uint8(paletteEmpty)
+			if tile := board.GetTile(x, y); tile != nil {
+				switch tile.Type {
+				case island.TileLand:
+					index = paletteLand
+				case island.TileSea:
+					index = paletteSea
+				case island.TileBridge:
+					index = paletteBridge
+				}
+			}
+			if highlight != nil && highlight.X == x && highlight.Y == y {
+				index = paletteHighlight
+			}
+
+			for py := y*tileSize + 1; py < (y+1)*tileSize; py++ {
+				for px := x*tileSize + 1; px < (x+1)*tileSize; px++ {
+					img.SetColorIndex(px, py, index)
+				}
+			}
+		}
+	}
+	return img
+}
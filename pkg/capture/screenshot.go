@@ -0,0 +1,34 @@
+// Package capture turns the screen and recorded solutions into shareable images.
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Screenshot copies the current contents of an ebiten image
+func Screenshot(screen *ebiten.Image) *image.RGBA {
+	bounds := screen.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	screen.ReadPixels(img.Pix)
+	return img
+}
+
+// EncodePNG encodes an image as PNG
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FileName returns a timestamped file name such as island-merge_20261016_150405.png
+func FileName(ext string, t time.Time) string {
+	return fmt.Sprintf("island-merge_%s.%s", t.Format("20060102_150405"), ext)
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// autoSaveAfterMove counts a move toward the next auto-save and saves once
+// enough have been made
+func (g *Game) autoSaveAfterMove() {
+	if !g.autoSave {
+		return
+	}
+	g.movesSinceSave++
+	if g.movesSinceSave >= g.autoSaveMoves {
+		g.autoSaveNow()
+	}
+}
+
+// autoSaveNow saves the game in progress, if auto-save is on and there is one,
+// and shows the save toast
+func (g *Game) autoSaveNow() {
+	if !g.autoSave || !g.saveGame() {
+		return
+	}
+	g.movesSinceSave = 0
+	g.saveToast.Show()
+}
+
+// autoSaveOnBlur saves when the window loses focus, as the player may not come
+// back to it. With power saving on the game stops updating as soon as it loses
+// focus, so only the move count covers it then.
+func (g *Game) autoSaveOnBlur() {
+	focused := ebiten.IsFocused()
+	if g.focused && !focused {
+		g.autoSaveNow()
+	}
+	g.focused = focused
+}
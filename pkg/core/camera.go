@@ -0,0 +1,82 @@
+package core
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// zoomPanner is a view the zoom and pan controls move: the game board or the
+// level editor's grid
+type zoomPanner interface {
+	Zoom(factor float64)
+	Pan(dx, dy float64)
+}
+
+// handleViewControls zooms and pans a view with the keyboard
+func (g *Game) handleViewControls(view zoomPanner) {
+	if g.input.IsControlJustPressed(systems.ControlZoomIn) {
+		view.Zoom(zoomStep)
+	}
+	if g.input.IsControlJustPressed(systems.ControlZoomOut) {
+		view.Zoom(1 / zoomStep)
+	}
+
+	// Pan by time rather than ticks so the speed doesn't depend on the frame rate setting
+	step := panSpeed / float64(ebiten.TPS())
+	var dx, dy float64
+	if g.input.IsControlPressed(systems.ControlPanLeft) {
+		dx += step
+	}
+	if g.input.IsControlPressed(systems.ControlPanRight) {
+		dx -= step
+	}
+	if g.input.IsControlPressed(systems.ControlPanUp) {
+		dy += step
+	}
+	if g.input.IsControlPressed(systems.ControlPanDown) {
+		dy -= step
+	}
+	if dx != 0 || dy != 0 {
+		view.Pan(dx, dy)
+	}
+}
+
+// zoomToFit frames the whole board, for a game that just started or was loaded
+func (g *Game) zoomToFit() {
+	if g.world.Board == nil {
+		g.render.ResetView()
+		return
+	}
+	g.render.FrameBoard(g.world.Board.Width, g.world.Board.Height)
+}
+
+// focusMergedGroup glides the camera to the group a bridge just joined, when
+// part of it is off screen. Boards that fit the screen never move.
+func (g *Game) focusMergedGroup(e events.IslandsMerged) {
+	land := g.world.Board.GroupLand(e.X, e.Y)
+	if len(land) == 0 {
+		return
+	}
+	minX, minY, maxX, maxY := land[0].X, land[0].Y, land[0].X, land[0].Y
+	for _, p := range land[1:] {
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+		maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+	}
+
+	width, height := g.Layout(0, 0)
+	viewMinX, viewMinY, viewMaxX, viewMaxY := g.render.VisibleTiles(width, height)
+	if float64(minX) >= viewMinX && float64(minY) >= viewMinY && float64(maxX+1) <= viewMaxX && float64(maxY+1) <= viewMaxY {
+		return
+	}
+	g.render.FocusOn(float64(minX+maxX+1)/2, float64(minY+maxY+1)/2)
+}
+
+// centerOnTile glides the camera to a double-clicked tile
+func (g *Game) centerOnTile(screenX, screenY int) {
+	gridX, gridY := g.render.ScreenToGrid(screenX, screenY)
+	if g.world.Board.GetTile(gridX, gridY) == nil {
+		return
+	}
+	g.render.FocusOn(float64(gridX)+0.5, float64(gridY)+0.5)
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// refreshChallenge generates the current week's challenge when the week has
+// changed and loads the profile's progress in it
+func (g *Game) refreshChallenge() {
+	now := time.Now()
+	if g.challenge == nil || !now.Before(g.challenge.Ends) {
+		challenge, err := levels.NewWeeklyChallenge(now, allMutatorNames())
+		if err != nil {
+			fmt.Println("Failed to generate the weekly challenge:", err)
+			g.mainMenu.SetItemVisible(challengeMenuItem, false)
+			return
+		}
+		g.challenge = challenge
+	}
+	g.challengeScores = g.saveSystem.ChallengeFor(g.challenge.Week)
+}
+
+// allMutatorNames lists every mutator in the order they are offered
+func allMutatorNames() []string {
+	names := make([]string, len(Mutators))
+	for i, mutator := range Mutators {
+		names[i] = string(mutator.ID)
+	}
+	return names
+}
+
+// startChallenge plays the first stage of the week's challenge not yet won. Once
+// all are won, the playlist starts over so the combined score can be improved.
+func (g *Game) startChallenge() {
+	g.refreshChallenge()
+	if g.challenge == nil {
+		return
+	}
+	stage := g.challengeScores.NextStage(len(g.challenge.Stages))
+	if stage < 0 {
+		stage = 0
+	}
+	g.mutators = mutatorIDs(g.challenge.Stages[stage].Mutators)
+	g.startLevel(g.challenge.Stages[stage].Level)
+}
+
+// challengeStage returns the index of the challenge stage being played, or -1
+func (g *Game) challengeStage() int {
+	if g.challenge == nil || g.currentLevel == nil {
+		return -1
+	}
+	return g.challenge.Stage(g.currentLevel.ID)
+}
+
+// recordChallengeStage adds a won stage to the combined score; assisted games do not count
+func (g *Game) recordChallengeStage(e events.GameWon) {
+	if g.challenge == nil || g.world.Assisted {
+		return
+	}
+	stage := g.challenge.Stage(e.LevelID)
+	if stage < 0 {
+		return
+	}
+	progress, err := g.saveSystem.RecordChallengeStage(g.challenge.Week, stage, e.Points)
+	if err != nil {
+		fmt.Println("Failed to record the challenge stage:", err)
+		return
+	}
+	g.challengeScores = progress
+}
+
+// updateChallengeMenuItem shows the week's progress and the time left beside the menu item
+func (g *Game) updateChallengeMenuItem() {
+	if g.challenge == nil || !time.Now().Before(g.challenge.Ends) {
+		g.refreshChallenge()
+		if g.challenge == nil {
+			return
+		}
+	}
+	detail := fmt.Sprintf("%d/%d", g.challengeScores.Completed(), len(g.challenge.Stages))
+	if total := g.challengeScores.Total(); total > 0 {
+		detail += fmt.Sprintf(" %d pts", total)
+	}
+	detail += ", " + formatTimeLeft(time.Until(g.challenge.Ends)) + " left"
+	g.mainMenu.SetItemDetail(challengeMenuItem, detail)
+}
+
+// challengeHUDLine names the stage being played and the combined score so far
+func (g *Game) challengeHUDLine(stage int) string {
+	return fmt.Sprintf("Weekly challenge %d/%d, total %d pts", stage+1, len(g.challenge.Stages), g.challengeScores.Total())
+}
+
+// formatTimeLeft rounds a duration down to its two largest units, e.g. "3d 4h"
+func formatTimeLeft(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", max(int(d.Minutes()), 1))
+	}
+}
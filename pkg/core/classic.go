@@ -0,0 +1,41 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// classicLevelOptions shapes a generated Classic board. Small boards are dense
+// with islands and large ones sparse, so each size has a sensible number to connect.
+func classicLevelOptions(width, height int) levels.GenerateOptions {
+	density := 1 / math.Sqrt(float64(width*height))
+	return levels.GenerateOptions{
+		Width:   width,
+		Height:  height,
+		Density: max(0.05, min(density, 0.25)),
+		MinPar:  1,
+	}
+}
+
+// startClassic plays Classic on a freshly generated board of the chosen size and
+// remembers the size for next time
+func (g *Game) startClassic(width, height int) {
+	if settings, err := g.saveSystem.LoadSettings(); err == nil {
+		settings.ClassicWidth, settings.ClassicHeight = width, height
+		if err := g.saveSystem.SaveSettings(settings); err != nil {
+			fmt.Println("Failed to save the Classic board size:", err)
+		}
+	}
+
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), classicLevelOptions(width, height))
+	if err != nil {
+		fmt.Println("Failed to generate a Classic board:", err)
+		return
+	}
+	levelData.ID = fmt.Sprintf("classic_%dx%d_%d", width, height, source.Seed())
+	levelData.Name = fmt.Sprintf("Classic %dx%d", width, height)
+	g.startSeededLevel(levelData, ModeClassic, source)
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/share"
+)
+
+// Co-editing sessions are online matches that are not stored: a restart ends
+// the player's part in one, and the level stays in the editor to export.
+
+// startCoEdit handles the editor's Co-edit button, sharing its level through
+// a new session and handing its code to the player for the other authors
+func (g *Game) startCoEdit(events.CoEditRequested) {
+	if !gamenet.SupportsMatches(g.onlineURL) {
+		g.levelEditor.Status = "Co-editing needs a ws:// or wss:// online_url"
+		return
+	}
+	id, err := gamenet.NewMatchID()
+	if err != nil {
+		fmt.Println("Failed to start a co-editing session:", err)
+		g.levelEditor.Status = "Co-editing could not be started"
+		return
+	}
+	g.levelEditor.StartCoEdit(gamenet.JoinMatch(g.onlineURL, id, nil), g.saveSystem.CurrentProfile().Name, true)
+
+	fmt.Println("Co-editing code:", id)
+	err = share.CopyText(id)
+	if err == nil {
+		g.levelEditor.Status = "Copied the co-editing code; send it to the other authors"
+		return
+	}
+	if !errors.Is(err, share.ErrCopyUnsupported) {
+		fmt.Println("Copying the co-editing code failed:", err)
+	}
+	g.levelEditor.Status = "Send the other authors the co-editing code " + id
+}
+
+// joinCoEdit opens the editor on the level of a session another author started
+func (g *Game) joinCoEdit(id string) error {
+	if !gamenet.SupportsMatches(g.onlineURL) {
+		return errors.New("co-editing needs a ws:// or wss:// online_url")
+	}
+	g.world.State = StateLevelEditor
+	g.levelEditor.StartCoEdit(gamenet.JoinMatch(g.onlineURL, id, nil), g.saveSystem.CurrentProfile().Name, false)
+	g.levelEditor.Status = "Joining the co-editing session..."
+	return nil
+}
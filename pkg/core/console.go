@@ -0,0 +1,209 @@
+package core
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// consoleCommand runs a developer console command and returns the text to log
+type consoleCommand struct {
+	usage string
+	run   func(g *Game, args []string) (string, error)
+}
+
+// consoleCommands are the developer console's commands, keyed by their first word
+var consoleCommands = map[string]consoleCommand{
+	"load":   {"load level <id>", (*Game).consoleLoad},
+	"win":    {"win", (*Game).consoleWin},
+	"give":   {"give stars <0-3>", (*Game).consoleGive},
+	"seed":   {"seed <n>", (*Game).consoleSeed},
+	"toggle": {"toggle overlay <help|components|inspector|profiler>", (*Game).consoleToggle},
+	"export": {"export graph <dot|graphml>", (*Game).consoleExport},
+}
+
+// componentColors tint each union-find component in the components overlay
+var componentColors = []color.RGBA{
+	{244, 67, 54, 255},
+	{33, 150, 243, 255},
+	{76, 175, 80, 255},
+	{255, 193, 7, 255},
+	{156, 39, 176, 255},
+	{0, 188, 212, 255},
+	{255, 87, 34, 255},
+	{121, 85, 72, 255},
+}
+
+// runConsoleCommand dispatches one line typed into the developer console
+func (g *Game) runConsoleCommand(line string) string {
+	fields := strings.Fields(line)
+	if strings.EqualFold(fields[0], "help") {
+		return consoleHelp()
+	}
+	command, ok := consoleCommands[strings.ToLower(fields[0])]
+	if !ok {
+		return fmt.Sprintf("Unknown command %q; try help", fields[0])
+	}
+
+	output, err := command.run(g, fields[1:])
+	if err != nil {
+		return fmt.Sprintf("%v (usage: %s)", err, command.usage)
+	}
+	return output
+}
+
+func consoleHelp() string {
+	usages := make([]string, 0, len(consoleCommands))
+	for _, command := range consoleCommands {
+		usages = append(usages, command.usage)
+	}
+	sort.Strings(usages)
+	return strings.Join(usages, "\n")
+}
+
+// consoleLoad starts any level, locked or not
+func (g *Game) consoleLoad(args []string) (string, error) {
+	if len(args) != 2 || args[0] != "level" {
+		return "", fmt.Errorf("expected a level ID")
+	}
+
+	levelData := g.levelManager.GetLevelByID(args[1])
+	if levelData == nil {
+		return "", fmt.Errorf("no level %q", args[1])
+	}
+	g.customLevelID = ""
+	g.startLevel(levelData)
+	return "Loaded " + levelData.Name, nil
+}
+
+// consoleWin plays the solver's moves until every island is connected, so the
+// normal win checks run on the next update
+func (g *Game) consoleWin(args []string) (string, error) {
+	if g.world.State != StatePlaying || g.world.Board == nil || g.world.GameWon {
+		return "", fmt.Errorf("no game in progress")
+	}
+
+	for !g.world.Board.IsAllConnected() {
+		move, ok := solver.NextMove(g.world.Board)
+		if !ok {
+			return "", fmt.Errorf("the solver found no way to connect this board")
+		}
+		g.buildBridge(move.X, move.Y)
+	}
+	return fmt.Sprintf("Connected in %d moves", g.world.Score.Moves), nil
+}
+
+// consoleGive records a completion of the current level with the given stars.
+// It is marked assisted so it stays off the leaderboards.
+func (g *Game) consoleGive(args []string) (string, error) {
+	if len(args) != 2 || args[0] != "stars" {
+		return "", fmt.Errorf("expected a star count")
+	}
+	stars, err := strconv.Atoi(args[1])
+	if err != nil || stars < 0 || stars > 3 {
+		return "", fmt.Errorf("stars must be 0 to 3")
+	}
+	if g.currentLevel == nil {
+		return "", fmt.Errorf("no level loaded")
+	}
+
+	score := &levels.Score{Moves: g.currentLevel.OptimalMoves, Stars: stars, Date: time.Now()}
+	g.currentLevel.BestScore = score
+	g.levelManager.Progress[g.currentLevel.ID] = score
+	g.levelManager.UnlockNextLevel(g.currentLevel.ID)
+	err = g.saveSystem.RecordLevelCompletion(storage.Score{
+		Level:    g.currentLevel.ID,
+		Mode:     int(g.world.Mode),
+		Moves:    score.Moves,
+		Date:     score.Date,
+		Stars:    stars,
+		Assisted: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	if g.world.GameWon {
+		g.revealedStars = stars
+	}
+	return fmt.Sprintf("Gave %d stars on %s", stars, g.currentLevel.ID), nil
+}
+
+// consoleSeed starts every game from now on with the seed and makes particles
+// and traffic repeatable too
+func (g *Game) consoleSeed(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected a seed")
+	}
+	seed, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("seed must be a number")
+	}
+
+	g.SetSeed(seed)
+	g.animation.Particles().Seed(seed)
+	g.traffic.Seed(seed)
+	if g.opponent != nil {
+		g.opponent.Seed(seed)
+	}
+	return fmt.Sprintf("Seeded with %d", seed), nil
+}
+
+func (g *Game) consoleToggle(args []string) (string, error) {
+	if len(args) != 2 || args[0] != "overlay" {
+		return "", fmt.Errorf("expected an overlay name")
+	}
+
+	switch args[1] {
+	case "help":
+		g.helpOverlay.Toggle()
+		return "", nil
+	case "components":
+		g.componentsOverlay = !g.componentsOverlay
+		return fmt.Sprintf("Components overlay %s", onOff(g.componentsOverlay)), nil
+	case "inspector":
+		g.inspector.Toggle()
+		return fmt.Sprintf("Board inspector %s", onOff(g.inspector.IsVisible())), nil
+	case "profiler":
+		g.profiler.SetEnabled(!g.profiler.Enabled())
+		return fmt.Sprintf("Profiler %s", onOff(g.profiler.Enabled())), nil
+	}
+	return "", fmt.Errorf("no overlay %q", args[1])
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// drawComponentsOverlay tints land and bridge tiles by their union-find root, so
+// tiles of one connected group share a color
+func (g *Game) drawComponentsOverlay(screen *ebiten.Image, board *island.Board) {
+	colors := make(map[int]color.RGBA)
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			tile := board.GetTile(x, y)
+			if tile == nil || (tile.Type != island.TileLand && tile.Type != island.TileBridge) {
+				continue
+			}
+
+			root := board.UnionFind.Find(y*board.Width + x)
+			col, ok := colors[root]
+			if !ok {
+				col = componentColors[len(colors)%len(componentColors)]
+				colors[root] = col
+			}
+			g.render.DrawTileHighlight(screen, x, y, col)
+		}
+	}
+}
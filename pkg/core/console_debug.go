@@ -0,0 +1,6 @@
+// +build debug
+
+package core
+
+// consoleAvailable enables the developer console; build with -tags debug
+const consoleAvailable = true
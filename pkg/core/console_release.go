@@ -0,0 +1,6 @@
+// +build !debug
+
+package core
+
+// consoleAvailable keeps cheat commands out of release builds
+const consoleAvailable = false
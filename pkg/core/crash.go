@@ -0,0 +1,100 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// ErrCrashed is returned from Update after a panic in the game loop was recovered
+// and a crash report was written
+var ErrCrashed = errors.New("game crashed")
+
+// Update runs one tick of the game loop. A panic is recovered, written to a crash
+// report and turned into ErrCrashed so the game stops cleanly.
+func (g *Game) Update() (err error) {
+	if g.crashErr != nil {
+		return g.crashErr
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = g.reportCrash(r)
+		}
+	}()
+	return g.update()
+}
+
+// Draw renders one frame, recovering panics like Update
+func (g *Game) Draw(screen *ebiten.Image) {
+	if g.crashErr != nil {
+		return
+	}
+	defer func() {
+		// Draw cannot return an error, so the next Update stops the game
+		if r := recover(); r != nil {
+			g.crashErr = g.reportCrash(r)
+		}
+	}()
+	g.draw(screen)
+}
+
+// reportCrash writes the panic, its stack trace and the game in progress to
+// storage so the next launch can offer to restore the last auto-save
+func (g *Game) reportCrash(r any) error {
+	report := &storage.CrashReport{
+		Time:  time.Now(),
+		Error: fmt.Sprint(r),
+		Stack: string(debug.Stack()),
+		State: g.crashState(),
+	}
+	if err := g.saveSystem.SaveCrashReport(report); err != nil {
+		fmt.Println("Failed to save crash report:", err)
+	}
+	return fmt.Errorf("%w: %v", ErrCrashed, r)
+}
+
+// crashState serializes the world for the crash report; the world may be what
+// caused the panic, so a second panic only loses the state
+func (g *Game) crashState() (state *storage.CurrentGameState) {
+	defer func() {
+		if recover() != nil {
+			state = nil
+		}
+	}()
+	return g.gameStateData()
+}
+
+// offerCrashRecovery asks whether to restore the last auto-save when the previous
+// session crashed
+func (g *Game) offerCrashRecovery() {
+	report, err := g.saveSystem.LoadCrashReport()
+	if err != nil || report.Offered {
+		return
+	}
+	// The report stays in storage for bug reports; it is only offered once
+	report.Offered = true
+	g.saveSystem.SaveCrashReport(report)
+	fmt.Printf("Previous session crashed at %s: %s\n", report.Time.Format(time.RFC3339), report.Error)
+
+	g.crashDialog.Title = "Island Merge closed unexpectedly"
+	g.crashDialog.Lines = []string{"A crash report was saved."}
+	if !g.saveSystem.HasSavedGame() {
+		g.crashDialog.Lines = append(g.crashDialog.Lines, "There is no auto-save to restore.")
+		g.crashDialog.ConfirmLabel = "OK"
+		g.crashDialog.CancelLabel = ""
+		g.crashDialog.OnConfirm = nil
+	} else {
+		g.crashDialog.Lines = append(g.crashDialog.Lines, "Restore your last auto-save?")
+		g.crashDialog.ConfirmLabel = "Restore"
+		g.crashDialog.CancelLabel = "Not now"
+		g.crashDialog.OnConfirm = func() {
+			g.profileSelectUI.Hide()
+			g.loadGame()
+		}
+	}
+	g.crashDialog.Show()
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/url"
+	"strconv"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// seededLevelOptions shapes the level generated for a ?seed= link; changing them
+// changes the level every existing seed link opens
+var seededLevelOptions = levels.GenerateOptions{
+	Width:   10,
+	Height:  10,
+	Density: 0.08,
+	MinPar:  8,
+	MaxPar:  16,
+}
+
+// OpenDeepLink starts the level a link points at, bypassing the menu. The first
+// parameter present wins:
+//
+//	code=<share code>   a level shared with levels.EncodeShareCode
+//	level=<id>          a built-in or installed level, e.g. expert_01
+//	seed=<text>         a level generated from the text, the same for everyone
+//	turn=<game code>    a correspondence game sent by the other player
+//	race=<race code>    an online race the other player started
+//	edit=<session code> a level another author is co-editing
+//
+// Links without any of them leave the game at the menu. Any link can add
+// run=<number> to start every game with a seed from a results screen.
+func (g *Game) OpenDeepLink(params url.Values) error {
+	if run := params.Get("run"); run != "" {
+		seed, err := strconv.ParseInt(run, 10, 64)
+		if err != nil {
+			return fmt.Errorf("run must be a number: %q", run)
+		}
+		g.SetSeed(seed)
+	}
+
+	var levelData *levels.LevelData
+	var err error
+	switch {
+	case params.Get("code") != "":
+		levelData, err = levels.DecodeShareCode(params.Get("code"))
+	case params.Get("level") != "":
+		id := params.Get("level")
+		if levelData = g.levelManager.GetLevelByID(id); levelData == nil {
+			err = fmt.Errorf("%w %q", levels.ErrUnknownLevel, id)
+		}
+	case params.Get("seed") != "":
+		levelData, err = seededLevel(params.Get("seed"))
+	case params.Get("turn") != "":
+		return g.openCorrespondenceCode(params.Get("turn"))
+	case params.Get("race") != "":
+		return g.joinOnlineRace(params.Get("race"))
+	case params.Get("edit") != "":
+		return g.joinCoEdit(params.Get("edit"))
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	g.startExternalLevel(levelData)
+	return nil
+}
+
+// seededLevel generates the level for a seed link
+func seededLevel(seed string) (*levels.LevelData, error) {
+	hash := fnv.New64a()
+	hash.Write([]byte(seed))
+	levelData, err := levels.Generate(rand.New(rand.NewSource(int64(hash.Sum64()))), seededLevelOptions)
+	if err != nil {
+		return nil, err
+	}
+	levelData.ID = "seed_" + seed
+	levelData.Name = "Seed " + seed
+	return levelData, nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/random"
+)
+
+// defeat is how the defeat screen explains one way of losing
+type defeat struct {
+	title  string
+	reason string
+}
+
+// defeats explains each GameLost reason; reasons without an entry read as a
+// failed objective
+var defeats = map[string]defeat{
+	"time_up":   {"Time's up!", "The clock ran out before every island was connected."},
+	"storm":     {"Out of moves", "The storm outlasted the move budget."},
+	"ai_won":    {"Outraced", "The AI opponent connected every island first."},
+	"outscored": {"Outscored", "Your opponent's bridges joined more island groups than yours."},
+	"draw":      {"Draw", "Both players' bridges joined as many island groups."},
+
+	"opponent_won":    {"Outraced", "Your opponent connected every island first."},
+	"connection_lost": {"Disconnected", "The connection to the race could not be restored in time."},
+}
+
+var objectiveFailed = defeat{"Defeat", "The objective was not met."}
+
+// showDefeat covers the lost game with why it was lost and how far it got
+func (g *Game) showDefeat(reason string) {
+	d, ok := defeats[reason]
+	if !ok {
+		d = objectiveFailed
+	}
+	g.defeatScreen.Title = d.title
+	g.defeatScreen.Reason = d.reason
+	g.defeatScreen.Stats = g.defeatStats()
+	g.defeatScreen.Show()
+}
+
+// defeatStats sums up the attempt for the defeat screen
+func (g *Game) defeatStats() []string {
+	w := g.world
+	connected, total := w.Board.ConnectedIslands()
+	stats := []string{
+		fmt.Sprintf("Islands connected   %d of %d (%d%%)", connected, total, connected*100/max(total, 1)),
+		fmt.Sprintf("Moves               %d", w.Score.Moves),
+		fmt.Sprintf("Time                %d:%02d", int(w.Score.Time.Minutes()), int(w.Score.Time.Seconds())%60),
+		fmt.Sprintf("Bridges standing    %d", len(w.Board.Bridges())),
+		fmt.Sprintf("Score               %d", w.Score.Points),
+	}
+	if g.opponent != nil {
+		stats = append(stats, fmt.Sprintf("AI moves            %d", g.opponent.Moves))
+	}
+	if change := g.ratingChange; change != nil {
+		stats = append(stats, fmt.Sprintf("Rating              %d (%+d)", change.Rating, change.Change))
+	}
+	if w.Random != nil {
+		stats = append(stats, fmt.Sprintf("Seed                %d", w.Random.Seed()))
+	}
+	return stats
+}
+
+// retryGame plays the lost game again from its seed: the same board, the same
+// mutators and, when racing, the same AI
+func (g *Game) retryGame() {
+	var skill ai.Skill
+	var personality ai.Personality
+	if g.opponent != nil {
+		skill, personality = g.opponent.Skill, g.opponent.Personality
+	}
+	if g.world.Correspondence != nil {
+		g.showCorrespondencePicker() // A finished game cannot be played again
+		return
+	}
+	if g.world.OnlineRace != nil {
+		g.showOnlineRace() // A new race, with a new code for the opponent
+		return
+	}
+	if g.currentLevel == nil {
+		g.startGameMode(g.world.Mode)
+	} else {
+		g.mutators = g.world.Rules.Mutators
+		g.startSeededLevel(g.currentLevel, g.world.Mode, random.New(g.world.Random.Seed()))
+	}
+	if skill != ai.SkillOff {
+		g.raceOpponent(skill, personality)
+	}
+}
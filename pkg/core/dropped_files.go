@@ -0,0 +1,44 @@
+package core
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// receiveDroppedFiles hands the first JSON file dropped on the window to the
+// save data import, and the first .turn file to correspondence games. Browsers
+// read dropped files asynchronously, so reading happens off the game loop and
+// the file arrives through PollUploadedSaveData or droppedTurns.
+func (g *Game) receiveDroppedFiles() {
+	files := ebiten.DroppedFiles()
+	if files == nil {
+		return
+	}
+	go func() {
+		fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			ext := strings.ToLower(path.Ext(name))
+			if ext != ".json" && ext != ".turn" {
+				return nil
+			}
+			data, err := fs.ReadFile(files, name)
+			if err != nil {
+				return nil
+			}
+			if ext == ".turn" {
+				select {
+				case g.droppedTurns <- strings.TrimSpace(string(data)):
+				default: // One is already waiting
+				}
+			} else {
+				g.saveSystem.ReceiveSaveData(data)
+			}
+			return fs.SkipAll
+		})
+	}()
+}
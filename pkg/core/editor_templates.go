@@ -0,0 +1,44 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// loadEditorTemplates hands the saved templates to the level editor
+func (g *Game) loadEditorTemplates() {
+	templates, err := g.saveSystem.LoadEditorTemplates()
+	if err != nil {
+		fmt.Println("Failed to load editor templates:", err)
+		return
+	}
+	g.levelEditor.SetUserTemplates(templates)
+}
+
+// saveEditorTemplate stores a part of the board the editor saved as a template
+func (g *Game) saveEditorTemplate(e events.TemplateSaved) {
+	template := storage.EditorTemplate{
+		ID:      fmt.Sprintf("template_%d", time.Now().UnixNano()),
+		Name:    e.Name,
+		Tiles:   e.Tiles,
+		Created: time.Now(),
+	}
+	if err := g.saveSystem.SaveEditorTemplate(template); err != nil {
+		fmt.Println("Failed to save editor template:", err)
+		g.levelEditor.Status = "Template could not be saved"
+		return
+	}
+	g.loadEditorTemplates()
+}
+
+// deleteEditorTemplate removes a template the player deleted in the editor
+func (g *Game) deleteEditorTemplate(e events.TemplateDeleted) {
+	if err := g.saveSystem.DeleteEditorTemplate(e.ID); err != nil {
+		fmt.Println("Failed to delete editor template:", err)
+		return
+	}
+	g.loadEditorTemplates()
+}
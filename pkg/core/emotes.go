@@ -0,0 +1,215 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+const (
+	emoteCooldown   = time.Second // Between two of the player's emotes
+	emoteBubbleLife = 2500 * time.Millisecond
+	emoteReplyDelay = 1200 * time.Millisecond // How long the AI takes to answer
+)
+
+// emoteChannel carries emotes between the two racers. Receive is polled every
+// frame and returns the other racer's emotes one at a time as they arrive.
+type emoteChannel interface {
+	Send(emote ui.Emote)
+	Receive() (ui.Emote, bool)
+}
+
+// emoteBubble is an emote on screen since at
+type emoteBubble struct {
+	emote    ui.Emote
+	opponent bool
+	at       time.Time
+}
+
+// aiEmotes is the channel's other end when racing the AI: the AI answers the
+// player's emotes and reacts to the race once each way it can go
+type aiEmotes struct {
+	progress func() (player, opponent float64)
+	clock    clock.Clock
+	pending  []pendingEmote // In the order they are due
+	reacted  map[ui.Emote]bool
+}
+
+type pendingEmote struct {
+	emote ui.Emote
+	due   time.Time
+}
+
+// aiReplies answers each emote the player sends
+var aiReplies = map[ui.Emote]ui.Emote{
+	ui.EmoteGG:      ui.EmoteGG,
+	ui.EmoteNice:    ui.EmoteNice,
+	ui.EmoteShocked: ui.EmoteHurry,
+	ui.EmoteHurry:   ui.EmoteShocked,
+}
+
+func newAIEmotes(progress func() (player, opponent float64), clk clock.Clock) *aiEmotes {
+	return &aiEmotes{progress: progress, clock: clk, reacted: make(map[ui.Emote]bool)}
+}
+
+func (a *aiEmotes) Send(emote ui.Emote) {
+	a.queue(aiReplies[emote])
+}
+
+func (a *aiEmotes) Receive() (ui.Emote, bool) {
+	a.react()
+	if len(a.pending) == 0 || a.clock.Now().Before(a.pending[0].due) {
+		return 0, false
+	}
+	emote := a.pending[0].emote
+	a.pending = a.pending[1:]
+	return emote, true
+}
+
+func (a *aiEmotes) queue(emote ui.Emote) {
+	a.pending = append(a.pending, pendingEmote{emote, a.clock.Now().Add(emoteReplyDelay)})
+}
+
+// react sends GG when the player wins, a shocked face when they pull well
+// ahead and a stopwatch when the AI nears the finish in front
+func (a *aiEmotes) react() {
+	player, opponent := a.progress()
+	var emote ui.Emote
+	switch {
+	case player >= 1:
+		emote = ui.EmoteGG
+	case player-opponent >= 0.25:
+		emote = ui.EmoteShocked
+	case opponent >= 0.75 && opponent > player:
+		emote = ui.EmoteHurry
+	default:
+		return
+	}
+	if !a.reacted[emote] {
+		a.reacted[emote] = true
+		a.queue(emote)
+	}
+}
+
+// matchEmotes is the channel's other end in online races: emotes go out as
+// match deltas, and the opponent's are handed over as the race receives them
+type matchEmotes struct {
+	send     func(ui.Emote)
+	received []ui.Emote
+}
+
+// raceEmote is the body of an emote delta, naming the emote
+type raceEmote struct {
+	Emote string `json:"emote"`
+}
+
+func (m *matchEmotes) Send(emote ui.Emote) {
+	m.send(emote)
+}
+
+func (m *matchEmotes) Receive() (ui.Emote, bool) {
+	if len(m.received) == 0 {
+		return 0, false
+	}
+	emote := m.received[0]
+	m.received = m.received[1:]
+	return emote, true
+}
+
+// emoteNamed is the emote with a name, as sent in emote deltas
+func emoteNamed(name string) (ui.Emote, bool) {
+	for emote := ui.Emote(0); emote.String() != "Unknown"; emote++ {
+		if emote.String() == name {
+			return emote, true
+		}
+	}
+	return 0, false
+}
+
+// racing reports whether the player is racing the AI or another player online
+func (g *Game) racing() bool {
+	return g.opponent != nil || g.world.OnlineRace != nil
+}
+
+// raceProgress is how far each racer is from connecting every island
+func (g *Game) raceProgress() (player, opponent float64) {
+	if r := g.world.OnlineRace; r != nil {
+		return r.progress(g.world.Board)
+	}
+	return g.opponent.ProgressOf(g.world.Board), g.opponent.Progress()
+}
+
+// emoteWheelShown reports whether the emote button is on screen: in races,
+// including the victory screen so the player can say GG
+func (g *Game) emoteWheelShown() bool {
+	return g.racing() && g.emotes != nil && g.world.State == StatePlaying && g.world.Board != nil
+}
+
+// sendEmote sends an emote to the opponent and shows it over the player's bar
+func (g *Game) sendEmote(emote ui.Emote) {
+	if !g.emoteWheelShown() {
+		return
+	}
+	now := g.clock.Now()
+	if now.Sub(g.lastEmote) < emoteCooldown {
+		return
+	}
+	g.lastEmote = now
+	g.emotes.Send(emote)
+	g.emoteBubbles = append(g.emoteBubbles, emoteBubble{emote, false, now})
+}
+
+// updateEmotes shows the opponent's emotes as they arrive, unless muted, and
+// lets old bubbles go
+func (g *Game) updateEmotes() {
+	if !g.racing() || g.emotes == nil {
+		return
+	}
+	now := g.clock.Now()
+	for {
+		emote, ok := g.emotes.Receive()
+		if !ok {
+			break
+		}
+		if !g.muteEmotes {
+			g.emoteBubbles = append(g.emoteBubbles, emoteBubble{emote, true, now})
+		}
+	}
+	for len(g.emoteBubbles) > 0 && now.Sub(g.emoteBubbles[0].at) >= emoteBubbleLife {
+		g.emoteBubbles = g.emoteBubbles[1:]
+	}
+}
+
+// bubblesNow returns the bubbles for the race bars, aged by the clock
+func (g *Game) bubblesNow() []ui.EmoteBubble {
+	now := g.clock.Now()
+	bubbles := make([]ui.EmoteBubble, 0, len(g.emoteBubbles))
+	for _, bubble := range g.emoteBubbles {
+		age := float64(now.Sub(bubble.at)) / float64(emoteBubbleLife)
+		bubbles = append(bubbles, ui.EmoteBubble{Emote: bubble.emote, Opponent: bubble.opponent, Age: age})
+	}
+	return bubbles
+}
+
+// toggleMuteEmotes hides or shows the opponent's emotes from now on, clearing
+// any already on screen when muting
+func (g *Game) toggleMuteEmotes() {
+	settings, _ := g.saveSystem.LoadSettings()
+	settings.MuteEmotes = !settings.MuteEmotes
+	if err := g.saveSystem.SaveSettings(settings); err != nil {
+		fmt.Println("Failed to save the emote setting:", err)
+	}
+	g.applySettings(settings)
+
+	if g.muteEmotes {
+		kept := g.emoteBubbles[:0]
+		for _, bubble := range g.emoteBubbles {
+			if !bubble.opponent {
+				kept = append(kept, bubble)
+			}
+		}
+		g.emoteBubbles = kept
+	}
+}
@@ -1,14 +1,31 @@
 package core
 
 import (
+	"fmt"
 	"image/color"
+	"slices"
 	"time"
 	
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/ponyo877/island-merge/pkg/achievements"
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/analytics"
+	"github.com/ponyo877/island-merge/pkg/assets"
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/clock"
 	"github.com/ponyo877/island-merge/pkg/editor"
+	"github.com/ponyo877/island-merge/pkg/engine"
+	"github.com/ponyo877/island-merge/pkg/events"
 	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/jsapi"
 	"github.com/ponyo877/island-merge/pkg/levels"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/profiler"
+	"github.com/ponyo877/island-merge/pkg/quests"
+	"github.com/ponyo877/island-merge/pkg/random"
+	"github.com/ponyo877/island-merge/pkg/remote"
+	"github.com/ponyo877/island-merge/pkg/replay"
+	"github.com/ponyo877/island-merge/pkg/solver"
 	"github.com/ponyo877/island-merge/pkg/storage"
 	"github.com/ponyo877/island-merge/pkg/systems"
 	"github.com/ponyo877/island-merge/pkg/ui"
@@ -16,56 +33,292 @@ import (
 
 type Game struct {
 	world           *World
+	events          *events.Bus
 	input           *systems.InputSystem
 	render          *systems.RenderSystem
+	clock           *clock.GameClock // Gameplay time; paused while the settings panel covers a game
+	animClock       *clock.GameClock // Follows clock, scaled by the animation speed setting
 	animation       *systems.AnimationSystem
+	sound           *systems.SoundSystem
+	traffic         *systems.TrafficSystem // Cosmetic travelers on the bridge network
 	mainMenu        *ui.Menu
+	showcase        *menuShowcase // Demo board solving itself behind the main menu
+	menuCardsFresh  bool          // The menu's continue and recent cards match storage
+	thumbnails      *ui.Thumbnails // Board miniatures for level buttons and menu cards
 	levelEditor     *editor.LevelEditor
 	achievementSys  *achievements.AchievementSystem
 	achievementUI   *ui.AchievementsUI
+	quests          *quests.Log // Daily and weekly goals; their tokens unlock bridge paints
+	questsUI        *ui.QuestsUI
+	theme           assets.Theme // Board colours from theme.json, before the bridge paint
+	bridgePaint     string       // Cosmetic bridge colour chosen in the quests panel
+	title           string       // Achievement title chosen in the achievements panel
+	analytics       *analytics.Recorder // Opt-in anonymous gameplay statistics
+	outbox          *gamenet.Queue      // Score submissions and level uploads waiting for the online backend
+	onlineURL       string              // Where the outbox sends and online matches connect; empty keeps the game offline
+	jsAPI           *jsapi.API          // Lets pages embedding the wasm build drive the game
 	saveSystem      *storage.SaveSystem
 	saveLoadUI      *ui.SaveLoadUI
 	levelManager    *levels.LevelManager
 	levelSelectUI   *ui.LevelSelectUI
+	customLevelsUI  *ui.CustomLevelsUI
+	profileSelectUI *ui.ProfileSelectUI
+	tutorialUI      *ui.TutorialUI
+	tooltip         *ui.Tooltip
+	helpOverlay     *ui.HelpOverlay
+	console         *ui.Console // Developer console; only opens in debug builds
+	componentsOverlay bool      // Console overlay tinting tiles by connected group
+	inspector       *ui.BoardInspector
+	profiler        *profiler.Profiler // Per-system frame timings, recorded while the overlay is shown
+	profilerOverlay *ui.ProfilerOverlay
+	seed            *int64      // Console seed for repeatable randomness; nil uses the time
+	hud             *ui.HUD
 	currentLevel    *levels.LevelData
+	opponent        *ai.Opponent // AI racer for Time Attack, nil when disabled
+	weeklyFetcher   *remote.WeeklyFetcher
+	weeklyLevel     *levels.WeeklyLevel // nil until downloaded or loaded from cache
+	weeklyFailed    bool
+	challenge       *levels.WeeklyChallenge // This week's playlist; regenerated when the week changes
+	challengeScores storage.ChallengeProgress
+	offline         bool // No network connection; downloads and uploads wait for one
+	storageFull     bool // Saved data stopped fitting in storage
+	storageWarning  *ui.StorageWarning
+	window          *storage.WindowState // Desktop window placement, saved on close
+	presence        *presenceReporter    // Tells presence providers what the player is doing
+	customLevelID   string // Custom level being played, for its workshop stats
+	replay          *replay.Replay // Bridges built this game, for solution GIFs
+	screenshotDue   bool            // F12 was pressed; taken at the end of the next Draw
+	captureMessage  string
+	captureMessageAt time.Time
+	hintTile        *island.Point // Solver's suggested move, shown until hintUntil
+	hintUntil       time.Time
+	moveFeedbackOn  bool             // Move feedback setting
+	autoSave        bool             // Save every few moves and on significant events so a crash loses little
+	autoSaveMoves   int              // Moves between auto-saves
+	movesSinceSave  int
+	focused         bool             // Whether the window had focus last frame
+	saveToast       *ui.SaveToast
+	doNotDisturb    bool             // Achievement notifications wait for the level to end
+	crashErr        error            // Panic recovered in Draw, returned by the next Update
+	crashDialog     *ui.ConfirmDialog
+	sizePicker      *ui.SizePicker // Board size for Classic games
+	mutatorPicker   *ui.MutatorPicker
+	mutators        []MutatorID         // Picked for the next level started; cleared once it starts
+	pendingLevel    *levels.LevelData   // Level waiting for its mutators to be picked
+	speedrunPicker  *ui.ChoicePicker
+	speedrun        *speedrun // Run in progress; nil outside Speedrun mode
+	zenPicker       *ui.ChoicePicker
+	correspondencePicker *ui.ChoicePicker
+	onlinePicker         *ui.ChoicePicker
+	correspondenceIDs    []string    // Games listed in the picker after New game, by ID
+	droppedTurns         chan string // Codes from .turn files dropped on the window
+	raceMatch            *gamenet.Match // The online race's match while it is being played
+	defeatScreen    *ui.DefeatScreen // Covers a lost game with its statistics and a retry
+	votePrompt      *ui.VotePrompt
+	nextLevelButton *ui.NextLevelButton   // Offers the recommended level on the victory screen
+	recommendation  *levels.Recommendation // Level suggested from recent wins; nil when none is left
+	nextLevelAt     time.Time             // When auto-advance plays the recommended level
+	autoAdvance     bool                  // Play the recommended level by itself after each win
+	voteLevel       *levels.LevelData // Shared level waiting to be voted on; nil when none
+	voteDue         time.Time         // When the vote prompt opens over the won level
+	votePoster      *remote.VotePoster
+	votesInFlight   []storage.LevelVote
+	assetWatcher    *assets.Watcher // Reloads edited levels and theme in dev mode
+	moveAnalyzer    *solver.Analyzer // Rates moves for the feedback aid; nil until a move is rated
+	lastMove        *island.Point    // Last rated bridge, highlighted until lastMoveUntil
+	lastMoveQuality solver.MoveQuality
+	lastMoveUntil   time.Time
+	planBar         *ui.PlanBar
+	planning        bool          // Board clicks plan bridges instead of building them
+	plannedBridges  []island.Point // Ghost bridges waiting to be committed, in the order planned
+	emoteWheel      *ui.EmoteWheel
+	ratingChange    *storage.RatingEntry // Rating after the race just finished, shown with its result; nil otherwise
+	taunts          raceTaunts           // What the AI racer has said this race
+	emotes          emoteChannel   // Carries emotes to and from the racing opponent; nil outside races
+	emoteBubbles    []emoteBubble  // Emotes floating over the race bars, oldest first
+	lastEmote       time.Time      // When the player last sent an emote
+	muteEmotes      bool           // Hide the opponent's emotes
+	measure         *measurement   // Measuring tool's tiles while M is held; nil otherwise
+	miniMap         *ui.MiniMap    // Whole board at a glance when it runs off screen
+	victoryAnim     *systems.Animation // Head of the victory sequence; star reveals chain after it
+	revealedStars   int
+	wonStars        int // Stars earned by the level just won, for the share card
+	recordBanner    string // Records broken by the last win, shown on the results screen
+	recordBannerAt  time.Time
+	relaxed         bool // Relaxed mode setting; applies to games started after it changes
+	modeDefaults    map[int]storage.ModeDefaults // Per-mode overrides of relaxed mode and move feedback
+	launched        bool // Whether the launch setting has opened its game yet
+	overtime        bool // Time Attack overtime setting; applies to games started after it changes
+	heartbeatSecond int  // Last second of the countdown the heartbeat played for
+	session         playSession // This sitting of play, for play time and the session summary
+	idlePause       time.Duration // How long a timed level waits for input before pausing; 0 never
+	idleSince       time.Time     // Last input, or when idling stopped mattering
+	idlePaused      bool          // The pause was for inactivity, so a click resumes
 }
 
+// captureMessageDuration is how long capture results stay in the HUD hints
+const captureMessageDuration = 3 * time.Second
+
+// starRevealDuration is how long each earned star takes to pop in
+const starRevealDuration = 400 * time.Millisecond
+
+// islandMergeDuration is how long the flash lasts when a bridge joins islands
+const islandMergeDuration = 600 * time.Millisecond
+
+// victoryPathStepDuration is how long the victory pulse takes to advance one tile
+const victoryPathStepDuration = 80 * time.Millisecond
+
+// Board camera and hint controls
+const (
+	zoomStep     = 1.25
+	panSpeed     = 360.0 // Pixels per second while a pan control is held
+	hintDuration = 3 * time.Second
+	
+	moveFeedbackDuration = 1500 * time.Millisecond
+)
+
+// moveQualityColors and moveQualityLabels are indexed by solver.MoveQuality
+var (
+	moveQualityColors = []color.RGBA{{76, 175, 80, 255}, {255, 193, 7, 255}, {244, 67, 54, 255}}
+	moveQualityLabels = []string{"Good move!", "Okay move", "Wasted move"}
+)
+
+// weeklyRefreshInterval is how long a cached level of the week is used before downloading again
+const weeklyRefreshInterval = 24 * time.Hour
+
 func NewGame() *Game {
-	achievementSys := achievements.NewAchievementSystem()
+	bus := events.NewBus()
+	gameClock := clock.NewGameClock(clock.System())
+	animClock := clock.NewGameClock(gameClock)
+	achievementSys := achievements.NewAchievementSystem(clock.System())
 	saveSystem := storage.NewSaveSystem()
-	levelEditor := editor.NewLevelEditor()
+	levelEditor := editor.NewLevelEditor(bus)
 	levelManager := levels.NewLevelManager()
 	
 	game := &Game{
+		events:         bus,
 		input:          systems.NewInputSystem(),
-		render:         systems.NewRenderSystem(),
-		animation:      systems.NewAnimationSystem(),
+		render:         systems.NewRenderSystem(animClock),
+		clock:          gameClock,
+		animClock:      animClock,
+		animation:      systems.NewAnimationSystem(animClock),
+		sound:          systems.NewSoundSystem(),
+		traffic:        systems.NewTrafficSystem(gameClock),
 		levelEditor:    levelEditor,
 		achievementSys: achievementSys,
-		achievementUI:  ui.NewAchievementsUI(achievementSys),
+		achievementUI:  ui.NewAchievementsUI(achievementSys, animClock),
+		analytics:      analytics.NewRecorder(saveSystem),
+		outbox:         gamenet.NewQueue(saveSystem),
+		jsAPI:          jsapi.New(),
 		saveSystem:     saveSystem,
-		saveLoadUI:     ui.NewSaveLoadUI(saveSystem),
+		saveLoadUI:     ui.NewSaveLoadUI(saveSystem, bus),
 		levelManager:   levelManager,
 		levelSelectUI:  ui.NewLevelSelectUI(levelManager),
+		customLevelsUI: ui.NewCustomLevelsUI(saveSystem),
+		profileSelectUI: ui.NewProfileSelectUI(saveSystem),
+		tutorialUI:     ui.NewTutorialUI(),
+		tooltip:        ui.NewTooltip(),
+		crashDialog:    ui.NewConfirmDialog(),
+		sizePicker:     ui.NewSizePicker(),
+		mutatorPicker:  ui.NewMutatorPicker(mutatorOptions()),
+		speedrunPicker: ui.NewChoicePicker(),
+		zenPicker:      ui.NewChoicePicker(),
+		correspondencePicker: ui.NewChoicePicker(),
+		onlinePicker:         ui.NewChoicePicker(),
+		droppedTurns:   make(chan string, 1),
+		defeatScreen:   ui.NewDefeatScreen(),
+		votePrompt:     ui.NewVotePrompt(),
+		nextLevelButton: ui.NewNextLevelButton(),
+		quests:         quests.NewLog(),
+		questsUI:       ui.NewQuestsUI(),
+		storageWarning: ui.NewStorageWarning(),
+		planBar:        ui.NewPlanBar(),
+		emoteWheel:     ui.NewEmoteWheel(),
+		miniMap:        ui.NewMiniMap(),
+		helpOverlay:    ui.NewHelpOverlay(),
+		console:        ui.NewConsole(),
+		inspector:      ui.NewBoardInspector(),
+		profiler:       profiler.New(),
+		profilerOverlay: ui.NewProfilerOverlay(),
+		hud:            ui.NewHUD(),
+		saveToast:      ui.NewSaveToast(),
+		focused:        true,
+		assetWatcher:   assets.NewWatcher(),
 	}
 	
-	// Set up callbacks
-	levelEditor.OnLevelCreated = func() {
-		achievementSys.OnLevelCreated()
-	}
-	
-	game.saveLoadUI.OnSaveGame = game.saveGame
-	game.saveLoadUI.OnLoadGame = game.loadGame
+	// Set up event subscribers and callbacks
+	game.subscribeEvents()
+	game.subscribeQuests()
+	game.analytics.Subscribe(bus)
+	game.presence = newPresenceReporter(game.presenceLevelName)
+	game.presence.Subscribe(bus)
 	
-	game.levelSelectUI.OnLevelSelected = game.startLevel
+	game.levelSelectUI.OnLevelSelected = game.chooseMutators
 	game.levelSelectUI.OnBack = func() {
 		game.world.State = StateMenu
 	}
+	game.levelSelectUI.OnImportPack = game.importLevelPack
+	game.sizePicker.OnPick = game.startClassic
+	game.mutatorPicker.OnPlay = game.startWithMutators
+	game.speedrunPicker.OnChoose = game.startSpeedrun
+	game.zenPicker.OnChoose = game.startZen
+	game.correspondencePicker.OnChoose = game.startCorrespondence
+	game.onlinePicker.OnChoose = game.chooseOnline
+	game.defeatScreen.OnRetry = game.retryGame
+	game.nextLevelButton.OnPlay = game.playRecommended
+	game.defeatScreen.OnLevelSelect = func() {
+		game.world.State = StateLevelSelect
+		game.levelSelectUI.Show()
+	}
+	game.defeatScreen.OnMenu = func() {
+		game.world.State = StateMenu
+	}
+	game.storageWarning.OnFreeSpace = game.saveLoadUI.OpenDataTab
+	game.planBar.OnToggle = game.togglePlanning
+	game.planBar.OnCommit = game.commitPlan
+	game.emoteWheel.OnEmote = game.sendEmote
+	game.emoteWheel.OnMute = game.toggleMuteEmotes
+	game.miniMap.OnJump = game.render.CenterOn
+	game.mutatorPicker.OnCancel = func() {
+		game.levelSelectUI.Show()
+	}
+	game.achievementUI.OnTitle = game.pickTitle
+	game.achievementUI.OnShown = func(*achievements.Achievement) {
+		game.sound.Play(systems.SoundAchievement)
+	}
+	game.customLevelsUI.OnLevelSelected = game.startCustomLevel
+	game.customLevelsUI.OnExported = game.uploadLevelPack
+	game.customLevelsUI.OnBack = func() {
+		game.world.State = StateMenu
+	}
+	game.tutorialUI.OnFinished = game.finishTutorial
+	game.profileSelectUI.OnProfileSelected = game.switchProfile
+	game.profileSelectUI.OnBack = func() {
+		game.world.State = StateMenu
+	}
+	game.console.OnCommand = game.runConsoleCommand
+	game.questsUI.OnPaint = game.pickBridgePaint
 	
-	// Try to load saved achievements
+	// Try to load saved achievements and quests, race rating, installed level packs, editor templates and the profile's level progress
 	game.loadAchievements()
+	game.loadQuests()
+	game.showRatings()
+	game.loadLevelPacks()
+	game.loadEditorTemplates()
+	game.restoreLevelProgress()
 	
 	game.mainMenu = ui.NewMainMenu(game.handleMenuAction)
+	game.showcase = newMenuShowcase(animClock)
+	game.mainMenu.Backdrop = game.showcase.Draw
+	game.theme = game.render.Theme()
+	game.thumbnails = ui.NewThumbnails(game.theme)
+	game.mainMenu.Thumbnails = game.thumbnails
+	game.levelSelectUI.Thumbnails = game.thumbnails
+	game.initWeeklyLevel()
+	game.refreshChallenge()
+	game.refreshQuests()
+	game.showLastSession()
+	game.initVotePoster()
 	
 	// Initialize with menu state
 	game.world = &World{
@@ -73,78 +326,719 @@ func NewGame() *Game {
 		Mode:  ModeClassic,
 	}
 	
+	game.saveLoadUI.OnSettingsChanged = game.applySettings
+	game.saveLoadUI.AnalyticsPending = game.analytics.Pending
+	game.saveLoadUI.Modes = modeOptions()
+	
+	// First launch starts with the tutorial; shared devices ask who is playing
+	// first, and otherwise the game opens on what the player chose
+	if settings, err := saveSystem.LoadSettings(); err == nil {
+		game.applySettings(settings)
+		if settings.ShowTutorial {
+			game.startTutorial()
+		} else if len(saveSystem.Profiles()) <= 1 {
+			game.launch(settings)
+		}
+	}
+	if len(saveSystem.Profiles()) > 1 {
+		game.showProfileSelect()
+	}
+	game.offerCrashRecovery()
+	
+	// Embedding pages can only start levels once everything above is ready
+	game.jsAPI.Install(bus)
+	
 	return game
 }
 
+// showProfileSelect opens the profile picker over an empty screen
+func (g *Game) showProfileSelect() {
+	g.currentLevel = nil
+	g.opponent = nil
+	g.world = &World{
+		State: StateProfileSelect,
+		Mode:  ModeClassic,
+	}
+	g.profileSelectUI.Show()
+}
+
+// switchProfile saves the current player's achievements and loads everything
+// belonging to the chosen profile
+func (g *Game) switchProfile(profileID string) {
+	g.saveSession()
+	g.session = playSession{}
+	if achievementData, err := g.achievementSys.SaveToJSON(); err == nil {
+		g.saveSystem.SaveAchievements(achievementData)
+	}
+	g.saveQuests()
+	if err := g.saveSystem.SwitchProfile(profileID); err != nil {
+		fmt.Println("Failed to switch profile:", err)
+	}
+	
+	g.achievementSys.Reset()
+	g.loadAchievements()
+	g.loadQuests()
+	g.showRatings()
+	g.restoreLevelProgress()
+	g.refreshChallenge()
+	g.refreshQuests()
+	g.postLevelVotes()
+	g.showLastSession()
+	
+	settings, _ := g.saveSystem.LoadSettings()
+	g.applySettings(settings)
+	g.world.State = StateMenu
+	if settings.ShowTutorial {
+		g.startTutorial()
+	} else {
+		g.launch(settings)
+	}
+}
+
+// restoreLevelProgress replays the active profile's completed levels so the
+// same levels are unlocked and show the same best scores as when it last played
+func (g *Game) restoreLevelProgress() {
+	g.levelManager.ResetProgress()
+	
+	progress, err := g.saveSystem.LoadProgress()
+	if err != nil {
+		g.refreshRecommendation()
+		return
+	}
+	for _, levelID := range progress.CompletedLevels {
+		level := g.levelManager.GetLevelByID(levelID)
+		if level == nil {
+			continue // Weekly levels and removed packs
+		}
+		g.levelManager.UnlockNextLevel(levelID)
+		
+		for _, entry := range g.saveSystem.LevelHighScores(levelID) {
+			if level.BestScore == nil || entry.Stars > level.BestScore.Stars {
+				level.BestScore = &levels.Score{Moves: entry.Moves, Time: entry.Time, Stars: entry.Stars, Date: entry.Date}
+			}
+		}
+		if level.BestScore != nil {
+			g.levelManager.Progress[levelID] = level.BestScore
+		}
+	}
+	g.refreshRecommendation()
+}
+
+// applySettings applies settings that take effect immediately
+func (g *Game) applySettings(settings *storage.GameSettings) {
+	g.animClock.SetScale(settings.AnimationSpeed)
+	g.sound.SetEnabled(settings.SoundEnabled)
+	g.input.SetBindings(systems.BindingsFromSettings(settings.KeyBindings))
+	
+	quality := settings.Graphics()
+	ebiten.SetTPS(quality.TPS)
+	ebiten.SetRunnableOnUnfocused(quality.RunWhenUnfocused)
+	ebiten.SetVsyncEnabled(quality.VSync)
+	g.render.SetEffects(quality.AmbientAnimations, quality.ReducedEffects)
+	g.showcase.SetEffects(quality.AmbientAnimations, quality.ReducedEffects)
+	g.traffic.SetEnabled(quality.AmbientAnimations)
+	g.animation.SetReducedEffects(quality.ReducedEffects)
+	
+	// Relaxed mode has no timers, so the purely timed mode is hidden
+	g.analytics.Configure(settings.AnalyticsEnabled, settings.AnalyticsURL)
+	g.configureOnline(settings.OnlineURL)
+	g.autoSave = settings.AutoSave
+	g.autoSaveMoves = settings.AutoSaveEvery()
+	g.relaxed = settings.RelaxedMode
+	g.overtime = settings.TimeAttackOvertime
+	g.idlePause = settings.IdlePauseAfter()
+	g.moveFeedbackOn = settings.MoveFeedback
+	g.modeDefaults = settings.ModeDefaults
+	g.doNotDisturb = settings.DoNotDisturb
+	g.bridgePaint = settings.BridgePaint
+	g.title = settings.Title
+	g.achievementUI.SetTitle(settings.Title)
+	g.muteEmotes = settings.MuteEmotes
+	g.autoAdvance = settings.AutoAdvance
+	g.applyTheme()
+	g.mainMenu.SetItemVisible(timeAttackMenuItem, !g.relaxedIn(ModeTimeAttack))
+	g.mainMenu.SetItemVisible(speedrunMenuItem, !g.relaxed)
+	g.updateWeeklyMenuItem()
+	g.updateZenMenuItem()
+	g.updateCorrespondenceMenuItem()
+}
+
+// levelInProgress reports whether a level is being played or is paused, as
+// opposed to showing its results
+func (g *Game) levelInProgress() bool {
+	playing := g.world.State == StatePlaying || g.world.State == StatePaused
+	return playing && g.world.Board != nil && !g.world.GameWon && !g.defeatScreen.IsOpen()
+}
+
+// subscribeEvents wires systems that react to gameplay instead of being called from Update
+func (g *Game) subscribeEvents() {
+	events.Subscribe(g.events, func(events.GameStarted) {
+		g.achievementSys.OnGameStart()
+	})
+	events.Subscribe(g.events, func(events.GameStarted) {
+		g.replay = replay.New(g.world.Board)
+	})
+	events.Subscribe(g.events, func(events.GameStarted) {
+		g.zoomToFit()
+		g.hintTile = nil
+		g.moveAnalyzer = nil
+		g.lastMove = nil
+		g.clearPlan()
+		g.measure = nil
+		g.movesSinceSave = 0
+		g.animation.Clear()
+		g.victoryAnim = nil
+		g.revealedStars = 0
+		g.wonStars = 0
+		g.heartbeatSecond = 0
+		g.ratingChange = nil
+		g.defeatScreen.Hide()
+	})
+	events.Subscribe(g.events, func(e events.LevelCompleted) {
+		g.revealStars(e.Stars)
+		g.wonStars = e.Stars
+		g.session.levels++
+		g.session.stars += e.Stars
+		g.saveSession()
+	})
+	events.Subscribe(g.events, g.recordLevelResult)
+	events.Subscribe(g.events, func(events.BridgeBuilt) {
+		g.achievementSys.OnBridgeBuilt()
+	})
+	events.Subscribe(g.events, func(events.BridgeBuilt) {
+		g.autoSaveAfterMove()
+	})
+	events.Subscribe(g.events, func(events.BridgeRemoved) {
+		g.autoSaveAfterMove()
+	})
+	events.Subscribe(g.events, func(events.GameWon) {
+		g.autoSaveNow()
+	})
+	events.Subscribe(g.events, func(events.BridgeBuilt) {
+		g.saveZenSession()
+	})
+	events.Subscribe(g.events, func(events.BridgeRemoved) {
+		g.saveZenSession()
+	})
+	events.Subscribe(g.events, g.correspondenceMoved)
+	events.Subscribe(g.events, func(e events.BridgeBuilt) {
+		g.raceMoved(gamenet.DeltaBridge, e.X, e.Y)
+	})
+	events.Subscribe(g.events, func(e events.BridgeRemoved) {
+		g.raceMoved(gamenet.DeltaRemove, e.X, e.Y)
+	})
+	events.Subscribe(g.events, func(e events.BridgeBuilt) {
+		x, y := g.render.TileCenter(e.X, e.Y)
+		g.animation.Particles().EmitSplash(x, y, 16)
+	})
+	events.Subscribe(g.events, func(e events.IslandsMerged) {
+		g.animation.AddAnimationWithData(systems.AnimationIslandMerge, e.X, e.Y, islandMergeDuration, g.world.Board.GroupLand(e.X, e.Y))
+		g.sound.Play(systems.SoundIslandsMerged)
+	})
+	events.Subscribe(g.events, g.focusMergedGroup)
+	events.Subscribe(g.events, func(e events.BridgeRemoved) {
+		x, y := g.render.TileCenter(e.X, e.Y)
+		g.animation.Particles().EmitDust(x, y, 12)
+	})
+	events.Subscribe(g.events, func(events.GameWon) {
+		g.animation.Particles().EmitConfetti(0, 0, 640, 120)
+	})
+	events.Subscribe(g.events, func(e events.BridgeBuilt) {
+		if g.replay != nil {
+			g.replay.Record(e.X, e.Y, e.At)
+		}
+	})
+	events.Subscribe(g.events, func(e events.BridgeRemoved) {
+		if g.replay != nil {
+			g.replay.RecordRemoval(e.X, e.Y, e.At)
+		}
+	})
+	events.Subscribe(g.events, func(e events.BridgeCollapsed) {
+		if g.replay != nil {
+			g.replay.RecordCollapse(e.X, e.Y, e.At)
+		}
+		x, y := g.render.TileCenter(e.X, e.Y)
+		g.animation.Particles().EmitSplash(x, y, 24)
+	})
+	events.Subscribe(g.events, func(e events.BridgeRepaired) {
+		x, y := g.render.TileCenter(e.X, e.Y)
+		g.animation.Particles().EmitDust(x, y, 8)
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		g.achievementSys.OnGameWin(e.Moves, e.Time, e.IsTimeAttack, e.IsPerfect)
+		g.achievementSys.OnMutatorWin(e.Mutators, len(Mutators))
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		g.handleLevelCompletion(e.Time, e.Moves, e.Points)
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		g.recordWeeklyScore(e)
+		g.recordChallengeStage(e)
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		g.recordPersonalBest(e)
+	})
+	events.Subscribe(g.events, func(e events.GameStarted) {
+		g.loadPersonalBest(e.LevelID)
+	})
+	events.Subscribe(g.events, g.endSpeedrun)
+	events.Subscribe(g.events, g.recordSplit)
+	events.Subscribe(g.events, g.queueVotePrompt)
+	events.Subscribe(g.events, g.rateRaceWin)
+	events.Subscribe(g.events, func(events.GameWon) {
+		g.taunt(ai.TauntLost)
+	})
+	events.Subscribe(g.events, g.tauntRaceEnd)
+	events.Subscribe(g.events, g.rateRaceLoss) // Before the defeat screen, which shows the change
+	events.Subscribe(g.events, func(e events.GameLost) {
+		g.showDefeat(e.Reason)
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		if e.LevelID != "" && e.LevelID == g.customLevelID {
+			g.saveSystem.RecordCustomLevelCompletion(e.LevelID, storage.ScoreData{Moves: e.Moves, Time: e.Time})
+		}
+	})
+	events.Subscribe(g.events, func(events.LevelCreated) {
+		g.achievementSys.OnLevelCreated()
+	})
+	events.Subscribe(g.events, func(e events.LevelCreated) {
+		g.saveCreatedLevel(e)
+	})
+	events.Subscribe(g.events, g.exportEditorGraph)
+	events.Subscribe(g.events, g.saveEditorTemplate)
+	events.Subscribe(g.events, g.startCoEdit)
+	events.Subscribe(g.events, g.deleteEditorTemplate)
+	events.Subscribe(g.events, g.recordRecentLevel)
+	events.Subscribe(g.events, func(events.SaveRequested) {
+		g.saveGame()
+	})
+	events.Subscribe(g.events, func(events.LoadRequested) {
+		g.loadGame()
+	})
+	events.Subscribe(g.events, func(events.TutorialRequested) {
+		g.startTutorial()
+	})
+	events.Subscribe(g.events, func(events.ProfileSelectRequested) {
+		g.showProfileSelect()
+	})
+	events.Subscribe(g.events, func(events.SaveDataImported) {
+		g.reloadImportedData()
+	})
+}
+
+// reloadImportedData picks up imported achievements and progress. Achievements
+// are re-evaluated against the imported statistics, so trophies earned on
+// another device unlock even if its save predates them.
+func (g *Game) reloadImportedData() {
+	g.achievementSys.Reset()
+	g.loadAchievements()
+	if g.achievementSys.Reevaluate() > 0 {
+		if achievementData, err := g.achievementSys.SaveToJSON(); err == nil {
+			g.saveSystem.SaveAchievements(achievementData)
+		}
+	}
+	g.restoreLevelProgress()
+	g.refreshChallenge()
+}
+
+func (g *Game) startTutorial() {
+	g.currentLevel = nil
+	g.opponent = nil
+	g.tutorialUI.Start()
+	g.world = &World{
+		State: StateTutorial,
+		Mode:  ModeClassic,
+	}
+}
+
+func (g *Game) finishTutorial() {
+	if settings, err := g.saveSystem.LoadSettings(); err == nil {
+		settings.ShowTutorial = false
+		g.saveSystem.SaveSettings(settings)
+	}
+	g.world.State = StateMenu
+}
+
+// Main menu indices, in the order of the items in ui.NewMainMenu
+const (
+	levelSelectMenuItem = iota
+	timeAttackMenuItem
+	puzzleMenuItem
+	editorMenuItem
+	customLevelsMenuItem
+	weeklyLevelMenuItem
+	bridgeCountsMenuItem
+	classicMenuItem
+	stormMenuItem
+	challengeMenuItem
+	speedrunMenuItem
+	zenMenuItem
+	questsMenuItem
+	onlineMenuItem
+)
+
 func (g *Game) handleMenuAction(action int) {
 	switch action {
-	case 0: // Level Select
+	case levelSelectMenuItem:
 		g.world.State = StateLevelSelect
 		g.levelSelectUI.Show()
-	case 1: // Time Attack
-		g.startGameMode(1)
-	case 2: // Puzzle Mode
-		g.startGameMode(2)
-	case 3: // Level Editor
+	case timeAttackMenuItem:
+		g.startTimeAttack()
+	case puzzleMenuItem:
+		g.startGameMode(ModePuzzle)
+	case editorMenuItem:
 		g.world.State = StateLevelEditor
+	case customLevelsMenuItem:
+		g.world.State = StateCustomLevels
+		g.customLevelsUI.Show()
+	case weeklyLevelMenuItem:
+		if g.weeklyLevel != nil {
+			g.startLevel(g.weeklyLevel.Level)
+		}
+	case bridgeCountsMenuItem:
+		g.startBridgeCounts()
+	case classicMenuItem: // On a board of a chosen size
+		if settings, err := g.saveSystem.LoadSettings(); err == nil {
+			g.sizePicker.Select(settings.ClassicWidth, settings.ClassicHeight)
+		}
+		g.sizePicker.Show()
+	case stormMenuItem:
+		g.startStorm()
+	case challengeMenuItem: // Weekly challenge playlist
+		g.startChallenge()
+	case speedrunMenuItem: // Every level of a difficulty back to back
+		g.showSpeedrunPicker()
+	case zenMenuItem: // Endless board that grows each time it is connected
+		g.showZenPicker()
+	case questsMenuItem: // Daily and weekly quests and the bridge paints they unlock
+		g.showQuests()
+	case onlineMenuItem: // Online Race and Correspondence
+		g.showOnlinePicker()
+	}
+}
+
+// initWeeklyLevel shows the cached level of the week and downloads a new one when it is stale
+func (g *Game) initWeeklyLevel() {
+	settings, _ := g.saveSystem.LoadSettings()
+	g.weeklyFetcher = remote.NewWeeklyFetcher(settings.WeeklyLevelURL)
+	g.mainMenu.SetItemVisible(weeklyLevelMenuItem, g.weeklyFetcher.Enabled())
+	if !g.weeklyFetcher.Enabled() {
+		return
+	}
+	
+	stale := true
+	if cached, err := g.saveSystem.LoadWeeklyLevel(); err == nil {
+		if weekly, err := levels.ParseWeekly(cached.Data); err == nil {
+			g.weeklyLevel = weekly
+			stale = time.Since(cached.FetchedAt) > weeklyRefreshInterval
+		}
+	}
+	if stale && g.saveSystem.Offline() {
+		g.weeklyFailed = true
+	} else if stale {
+		g.weeklyFetcher.Start()
+	}
+	g.updateWeeklyMenuItem()
+}
+
+// checkOffline notes on the menu when the connection drops and retries a failed
+// level of the week download once it returns
+func (g *Game) checkOffline() {
+	offline := g.saveSystem.Offline()
+	if offline == g.offline {
+		return
+	}
+	g.offline = offline
+	if offline {
+		g.mainMenu.Status = "Offline - progress is saved on this device"
+		return
+	}
+	
+	g.mainMenu.Status = ""
+	if g.weeklyFailed && g.weeklyFetcher.Enabled() {
+		g.weeklyFailed = false
+		g.weeklyFetcher.Start()
+		g.updateWeeklyMenuItem()
+	}
+	g.postLevelVotes()
+	g.outbox.RetryNow()
+}
+
+// checkStorage warns when saved data stops fitting in storage and takes the
+// warning down once it fits again
+func (g *Game) checkStorage() {
+	full := g.saveSystem.StorageFull()
+	if full == g.storageFull {
+		return
+	}
+	g.storageFull = full
+	if full {
+		fmt.Println("Storage is full; progress may not be saved")
+		g.storageWarning.Show()
+	} else {
+		g.storageWarning.Hide()
+	}
+}
+
+// pollWeeklyLevel picks up a finished download; failures keep the cached level
+func (g *Game) pollWeeklyLevel() {
+	result, ok := g.weeklyFetcher.Poll()
+	if !ok {
+		return
+	}
+	
+	if result.Err != nil {
+		fmt.Println("Level of the week unavailable:", result.Err)
+		g.weeklyFailed = true
+	} else {
+		if err := g.saveSystem.SaveWeeklyLevel(result.Data); err != nil {
+			fmt.Println("Failed to cache level of the week:", err)
+		}
+		g.weeklyLevel = result.Weekly
+	}
+	g.updateWeeklyMenuItem()
+}
+
+// updateWeeklyMenuItem shows the week and its best score beside the menu item
+func (g *Game) updateWeeklyMenuItem() {
+	detail := "Downloading..."
+	if g.weeklyLevel != nil {
+		detail = g.weeklyLevel.Week
+		mode := int(g.levelMode(g.weeklyLevel.Level))
+		if scores := g.saveSystem.HighScoresFor(g.weeklyLevel.LeaderboardID(), mode, g.relaxed); len(scores) > 0 {
+			best := scores[0]
+			detail += fmt.Sprintf(" best %d pts %d moves", best.Points, best.Moves)
+		}
+	} else if g.weeklyFailed {
+		detail = "Unavailable offline"
+	}
+	g.mainMenu.SetItemDetail(weeklyLevelMenuItem, detail)
+}
+
+// recordWeeklyScore shows a new best on the level of the week beside its menu item;
+// the score itself is recorded with the level's completion
+func (g *Game) recordWeeklyScore(e events.GameWon) {
+	if g.weeklyLevel == nil || e.LevelID != g.weeklyLevel.LeaderboardID() {
+		return
 	}
+	g.updateWeeklyMenuItem()
 }
 
-func (g *Game) startGameMode(mode int) {
+func (g *Game) startGameMode(mode ModeID) {
+	g.autoSaveNow()
 	board := island.NewBoard(5, 5)
 	board.SetupLevel1() // Simple predefined level for MVP
 	
+	g.currentLevel = nil
+	g.opponent = nil
 	g.world = &World{
 		State:     StatePlaying,
-		Mode:      GameMode(mode),
+		Mode:      mode,
 		Board:     board,
 		Score:     Score{},
-		StartTime: time.Now(),
+		StartTime: g.clock.Now(),
+		Relaxed:   g.relaxedIn(mode),
+		Overtime:  g.overtime,
+		Random:    g.newRandom(),
 	}
-	
-	// Set time limit for Time Attack mode
-	if mode == 1 { // ModeTimeAttack
-		g.world.TimeLimit = time.Minute * 2 // 2 minutes
+	LookupMode(mode).Init(g.world)
+	if g.world.Relaxed {
+		g.world.TimeLimit = 0
 	}
 	
-	// Track game start
-	g.achievementSys.OnGameStart()
+	g.events.Publish(events.GameStarted{Mode: int(mode)})
+}
+
+// newRandom seeds a new game from the seed override when one is set, so the
+// game repeats one played before, and from the clock otherwise
+func (g *Game) newRandom() *random.Source {
+	if g.seed != nil {
+		return random.New(*g.seed)
+	}
+	return random.FromClock()
+}
+
+// SetSeed starts every game from now on with the same seed, such as one read
+// off a results screen, to play that game again
+func (g *Game) SetSeed(seed int64) {
+	g.seed = &seed
 }
 
 func (g *Game) startLevel(levelData *levels.LevelData) {
+	g.startLevelInMode(levelData, g.levelMode(levelData))
+}
+
+// startLevelInMode plays a level under the given mode's rules
+func (g *Game) startLevelInMode(levelData *levels.LevelData, mode ModeID) {
+	g.startSeededLevel(levelData, mode, g.newRandom())
+}
+
+// startSeededLevel plays a level with the seed it was generated from, which
+// the rest of the game then draws from too
+func (g *Game) startSeededLevel(levelData *levels.LevelData, mode ModeID, source *random.Source) {
+	g.autoSaveNow()
 	// Create board from level data
-	board := island.NewBoard(levelData.Width, levelData.Height)
-	
-	// Set tiles according to level grid
-	for y := 0; y < levelData.Height; y++ {
-		for x := 0; x < levelData.Width; x++ {
-			if y < len(levelData.Grid) && x < len(levelData.Grid[y]) {
-				board.SetTile(x, y, levelData.Grid[y][x])
-			}
-		}
-	}
+	board := levelData.NewBoard()
 	
 	g.currentLevel = levelData
+	g.opponent = nil
 	g.world = &World{
 		State:     StatePlaying,
-		Mode:      GameMode(int(levelData.Difficulty)),
+		Mode:      mode,
 		Board:     board,
 		Score:     Score{},
-		StartTime: time.Now(),
+		StartTime: g.clock.Now(),
 		TimeLimit: levelData.TimeLimit,
+		Relaxed:   g.relaxedIn(mode),
+		Overtime:  g.overtime,
+		Rules:     NewRuleSet(g.mutators),
+		Random:    source,
+	}
+	g.mutators = nil
+	if g.world.Relaxed {
+		g.world.TimeLimit = 0
+	}
+	LookupMode(g.world.Mode).Init(g.world)
+	
+	g.events.Publish(events.GameStarted{Mode: int(g.world.Mode), LevelID: levelData.ID})
+}
+
+// startCustomLevel plays a level from the custom level browser
+func (g *Game) startCustomLevel(custom *storage.CustomLevel) {
+	levelData := custom.LevelData()
+	// Custom levels carry no par, so rate stars against the solver's estimate
+	if optimal := solver.OptimalMoves(levelData.NewBoard()); optimal > 0 {
+		levelData.OptimalMoves = optimal
+	}
+	g.startLevel(levelData)
+	
+	g.customLevelID = custom.ID
+	if err := g.saveSystem.RecordCustomLevelPlay(custom.ID); err != nil {
+		fmt.Println("Failed to record play:", err)
+	}
+}
+
+// saveCreatedLevel stores a level exported from the editor so the custom level
+// browser lists it. Exporting a level again saves a new version of it, and the
+// storage keeps the one it replaces in the level's history.
+func (g *Game) saveCreatedLevel(e events.LevelCreated) {
+	existing, _ := g.saveSystem.LoadCustomLevels()
+	i := slices.IndexFunc(existing, func(level storage.CustomLevel) bool { return level.ID == e.LevelID })
+	level := &storage.CustomLevel{
+		ID:        e.LevelID,
+		Name:      fmt.Sprintf("%s %d", e.Name, len(existing)+1),
+		CreatedAt: time.Now(),
+	}
+	if i >= 0 {
+		level = &existing[i]
+	}
+	level.Width, level.Height, level.Tiles = e.Width, e.Height, e.Tiles
+	level.Difficulty = ""
+	if difficulty, ok := levels.EstimateDifficulty(level.LevelData().NewBoard()); ok {
+		level.Difficulty = difficulty.Label()
+	}
+	if err := g.saveSystem.SaveCustomLevel(level); err != nil {
+		fmt.Println("Failed to save custom level:", err)
+		g.levelEditor.Status = "Export failed: the level could not be saved"
+		return
+	}
+	if i >= 0 {
+		g.levelEditor.Status = "Saved a new version of " + level.Name
+	} else {
+		g.levelEditor.Status = "Saved " + level.Name + " to Custom Levels"
+	}
+}
+
+// loadLevelPacks adds every stored or dropped-in level pack to the level manager
+func (g *Game) loadLevelPacks() int {
+	loaded := 0
+	for _, data := range g.saveSystem.LoadLevelPacks() {
+		if _, err := g.levelManager.LoadPack(data); err != nil {
+			fmt.Println("Skipping level pack:", err)
+			continue
+		}
+		loaded++
+	}
+	return loaded
+}
+
+// importLevelPack opens the file picker in the browser; on desktop it rescans the packs folder
+func (g *Game) importLevelPack() {
+	if err := g.saveSystem.RequestLevelPackUpload(); err == nil {
+		g.levelSelectUI.SetStatus("Choose a level pack or PNG level image...")
+		return
+	}
+	
+	fmt.Println("Level packs are read from", g.saveSystem.PackDirectory())
+	g.levelSelectUI.SetStatus(fmt.Sprintf("Loaded %d level packs", g.loadLevelPacks()))
+}
+
+// addUploadedPack installs a pack picked through the file picker and keeps it for later sessions
+func (g *Game) addUploadedPack(data []byte) {
+	if levels.IsPNG(data) {
+		g.importLevelImage(data)
+		return
+	}
+	levelSet, err := g.levelManager.LoadPack(data)
+	if err != nil {
+		g.levelSelectUI.SetStatus("Import failed: not a valid level pack")
+		fmt.Println("Level pack import failed:", err)
+		return
+	}
+	g.thumbnails.Clear() // A newer version of an installed pack may reuse its level IDs
+	if err := g.saveSystem.SaveLevelPack(levelSet.PackID, data); err != nil {
+		fmt.Println("Failed to store level pack:", err)
+	}
+	g.levelSelectUI.SelectPack(levelSet.PackID)
+	g.levelSelectUI.SetStatus("Imported " + levelSet.Name)
+}
+
+// importLevelImage stores a level drawn one pixel per tile as a custom level
+func (g *Game) importLevelImage(data []byte) {
+	level, err := levels.ParseLevelPNG(data)
+	if err != nil {
+		g.levelSelectUI.SetStatus("Import failed: not a valid level image")
+		fmt.Println("Level image import failed:", err)
+		return
 	}
 	
-	// Track game start
-	g.achievementSys.OnGameStart()
+	existing, _ := g.saveSystem.LoadCustomLevels()
+	level.ID = fmt.Sprintf("custom_%d", time.Now().UnixNano())
+	level.Name = fmt.Sprintf("Imported Level %d", len(existing)+1)
+	custom := storage.CustomLevelFromData(level)
+	if err := g.saveSystem.SaveCustomLevel(&custom); err != nil {
+		fmt.Println("Failed to save custom level:", err)
+		g.levelSelectUI.SetStatus("Import failed: could not save the level")
+		return
+	}
+	g.levelSelectUI.SetStatus(fmt.Sprintf("Imported %s into Custom Levels", level.Name))
+}
+
+// levelMode picks the rules a level is played under: timed levels enforce their
+// limit unless Time Attack games are relaxed
+func (g *Game) levelMode(levelData *levels.LevelData) ModeID {
+	if levelData.TimeLimit > 0 && !g.relaxedIn(ModeTimeAttack) {
+		return ModeTimeAttack
+	}
+	return ModeClassic
 }
 
-func (g *Game) handleLevelCompletion(completionTime time.Duration, moves int) {
+func (g *Game) handleLevelCompletion(completionTime time.Duration, moves, points int) {
 	if g.currentLevel == nil {
 		return
 	}
 	
-	// Calculate stars
+	// Calculate stars; relaxed games are rated on moves alone and assisted games earn none
 	stars := g.levelManager.CalculateStars(g.currentLevel, moves, completionTime)
+	if g.world.Relaxed {
+		stars = levels.CalculateMoveStars(g.currentLevel, moves)
+	}
+	if g.world.TimeLimit > 0 && completionTime > g.world.TimeLimit {
+		stars = min(stars, 1) // Finished in overtime
+	}
+	if g.world.Assisted {
+		stars = 0
+	}
 	
 	// Create score record
 	score := &levels.Score{
@@ -165,17 +1059,188 @@ func (g *Game) handleLevelCompletion(completionTime time.Duration, moves int) {
 	
 	// Update progress tracking
 	g.levelManager.Progress[g.currentLevel.ID] = score
+	
+	// Re-simulate the game before it can reach a leaderboard
+	verifyErr := engine.Verify(g.replay, g.currentLevel, engine.Claim{Moves: moves, Time: completionTime, Stars: stars})
+	if verifyErr != nil {
+		fmt.Println("Score failed verification:", verifyErr)
+	}
+	
+	// Persist for the active profile
+	err := g.saveSystem.RecordLevelCompletion(storage.Score{
+		Level:       g.currentLevel.ID,
+		Mode:        int(g.world.Mode),
+		Moves:       moves,
+		Time:        completionTime,
+		Points:      points,
+		Date:        score.Date,
+		Stars:       stars,
+		Relaxed:     g.world.Relaxed,
+		Assisted:    g.world.Assisted,
+		Unverified:  verifyErr != nil,
+		PlayerTitle: g.wornTitle(),
+	})
+	if err != nil {
+		fmt.Println("Failed to record level completion:", err)
+	}
+	if verifyErr == nil && !g.world.Assisted {
+		g.submitScore(moves, completionTime, points, stars)
+	}
+	
+	g.events.Publish(events.LevelCompleted{
+		LevelID: g.currentLevel.ID,
+		Moves:   moves,
+		Time:    completionTime,
+		Stars:   stars,
+	})
 }
 
-func (g *Game) Update() error {
+func (g *Game) update() error {
+	// Closing the desktop window ends the game once its placement is saved
+	if ebiten.IsWindowBeingClosed() {
+		g.autoSaveNow()
+		g.saveSession()
+		g.saveWindowState()
+		return ebiten.Termination
+	}
+	
+	g.profiler.BeginFrame()
+	defer g.profiler.Exit()
+	
+	// Gameplay time stands still while the settings panel covers a game
+	g.clock.SetPaused(g.world.State == StatePaused || g.saveLoadUI.IsOpen())
+	g.trackPlayTime()
+	g.autoSaveOnBlur()
+	
 	// Update animations and achievements UI
+	g.profiler.Enter(profiler.Update, profiler.Animation)
 	g.animation.Update()
+	g.achievementUI.SetDeferred(g.doNotDisturb && g.levelInProgress())
 	g.achievementUI.Update()
 	
+	// Install level packs the player picked since the last frame
+	g.profiler.Enter(profiler.Update, profiler.Other)
+	for _, data := range g.saveSystem.PollUploadedPacks() {
+		g.addUploadedPack(data)
+	}
+	g.receiveDroppedFiles()
+	g.pollDroppedTurn()
+	g.updateOnlineRace()
+	if data, ok := g.saveSystem.PollUploadedSaveData(); ok {
+		g.saveLoadUI.PreviewImport(data)
+	}
+	g.checkOffline()
+	g.checkStorage()
+	g.pollWeeklyLevel()
+	g.pollVotePoster()
+	g.reloadAssets()
+	if !g.offline {
+		g.analytics.Update()
+		g.outbox.Update()
+	}
+	g.runPageCommands()
+	
 	// Handle input based on game state
-	if action := g.input.Update(); action != nil {
-		// Check for settings button click first
-		if action.Type == systems.ActionClick && g.saveLoadUI.IsSettingsButtonClicked(action.X, action.Y) {
+	g.profiler.Enter(profiler.Update, profiler.Input)
+	action := g.input.Update()
+	pointer := g.input.Pointer()
+	g.checkIdle()
+	
+	// While a control is being rebound, the next key or button press is its new binding
+	if g.saveLoadUI.IsCapturingBinding() {
+		if input, ok := g.input.CaptureInput(); ok {
+			g.saveLoadUI.CaptureBinding(input)
+			action = nil
+		}
+	}
+	
+	// Capture shortcuts: F12 anywhere, G once the puzzle is solved
+	if g.input.IsScreenshotPressed() {
+		g.screenshotDue = true
+	}
+	if g.input.IsReplayCapturePressed() && g.world.GameWon && !g.console.IsOpen() {
+		g.saveReplayGIF()
+	}
+	if (g.input.IsShareCardPressed() || g.input.IsShareCardCopyPressed()) && g.world.GameWon && !g.console.IsOpen() {
+		g.exportShareCard(g.input.IsShareCardCopyPressed())
+	}
+	if g.input.IsSplitsExportPressed() && g.speedrun != nil && g.speedrun.finished && g.world.State == StatePlaying && !g.console.IsOpen() {
+		g.exportSpeedrun()
+	}
+	
+	// The developer console takes the keyboard while open
+	if consoleAvailable && g.input.IsConsolePressed() {
+		g.console.Toggle()
+	}
+	if g.console.IsOpen() {
+		text := g.input.Text()
+		g.console.HandleText(text)
+		if text.Up {
+			g.console.HandleHistory(-1)
+		} else if text.Down {
+			g.console.HandleHistory(1)
+		}
+	}
+	
+	// F11 switches between the window and fullscreen
+	if g.input.IsFullscreenPressed() {
+		g.toggleFullscreen()
+	}
+	
+	// F3 toggles the board inspector for diagnosing connectivity, F4 the profiler
+	if g.input.IsInspectorPressed() {
+		g.inspector.Toggle()
+	}
+	if g.input.IsProfilerPressed() {
+		g.profiler.SetEnabled(!g.profiler.Enabled())
+	}
+	
+	// Help overlay annotates the in-game HUD
+	if !g.console.IsOpen() && g.input.IsHelpPressed() && (g.world.State == StatePlaying || g.world.State == StateGameOver) {
+		g.helpOverlay.Toggle()
+	}
+	if g.world.State != StatePlaying && g.world.State != StateGameOver {
+		g.helpOverlay.Hide()
+	}
+	
+	// Clicks go to overlays and panels first; unclaimed clicks reach the current screen
+	g.profiler.Enter(profiler.Update, profiler.UI)
+	var screenAction *systems.Action
+	if action != nil {
+		if g.crashDialog.HandleClick(action.X, action.Y) {
+			// Crash recovery question is answered before anything else
+		} else if g.sizePicker.HandleClick(action.X, action.Y) {
+			// Board size picker is modal too
+		} else if g.mutatorPicker.HandleClick(action.X, action.Y) {
+			// So is the mutator picker
+		} else if g.speedrunPicker.HandleClick(action.X, action.Y) {
+			// And the speedrun difficulty picker
+		} else if g.zenPicker.HandleClick(action.X, action.Y) {
+			// And the Zen session picker
+		} else if g.correspondencePicker.HandleClick(action.X, action.Y) {
+			// And the correspondence game picker
+		} else if g.onlinePicker.HandleClick(action.X, action.Y) {
+			// And the picker of online modes
+		} else if g.defeatScreen.HandleClick(action.X, action.Y) {
+			// And the defeat screen
+		} else if g.votePrompt.HandleClick(action.X, action.Y) {
+			// And the shared level vote
+		} else if g.questsUI.HandleClick(action.X, action.Y) {
+			// And the quests panel
+		} else if g.storageWarning.HandleClick(action.X, action.Y) {
+			// The storage warning only takes clicks on itself
+		} else if g.helpOverlay.IsVisible() {
+			// Any click dismisses the help overlay
+			if action.Type == systems.ActionClick {
+				g.helpOverlay.Hide()
+			}
+		} else if g.world.State == StateTutorial {
+			// The tutorial owns the whole screen while it runs
+			if action.Type == systems.ActionClick {
+				gridX, gridY := g.render.ScreenToGrid(action.X, action.Y)
+				g.tutorialUI.HandleClick(action.X, action.Y, gridX, gridY)
+			}
+		} else if action.Type == systems.ActionClick && g.saveLoadUI.IsSettingsButtonClicked(action.X, action.Y) {
 			g.saveLoadUI.TogglePanel()
 		} else if action.Type == systems.ActionClick && g.achievementUI.IsAchievementButtonClicked(action.X, action.Y) {
 			g.achievementUI.TogglePanel()
@@ -185,141 +1250,770 @@ func (g *Game) Update() error {
 			// Achievement UI handled the click
 		} else if g.levelSelectUI.HandleClick(action.X, action.Y) {
 			// Level select UI handled the click
+		} else if g.customLevelsUI.HandleClick(action.X, action.Y) {
+			// Custom level browser handled the click
+		} else if g.profileSelectUI.HandleClick(action.X, action.Y) {
+			// Profile picker handled the click
+		} else if action.Type == systems.ActionClick && g.nextLevelButton.HandleClick(action.X, action.Y) {
+			// Recommended level started from the victory screen
+		} else if action.Type == systems.ActionClick && g.emoteWheelShown() && g.emoteWheel.HandleClick(action.X, action.Y) {
+			// Emote wheel handled the click
+		} else if action.Type == systems.ActionClick && g.planBarShown() && g.planBar.HandleClick(action.X, action.Y) {
+			// Planning buttons handled the click
+		} else if action.Type == systems.ActionClick && g.miniMapShown() && g.miniMap.HandleClick(action.X, action.Y, g.world.Board.Width, g.world.Board.Height) {
+			// Mini-map moved the camera
 		} else {
-			switch g.world.State {
-			case StateMenu:
-				g.mainMenu.Update(action.X, action.Y, action.Type == systems.ActionClick)
-			case StatePlaying:
-				g.handleGameAction(action)
-			case StateLevelSelect:
-				// Level select is handled above
-			case StateLevelEditor:
-				if g.levelEditor.Update(action.X, action.Y, action.Type == systems.ActionClick) {
-					g.world.State = StateMenu // Return to menu
-				}
-			}
+			screenAction = action
+		}
+	}
+	
+	// Hover follows the pointer every frame; screens under an open panel see no hover
+	hoverX, hoverY := pointer.X, pointer.Y
+	g.crashDialog.UpdateHover(hoverX, hoverY)
+	g.sizePicker.UpdateHover(hoverX, hoverY)
+	g.mutatorPicker.UpdateHover(hoverX, hoverY)
+	g.speedrunPicker.UpdateHover(hoverX, hoverY)
+	g.zenPicker.UpdateHover(hoverX, hoverY)
+	g.correspondencePicker.UpdateHover(hoverX, hoverY)
+	g.onlinePicker.UpdateHover(hoverX, hoverY)
+	g.defeatScreen.UpdateHover(hoverX, hoverY)
+	g.votePrompt.UpdateHover(hoverX, hoverY)
+	g.questsUI.UpdateHover(hoverX, hoverY)
+	g.storageWarning.UpdateHover(hoverX, hoverY)
+	if g.crashDialog.IsOpen() || g.sizePicker.IsOpen() || g.mutatorPicker.IsOpen() || g.speedrunPicker.IsOpen() || g.zenPicker.IsOpen() || g.correspondencePicker.IsOpen() || g.onlinePicker.IsOpen() || g.defeatScreen.IsOpen() || g.votePrompt.IsOpen() || g.questsUI.IsOpen() {
+		hoverX, hoverY = -1, -1
+	}
+	g.saveLoadUI.UpdateHover(hoverX, hoverY)
+	g.achievementUI.UpdateHover(hoverX, hoverY)
+	if g.saveLoadUI.IsOpen() || g.achievementUI.IsOpen() || g.helpOverlay.IsVisible() {
+		hoverX, hoverY = -1, -1
+	}
+	g.levelSelectUI.UpdateHover(hoverX, hoverY)
+	g.customLevelsUI.UpdateHover(hoverX, hoverY)
+	g.profileSelectUI.UpdateHover(hoverX, hoverY)
+	g.nextLevelButton.UpdateHover(hoverX, hoverY)
+	g.planBar.UpdateHover(hoverX, hoverY)
+	g.emoteWheel.UpdateHover(hoverX, hoverY)
+	if !g.console.IsOpen() {
+		text := g.input.Text()
+		g.profileSelectUI.HandleText(text)
+		if g.world.State == StateLevelEditor {
+			g.levelEditor.HandleText(text)
+		}
+		// Level lists search as the player types, unless a panel covers them
+		if !g.saveLoadUI.IsOpen() && !g.achievementUI.IsOpen() {
+			g.levelSelectUI.HandleText(text)
+			g.customLevelsUI.HandleText(text)
+		}
+	}
+	if pointer.LeftJustReleased {
+		g.customLevelsUI.HandleRelease(hoverX, hoverY)
+	}
+	
+	// Wheel scrolls whichever scrollable panel is on top
+	if pointer.WheelY != 0 {
+		if g.achievementUI.IsOpen() {
+			g.achievementUI.HandleScroll(-pointer.WheelY)
+		} else if g.levelSelectUI.IsShown() {
+			g.levelSelectUI.HandleScroll(-pointer.WheelY)
+		} else if g.customLevelsUI.IsShown() {
+			g.customLevelsUI.HandleScroll(-pointer.WheelY)
+		}
+	}
+	
+	clicked := screenAction != nil && screenAction.Type == systems.ActionClick
+	if g.world.State != StateMenu || g.saveLoadUI.IsOpen() {
+		g.menuCardsFresh = false // Games and the settings panel can change the save
+	}
+	switch g.world.State {
+	case StateMenu:
+		if !g.menuCardsFresh && !g.saveLoadUI.IsOpen() {
+			g.refreshMenuCards()
+		}
+		g.updateChallengeMenuItem()
+		g.refreshQuests()
+		g.showcase.Update()
+		g.mainMenu.Update(hoverX, hoverY, clicked)
+	case StatePlaying, StatePaused:
+		if g.boardControlsActive(action, screenAction) {
+			g.profiler.Enter(profiler.Update, profiler.Board)
+			g.handleBoardControls(pointer)
+			g.profiler.Enter(profiler.Update, profiler.UI)
+		}
+	case StateLevelEditor:
+		if g.levelEditor.Update(hoverX, hoverY, clicked, pointer.LeftDown) {
+			g.levelEditor.StopCoEdit()
+			g.world.State = StateMenu // Return to menu
+		}
+		if !g.levelEditor.Typing() {
+			g.handleViewControls(g.levelEditor)
+		}
+		if pointer.WheelY > 0 {
+			g.levelEditor.Zoom(zoomStep)
+		} else if pointer.WheelY < 0 {
+			g.levelEditor.Zoom(1 / zoomStep)
 		}
 	}
 	
+	g.tooltip.Update(pointer.X, pointer.Y, g.tooltipRegions())
+	
 	// Update game logic for playing state
+	g.profiler.Enter(profiler.Update, profiler.Board)
 	if g.world.State == StatePlaying && g.world.Board != nil {
 		// Update timer
-		g.world.Score.Time = time.Since(g.world.StartTime)
+		g.world.Score.Time = g.world.Rules.gameTime(clock.Since(g.clock, g.world.StartTime))
+		g.traffic.Update(g.world.Board)
+		g.heartbeat()
+		
+		mode := LookupMode(g.world.Mode)
+		
+		// Check mode-specific failure conditions
+		if lost, reason := mode.CheckLose(g.world); lost && !g.world.GameWon {
+			g.world.State = StateGameOver
+			g.events.Publish(events.GameLost{Mode: int(g.world.Mode), LevelID: g.currentLevelID(), Moves: g.world.Score.Moves, Reason: reason})
+		}
 		
-		// Check time limit for Time Attack mode
-		if g.world.Mode == ModeTimeAttack && g.world.TimeLimit > 0 {
-			if g.world.Score.Time >= g.world.TimeLimit {
+		// Advance the AI racer; the player loses if it connects everything first
+		if g.opponent != nil && !g.world.GameWon && g.world.State == StatePlaying {
+			g.opponent.Update()
+			if g.opponent.IsFinished() {
 				g.world.State = StateGameOver
+				g.events.Publish(events.GameLost{Mode: int(g.world.Mode), LevelID: g.currentLevelID(), Moves: g.world.Score.Moves, Reason: "ai_won"})
 			}
 		}
+		g.updateEmotes()
+		g.updateTaunts()
 		
 		// Check win condition
-		if g.world.Board.IsAllConnected() && !g.world.GameWon {
+		if g.world.State == StatePlaying && !g.world.GameWon && mode.CheckWin(g.world) {
 			g.world.GameWon = true
-			// Add victory animation
-			g.animation.AddAnimation(systems.AnimationVictory, 320, 240, time.Second*2)
-			
-			// Track achievement progress
-			gameTime := g.world.Score.Time
-			moves := g.world.Score.Moves
-			isTimeAttack := g.world.Mode == ModeTimeAttack
+			g.world.Score.finishScore(g.world.TimeLimit, g.world.Relaxed, g.world.Rules.ScoreMultiplier())
+			g.playVictorySequence()
 			
 			// Calculate if perfect based on current level
+			moves := g.world.Score.Moves
 			isPerfect := false
+			levelID := ""
 			if g.currentLevel != nil {
 				isPerfect = moves <= g.currentLevel.OptimalMoves
-				
-				// Handle level completion
-				g.handleLevelCompletion(gameTime, moves)
+				levelID = g.currentLevel.ID
 			} else {
 				isPerfect = moves <= 2 // For legacy levels
 			}
 			
-			g.achievementSys.OnGameWin(moves, gameTime, isTimeAttack, isPerfect)
+			g.events.Publish(events.GameWon{
+				Mode:         int(g.world.Mode),
+				LevelID:      levelID,
+				Moves:        moves,
+				Time:         g.world.Score.Time,
+				Points:       g.world.Score.Points,
+				MoveTimes:    g.world.Score.ThinkingTimes(),
+				Mutators:     len(g.world.Rules.Mutators),
+				IsTimeAttack: g.world.Mode == ModeTimeAttack,
+				IsPerfect:    isPerfect,
+			})
 		}
+		g.advanceSpeedrun()
+		g.growZen()
+		g.showVotePrompt()
 	}
+	g.updateNextLevel()
 	
+	g.jsAPI.SetStats(g.pageStats())
 	return nil
 }
 
-func (g *Game) Draw(screen *ebiten.Image) {
+// playVictorySequence starts the victory flash together with the network pulse
+func (g *Game) playVictorySequence() {
+	g.victoryAnim = g.animation.AddAnimation(systems.AnimationVictory, 320, 240, time.Second*2)
+	g.addVictoryPathAnimation()
+}
+
+// revealStars pops the earned stars in one after another once the victory flash ends
+func (g *Game) revealStars(stars int) {
+	if g.victoryAnim == nil {
+		return
+	}
+	
+	prev := g.victoryAnim
+	for i := 0; i < stars; i++ {
+		revealed := i + 1
+		star := systems.NewAnimation(systems.AnimationStarReveal, i, 0, starRevealDuration).
+			WithEasing(systems.EaseOutBack).
+			OnComplete(func() { g.revealedStars = revealed })
+		prev = prev.Then(star)
+	}
+}
+
+// addVictoryPathAnimation celebrates the finished network by sending a pulse
+// along the bridges from the first island to all the others
+func (g *Game) addVictoryPathAnimation() {
+	start, ok := g.world.Board.FirstIslandTile()
+	if !ok {
+		return
+	}
+	layers := g.world.Board.NetworkLayers(start)
+	duration := time.Second + time.Duration(len(layers))*victoryPathStepDuration
+	g.animation.AddAnimationWithData(systems.AnimationVictoryPath, start.X, start.Y, duration, layers)
+}
+
+func (g *Game) draw(screen *ebiten.Image) {
+	g.profiler.Enter(profiler.Draw, profiler.UI)
 	switch g.world.State {
 	case StateMenu:
 		g.mainMenu.Draw(screen)
-	case StatePlaying, StateGameOver:
+	case StatePlaying, StatePaused, StateGameOver:
 		if g.world.Board != nil {
-			g.render.Draw(screen, g.world.Board, g.world.Score.Moves, g.world.GameWon)
-			g.render.DrawHover(screen, g.world.Board, g.input.MouseX, g.input.MouseY)
-			g.render.DrawGameMode(screen, g.world)
+			g.profiler.Enter(profiler.Draw, profiler.Render)
+			g.render.Draw(screen, g.world.Board, g.world.GameWon)
+			g.render.DrawTravelers(screen, g.traffic.Travelers())
+			pointer := g.input.Pointer()
+			if !g.world.Rules.HideHover {
+				gridX, gridY := g.boardTile(pointer.X, pointer.Y)
+				g.render.DrawHoverTile(screen, g.world.Board, gridX, gridY)
+			}
+			for _, planned := range g.plannedBridges {
+				if tile := g.world.Board.GetTile(planned.X, planned.Y); tile != nil && tile.Type == island.TileSea {
+					g.render.DrawPlannedBridge(screen, planned.X, planned.Y)
+				}
+			}
+			if g.input.IsMeasureHeld() {
+				g.drawMeasurement(screen)
+			}
+			if g.hintTile != nil && g.clock.Now().Before(g.hintUntil) {
+				g.render.DrawTileHighlight(screen, g.hintTile.X, g.hintTile.Y, color.RGBA{255, 215, 0, 255})
+			}
+			if g.lastMove != nil && g.clock.Now().Before(g.lastMoveUntil) {
+				g.render.DrawTileHighlight(screen, g.lastMove.X, g.lastMove.Y, moveQualityColors[g.lastMoveQuality])
+			}
+			if g.componentsOverlay {
+				g.drawComponentsOverlay(screen, g.world.Board)
+			}
+			if hints := g.world.LineHints; hints != nil {
+				rows, cols := solver.BridgeLineCounts(g.world.Board)
+				ui.DrawLineHints(screen, g.render.BoardBounds(g.world.Board), g.render.TileSize(), hints.Rows, hints.Cols, rows, cols)
+			}
+			g.profiler.Enter(profiler.Draw, profiler.UI)
+			g.hud.Draw(screen, g.render.BoardBounds(g.world.Board), g.hudData())
+			g.inspector.Draw(screen, g.world.Board, g.render, pointer.X, pointer.Y)
+			if g.miniMapShown() {
+				g.drawMiniMap(screen)
+			}
+			if g.planBarShown() {
+				g.planBar.Draw(screen, g.planning, len(g.plannedBridges))
+			}
+			if g.emoteWheelShown() {
+				g.emoteWheel.Draw(screen, g.muteEmotes)
+			}
 		}
+		if g.world.State == StatePaused {
+			msg, hint := g.pauseMessages()
+			g.render.DrawPauseOverlay(screen, msg, hint)
+		}
+		if g.world.GameWon && g.currentLevel != nil {
+			g.render.DrawVictoryStars(screen, g.revealedStars)
+		}
+		g.nextLevelButton.Draw(screen)
+		g.profiler.Enter(profiler.Draw, profiler.Animation)
 		g.render.DrawAnimations(screen, g.animation.GetAnimations())
+		g.render.DrawParticles(screen, g.animation.Particles().Particles())
 		// Draw UI buttons
+		g.profiler.Enter(profiler.Draw, profiler.UI)
 		g.saveLoadUI.DrawSettingsButton(screen, 10, 10)
 		g.achievementUI.DrawAchievementButton(screen, 500, 10)
 	case StateLevelSelect:
 		// Draw a simple background
 		screen.Fill(color.RGBA{240, 240, 240, 255})
 		g.levelSelectUI.Draw(screen)
+	case StateCustomLevels:
+		screen.Fill(color.RGBA{240, 240, 240, 255})
+		g.customLevelsUI.Draw(screen)
+	case StateProfileSelect:
+		screen.Fill(color.RGBA{240, 240, 240, 255})
+		g.profileSelectUI.Draw(screen)
 	case StateLevelEditor:
 		g.levelEditor.Draw(screen)
+	case StateTutorial:
+		g.profiler.Enter(profiler.Draw, profiler.Render)
+		g.render.Draw(screen, g.tutorialUI.Board, false)
+		g.profiler.Enter(profiler.Draw, profiler.UI)
+		g.hud.Draw(screen, g.render.BoardBounds(g.tutorialUI.Board), ui.HUDData{
+			ModeName: "Tutorial",
+			Moves:    g.tutorialUI.Moves,
+		})
+		if x, y, ok := g.tutorialUI.CurrentTarget(); ok {
+			g.render.DrawTileHighlight(screen, x, y, color.RGBA{255, 215, 0, g.tutorialUI.HighlightAlpha()})
+		}
+		g.tutorialUI.Draw(screen)
 	}
 	
 	// Always draw UI panels on top
 	g.saveLoadUI.Draw(screen)
 	g.achievementUI.Draw(screen)
+	g.helpOverlay.Draw(screen, g.helpAnnotations())
+	g.storageWarning.Draw(screen)
+	g.saveToast.Draw(screen)
+	g.tooltip.Draw(screen)
+	g.sizePicker.Draw(screen)
+	g.mutatorPicker.Draw(screen)
+	g.speedrunPicker.Draw(screen)
+	g.zenPicker.Draw(screen)
+	g.correspondencePicker.Draw(screen)
+	g.onlinePicker.Draw(screen)
+	g.defeatScreen.Draw(screen)
+	g.votePrompt.Draw(screen)
+	g.questsUI.Draw(screen)
+	g.crashDialog.Draw(screen)
+	g.console.Draw(screen)
+	g.profiler.Exit()
+	g.profilerOverlay.Draw(screen, g.profiler)
+	
+	if g.screenshotDue {
+		g.screenshotDue = false
+		g.saveScreenshot(screen)
+	}
+}
+
+// saveScreenshot exports the finished frame as a PNG
+func (g *Game) saveScreenshot(screen *ebiten.Image) {
+	data, err := capture.EncodePNG(capture.Screenshot(screen))
+	if err == nil {
+		name := capture.FileName("png", time.Now())
+		if err = g.saveSystem.ExportFile(name, data); err == nil {
+			g.showCaptureMessage("Saved " + name)
+			return
+		}
+	}
+	fmt.Println("Screenshot failed:", err)
+	g.showCaptureMessage("Screenshot failed")
+}
+
+// saveReplayGIF exports the solution played this game as an animated GIF
+func (g *Game) saveReplayGIF() {
+	if g.replay == nil {
+		return
+	}
+	data, err := capture.EncodeGIF(g.replay)
+	if err == nil {
+		name := capture.FileName("gif", time.Now())
+		if err = g.saveSystem.ExportFile(name, data); err == nil {
+			g.showCaptureMessage("Saved " + name)
+			return
+		}
+	}
+	fmt.Println("Replay capture failed:", err)
+	g.showCaptureMessage("Replay capture failed")
+}
+
+func (g *Game) showCaptureMessage(message string) {
+	g.captureMessage = message
+	g.captureMessageAt = time.Now()
+}
+
+// hudData collects the current game's HUD values
+func (g *Game) hudData() ui.HUDData {
+	mode := LookupMode(g.world.Mode)
+	connected, islands := g.world.Board.ConnectedIslands()
+	data := ui.HUDData{
+		ModeName:  mode.Name(),
+		Moves:     g.world.Score.Moves,
+		Time:      g.world.Score.Time,
+		Points:    g.world.Score.Points,
+		Combo:     g.world.Score.ComboMultiplier(),
+		BestMoves: g.world.Score.BestMoves,
+		BestTime:  g.world.Score.BestTime,
+		HideTime:  g.world.Relaxed,
+		Connected: connected,
+		Islands:   islands,
+		Traffic:   g.traffic.Stats().PerMinute,
+		Extras:    mode.HUDExtras(g.world),
+		Objectives: g.objectiveLines(connected, islands),
+		Hints: []string{
+			"Click on sea tiles to build bridges",
+			"Connect all islands to win!",
+		},
+	}
+	if g.planning {
+		data.Hints[0] = "Planning: click sea tiles to plan bridges, then Commit"
+	}
+	if g.input.IsMeasureHeld() {
+		data.Hints[0] = g.measureHint()
+	}
+	if g.world.Relaxed {
+		data.ModeName += " (Relaxed)"
+	}
+	if label := g.world.Rules.label(); label != "" {
+		data.Extras = append(data.Extras, label)
+	}
+	if budget, ok := g.world.Board.Rules().(island.BridgeBudget); ok {
+		data.Extras = append(data.Extras, fmt.Sprintf("Bridges left: %d", budget.Remaining(g.world.Board)))
+	}
+	if stage := g.challengeStage(); stage >= 0 {
+		data.Extras = append(data.Extras, g.challengeHUDLine(stage))
+	}
+	if g.world.Mode == ModeTimeAttack && g.world.TimeLimit > 0 {
+		data.Countdown = &ui.Countdown{Left: g.world.TimeLimit - g.world.Score.Time, Overtime: g.world.Overtime}
+	}
+	if g.world.GameWon {
+		data.Hints = []string{"Press G to save your solution as a GIF", "Press C to save a share card, Shift+C to copy it"}
+		data.Results = g.world.Score.resultLines()
+		if line := g.world.Score.moveStatsLine(); line != "" {
+			data.Results = append(data.Results, line)
+		}
+		if line := g.ratingLine(); line != "" {
+			data.Results = append(data.Results, line)
+		}
+		data.MoveTimes = g.world.Score.ThinkingTimes()
+		data.SlowestMove, _ = g.world.Score.SlowestMove()
+		data.RecordBanner = g.visibleRecordBanner()
+		data.Player = g.playerName()
+	}
+	if g.world.GameWon && g.world.Random != nil {
+		data.Results = append(data.Results, fmt.Sprintf("Seed %d", g.world.Random.Seed()))
+	}
+	if sr := g.speedrun; sr != nil && g.world.Mode == ModeSpeedrun {
+		data.Splits = sr.splitRows(g.world.Score.Time)
+		if sr.finished {
+			data.Results = append(data.Results, sr.resultLines()...)
+			data.Hints = append(data.Hints, "Press T to save the splits as text")
+		}
+	}
+	if g.lastMove != nil && g.clock.Now().Before(g.lastMoveUntil) {
+		data.Hints = append(data.Hints, moveQualityLabels[g.lastMoveQuality])
+	}
+	if g.world.Assisted {
+		data.Hints = append(data.Hints, "Move feedback is on: no stars this game")
+	}
+	if g.captureMessage != "" && time.Since(g.captureMessageAt) < captureMessageDuration {
+		data.Hints = append(data.Hints, g.captureMessage)
+	}
+	
+	if g.opponent != nil {
+		data.Race = &ui.RaceStatus{
+			PlayerProgress: g.opponent.ProgressOf(g.world.Board),
+			AIProgress:     g.opponent.Progress(),
+			AIName:         g.opponentName(),
+			AIMoves:        g.opponent.Moves,
+			AIPersonality:  int(g.opponent.PlaysAs()),
+			Taunt:          g.tauntNow(),
+			Bubbles:        g.bubblesNow(),
+		}
+	} else if r := g.world.OnlineRace; r != nil && r.joined() {
+		player, opponent := r.progress(g.world.Board)
+		data.Race = &ui.RaceStatus{
+			PlayerProgress: player,
+			AIProgress:     opponent,
+			AIName:         r.Name,
+			AIMoves:        r.Moves,
+			Online:         true,
+			Bubbles:        g.bubblesNow(),
+		}
+	}
+	
+	return data
+}
+
+// tooltipRegions returns the hoverable regions of whichever UI is in front
+func (g *Game) tooltipRegions() []ui.TooltipRegion {
+	if g.saveLoadUI.IsOpen() {
+		return g.saveLoadUI.TooltipRegions()
+	}
+	
+	switch g.world.State {
+	case StateLevelSelect:
+		return g.levelSelectUI.TooltipRegions()
+	case StateLevelEditor:
+		return g.levelEditor.TooltipRegions()
+	}
+	return nil
+}
+
+// helpAnnotations describes the in-game HUD elements for the help overlay
+func (g *Game) helpAnnotations() []ui.HelpAnnotation {
+	annotations := []ui.HelpAnnotation{
+		{X: 10, Y: 10, Width: 100, Height: 30, Label: "Settings: save, load and options", LabelX: 20, LabelY: 100},
+		{X: 500, Y: 10, Width: 120, Height: 30, Label: "Achievements unlocked so far", LabelX: 420, LabelY: 140},
+	}
+	
+	hudLabels := []struct {
+		anchor ui.HUDAnchor
+		label  string
+		x, y   int
+	}{
+		{ui.HUDTopLeft, "Moves used and elapsed time", 20, 150},
+		{ui.HUDTopRight, "Current mode\nTimed modes show time left", 420, 200},
+		{ui.HUDObjectives, "Level objectives: green\nwhen met, red when failed", 420, 260},
+		{ui.HUDBottom, "Goal reminder", 250, 250},
+		{ui.HUDRace, "Your progress vs the AI", 20, 380},
+	}
+	for _, hudLabel := range hudLabels {
+		if rect, ok := g.hud.Region(hudLabel.anchor); ok {
+			annotations = append(annotations, ui.HelpAnnotation{
+				X: rect.Min.X, Y: rect.Min.Y, Width: rect.Dx(), Height: rect.Dy(),
+				Label: hudLabel.label, LabelX: hudLabel.x, LabelY: hudLabel.y,
+			})
+		}
+	}
+	
+	if g.world.Board != nil {
+		bounds := g.render.BoardBounds(g.world.Board)
+		annotations = append(annotations, ui.HelpAnnotation{
+			X: bounds.Min.X, Y: bounds.Min.Y,
+			Width: bounds.Dx(), Height: bounds.Dy(),
+			Label:  "Click sea tiles next to land or\nbridges to build; connect every island",
+			LabelX: 20, LabelY: 300,
+		})
+	}
+	if g.planBarShown() {
+		annotations = append(annotations, ui.HelpAnnotation{
+			X: 565, Y: 380, Width: 66, Height: 54,
+			Label:  "Plan bridges without spending\nmoves, then build them all",
+			LabelX: 440, LabelY: 330,
+		})
+	}
+	if g.emoteWheelShown() {
+		annotations = append(annotations, ui.HelpAnnotation{
+			X: 565, Y: 350, Width: 66, Height: 24,
+			Label:  "Send emotes to your opponent",
+			LabelX: 400, LabelY: 304,
+		})
+	}
+	
+	return annotations
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
 	return 640, 480
 }
 
-func (g *Game) handleGameAction(action *systems.Action) {
-	if action.Type == systems.ActionClick {
-		// Convert screen coordinates to grid coordinates
-		// Account for grid offset (160, 120) and tile size (64)
-		gridX := (action.X - 160) / 64
-		gridY := (action.Y - 120) / 64
-		
-		// Try to build bridge
-		if g.world.Board.CanBuildBridge(gridX, gridY) {
-			g.world.Board.BuildBridge(gridX, gridY)
-			g.world.Score.Moves++
-			// Add build animation
-			g.animation.AddAnimation(systems.AnimationBridgeBuild, gridX, gridY, time.Millisecond*500)
-			// Track bridge building achievement
-			g.achievementSys.OnBridgeBuilt()
+// boardControlsActive reports whether board controls may act this frame: no panel
+// covers the board and any click was not already claimed by a button or panel
+func (g *Game) boardControlsActive(action, screenAction *systems.Action) bool {
+	if g.saveLoadUI.IsOpen() || g.achievementUI.IsOpen() || g.helpOverlay.IsVisible() || g.console.IsOpen() {
+		return false
+	}
+	return action == nil || screenAction != nil
+}
+
+// handleBoardControls runs the rebindable gameplay controls
+func (g *Game) handleBoardControls(pointer systems.PointerState) {
+	if g.input.IsControlJustPressed(systems.ControlPause) {
+		if g.world.State == StatePaused {
+			g.world.State = StatePlaying
+		} else {
+			g.world.State = StatePaused
+		}
+		g.idlePaused = false
+		return
+	}
+	if g.world.State == StatePaused {
+		// A pause for inactivity ends with any click, which builds nothing
+		if g.idlePaused && pointer.LeftJustPressed {
+			g.world.State = StatePlaying
+			g.idlePaused = false
 		}
+		return
+	}
+	
+	if g.input.IsControlJustPressed(systems.ControlPlan) {
+		g.togglePlanning()
+	}
+	if pointer.DoubleClick {
+		g.centerOnTile(pointer.X, pointer.Y)
+	}
+	
+	gridX, gridY := g.boardTile(pointer.X, pointer.Y)
+	build, demolish := g.buildBridge, g.demolishBridge
+	if g.input.IsMeasureHeld() {
+		// Clicks pick tiles to measure between while M is held
+		build = g.measureTile
+		demolish = func(int, int) {}
+	} else {
+		g.measure = nil
+	}
+	if g.planning && !g.input.IsMeasureHeld() {
+		build = g.planBridge
+		demolish = func(x, y int) { g.unplanBridge(x, y) }
+	}
+	if g.input.IsControlJustPressed(systems.ControlBuild) {
+		build(gridX, gridY)
+	}
+	if g.input.IsControlJustPressed(systems.ControlDemolish) {
+		demolish(gridX, gridY)
+	}
+	// Fingers have one button: a tap builds and a long press demolishes
+	if g.input.IsTapped() {
+		build(gridX, gridY)
+	}
+	if g.input.IsLongPressed() {
+		demolish(gridX, gridY)
+	}
+	if g.input.IsControlJustPressed(systems.ControlHint) {
+		g.showHint()
 	}
+	g.handleViewControls(g.render)
 }
 
-func (g *Game) loadAchievements() {
-	// Try to load achievements from storage
-	if err := g.saveSystem.LoadAchievements(g.achievementSys); err == nil {
-		// Successfully loaded
+func (g *Game) buildBridge(gridX, gridY int) {
+	// Clicking a damaged bridge again repairs it
+	if g.repairBridge(gridX, gridY) {
+		return
+	}
+	if !g.world.Board.CanBuildBridge(gridX, gridY) || !g.correspondenceMayBuild() || !g.onlineRaceMayBuild() {
+		return
+	}
+	quality := g.rateMove(gridX, gridY)
+	if g.moveFeedbackIn(g.world.Mode) && !g.world.GameWon {
+		g.showMoveFeedback(gridX, gridY, quality)
+	}
+	groups := g.world.Board.IslandGroupCount()
+	g.world.Board.BuildBridge(gridX, gridY)
+	merged := groups - g.world.Board.IslandGroupCount()
+	g.world.Score.Moves += g.world.Rules.moveCost()
+	at := g.moveTime()
+	if !g.world.GameWon {
+		g.world.Score.scoreMove(merged, quality == solver.MoveOptimal)
+	}
+	LookupMode(g.world.Mode).OnMove(g.world)
+	g.hintTile = nil
+	g.events.Publish(events.BridgeBuilt{X: gridX, Y: gridY, Moves: g.world.Score.Moves, At: at})
+	if merged > 0 {
+		g.events.Publish(events.IslandsMerged{X: gridX, Y: gridY, Merged: merged})
 	}
+	g.announceCollapses(at)
 }
 
-func (g *Game) saveGame() {
-	if g.world.State != StatePlaying || g.world.Board == nil {
+// demolishBridge removes a bridge; it costs a move like building one
+func (g *Game) demolishBridge(gridX, gridY int) {
+	// Correspondence games only ever add bridges
+	if g.world.GameWon || g.world.Correspondence != nil || !g.world.Board.RemoveBridge(gridX, gridY) {
 		return
 	}
+	g.world.Score.Moves++
+	at := g.moveTime()
+	g.world.Score.scoreMove(0, false)
+	LookupMode(g.world.Mode).OnMove(g.world)
+	g.hintTile = nil
+	g.moveAnalyzer = nil // Removals change the board in ways the analyzer does not follow
+	g.events.Publish(events.BridgeRemoved{X: gridX, Y: gridY, At: at})
+	g.announceCollapses(at)
+}
+
+// moveTime records the game time of a move that was just made and returns it
+func (g *Game) moveTime() time.Duration {
+	at := g.world.Rules.gameTime(clock.Since(g.clock, g.world.StartTime))
+	g.world.Score.recordMoveTime(at)
+	return at
+}
+
+// boardTile is the tile a pointer position acts on, after mirrored controls
+func (g *Game) boardTile(x, y int) (int, int) {
+	gridX, gridY := g.render.ScreenToGrid(x, y)
+	return g.world.Rules.mirrorX(g.world.Board, gridX), gridY
+}
+
+// rateMove compares a bridge about to be built with the solver, for combos and move feedback
+func (g *Game) rateMove(gridX, gridY int) solver.MoveQuality {
+	if g.moveAnalyzer == nil {
+		g.moveAnalyzer = solver.NewAnalyzer(g.world.Board)
+	}
+	return g.moveAnalyzer.Rate(g.world.Board, solver.Move{X: gridX, Y: gridY})
+}
+
+// showMoveFeedback highlights how good a move was; a game with rated moves no
+// longer earns stars
+func (g *Game) showMoveFeedback(gridX, gridY int, quality solver.MoveQuality) {
+	g.lastMoveQuality = quality
+	g.lastMove = &island.Point{X: gridX, Y: gridY}
+	g.lastMoveUntil = g.clock.Now().Add(moveFeedbackDuration)
+	g.world.Assisted = true
+}
+
+// showHint highlights the solver's next move for a few seconds
+func (g *Game) showHint() {
+	if g.world.GameWon {
+		return
+	}
+	move, ok := solver.NextMove(g.world.Board)
+	if !ok {
+		return
+	}
+	g.hintTile = &island.Point{X: move.X, Y: move.Y}
+	g.hintUntil = g.clock.Now().Add(hintDuration)
+	g.events.Publish(events.HintShown{Mode: int(g.world.Mode), LevelID: g.currentLevelID(), Moves: g.world.Score.Moves})
+}
+
+// currentLevelID returns the ID of the level being played, or "" for mode games
+func (g *Game) currentLevelID() string {
+	if g.currentLevel == nil {
+		return ""
+	}
+	return g.currentLevel.ID
+}
+
+func (g *Game) loadAchievements() {
+	// Achievements are stored as the JSON string from SaveToJSON
+	var data string
+	if err := g.saveSystem.LoadAchievements(&data); err == nil {
+		if err := g.achievementSys.LoadFromJSON(data); err != nil {
+			fmt.Println("Failed to load achievements:", err)
+		}
+	}
+}
+
+// saveGame saves the game in progress and reports whether there was one to save
+func (g *Game) saveGame() bool {
+	gameState := g.gameStateData()
+	if gameState == nil {
+		return false
+	}
+	
+	g.saveSystem.SaveGameState(gameState)
+	g.saveSession()
 	
-	// Convert current game state to save format
-	gameState := &storage.CurrentGameState{
+	// Also save achievements and quest progress
+	if achievementData, err := g.achievementSys.SaveToJSON(); err == nil {
+		g.saveSystem.SaveAchievements(achievementData)
+	}
+	g.saveQuests()
+	return true
+}
+
+// gameStateData converts the game in progress to its save format, or returns nil
+// when no game is being played
+func (g *Game) gameStateData() *storage.CurrentGameState {
+	if g.world == nil || (g.world.State != StatePlaying && g.world.State != StatePaused) || g.world.Board == nil {
+		return nil
+	}
+	if g.world.Correspondence != nil {
+		return nil // Kept with the other correspondence games instead
+	}
+	if g.world.OnlineRace != nil {
+		return nil // Rejoined through its match session instead
+	}
+	
+	return &storage.CurrentGameState{
 		Mode:      int(g.world.Mode),
 		Board:     g.boardToSaveData(g.world.Board),
 		Score:     g.scoreToSaveData(g.world.Score),
 		StartTime: g.world.StartTime,
 		TimeLimit: g.world.TimeLimit,
 		GameWon:   g.world.GameWon,
+		Relaxed:   g.world.Relaxed,
+		Assisted:  g.world.Assisted,
+		Overtime:  g.world.Overtime,
+		Seed:      g.world.Random.Seed(),
+		Hazards:   g.hazardsToSaveData(g.world.Hazards),
+		Zen:       g.zenToSaveData(g.world.Zen),
+		Mutators:  mutatorNames(g.world.Rules.Mutators),
 	}
-	
-	g.saveSystem.SaveGameState(gameState)
-	
-	// Also save achievements
-	if achievementData, err := g.achievementSys.SaveToJSON(); err == nil {
-		g.saveSystem.SaveAchievements(achievementData)
+}
+
+func (g *Game) hazardsToSaveData(hazards *Hazards) *storage.HazardData {
+	if hazards == nil {
+		return nil
 	}
+	return &storage.HazardData{MoveBudget: hazards.MoveBudget}
 }
 
 func (g *Game) loadGame() {
@@ -327,19 +2021,41 @@ func (g *Game) loadGame() {
 	if err != nil {
 		return
 	}
-	
+	g.restoreGameState(gameState)
+}
+
+// restoreGameState turns a saved game back into the world being played
+func (g *Game) restoreGameState(gameState *storage.CurrentGameState) {
 	// Convert saved state back to game world
 	board := g.saveDataToBoard(gameState.Board)
 	
 	g.world = &World{
 		State:     StatePlaying,
-		Mode:      GameMode(gameState.Mode),
+		Mode:      ModeID(gameState.Mode),
 		Board:     board,
 		Score:     g.saveDataToScore(gameState.Score),
-		StartTime: gameState.StartTime,
 		TimeLimit: gameState.TimeLimit,
 		GameWon:   gameState.GameWon,
+		Relaxed:   gameState.Relaxed,
+		Assisted:  gameState.Assisted,
+		Overtime:  gameState.Overtime,
+		Rules:     NewRuleSet(mutatorIDs(gameState.Mutators)),
+		Random:    random.New(gameState.GameSeed()),
 	}
+	if gameState.Hazards != nil {
+		g.world.Hazards = &Hazards{MoveBudget: gameState.Hazards.MoveBudget}
+	}
+	if gameState.Zen != nil {
+		g.world.Zen = &Zen{Rings: gameState.Zen.Rings}
+	}
+	g.zoomToFit()
+	g.moveAnalyzer = nil
+	g.lastMove = nil
+	g.clearPlan()
+	// Resume the timer where it stopped rather than counting the time spent away
+	g.world.StartTime = g.clock.Now().Add(-g.world.Rules.clockTime(g.world.Score.Time))
+	// Bridges built before saving are part of the starting board
+	g.replay = replay.New(board)
 }
 
 func (g *Game) boardToSaveData(board *island.Board) storage.BoardData {
@@ -354,12 +2070,22 @@ func (g *Game) boardToSaveData(board *island.Board) storage.BoardData {
 		}
 	}
 	
-	return storage.BoardData{
-		Width:   board.Width,
-		Height:  board.Height,
-		Tiles:   tiles,
-		Islands: board.Islands,
+	components, err := board.MarshalComponents()
+	if err != nil {
+		fmt.Println("Failed to save board components:", err)
 	}
+	data := storage.BoardData{
+		Width:      board.Width,
+		Height:     board.Height,
+		Tiles:      tiles,
+		Islands:    board.Islands,
+		Components: components,
+	}
+	if budget, ok := board.Rules().(island.BridgeBudget); ok {
+		data.BridgeBudget = budget.Max
+	}
+	data.MergeLand = board.Rules().MergesLand()
+	return data
 }
 
 func (g *Game) saveDataToBoard(data storage.BoardData) *island.Board {
@@ -370,8 +2096,18 @@ func (g *Game) saveDataToBoard(data storage.BoardData) *island.Board {
 			if y < len(data.Tiles) && x < len(data.Tiles[y]) {
 				board.SetTile(x, y, island.TileType(data.Tiles[y][x]))
 			}
+			// Saves from before components kept bridge health in a grid of its own
+			if y < len(data.Health) && x < len(data.Health[y]) {
+				board.SetBridgeHealth(x, y, data.Health[y][x])
+			}
 		}
 	}
+	if err := board.UnmarshalComponents(data.Components); err != nil {
+		fmt.Println("Skipped saved board components:", err)
+	}
+	if data.BridgeBudget > 0 || data.MergeLand {
+		board.SetRules(island.LevelRules(data.MergeLand, data.BridgeBudget))
+	}
 	
 	board.Islands = data.Islands
 	return board
@@ -379,17 +2115,35 @@ func (g *Game) saveDataToBoard(data storage.BoardData) *island.Board {
 
 func (g *Game) scoreToSaveData(score Score) storage.ScoreData {
 	return storage.ScoreData{
-		Moves:    score.Moves,
-		Time:     score.Time,
-		BestTime: score.BestTime,
+		Moves:        score.Moves,
+		Time:         score.Time,
+		BestTime:     score.BestTime,
+		BestMoves:    score.BestMoves,
+		BestPoints:   score.BestPoints,
+		Points:       score.Points,
+		Combo:        score.Combo,
+		BestCombo:    score.BestCombo,
+		IslandPoints: score.IslandPoints,
+		ComboPoints:  score.ComboPoints,
+		TimeBonus:    score.TimeBonus,
+		MoveTimes:    score.MoveTimes,
 	}
 }
 
 func (g *Game) saveDataToScore(data storage.ScoreData) Score {
 	return Score{
-		Moves:     data.Moves,
-		Time:      data.Time,
-		BestTime:  data.BestTime,
-		BestMoves: data.Moves, // Approximate
+		Moves:      data.Moves,
+		Time:       data.Time,
+		BestTime:   data.BestTime,
+		BestMoves:  data.BestMoves,
+		BestPoints: data.BestPoints,
+
+		Points:       data.Points,
+		Combo:        data.Combo,
+		BestCombo:    data.BestCombo,
+		IslandPoints: data.IslandPoints,
+		ComboPoints:  data.ComboPoints,
+		TimeBonus:    data.TimeBonus,
+		MoveTimes:    data.MoveTimes,
 	}
 }
\ No newline at end of file
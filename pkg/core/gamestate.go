@@ -9,12 +9,22 @@ const (
 	StateGameOver
 	StateLevelSelect
 	StateLevelEditor
+	StateTutorial
+	StateCustomLevels
+	StateProfileSelect
 )
 
-type GameMode int
+// ModeID identifies a registered GameMode
+type ModeID int
 
 const (
-	ModeClassic GameMode = iota
+	ModeClassic ModeID = iota
 	ModeTimeAttack
 	ModePuzzle
+	ModeCounts
+	ModeStorm
+	ModeSpeedrun
+	ModeZen
+	ModeOnlineRace
+	ModeCorrespondence
 )
\ No newline at end of file
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// exportBoardGraph writes the board's islands and bridges as a "dot" or
+// "graphml" file and returns the file name
+func (g *Game) exportBoardGraph(board *island.Board, format string) (string, error) {
+	graph := board.Graph()
+	var data []byte
+	switch format {
+	case "dot":
+		data = graph.DOT()
+	case "graphml":
+		data = graph.GraphML()
+	default:
+		return "", fmt.Errorf("unknown graph format %q, expected dot or graphml", format)
+	}
+
+	name := capture.FileName(format, time.Now())
+	if err := g.saveSystem.ExportFile(name, data); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// exportEditorGraph handles the editor's Graph button
+func (g *Game) exportEditorGraph(e events.GraphExportRequested) {
+	name, err := g.exportBoardGraph(g.levelEditor.CurrentBoard(), e.Format)
+	if err != nil {
+		fmt.Println("Graph export failed:", err)
+		g.levelEditor.Status = "Graph export failed"
+		return
+	}
+	g.levelEditor.Status = "Saved " + name
+}
+
+// consoleExport writes the board being played, or the editor's board, as a graph
+func (g *Game) consoleExport(args []string) (string, error) {
+	if len(args) != 2 || args[0] != "graph" {
+		return "", fmt.Errorf("expected graph and a format")
+	}
+	board := g.world.Board
+	if g.world.State == StateLevelEditor {
+		board = g.levelEditor.CurrentBoard()
+	}
+	if board == nil {
+		return "", fmt.Errorf("no board to export")
+	}
+
+	name, err := g.exportBoardGraph(board, args[1])
+	if err != nil {
+		return "", err
+	}
+	return "Saved " + name, nil
+}
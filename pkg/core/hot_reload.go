@@ -0,0 +1,42 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ponyo877/island-merge/pkg/assets"
+)
+
+// reloadAssets picks up level packs and theme colours edited on disk in dev
+// mode. Icons are only read at startup.
+func (g *Game) reloadAssets() {
+	var levelsChanged, themeChanged bool
+	for _, name := range g.assetWatcher.Poll() {
+		switch {
+		case strings.HasPrefix(name, "levels/"):
+			levelsChanged = true
+		case name == assets.ThemeFile:
+			themeChanged = true
+		}
+	}
+
+	if levelsChanged {
+		if err := g.levelManager.ReloadBuiltin(); err != nil {
+			fmt.Println("Keeping the previous levels:", err)
+		} else {
+			g.restoreLevelProgress()
+			g.thumbnails.Clear()
+			fmt.Println("Reloaded the built-in levels")
+		}
+	}
+	if themeChanged {
+		theme, err := assets.LoadTheme()
+		if err != nil {
+			fmt.Println("Keeping the previous theme:", err)
+		} else {
+			g.theme = theme
+			g.applyTheme()
+			fmt.Println("Reloaded the theme")
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package core
+
+import "time"
+
+// checkIdle pauses a timed level once the player has left it alone for the
+// idle pause time, so stepping away does not run out the clock
+func (g *Game) checkIdle() {
+	now := time.Now()
+	timed := g.world.State == StatePlaying && g.world.TimeLimit > 0 && !g.world.GameWon
+	if g.input.Active() || !timed || g.saveLoadUI.IsOpen() || g.idleSince.IsZero() {
+		g.idleSince = now
+		return
+	}
+	if g.idlePause > 0 && now.Sub(g.idleSince) >= g.idlePause {
+		g.world.State = StatePaused
+		g.idlePaused = true
+	}
+}
+
+// pauseMessages are the pause overlay's reason and hint
+func (g *Game) pauseMessages() (string, string) {
+	if g.idlePaused {
+		return "Paused due to inactivity", "Click to resume"
+	}
+	return "Paused", ""
+}
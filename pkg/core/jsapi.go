@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/jsapi"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// stateNames are the game states as reported to embedding pages
+var stateNames = map[GameState]string{
+	StateMenu:          "menu",
+	StatePlaying:       "playing",
+	StatePaused:        "paused",
+	StateGameOver:      "game_over",
+	StateLevelSelect:   "level_select",
+	StateLevelEditor:   "level_editor",
+	StateTutorial:      "tutorial",
+	StateCustomLevels:  "custom_levels",
+	StateProfileSelect: "profile_select",
+}
+
+// runPageCommands carries out requests from a page embedding the game
+func (g *Game) runPageCommands() {
+	for _, command := range g.jsAPI.Poll() {
+		command.Done(g.runPageCommand(command))
+	}
+}
+
+func (g *Game) runPageCommand(command jsapi.Command) error {
+	levelData := command.Level
+	if levelData == nil {
+		levelData = g.levelManager.GetLevelByID(command.LevelID)
+		if levelData == nil {
+			return fmt.Errorf("%w %q", levels.ErrUnknownLevel, command.LevelID)
+		}
+	}
+	g.startExternalLevel(levelData)
+	return nil
+}
+
+// startExternalLevel plays a level chosen from outside the game, by an embedding
+// page or a link, closing whatever screen was open
+func (g *Game) startExternalLevel(levelData *levels.LevelData) {
+	if levelData.OptimalMoves <= 0 {
+		// Rate stars against the solver like custom levels without a par
+		levelData.OptimalMoves = max(solver.OptimalMoves(levelData.NewBoard()), 0)
+	}
+
+	g.levelSelectUI.Hide()
+	g.customLevelsUI.Hide()
+	g.profileSelectUI.Hide()
+	g.helpOverlay.Hide()
+	g.customLevelID = ""
+	g.startLevel(levelData)
+}
+
+// pageStats is the snapshot of the current game pages can read
+func (g *Game) pageStats() jsapi.Stats {
+	stats := jsapi.Stats{
+		State:   stateNames[g.world.State],
+		Mode:    LookupMode(g.world.Mode).Name(),
+		LevelID: g.currentLevelID(),
+		Moves:   g.world.Score.Moves,
+		TimeMS:  g.world.Score.Time.Milliseconds(),
+		Won:     g.world.GameWon,
+	}
+	if g.world.Board != nil {
+		stats.Islands = g.world.Board.IslandGroupCount()
+	}
+	return stats
+}
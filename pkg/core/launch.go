@@ -0,0 +1,77 @@
+package core
+
+import (
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// launchModes are the modes that can be preferred and given their own
+// defaults; Speedrun is left out as it starts from a difficulty picker
+var launchModes = []ModeID{ModeClassic, ModeTimeAttack, ModePuzzle, ModeCounts, ModeStorm, ModeZen}
+
+// modeOptions lists launchModes for the settings panel's Modes tab
+func modeOptions() []ui.ModeOption {
+	options := make([]ui.ModeOption, len(launchModes))
+	for i, mode := range launchModes {
+		options[i] = ui.ModeOption{ID: int(mode), Name: LookupMode(mode).Name()}
+	}
+	return options
+}
+
+// relaxedIn reports whether games of a mode start without timers, following the
+// mode's own default when the player set one
+func (g *Game) relaxedIn(mode ModeID) bool {
+	return g.modeDefaults[int(mode)].Relaxed(g.relaxed)
+}
+
+// moveFeedbackIn reports whether bridges are rated in games of a mode
+func (g *Game) moveFeedbackIn(mode ModeID) bool {
+	return g.modeDefaults[int(mode)].Feedback(g.moveFeedbackOn)
+}
+
+// launch opens the game on what the player chose to start with, once per run
+func (g *Game) launch(settings *storage.GameSettings) {
+	if g.launched {
+		return
+	}
+	g.launched = true
+
+	switch settings.LaunchInto {
+	case storage.LaunchPreferredMode:
+		g.startPreferredMode(ModeID(settings.PreferredMode), settings)
+	case storage.LaunchLastLevel:
+		for _, id := range g.saveSystem.RecentLevels() {
+			if _, start := g.recentLevel(id); start != nil {
+				start()
+				return
+			}
+		}
+	}
+}
+
+// startPreferredMode starts a game of the mode the way the main menu does,
+// skipping its pickers: Classic uses the last board size and Zen resumes the
+// saved session
+func (g *Game) startPreferredMode(mode ModeID, settings *storage.GameSettings) {
+	switch mode {
+	case ModeClassic:
+		width, height := settings.ClassicWidth, settings.ClassicHeight
+		if width < ui.MinGridSize || height < ui.MinGridSize {
+			// Never picked; use the size picker's default
+			width, height = ui.GridSizePresets[1].Width, ui.GridSizePresets[1].Height
+		}
+		g.startClassic(width, height)
+	case ModeTimeAttack:
+		if !g.relaxedIn(ModeTimeAttack) {
+			g.startTimeAttack()
+		}
+	case ModePuzzle:
+		g.startGameMode(ModePuzzle)
+	case ModeCounts:
+		g.startBridgeCounts()
+	case ModeStorm:
+		g.startStorm()
+	case ModeZen:
+		g.startZen(0)
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/remote"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// votePromptDelay lets the victory sequence play before the vote prompt covers it
+const votePromptDelay = 2500 * time.Millisecond
+
+// isSharedLevel reports whether a level came from someone else: a share code
+// or an imported level pack
+func (g *Game) isSharedLevel(levelData *levels.LevelData) bool {
+	if strings.HasPrefix(levelData.ID, "shared_") {
+		return true
+	}
+	for _, set := range g.levelManager.LevelSets {
+		if set.PackID == "" {
+			continue
+		}
+		for _, level := range set.Levels {
+			if level.ID == levelData.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// queueVotePrompt asks for a vote on a shared level the first time it is won.
+// Assisted games and speedruns, which move straight on, are not asked.
+func (g *Game) queueVotePrompt(e events.GameWon) {
+	level := g.currentLevel
+	if level == nil || level.ID != e.LevelID || g.world.Assisted || e.Mode == int(ModeSpeedrun) || !g.isSharedLevel(level) {
+		return
+	}
+	if _, voted := g.saveSystem.LevelVoteFor(level.ID); voted {
+		return
+	}
+	g.voteLevel = level
+	g.voteDue = g.clock.Now().Add(votePromptDelay)
+}
+
+// showVotePrompt opens the queued prompt once the victory has been shown. It is
+// dropped if the player leaves the level first, and asked only once either way.
+func (g *Game) showVotePrompt() {
+	if g.voteLevel == nil {
+		return
+	}
+	if g.world.State != StatePlaying || !g.world.GameWon || g.currentLevel != g.voteLevel {
+		g.voteLevel = nil
+		return
+	}
+	if clock.Since(g.clock, g.voteDue) < 0 {
+		return
+	}
+	level := g.voteLevel
+	g.voteLevel = nil
+	g.votePrompt.OnSubmit = func(up bool, difficulty int) {
+		g.submitVote(level, up, difficulty)
+	}
+	g.votePrompt.Show(level.Name)
+}
+
+// submitVote stores a vote on a level and sends it on
+func (g *Game) submitVote(level *levels.LevelData, up bool, difficulty int) {
+	// A level that cannot be encoded is still voted on, just never posted
+	code, _ := levels.EncodeShareCode(level)
+	vote := storage.LevelVote{
+		LevelID:    level.ID,
+		ShareCode:  code,
+		Up:         up,
+		Difficulty: difficulty,
+		Date:       time.Now(),
+	}
+	if err := g.saveSystem.SaveLevelVote(vote); err != nil {
+		fmt.Println("Failed to save the level vote:", err)
+		return
+	}
+	g.postLevelVotes()
+}
+
+// initVotePoster sets up posting to the sharing backend and sends votes left
+// over from earlier sessions
+func (g *Game) initVotePoster() {
+	settings, _ := g.saveSystem.LoadSettings()
+	g.votePoster = remote.NewVotePoster(settings.LevelVoteURL)
+	g.postLevelVotes()
+}
+
+// postLevelVotes sends every vote the backend has not accepted yet. Votes wait
+// while offline or while an earlier post is in flight.
+func (g *Game) postLevelVotes() {
+	if g.saveSystem.Offline() || !g.votePoster.Enabled() || g.votePoster.Busy() {
+		return
+	}
+	pending := g.saveSystem.UnpostedLevelVotes()
+	votes := make([]remote.Vote, len(pending))
+	for i, vote := range pending {
+		votes[i] = remote.Vote{ShareCode: vote.ShareCode, Up: vote.Up, Difficulty: vote.Difficulty}
+	}
+	if g.votePoster.Start(votes) {
+		g.votesInFlight = pending
+	}
+}
+
+// pollVotePoster marks posted votes once the backend accepts them; failed
+// posts are retried when the connection comes back or the game restarts
+func (g *Game) pollVotePoster() {
+	done, err := g.votePoster.Poll()
+	if !done {
+		return
+	}
+	sent := g.votesInFlight
+	g.votesInFlight = nil
+	if err != nil {
+		fmt.Println("Level votes not posted:", err)
+		return
+	}
+	if err := g.saveSystem.MarkLevelVotesPosted(sent); err != nil {
+		fmt.Println("Failed to save posted level votes:", err)
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// measureColor marks the measured tiles and the route between them
+var measureColor = color.RGBA{0, 200, 230, 255}
+
+// measurement is the measuring tool's state while M is held: the first tile
+// clicked and, once a second is, the bridges a route between them takes
+type measurement struct {
+	from  island.Point
+	to    *island.Point
+	route []solver.Move
+	found bool
+}
+
+// measureTile picks a tile to measure from, or to once one is picked; a third
+// click starts a new measurement
+func (g *Game) measureTile(gridX, gridY int) {
+	if g.world.Board.GetTile(gridX, gridY) == nil {
+		return
+	}
+	point := island.Point{X: gridX, Y: gridY}
+	if g.measure == nil || g.measure.to != nil {
+		g.measure = &measurement{from: point}
+		return
+	}
+	g.measure.to = &point
+	g.measure.route, g.measure.found = solver.Distance(g.world.Board,
+		solver.Move{X: g.measure.from.X, Y: g.measure.from.Y}, solver.Move{X: gridX, Y: gridY})
+}
+
+// measureHint describes the measurement for the HUD
+func (g *Game) measureHint() string {
+	switch m := g.measure; {
+	case m == nil:
+		return "Measure: click a tile"
+	case m.to == nil:
+		return "Measure: click a second tile"
+	case !m.found:
+		return "Measure: no route between these tiles"
+	default:
+		manhattan := abs(m.to.X-m.from.X) + abs(m.to.Y-m.from.Y)
+		return fmt.Sprintf("Measure: %d bridges apart (Manhattan distance %d)", len(m.route), manhattan)
+	}
+}
+
+// drawMeasurement highlights the measured tiles and the bridges of the route between them
+func (g *Game) drawMeasurement(screen *ebiten.Image) {
+	m := g.measure
+	if m == nil {
+		return
+	}
+	routeColor := measureColor
+	routeColor.A = 160
+	for _, move := range m.route {
+		g.render.DrawTileHighlight(screen, move.X, move.Y, routeColor)
+	}
+	g.render.DrawTileHighlight(screen, m.from.X, m.from.Y, measureColor)
+	if m.to != nil {
+		g.render.DrawTileHighlight(screen, m.to.X, m.to.Y, measureColor)
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// Thumbnail cache keys of boards that are not installed levels
+const (
+	savedGameThumbnail    = "saved_game"
+	customThumbnailPrefix = "custom:"
+)
+
+// refreshMenuCards puts the saved game and the recently played levels on the
+// main menu. It reads storage, so it runs once each time the menu is shown.
+func (g *Game) refreshMenuCards() {
+	g.menuCardsFresh = true
+	g.thumbnails.Forget(savedGameThumbnail)
+
+	var continueCard *ui.MenuCard
+	if gameState, err := g.saveSystem.LoadGameState(); err == nil && !gameState.GameWon {
+		continueCard = &ui.MenuCard{
+			Title: "Continue",
+			Lines: []string{
+				LookupMode(ModeID(gameState.Mode)).Name(),
+				fmt.Sprintf("Time %02d:%02d", int(gameState.Score.Time.Minutes()), int(gameState.Score.Time.Seconds())%60),
+				fmt.Sprintf("%d moves", gameState.Score.Moves),
+			},
+			Board:  g.saveDataToBoard(gameState.Board),
+			Key:    savedGameThumbnail,
+			Action: g.loadGame,
+		}
+	}
+
+	recent := make([]*ui.MenuCard, 0)
+	for _, id := range g.saveSystem.RecentLevels() {
+		levelData, start := g.recentLevel(id)
+		if levelData == nil {
+			continue
+		}
+		key := ui.LevelThumbnailKey(id)
+		if g.levelManager.GetLevelByID(id) == nil {
+			key = customThumbnailPrefix + id
+			g.thumbnails.Forget(key) // Custom levels can be edited between visits
+		}
+		recent = append(recent, &ui.MenuCard{
+			Lines:  []string{levelData.Name},
+			Board:  levelData.NewBoard(),
+			Key:    key,
+			Action: start,
+		})
+	}
+	g.mainMenu.SetCards(continueCard, recent)
+}
+
+// recentLevel finds a level that can be played again from the menu, either
+// installed or made in the editor, and how to start it. Generated levels
+// cannot be found again, so nil is returned for them.
+func (g *Game) recentLevel(id string) (*levels.LevelData, func()) {
+	if levelData := g.levelManager.GetLevelByID(id); levelData != nil {
+		return levelData, func() { g.startLevel(levelData) }
+	}
+	customLevels, err := g.saveSystem.LoadCustomLevels()
+	if err != nil {
+		return nil, nil
+	}
+	for i := range customLevels {
+		if custom := &customLevels[i]; custom.ID == id {
+			return custom.LevelData(), func() { g.startCustomLevel(custom) }
+		}
+	}
+	return nil, nil
+}
+
+// recordRecentLevel remembers a started level for the menu's recently played row
+func (g *Game) recordRecentLevel(e events.GameStarted) {
+	if e.LevelID == "" {
+		return
+	}
+	if levelData, _ := g.recentLevel(e.LevelID); levelData == nil {
+		return
+	}
+	if err := g.saveSystem.RecordRecentLevel(e.LevelID); err != nil {
+		fmt.Println("Failed to record the recently played level:", err)
+	}
+}
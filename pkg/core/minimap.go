@@ -0,0 +1,28 @@
+package core
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// miniMapShown reports whether the board runs off screen, so the mini-map is needed
+func (g *Game) miniMapShown() bool {
+	switch g.world.State {
+	case StatePlaying, StatePaused, StateGameOver:
+	default:
+		return false
+	}
+	if g.world.Board == nil {
+		return false
+	}
+	width, height := g.Layout(0, 0)
+	return !g.render.BoardBounds(g.world.Board).In(image.Rect(0, 0, width, height))
+}
+
+// drawMiniMap draws the mini-map with the part of the board the camera shows
+func (g *Game) drawMiniMap(screen *ebiten.Image) {
+	bounds := screen.Bounds()
+	minX, minY, maxX, maxY := g.render.VisibleTiles(bounds.Dx(), bounds.Dy())
+	g.miniMap.Draw(screen, g.world.Board, g.render.Theme(), minX, minY, maxX, maxY)
+}
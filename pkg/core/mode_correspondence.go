@@ -0,0 +1,401 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/correspondence"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/random"
+	"github.com/ponyo877/island-merge/pkg/share"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// correspondenceBoardOptions shapes the boards new games are played on
+var correspondenceBoardOptions = levels.GenerateOptions{
+	Width:   7,
+	Height:  7,
+	Density: 0.12,
+	MinPar:  4,
+	MaxPar:  10,
+}
+
+const (
+	correspondenceShown    = 6  // Games listed in the picker, under New game
+	maxCorrespondenceGames = 20 // Kept in storage; finished games are dropped first
+)
+
+// Correspondence is the game being played in Correspondence mode and the
+// player's seat in it
+type Correspondence struct {
+	Game *correspondence.Game
+	Seat int
+}
+
+func (c *Correspondence) yourTurn() bool {
+	return !c.Game.Over() && c.Game.Turn() == c.Seat
+}
+
+func (c *Correspondence) opponent() string {
+	if name := c.Game.Players[1-c.Seat]; name != "" {
+		return name
+	}
+	return "your opponent"
+}
+
+// correspondenceMode alternates bridges between two players, one turn per code
+// sent between them; the higher score wins once the board is done
+type correspondenceMode struct{ baseMode }
+
+func (correspondenceMode) ID() ModeID   { return ModeCorrespondence }
+func (correspondenceMode) Name() string { return "Correspondence" }
+
+func (correspondenceMode) Init(w *World) {
+	w.TimeLimit = 0
+}
+
+func (correspondenceMode) CheckWin(w *World) bool {
+	c := w.Correspondence
+	return c != nil && c.Game.Over() && c.Game.Winner() == c.Seat
+}
+
+func (correspondenceMode) CheckLose(w *World) (bool, string) {
+	c := w.Correspondence
+	if c == nil || !c.Game.Over() {
+		return false, ""
+	}
+	switch c.Game.Winner() {
+	case c.Seat:
+		return false, ""
+	case correspondence.Draw:
+		return true, "draw"
+	default:
+		return true, "outscored"
+	}
+}
+
+func (correspondenceMode) HUDExtras(w *World) []string {
+	c := w.Correspondence
+	if c == nil {
+		return nil
+	}
+	lines := []string{fmt.Sprintf("You %d - %d %s", c.Game.Scores[c.Seat], c.Game.Scores[1-c.Seat], c.opponent())}
+	switch {
+	case c.Game.Over():
+	case c.yourTurn():
+		lines = append(lines, "Your turn: build one bridge")
+	default:
+		lines = append(lines, "Waiting for "+c.opponent())
+	}
+	return lines
+}
+
+func init() {
+	RegisterMode(correspondenceMode{})
+}
+
+// correspondenceMoved plays a bridge built in a correspondence game into it
+// and sends the game on
+func (g *Game) correspondenceMoved(e events.BridgeBuilt) {
+	c := g.world.Correspondence
+	if c == nil {
+		return
+	}
+	if _, err := c.Game.Play(e.X, e.Y); err != nil {
+		fmt.Println("Correspondence game out of step:", err)
+		return
+	}
+	g.saveCorrespondenceGame(c.Game, c.Seat)
+	g.sendCorrespondence(c.Game)
+}
+
+// correspondenceMayBuild reports whether a bridge may be built: always outside
+// correspondence games, and on the player's turn in them
+func (g *Game) correspondenceMayBuild() bool {
+	c := g.world.Correspondence
+	return c == nil || c.yourTurn()
+}
+
+// showCorrespondencePicker offers a new game and the games played so far,
+// those waiting on the player first
+func (g *Game) showCorrespondencePicker() {
+	games := g.loadCorrespondence()
+	slices.SortStableFunc(games, func(a, b storage.CorrespondenceGame) int {
+		if rank := correspondenceRank(a) - correspondenceRank(b); rank != 0 {
+			return rank
+		}
+		return b.Updated.Compare(a.Updated)
+	})
+	games = games[:min(len(games), correspondenceShown)]
+
+	g.correspondencePicker.Title = "Correspondence"
+	g.correspondencePicker.Choices = []ui.Choice{{Label: "New game", Detail: "you build first"}}
+	g.correspondenceIDs = nil
+	for _, game := range games {
+		opponent := game.Opponent
+		if opponent == "" {
+			opponent = "not joined yet"
+		}
+		detail := "Waiting"
+		switch {
+		case game.Over:
+			detail = game.Result
+		case game.YourTurn:
+			detail = "Your turn"
+		}
+		g.correspondencePicker.Choices = append(g.correspondencePicker.Choices, ui.Choice{
+			Label:    "vs " + opponent,
+			Detail:   detail,
+			Disabled: game.Over,
+		})
+		g.correspondenceIDs = append(g.correspondenceIDs, game.ID)
+	}
+	g.correspondencePicker.Show()
+}
+
+// correspondenceRank orders the picker: games on the player's turn, then
+// those waiting, then finished ones
+func correspondenceRank(game storage.CorrespondenceGame) int {
+	switch {
+	case game.Over:
+		return 2
+	case game.YourTurn:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// startCorrespondence starts a new game for choice 0 and opens a listed one otherwise
+func (g *Game) startCorrespondence(choice int) {
+	if choice == 0 {
+		g.newCorrespondence()
+		return
+	}
+	id := g.correspondenceIDs[choice-1]
+	for _, stored := range g.loadCorrespondence() {
+		if stored.ID != id {
+			continue
+		}
+		game, err := correspondence.Decode(stored.Code)
+		if err != nil {
+			fmt.Println("Failed to open the correspondence game:", err)
+			return
+		}
+		g.playCorrespondence(game, stored.Seat)
+		if !game.Over() && game.Turn() != stored.Seat {
+			// The code may not have reached the opponent; offer it again
+			g.sendCorrespondence(game)
+		}
+		return
+	}
+}
+
+// newCorrespondence starts a game on a generated board with the player first
+func (g *Game) newCorrespondence() {
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), correspondenceBoardOptions)
+	if err == nil {
+		var game *correspondence.Game
+		if game, err = correspondence.New(levelData, g.saveSystem.CurrentProfile().Name); err == nil {
+			g.saveCorrespondenceGame(game, 0)
+			g.playCorrespondenceFrom(game, 0, source)
+			return
+		}
+	}
+	fmt.Println("Failed to start a correspondence game:", err)
+}
+
+// openCorrespondenceCode opens a game received from the other player. A game
+// seen for the first time is joined in the seat whose turn it is; a code older
+// than the copy kept here opens the kept one.
+func (g *Game) openCorrespondenceCode(code string) error {
+	game, err := correspondence.Decode(code)
+	if err != nil {
+		return err
+	}
+
+	seat := game.Turn()
+	for _, stored := range g.loadCorrespondence() {
+		if stored.ID != game.ID {
+			continue
+		}
+		if stored.Over {
+			return errors.New("that correspondence game is over")
+		}
+		seat = stored.Seat
+		if kept, err := correspondence.Decode(stored.Code); err == nil && len(kept.Moves) > len(game.Moves) {
+			game = kept
+		}
+	}
+	if game.Players[seat] == "" {
+		game.SetPlayer(seat, g.saveSystem.CurrentProfile().Name)
+	}
+
+	g.saveCorrespondenceGame(game, seat)
+	g.playCorrespondence(game, seat)
+	return nil
+}
+
+func (g *Game) playCorrespondence(game *correspondence.Game, seat int) {
+	g.playCorrespondenceFrom(game, seat, g.newRandom())
+}
+
+// playCorrespondenceFrom shows a correspondence game's board as played so far
+func (g *Game) playCorrespondenceFrom(game *correspondence.Game, seat int, source *random.Source) {
+	g.autoSaveNow()
+	g.currentLevel = nil
+	g.opponent = nil
+	g.world = &World{
+		State:          StatePlaying,
+		Mode:           ModeCorrespondence,
+		Board:          game.Board.Clone(),
+		StartTime:      g.clock.Now(),
+		Correspondence: &Correspondence{Game: game, Seat: seat},
+		Random:         source,
+	}
+	LookupMode(ModeCorrespondence).Init(g.world)
+	g.events.Publish(events.GameStarted{Mode: int(ModeCorrespondence)})
+}
+
+// turnSubmission is a correspondence game passed on through the online backend
+type turnSubmission struct {
+	Game string `json:"game"`
+	Code string `json:"code"`
+	From string `json:"from"`
+	To   string `json:"to,omitempty"` // Empty until the opponent has joined
+}
+
+// sendCorrespondence hands the game to the player for their opponent: copied
+// to the clipboard in the browser and saved as a .turn file elsewhere. With an
+// online backend it is queued for the backend too.
+func (g *Game) sendCorrespondence(game *correspondence.Game) {
+	code, err := game.Encode()
+	if err != nil {
+		fmt.Println("Failed to encode the correspondence game:", err)
+		return
+	}
+	fmt.Println("Correspondence code:", code)
+
+	c := g.world.Correspondence
+	if g.onlineURL != "" && c != nil {
+		submission := turnSubmission{Game: game.ID, Code: code, From: game.Players[c.Seat], To: game.Players[1-c.Seat]}
+		if err := g.outbox.Enqueue(gamenet.KindTurn, submission); err != nil {
+			fmt.Println("Failed to queue the turn:", err)
+		}
+	}
+
+	err = share.CopyText(code)
+	if err == nil {
+		g.showCaptureMessage("Copied the game code; send it to your opponent")
+		return
+	}
+	if !errors.Is(err, share.ErrCopyUnsupported) {
+		fmt.Println("Copying the game code failed:", err)
+	}
+	name := fmt.Sprintf("island-merge_%s.turn", game.ID)
+	if err := g.saveSystem.ExportFile(name, []byte(code)); err != nil {
+		fmt.Println("Correspondence export failed:", err)
+		g.showCaptureMessage("Saving the game code failed")
+		return
+	}
+	g.showCaptureMessage("Saved " + name + "; send it to your opponent")
+}
+
+func (g *Game) loadCorrespondence() []storage.CorrespondenceGame {
+	games, err := g.saveSystem.LoadCorrespondence()
+	if err != nil {
+		return nil
+	}
+	return games
+}
+
+// saveCorrespondenceGame keeps a game as it stands, replacing the copy kept
+// before, and updates the menu badge
+func (g *Game) saveCorrespondenceGame(game *correspondence.Game, seat int) {
+	code, err := game.Encode()
+	if err != nil {
+		fmt.Println("Failed to encode the correspondence game:", err)
+		return
+	}
+	record := storage.CorrespondenceGame{
+		ID:       game.ID,
+		Code:     code,
+		Seat:     seat,
+		Opponent: game.Players[1-seat],
+		YourTurn: !game.Over() && game.Turn() == seat,
+		Over:     game.Over(),
+		Updated:  time.Now(),
+	}
+	if record.Over {
+		record.Result = correspondenceResult(game, seat)
+	}
+
+	games := slices.DeleteFunc(g.loadCorrespondence(), func(stored storage.CorrespondenceGame) bool {
+		return stored.ID == game.ID
+	})
+	games = append(games, record)
+	for len(games) > maxCorrespondenceGames {
+		// Drop the oldest finished game, or the oldest of all when none is finished
+		oldest := 0
+		for i, stored := range games {
+			if stored.Over != games[oldest].Over {
+				if stored.Over {
+					oldest = i
+				}
+			} else if stored.Updated.Before(games[oldest].Updated) {
+				oldest = i
+			}
+		}
+		games = slices.Delete(games, oldest, oldest+1)
+	}
+	if err := g.saveSystem.SaveCorrespondence(games); err != nil {
+		fmt.Println("Failed to save the correspondence game:", err)
+	}
+	g.updateCorrespondenceMenuItem()
+}
+
+// correspondenceResult sums up a finished game from the player's side
+func correspondenceResult(game *correspondence.Game, seat int) string {
+	score := fmt.Sprintf("%d-%d", game.Scores[seat], game.Scores[1-seat])
+	switch game.Winner() {
+	case seat:
+		return "Won " + score
+	case correspondence.Draw:
+		return "Drew " + score
+	default:
+		return "Lost " + score
+	}
+}
+
+// correspondenceWaiting counts the games waiting on the player
+func (g *Game) correspondenceWaiting() int {
+	waiting := 0
+	for _, game := range g.loadCorrespondence() {
+		if game.YourTurn {
+			waiting++
+		}
+	}
+	return waiting
+}
+
+// updateCorrespondenceMenuItem badges the Online menu item with the games waiting on the player
+func (g *Game) updateCorrespondenceMenuItem() {
+	g.mainMenu.SetItemBadge(onlineMenuItem, g.correspondenceWaiting())
+}
+
+// pollDroppedTurn opens a .turn file dropped on the window
+func (g *Game) pollDroppedTurn() {
+	select {
+	case code := <-g.droppedTurns:
+		if err := g.openCorrespondenceCode(code); err != nil {
+			fmt.Println("Ignoring dropped turn:", err)
+		}
+	default:
+	}
+}
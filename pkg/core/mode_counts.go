@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// countsLevelOptions shapes the generated boards of Bridge Counts mode
+var countsLevelOptions = levels.GenerateOptions{
+	Width:   8,
+	Height:  8,
+	Density: 0.1,
+	MinPar:  6,
+	MaxPar:  14,
+}
+
+func init() {
+	RegisterMode(countsMode{})
+}
+
+// countsMode is a deduction puzzle in the style of a nonogram: every row and
+// column shows how many bridge tiles the solution has there, and the board is
+// only solved when the islands are connected and every count matches
+type countsMode struct{ baseMode }
+
+func (countsMode) ID() ModeID   { return ModeCounts }
+func (countsMode) Name() string { return "Bridge Counts" }
+
+func (countsMode) Init(w *World) {
+	solution := solver.Solve(w.Board)
+	rows, cols := solver.LineCounts(solution.Moves, w.Board.Width, w.Board.Height)
+	// Bridges the level starts with count towards the clues too
+	builtRows, builtCols := solver.BridgeLineCounts(w.Board)
+	for i := range rows {
+		rows[i] += builtRows[i]
+	}
+	for i := range cols {
+		cols[i] += builtCols[i]
+	}
+	w.LineHints = &LineHints{Rows: rows, Cols: cols}
+	w.Board.SetRules(countsRules{Rules: w.Board.Rules(), hints: w.LineHints})
+}
+
+// countsRules add the line counts to a board's win condition
+type countsRules struct {
+	island.Rules
+	hints *LineHints
+}
+
+func (r countsRules) WinCondition(b *island.Board) bool {
+	if !r.Rules.WinCondition(b) {
+		return false
+	}
+	rows, cols := solver.BridgeLineCounts(b)
+	return slices.Equal(rows, r.hints.Rows) && slices.Equal(cols, r.hints.Cols)
+}
+
+func (countsMode) HUDExtras(w *World) []string {
+	if w.Board != nil && w.Board.IsAllConnected() {
+		return []string{"Connected, but the", "counts do not match"}
+	}
+	return []string{"Match every count"}
+}
+
+// startBridgeCounts starts Bridge Counts mode on a newly generated board
+func (g *Game) startBridgeCounts() {
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), countsLevelOptions)
+	if err != nil {
+		fmt.Println("Failed to generate a Bridge Counts board:", err)
+		return
+	}
+	levelData.ID = fmt.Sprintf("counts_%d", source.Seed())
+	levelData.Name = "Bridge Counts"
+	g.startSeededLevel(levelData, ModeCounts, source)
+}
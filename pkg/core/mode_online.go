@@ -0,0 +1,303 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/random"
+	"github.com/ponyo877/island-merge/pkg/share"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// onlineRaceBoardOptions shapes the board both racers play, generated from the
+// match ID so each side builds the same one
+var onlineRaceBoardOptions = timeAttackLevelOptions
+
+// OnlineRace is a race against another player through an online match. Each
+// racer builds on their own copy of the board; the opponent's bridges are
+// replayed on a copy here to show how far along they are.
+type OnlineRace struct {
+	Match         *gamenet.Match
+	Opponent      *island.Board // The opponent's copy of the board
+	Name          string        // The opponent's profile name; empty until they join
+	Moves         int           // The opponent's moves
+	initialGroups int
+	sent          map[string]bool // Refs of the player's deltas since this launch, not seen back yet
+	emotes        *matchEmotes
+}
+
+// joined reports whether the opponent is in the race; nobody builds before then
+func (r *OnlineRace) joined() bool {
+	return r.Name != ""
+}
+
+// raceTile is the body of a bridge or remove delta
+type raceTile struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// racePlayer is the body of a player delta
+type racePlayer struct {
+	Name string `json:"name"`
+}
+
+// onlineRaceMode races another player on the same board; the first to connect
+// every island wins
+type onlineRaceMode struct{ baseMode }
+
+func (onlineRaceMode) ID() ModeID   { return ModeOnlineRace }
+func (onlineRaceMode) Name() string { return "Online Race" }
+
+func (onlineRaceMode) Init(w *World) {
+	w.TimeLimit = 0
+}
+
+func (onlineRaceMode) CheckLose(w *World) (bool, string) {
+	r := w.OnlineRace
+	switch {
+	case r == nil:
+		return false, ""
+	case r.Opponent.IsSolved():
+		return true, "opponent_won"
+	case r.Match.State() == gamenet.MatchLost:
+		return true, "connection_lost"
+	}
+	return false, ""
+}
+
+func (onlineRaceMode) HUDExtras(w *World) []string {
+	r := w.OnlineRace
+	if r == nil {
+		return nil
+	}
+	switch {
+	case r.Match.State() == gamenet.MatchConnecting:
+		return []string{"Connecting..."}
+	case r.Match.State() == gamenet.MatchReconnecting:
+		return []string{"Connection lost, rejoining..."}
+	case !r.joined():
+		return []string{"Race code: " + r.Match.ID(), "Waiting for your opponent to join"}
+	}
+	return []string{"Racing " + r.Name}
+}
+
+func init() {
+	RegisterMode(onlineRaceMode{})
+}
+
+// onlineRaceMayBuild reports whether a bridge may be built: always outside
+// online races, and once the opponent has joined in them
+func (g *Game) onlineRaceMayBuild() bool {
+	r := g.world.OnlineRace
+	return r == nil || r.joined()
+}
+
+// showOnlineRace rejoins the race left by a restart within its grace period,
+// or starts a new race and invites the opponent with its code
+func (g *Game) showOnlineRace() {
+	g.leaveOnlineRace()
+	if match, ok := gamenet.ResumeMatch(g.saveSystem); ok {
+		if err := g.playOnlineRace(match); err != nil {
+			fmt.Println("Failed to rejoin the race:", err)
+		}
+		return
+	}
+
+	id, err := gamenet.NewMatchID()
+	if err == nil {
+		err = g.playOnlineRace(gamenet.JoinMatch(g.onlineURL, id, g.saveSystem))
+	}
+	if err != nil {
+		fmt.Println("Failed to start an online race:", err)
+		return
+	}
+	g.inviteToRace(id)
+}
+
+// joinOnlineRace joins the race a code from the other player points at
+func (g *Game) joinOnlineRace(id string) error {
+	if !gamenet.SupportsMatches(g.onlineURL) {
+		return errors.New("online races need a ws:// or wss:// online_url")
+	}
+	g.leaveOnlineRace()
+	return g.playOnlineRace(gamenet.JoinMatch(g.onlineURL, id, g.saveSystem))
+}
+
+// onlineRaceLevel generates the race's board from its match ID
+func onlineRaceLevel(id string) (*levels.LevelData, *random.Source, error) {
+	hash := fnv.New64a()
+	hash.Write([]byte(id))
+	source := random.New(int64(hash.Sum64()))
+	levelData, err := levels.Generate(source.Stream("board"), onlineRaceBoardOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	levelData.ID = "race_" + id
+	levelData.Name = "Online Race"
+	return levelData, source, nil
+}
+
+// playOnlineRace shows the race's board and introduces the player to the opponent
+func (g *Game) playOnlineRace(match *gamenet.Match) error {
+	levelData, source, err := onlineRaceLevel(match.ID())
+	if err != nil {
+		match.Close()
+		return err
+	}
+
+	g.autoSaveNow()
+	g.currentLevel = nil
+	g.opponent = nil
+	board := levelData.NewBoard()
+	g.world = &World{
+		State:     StatePlaying,
+		Mode:      ModeOnlineRace,
+		Board:     board,
+		StartTime: g.clock.Now(),
+		OnlineRace: &OnlineRace{
+			Match:         match,
+			Opponent:      board.Clone(),
+			initialGroups: board.IslandGroupCount(),
+			sent:          make(map[string]bool),
+		},
+		Random: source,
+	}
+	g.raceMatch = match
+	race := g.world.OnlineRace
+	race.emotes = &matchEmotes{send: func(emote ui.Emote) {
+		g.sendRaceDelta(gamenet.DeltaEmote, raceEmote{Emote: emote.String()})
+	}}
+	g.emotes = race.emotes
+	g.emoteBubbles = nil
+	g.emoteWheel.Close()
+	LookupMode(ModeOnlineRace).Init(g.world)
+	g.events.Publish(events.GameStarted{Mode: int(ModeOnlineRace)})
+	g.sendRaceDelta(gamenet.DeltaPlayer, racePlayer{Name: g.saveSystem.CurrentProfile().Name})
+	return nil
+}
+
+// inviteToRace hands the race code to the player for their opponent, copied
+// to the clipboard where it can be
+func (g *Game) inviteToRace(id string) {
+	fmt.Println("Race code:", id)
+	err := share.CopyText(id)
+	if err == nil {
+		g.showCaptureMessage("Copied the race code; send it to your opponent")
+		return
+	}
+	if !errors.Is(err, share.ErrCopyUnsupported) {
+		fmt.Println("Copying the race code failed:", err)
+	}
+	g.showCaptureMessage("Send your opponent the race code " + id)
+}
+
+// leaveOnlineRace closes the race's match, giving up the player's seat
+func (g *Game) leaveOnlineRace() {
+	if g.raceMatch != nil {
+		g.raceMatch.Close()
+		g.raceMatch = nil
+	}
+}
+
+// updateOnlineRace saves the match session and plays the deltas that arrived.
+// The match is left once its game is.
+func (g *Game) updateOnlineRace() {
+	if g.raceMatch == nil {
+		return
+	}
+	r := g.world.OnlineRace
+	if r == nil || r.Match != g.raceMatch || (g.world.State != StatePlaying && g.world.State != StatePaused && g.world.State != StateGameOver) {
+		g.leaveOnlineRace()
+		return
+	}
+	g.raceMatch.Update()
+	for _, delta := range g.raceMatch.Poll() {
+		g.receiveRaceDelta(r, delta)
+	}
+}
+
+// receiveRaceDelta plays a delta from the match. The player's own come back
+// too: those sent since this launch are on the board already, while older
+// ones rebuild it after a restart.
+func (g *Game) receiveRaceDelta(r *OnlineRace, delta gamenet.Delta) {
+	own := delta.Seat == r.Match.Seat()
+	if own && r.sent[delta.Ref] {
+		delete(r.sent, delta.Ref)
+		return
+	}
+
+	switch delta.Kind {
+	case gamenet.DeltaPlayer:
+		var player racePlayer
+		if own || r.joined() || json.Unmarshal(delta.Body, &player) != nil {
+			return
+		}
+		r.Name = player.Name
+		if r.Name == "" {
+			r.Name = "Opponent"
+		}
+		g.world.StartTime = g.clock.Now() // The race starts once both are in
+		g.showCaptureMessage(r.Name + " joined the race. Go!")
+	case gamenet.DeltaEmote:
+		var body raceEmote
+		if own || json.Unmarshal(delta.Body, &body) != nil {
+			return // The player's own were shown when sent
+		}
+		if emote, ok := emoteNamed(body.Emote); ok {
+			r.emotes.received = append(r.emotes.received, emote)
+		}
+	case gamenet.DeltaBridge, gamenet.DeltaRemove:
+		var tile raceTile
+		if json.Unmarshal(delta.Body, &tile) != nil {
+			return
+		}
+		board := r.Opponent
+		if own {
+			board = g.world.Board
+		}
+		if delta.Kind == gamenet.DeltaBridge {
+			if !board.CanBuildBridge(tile.X, tile.Y) {
+				return
+			}
+			board.BuildBridge(tile.X, tile.Y)
+		} else if !board.RemoveBridge(tile.X, tile.Y) {
+			return
+		}
+		if own {
+			g.world.Score.Moves++
+		} else {
+			r.Moves++
+		}
+	}
+}
+
+// raceMoved sends a bridge the player built or removed in an online race
+func (g *Game) raceMoved(kind string, x, y int) {
+	if g.world.OnlineRace == nil || g.world.GameWon {
+		return
+	}
+	g.sendRaceDelta(kind, raceTile{x, y})
+}
+
+func (g *Game) sendRaceDelta(kind string, body interface{}) {
+	r := g.world.OnlineRace
+	ref, err := r.Match.Send(kind, body)
+	if err != nil {
+		fmt.Println("Failed to send to the race:", err)
+		return
+	}
+	r.sent[ref] = true
+}
+
+// progress is how far each racer is from connecting every island
+func (r *OnlineRace) progress(board *island.Board) (player, opponent float64) {
+	return ai.ConnectionProgress(board, r.initialGroups), ai.ConnectionProgress(r.Opponent, r.initialGroups)
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// speedrunAdvanceDelay is how long a won level stays on screen before the next starts
+const speedrunAdvanceDelay = 1500 * time.Millisecond
+
+// speedrun chains every level of a set. Each level is timed on its own game
+// clock and the run time is their sum, so pausing stops the run too.
+type speedrun struct {
+	key      string // Identifies the set's record
+	set      *levels.LevelSet
+	level    int                    // Index of the level being played
+	segments []time.Duration        // Time spent on each finished level
+	record   storage.SpeedrunRecord // The set's record when the run started
+	wonAt    time.Time              // When the level being played was won; zero until then
+	finished bool
+}
+
+// speedrunMode plays a set's levels back to back without time limits
+type speedrunMode struct{ baseMode }
+
+func (speedrunMode) ID() ModeID   { return ModeSpeedrun }
+func (speedrunMode) Name() string { return "Speedrun" }
+
+func (speedrunMode) Init(w *World) {
+	w.TimeLimit = 0
+}
+
+func init() {
+	RegisterMode(speedrunMode{})
+}
+
+// speedrunKey identifies a level set's speedrun record
+func speedrunKey(set *levels.LevelSet) string {
+	return set.PackID + "/" + set.Name
+}
+
+// showSpeedrunPicker asks which level set to run. Sets with locked levels are
+// listed but cannot be picked.
+func (g *Game) showSpeedrunPicker() {
+	choices := make([]ui.Choice, len(g.levelManager.LevelSets))
+	for i, set := range g.levelManager.LevelSets {
+		choices[i] = ui.Choice{Label: set.Name, Detail: "no runs yet"}
+		if best := g.saveSystem.SpeedrunRecordFor(speedrunKey(set)).Best(); best > 0 {
+			choices[i].Detail = "PB " + ui.FormatRunTime(best)
+		}
+		for _, level := range set.Levels {
+			if !level.Unlocked {
+				choices[i] = ui.Choice{Label: set.Name, Detail: "locked", Disabled: true}
+				break
+			}
+		}
+	}
+	g.speedrunPicker.Title = "Speedrun - pick a difficulty"
+	g.speedrunPicker.Choices = choices
+	g.speedrunPicker.Show()
+}
+
+// startSpeedrun starts a run through a level set, by index into the level manager's sets
+func (g *Game) startSpeedrun(index int) {
+	set := g.levelManager.LevelSets[index]
+	if len(set.Levels) == 0 {
+		return
+	}
+	g.speedrun = &speedrun{
+		key:    speedrunKey(set),
+		set:    set,
+		record: g.saveSystem.SpeedrunRecordFor(speedrunKey(set)),
+	}
+	g.startLevelInMode(set.Levels[0], ModeSpeedrun)
+}
+
+// endSpeedrun drops the run when a game of any other kind starts
+func (g *Game) endSpeedrun(e events.GameStarted) {
+	if e.Mode != int(ModeSpeedrun) {
+		g.speedrun = nil
+	}
+}
+
+// recordSplit adds a won level's time to the run and the set's record
+func (g *Game) recordSplit(e events.GameWon) {
+	sr := g.speedrun
+	if sr == nil || e.Mode != int(ModeSpeedrun) {
+		return
+	}
+	sr.segments = append(sr.segments, e.Time)
+	sr.finished = len(sr.segments) == len(sr.set.Levels)
+	sr.wonAt = g.clock.Now()
+	if _, err := g.saveSystem.UpdateSpeedrun(sr.key, sr.segments, sr.finished); err != nil {
+		fmt.Println("Failed to save the speedrun splits:", err)
+	}
+}
+
+// advanceSpeedrun starts the next level of the run once the last win has been shown
+func (g *Game) advanceSpeedrun() {
+	sr := g.speedrun
+	if sr == nil || sr.finished || sr.wonAt.IsZero() || clock.Since(g.clock, sr.wonAt) < speedrunAdvanceDelay {
+		return
+	}
+	sr.level++
+	sr.wonAt = time.Time{}
+	g.startLevelInMode(sr.set.Levels[sr.level], ModeSpeedrun)
+}
+
+// runTime is the run's time so far, including the level being played
+func (sr *speedrun) runTime(current time.Duration) time.Duration {
+	total := current
+	if len(sr.segments) > sr.level {
+		total = 0 // The current level is won and already counted
+	}
+	for _, segment := range sr.segments {
+		total += segment
+	}
+	return total
+}
+
+// isGold reports whether a finished level beat the fastest time ever spent on it
+func (sr *speedrun) isGold(level int) bool {
+	return level < len(sr.record.Golds) && sr.segments[level] < sr.record.Golds[level]
+}
+
+// splitRows builds the split timer for the HUD
+func (sr *speedrun) splitRows(current time.Duration) []ui.SplitRow {
+	rows := make([]ui.SplitRow, len(sr.set.Levels))
+	var total time.Duration
+	for i, level := range sr.set.Levels {
+		row := ui.SplitRow{Name: level.Name, HasBest: i < len(sr.record.Splits)}
+		switch {
+		case i < len(sr.segments):
+			total += sr.segments[i]
+			row.Time, row.Done, row.Gold = total, true, sr.isGold(i)
+		case i == sr.level:
+			row.Time, row.Current = total+current, true
+		case row.HasBest:
+			row.Time = sr.record.Splits[i]
+		}
+		if row.HasBest && (row.Done || row.Current) {
+			row.Delta = row.Time - sr.record.Splits[i]
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// resultLines sums up a finished run for the victory screen
+func (sr *speedrun) resultLines() []string {
+	total := sr.runTime(0)
+	line := "Run time           " + ui.FormatRunTime(total)
+	if best := sr.record.Best(); best > 0 {
+		line += " (" + ui.FormatRunDelta(total-best) + ")"
+	}
+	return []string{line}
+}
+
+// summary is the finished run as plain text, one level per line
+func (sr *speedrun) summary(date time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Island Merge speedrun: %s\n", sr.set.Name)
+	fmt.Fprintf(&b, "Date: %s\n\n", date.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "%-24s %9s %9s %10s\n", "Level", "Segment", "Split", "vs best")
+	var total time.Duration
+	for i, segment := range sr.segments {
+		total += segment
+		delta := ""
+		if i < len(sr.record.Splits) {
+			delta = ui.FormatRunDelta(total - sr.record.Splits[i])
+		}
+		gold := ""
+		if sr.isGold(i) {
+			gold = " gold"
+		}
+		fmt.Fprintf(&b, "%-24s %9s %9s %10s%s\n", sr.set.Levels[i].Name, ui.FormatRunTime(segment), ui.FormatRunTime(total), delta, gold)
+	}
+	fmt.Fprintf(&b, "\nTotal: %s\n", ui.FormatRunTime(total))
+	if best := sr.record.Best(); best == 0 || total < best {
+		b.WriteString("New personal best!\n")
+	} else {
+		fmt.Fprintf(&b, "Personal best: %s\n", ui.FormatRunTime(best))
+	}
+	return b.String()
+}
+
+// exportSpeedrun saves the finished run's splits as a text file
+func (g *Game) exportSpeedrun() {
+	now := time.Now()
+	name := capture.FileName("txt", now)
+	if err := g.saveSystem.ExportFile(name, []byte(g.speedrun.summary(now))); err != nil {
+		fmt.Println("Speedrun export failed:", err)
+		g.showCaptureMessage("Speedrun export failed")
+		return
+	}
+	g.showCaptureMessage("Saved " + name)
+}
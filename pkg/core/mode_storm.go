@@ -0,0 +1,141 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// stormLevelOptions shapes the generated boards of Storm mode
+var stormLevelOptions = levels.GenerateOptions{
+	Width:   8,
+	Height:  8,
+	Density: 0.1,
+	MinPar:  6,
+	MaxPar:  14,
+}
+
+// Hazards wear bridges down as the game goes on
+const (
+	stormInterval = 3 // Moves between storms
+	stormStrikes  = 2 // Bridges a storm damages
+	tideInterval  = 4 // Moves between tides, which wear every bridge on the board's edge
+)
+
+// Hazards is the state of Storm mode. The weather is rolled from the game's
+// seed and the move number, so a game plays out the same way when it is resumed.
+type Hazards struct {
+	MoveBudget int            // Moves allowed before the storm wins
+	Collapsed  []island.Point // Bridges lost since the game last announced collapses
+}
+
+// stormMode wears bridges down with storms and tides. Damaged bridges can be
+// repaired at the cost of a move; the islands must all be connected before the
+// move budget runs out.
+type stormMode struct{ baseMode }
+
+func (stormMode) ID() ModeID   { return ModeStorm }
+func (stormMode) Name() string { return "Storm" }
+
+func (stormMode) Init(w *World) {
+	par := solver.OptimalMoves(w.Board)
+	w.Hazards = &Hazards{MoveBudget: par*2 + 5}
+}
+
+func (stormMode) OnMove(w *World) {
+	if w.Hazards == nil || w.GameWon {
+		return
+	}
+	moves := w.Score.Moves
+	rng := w.Random.StreamAt("storm", int64(moves))
+
+	if moves%stormInterval == 0 {
+		bridges := w.Board.Bridges()
+		rng.Shuffle(len(bridges), func(i, j int) { bridges[i], bridges[j] = bridges[j], bridges[i] })
+		for _, p := range bridges[:min(stormStrikes, len(bridges))] {
+			w.Hazards.damage(w.Board, p)
+		}
+	}
+	if moves%tideInterval == 0 {
+		for _, p := range w.Board.Bridges() {
+			if p.X == 0 || p.Y == 0 || p.X == w.Board.Width-1 || p.Y == w.Board.Height-1 {
+				w.Hazards.damage(w.Board, p)
+			}
+		}
+	}
+}
+
+func (h *Hazards) damage(board *island.Board, p island.Point) {
+	if board.DamageBridge(p.X, p.Y, 1) {
+		h.Collapsed = append(h.Collapsed, p)
+	}
+}
+
+func (stormMode) CheckLose(w *World) (bool, string) {
+	if w.Hazards != nil && w.Score.Moves >= w.Hazards.MoveBudget && !w.Board.IsSolved() {
+		return true, "storm"
+	}
+	return false, ""
+}
+
+func (stormMode) HUDExtras(w *World) []string {
+	if w.Hazards == nil {
+		return nil
+	}
+	damaged := len(island.ComponentsOf[island.BridgeDamage](w.Board))
+	moves := w.Score.Moves
+	return []string{
+		fmt.Sprintf("Moves left: %d", max(w.Hazards.MoveBudget-moves, 0)),
+		fmt.Sprintf("Storm in %d", stormInterval-moves%stormInterval),
+		fmt.Sprintf("Tide in %d", tideInterval-moves%tideInterval),
+		fmt.Sprintf("Damaged bridges: %d", damaged),
+	}
+}
+
+func init() {
+	RegisterMode(stormMode{})
+}
+
+// startStorm plays Storm mode on a freshly generated board
+func (g *Game) startStorm() {
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), stormLevelOptions)
+	if err != nil {
+		fmt.Println("Failed to generate a Storm board:", err)
+		return
+	}
+	levelData.ID = fmt.Sprintf("storm_%d", source.Seed())
+	levelData.Name = "Storm"
+	g.startSeededLevel(levelData, ModeStorm, source)
+}
+
+// repairBridge restores a damaged bridge; like building, it costs a move
+func (g *Game) repairBridge(gridX, gridY int) bool {
+	if g.world.GameWon || !g.world.Board.RepairBridge(gridX, gridY) {
+		return false
+	}
+	g.world.Score.Moves++
+	at := g.moveTime()
+	g.world.Score.scoreMove(0, false)
+	LookupMode(g.world.Mode).OnMove(g.world)
+	g.hintTile = nil
+	g.events.Publish(events.BridgeRepaired{X: gridX, Y: gridY, At: at})
+	g.announceCollapses(at)
+	return true
+}
+
+// announceCollapses publishes the bridges hazards destroyed during the last move
+func (g *Game) announceCollapses(at time.Duration) {
+	if g.world.Hazards == nil || len(g.world.Hazards.Collapsed) == 0 {
+		return
+	}
+	for _, p := range g.world.Hazards.Collapsed {
+		g.events.Publish(events.BridgeCollapsed{X: p.X, Y: p.Y, At: at})
+	}
+	g.world.Hazards.Collapsed = nil
+	g.moveAnalyzer = nil // Collapses change the board in ways the analyzer does not follow
+}
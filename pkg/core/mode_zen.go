@@ -0,0 +1,188 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/replay"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// zenStartOptions shapes the small board a Zen session starts from
+var zenStartOptions = levels.GenerateOptions{
+	Width:   5,
+	Height:  5,
+	Density: 0.12,
+	MinPar:  2,
+}
+
+// Each ring adds zenRingWidth tiles of sea on every side with a few more
+// islands than the ring before, up to zenMaxRingIslands
+const (
+	zenRingWidth      = 2
+	zenMaxRingIslands = 12
+)
+
+// Zen is the state of Zen mode. Rings are rolled from the game's seed and the
+// ring number, so a resumed session grows the same way.
+type Zen struct {
+	Rings  int       // Rings added since the session began
+	growAt time.Time // When the connected board grows; zero while islands are apart
+}
+
+// zenMode never ends: connecting every island adds a ring of sea and new
+// islands around the board instead of winning
+type zenMode struct{ baseMode }
+
+func (zenMode) ID() ModeID   { return ModeZen }
+func (zenMode) Name() string { return "Zen" }
+
+func (zenMode) Init(w *World) {
+	w.TimeLimit = 0
+}
+
+func (zenMode) CheckWin(w *World) bool {
+	return false
+}
+
+func (zenMode) HUDExtras(w *World) []string {
+	if w.Zen == nil {
+		return nil
+	}
+	connected, total := w.Board.ConnectedIslands()
+	return []string{
+		fmt.Sprintf("Ring: %d", w.Zen.Rings),
+		fmt.Sprintf("Islands: %d/%d", connected, total),
+	}
+}
+
+func init() {
+	RegisterMode(zenMode{})
+}
+
+// showZenPicker offers to resume the saved session or to start over; with
+// nothing saved a new session starts right away
+func (g *Game) showZenPicker() {
+	state, err := g.saveSystem.LoadZenSession()
+	if err != nil || state.Zen == nil {
+		g.startZen(0)
+		return
+	}
+	g.zenPicker.Title = "Zen"
+	g.zenPicker.Choices = []ui.Choice{
+		{Label: "Continue", Detail: fmt.Sprintf("ring %d, %dx%d", state.Zen.Rings, state.Board.Width, state.Board.Height)},
+		{Label: "New session", Detail: "replaces the saved board"},
+	}
+	g.zenPicker.Show()
+}
+
+// startZen resumes the saved session for choice 0 and starts a new one otherwise
+func (g *Game) startZen(choice int) {
+	if choice == 0 {
+		if state, err := g.saveSystem.LoadZenSession(); err == nil && state.Zen != nil {
+			g.currentLevel = nil
+			g.opponent = nil
+			g.restoreGameState(state)
+			g.events.Publish(events.GameStarted{Mode: int(ModeZen)})
+			return
+		}
+	}
+
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), zenStartOptions)
+	if err != nil {
+		fmt.Println("Failed to generate a Zen board:", err)
+		return
+	}
+
+	g.currentLevel = nil
+	g.opponent = nil
+	g.world = &World{
+		State:     StatePlaying,
+		Mode:      ModeZen,
+		Board:     levelData.NewBoard(),
+		StartTime: g.clock.Now(),
+		Relaxed:   g.relaxedIn(ModeZen),
+		Zen:       &Zen{},
+		Random:    source,
+	}
+	LookupMode(ModeZen).Init(g.world)
+	g.events.Publish(events.GameStarted{Mode: int(ModeZen)})
+	g.saveZenSession()
+}
+
+// growZen adds a ring once the connected board's network pulse has played.
+// Breaking the connection before then cancels the ring.
+func (g *Game) growZen() {
+	zen := g.world.Zen
+	if zen == nil || g.world.State != StatePlaying {
+		return
+	}
+	if !g.world.Board.IsSolved() {
+		zen.growAt = time.Time{}
+		return
+	}
+	if zen.growAt.IsZero() {
+		zen.growAt = g.clock.Now().Add(time.Second)
+		if start, ok := g.world.Board.FirstIslandTile(); ok {
+			zen.growAt = zen.growAt.Add(time.Duration(len(g.world.Board.NetworkLayers(start))) * victoryPathStepDuration)
+		}
+		g.addVictoryPathAnimation()
+		return
+	}
+	if clock.Since(g.clock, zen.growAt) < 0 {
+		return
+	}
+
+	zen.growAt = time.Time{}
+	zen.Rings++
+	rng := g.world.Random.StreamAt("zen_ring", int64(zen.Rings))
+	board, _ := levels.GrowBoard(rng, g.world.Board, zenRingWidth, min(zen.Rings+2, zenMaxRingIslands))
+	g.world.Board = board
+
+	// Everything that remembers tiles by position starts over on the grown board
+	g.replay = replay.New(board)
+	g.animation.Clear()
+	g.hintTile = nil
+	g.moveAnalyzer = nil
+	g.lastMove = nil
+	g.clearPlan()
+	g.measure = nil
+	g.render.FrameBoard(board.Width, board.Height)
+	g.saveZenSession()
+}
+
+// saveZenSession keeps the Zen game in its own slot after every change
+func (g *Game) saveZenSession() {
+	if g.world.Mode != ModeZen || g.world.Zen == nil {
+		return
+	}
+	state := g.gameStateData()
+	if state == nil {
+		return
+	}
+	if err := g.saveSystem.SaveZenSession(state); err != nil {
+		fmt.Println("Failed to save the Zen session:", err)
+	}
+	g.updateZenMenuItem()
+}
+
+// updateZenMenuItem shows the saved session's ring beside the menu item
+func (g *Game) updateZenMenuItem() {
+	detail := ""
+	if state, err := g.saveSystem.LoadZenSession(); err == nil && state.Zen != nil {
+		detail = fmt.Sprintf("Ring %d", state.Zen.Rings)
+	}
+	g.mainMenu.SetItemDetail(zenMenuItem, detail)
+}
+
+func (g *Game) zenToSaveData(zen *Zen) *storage.ZenData {
+	if zen == nil {
+		return nil
+	}
+	return &storage.ZenData{Rings: zen.Rings}
+}
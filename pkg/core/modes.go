@@ -0,0 +1,94 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// GameMode defines the rules and HUD extras of a playable mode. New modes are
+// added by registering an implementation instead of extending switch statements.
+type GameMode interface {
+	ID() ModeID
+	Name() string
+	Init(w *World)
+	OnMove(w *World)
+	CheckWin(w *World) bool
+	CheckLose(w *World) (lost bool, reason string)
+	HUDExtras(w *World) []string
+}
+
+var modeRegistry = make(map[ModeID]GameMode)
+
+// RegisterMode makes a mode available to the game, replacing any mode with the same ID
+func RegisterMode(mode GameMode) {
+	modeRegistry[mode.ID()] = mode
+}
+
+// LookupMode returns the registered mode, falling back to Classic for unknown IDs
+func LookupMode(id ModeID) GameMode {
+	if mode, ok := modeRegistry[id]; ok {
+		return mode
+	}
+	return modeRegistry[ModeClassic]
+}
+
+func init() {
+	RegisterMode(classicMode{})
+	RegisterMode(timeAttackMode{})
+	RegisterMode(puzzleMode{})
+}
+
+// baseMode provides the default rules: connect every island, no way to lose
+type baseMode struct{}
+
+func (baseMode) Init(w *World) {}
+
+func (baseMode) OnMove(w *World) {}
+
+func (baseMode) CheckWin(w *World) bool {
+	return w.Board != nil && w.Board.IsSolved()
+}
+
+func (baseMode) CheckLose(w *World) (bool, string) {
+	return false, ""
+}
+
+func (baseMode) HUDExtras(w *World) []string {
+	return nil
+}
+
+type classicMode struct{ baseMode }
+
+func (classicMode) ID() ModeID   { return ModeClassic }
+func (classicMode) Name() string { return "Classic Mode" }
+
+type timeAttackMode struct{ baseMode }
+
+func (timeAttackMode) ID() ModeID   { return ModeTimeAttack }
+func (timeAttackMode) Name() string { return "Time Attack" }
+
+func (timeAttackMode) Init(w *World) {
+	if w.TimeLimit == 0 {
+		w.TimeLimit = time.Minute * 2
+	}
+}
+
+func (timeAttackMode) CheckLose(w *World) (bool, string) {
+	if w.TimeLimit > 0 && w.Score.Time >= w.TimeLimit && !w.Overtime {
+		return true, "time_up"
+	}
+	return false, ""
+}
+
+// HUDExtras leaves the clock to the countdown and shows what overtime costs so far
+func (timeAttackMode) HUDExtras(w *World) []string {
+	if w.TimeLimit <= 0 || w.Score.Time <= w.TimeLimit {
+		return nil
+	}
+	return []string{fmt.Sprintf("Overtime: -%d points", overtimePenalty(w.TimeLimit, w.Score.Time))}
+}
+
+type puzzleMode struct{ baseMode }
+
+func (puzzleMode) ID() ModeID   { return ModePuzzle }
+func (puzzleMode) Name() string { return "Puzzle Mode" }
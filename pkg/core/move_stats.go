@@ -0,0 +1,45 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// recordMoveTime remembers when a move was made, as game time since the start
+func (s *Score) recordMoveTime(at time.Duration) {
+	s.MoveTimes = append(s.MoveTimes, at)
+}
+
+// ThinkingTimes returns how long the player took over each move, measured from
+// the previous move or the start of the game
+func (s Score) ThinkingTimes() []time.Duration {
+	times := make([]time.Duration, len(s.MoveTimes))
+	var previous time.Duration
+	for i, at := range s.MoveTimes {
+		times[i] = max(at-previous, 0)
+		previous = at
+	}
+	return times
+}
+
+// SlowestMove returns the index of the move that took longest to decide, or -1
+// if no moves were made
+func (s Score) SlowestMove() (int, time.Duration) {
+	slowest, longest := -1, time.Duration(0)
+	for i, thinking := range s.ThinkingTimes() {
+		if slowest < 0 || thinking > longest {
+			slowest, longest = i, thinking
+		}
+	}
+	return slowest, longest
+}
+
+// moveStatsLine summarises the thinking times for the victory screen
+func (s Score) moveStatsLine() string {
+	slowest, longest := s.SlowestMove()
+	if slowest < 0 {
+		return ""
+	}
+	average := s.MoveTimes[len(s.MoveTimes)-1] / time.Duration(len(s.MoveTimes))
+	return fmt.Sprintf("Avg %.1fs a move, slowest #%d %.1fs", average.Seconds(), slowest+1, longest.Seconds())
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// RuleSet adjusts how a board is played. The mode decides how a game is won or
+// lost; the rule set starts from the defaults and each chosen mutator changes it.
+// The zero value is the default rules.
+type RuleSet struct {
+	Mutators       []MutatorID
+	MirrorControls bool    // Clicks act on the tile mirrored left to right
+	HideHover      bool    // No highlight under the pointer
+	TimeScale      float64 // How fast the game clock runs; zero means normal speed
+	BridgeCost     int     // Moves a new bridge costs; zero means one
+}
+
+// MutatorID names a mutator in settings and saved games
+type MutatorID string
+
+const (
+	MutatorMirrored      MutatorID = "mirrored"
+	MutatorNoHover       MutatorID = "no_hover"
+	MutatorDoubleTime    MutatorID = "double_time"
+	MutatorCostlyBridges MutatorID = "costly_bridges"
+)
+
+// Mutator is an optional rule change that makes a level harder in exchange for
+// a higher score
+type Mutator struct {
+	ID          MutatorID
+	Name        string
+	Description string
+	Multiplier  float64 // Applied to the final score; multipliers of several mutators compound
+	apply       func(r *RuleSet)
+}
+
+// Mutators are offered in this order before a level starts
+var Mutators = []Mutator{
+	{MutatorMirrored, "Mirrored", "Clicks land on the opposite side", 1.5, func(r *RuleSet) { r.MirrorControls = true }},
+	{MutatorNoHover, "No Highlight", "No hover highlight on the board", 1.2, func(r *RuleSet) { r.HideHover = true }},
+	{MutatorDoubleTime, "Double Time", "The timer runs twice as fast", 1.5, func(r *RuleSet) { r.TimeScale = 2 }},
+	{MutatorCostlyBridges, "Costly Bridges", "Each bridge costs 2 moves", 1.3, func(r *RuleSet) { r.BridgeCost = 2 }},
+}
+
+// LookupMutator returns the mutator with the given ID
+func LookupMutator(id MutatorID) (Mutator, bool) {
+	for _, mutator := range Mutators {
+		if mutator.ID == id {
+			return mutator, true
+		}
+	}
+	return Mutator{}, false
+}
+
+// NewRuleSet applies mutators to the default rules; unknown IDs, e.g. from a
+// newer version's save, are skipped
+func NewRuleSet(ids []MutatorID) RuleSet {
+	var rules RuleSet
+	for _, id := range ids {
+		if mutator, ok := LookupMutator(id); ok {
+			mutator.apply(&rules)
+			rules.Mutators = append(rules.Mutators, id)
+		}
+	}
+	return rules
+}
+
+// ScoreMultiplier is the product of the active mutators' multipliers
+func (r RuleSet) ScoreMultiplier() float64 {
+	multiplier := 1.0
+	for _, id := range r.Mutators {
+		if mutator, ok := LookupMutator(id); ok {
+			multiplier *= mutator.Multiplier
+		}
+	}
+	return multiplier
+}
+
+// moveCost is the number of moves a new bridge costs
+func (r RuleSet) moveCost() int {
+	return max(r.BridgeCost, 1)
+}
+
+// gameTime converts time on the game clock into time on the game's timer
+func (r RuleSet) gameTime(elapsed time.Duration) time.Duration {
+	if r.TimeScale <= 0 {
+		return elapsed
+	}
+	return time.Duration(float64(elapsed) * r.TimeScale)
+}
+
+// clockTime is the inverse of gameTime, for resuming a saved timer
+func (r RuleSet) clockTime(gameTime time.Duration) time.Duration {
+	if r.TimeScale <= 0 {
+		return gameTime
+	}
+	return time.Duration(float64(gameTime) / r.TimeScale)
+}
+
+// mirrorX flips a board column left to right under mirrored controls
+func (r RuleSet) mirrorX(board *island.Board, gridX int) int {
+	if !r.MirrorControls || board == nil || gridX < 0 || gridX >= board.Width {
+		return gridX
+	}
+	return board.Width - 1 - gridX
+}
+
+// label lists the active mutators for the HUD, e.g. "Mirrored, Double Time x2.25"
+func (r RuleSet) label() string {
+	if len(r.Mutators) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(r.Mutators))
+	for _, id := range r.Mutators {
+		if mutator, ok := LookupMutator(id); ok {
+			names = append(names, mutator.Name)
+		}
+	}
+	return fmt.Sprintf("%s x%.2f", strings.Join(names, ", "), r.ScoreMultiplier())
+}
+
+// mutatorOptions lists the mutators for the picker
+func mutatorOptions() []ui.MutatorOption {
+	options := make([]ui.MutatorOption, len(Mutators))
+	for i, mutator := range Mutators {
+		options[i] = ui.MutatorOption{
+			ID:          string(mutator.ID),
+			Name:        mutator.Name,
+			Description: mutator.Description,
+			Multiplier:  mutator.Multiplier,
+		}
+	}
+	return options
+}
+
+// chooseMutators asks which mutators to play a level from the level select
+// under, starting with the ones picked last time
+func (g *Game) chooseMutators(levelData *levels.LevelData) {
+	g.pendingLevel = levelData
+	if settings, err := g.saveSystem.LoadSettings(); err == nil {
+		g.mutatorPicker.Select(settings.Mutators)
+	}
+	g.mutatorPicker.Show(levelData.Name)
+}
+
+// startWithMutators starts the level waiting in the picker and remembers the
+// mutators for next time
+func (g *Game) startWithMutators(ids []string) {
+	if settings, err := g.saveSystem.LoadSettings(); err == nil {
+		settings.Mutators = ids
+		if err := g.saveSystem.SaveSettings(settings); err != nil {
+			fmt.Println("Failed to save the mutators:", err)
+		}
+	}
+	if g.pendingLevel == nil {
+		return
+	}
+	g.mutators = mutatorIDs(ids)
+	g.startLevel(g.pendingLevel)
+	g.pendingLevel = nil
+}
+
+func mutatorIDs(names []string) []MutatorID {
+	ids := make([]MutatorID, len(names))
+	for i, name := range names {
+		ids[i] = MutatorID(name)
+	}
+	return ids
+}
+
+func mutatorNames(ids []MutatorID) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = string(id)
+	}
+	return names
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// objectiveLines checks the current level's objectives for the HUD checklist;
+// mode games without a level have none
+func (g *Game) objectiveLines(connected, islands int) []ui.ObjectiveLine {
+	if g.currentLevel == nil {
+		return nil
+	}
+	state := levels.ObjectiveState{
+		Connected: connected,
+		Islands:   islands,
+		Moves:     g.world.Score.Moves,
+		Time:      g.world.Score.Time,
+		Won:       g.world.GameWon,
+	}
+	progress := levels.EvaluateObjectives(g.currentLevel.Objectives, state)
+	lines := make([]ui.ObjectiveLine, len(progress))
+	for i, p := range progress {
+		lines[i] = ui.ObjectiveLine{
+			Text:   p.Text,
+			Met:    p.Status == levels.ObjectiveMet,
+			Failed: p.Status == levels.ObjectiveFailed,
+		}
+	}
+	return lines
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+)
+
+// scoreSubmission is a result sent to the online leaderboards, with the
+// replay the backend can verify it by
+type scoreSubmission struct {
+	Level        string          `json:"level"`
+	Mode         int             `json:"mode"`
+	Moves        int             `json:"moves"`
+	TimeMS       int64           `json:"time_ms"`
+	Points       int             `json:"points"`
+	Stars        int             `json:"stars"`
+	Relaxed      bool            `json:"relaxed,omitempty"`
+	Player       string          `json:"player"`
+	Title        string          `json:"title,omitempty"`
+	StartBridges []submittedTile `json:"start_bridges,omitempty"` // Already built when the replay begins, in a resumed game
+	Replay       []submittedMove `json:"replay"`
+}
+
+type submittedTile struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type submittedMove struct {
+	submittedTile
+	Removed bool  `json:"removed,omitempty"`
+	Forced  bool  `json:"forced,omitempty"` // Removed by the game, not the player
+	AtMS    int64 `json:"at_ms"`
+}
+
+// configureOnline points the outbox at the backend; messages queued earlier
+// are sent once one is set
+func (g *Game) configureOnline(url string) {
+	if url == g.onlineURL {
+		return
+	}
+	g.onlineURL = url
+	if url == "" {
+		g.outbox.SetTransport(nil)
+		return
+	}
+	transport, err := gamenet.NewTransport(url)
+	if err != nil {
+		fmt.Println("Online backend not set:", err)
+		g.outbox.SetTransport(nil)
+		return
+	}
+	g.outbox.SetTransport(transport)
+}
+
+// submitScore queues a verified level result for the online leaderboards
+func (g *Game) submitScore(moves int, completionTime time.Duration, points, stars int) {
+	if g.onlineURL == "" || g.replay == nil {
+		return
+	}
+	submission := scoreSubmission{
+		Level:   g.currentLevel.ID,
+		Mode:    int(g.world.Mode),
+		Moves:   moves,
+		TimeMS:  completionTime.Milliseconds(),
+		Points:  points,
+		Stars:   stars,
+		Relaxed: g.world.Relaxed,
+		Player:  g.saveSystem.CurrentProfile().Name,
+		Title:   g.wornTitle(),
+		Replay:  make([]submittedMove, len(g.replay.Moves)),
+	}
+	for _, bridge := range g.replay.Start.Bridges() {
+		submission.StartBridges = append(submission.StartBridges, submittedTile{bridge.X, bridge.Y})
+	}
+	for i, move := range g.replay.Moves {
+		submission.Replay[i] = submittedMove{submittedTile{move.X, move.Y}, move.Removed, move.Forced, move.At.Milliseconds()}
+	}
+	if err := g.outbox.Enqueue(gamenet.KindScore, submission); err != nil {
+		fmt.Println("Failed to queue the score:", err)
+	}
+}
+
+// uploadLevelPack queues an exported collection to be shared online
+func (g *Game) uploadLevelPack(collectionID string) {
+	if g.onlineURL == "" {
+		return
+	}
+	data, err := g.saveSystem.ExportCollection(collectionID)
+	if err == nil {
+		err = g.outbox.Enqueue(gamenet.KindLevel, json.RawMessage(data))
+	}
+	if err != nil {
+		fmt.Println("Failed to queue the level pack:", err)
+	}
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"fmt"
+
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// Rows of the picker the Online menu item opens
+const (
+	onlineRaceChoice = iota
+	correspondenceChoice
+)
+
+// showOnlinePicker offers the ways of playing against another player. Online
+// Race needs a WebSocket backend; Correspondence works with none, passing codes.
+func (g *Game) showOnlinePicker() {
+	race := ui.Choice{Label: "Online Race", Detail: "same board, first to connect"}
+	if !gamenet.SupportsMatches(g.onlineURL) {
+		race.Detail = "needs a ws:// online_url"
+		race.Disabled = true
+	}
+	correspondence := ui.Choice{Label: "Correspondence", Detail: "a turn at a time"}
+	if waiting := g.correspondenceWaiting(); waiting > 0 {
+		correspondence.Detail = fmt.Sprintf("%d on your turn", waiting)
+	}
+
+	g.onlinePicker.Title = "Online"
+	g.onlinePicker.Choices = []ui.Choice{race, correspondence}
+	g.onlinePicker.Show()
+}
+
+// chooseOnline opens the mode picked from the Online picker
+func (g *Game) chooseOnline(choice int) {
+	switch choice {
+	case onlineRaceChoice:
+		g.showOnlineRace()
+	case correspondenceChoice:
+		g.showCorrespondencePicker()
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// The new-record banner blinks for a few seconds, then stays on the results screen
+const (
+	recordBannerBlink = 400 * time.Millisecond
+	recordBannerFlash = 3 * time.Second
+)
+
+// loadPersonalBest puts a level's personal best into the score so the HUD can
+// compare the game in progress against it
+func (g *Game) loadPersonalBest(levelID string) {
+	g.recordBanner = ""
+	if levelID == "" {
+		return
+	}
+	best, ok := g.saveSystem.PersonalBestFor(levelID)
+	if !ok {
+		return
+	}
+	g.world.Score.BestMoves = best.Moves
+	g.world.Score.BestTime = best.Time
+	g.world.Score.BestPoints = best.Points
+}
+
+// recordPersonalBest merges a win into the level's personal best and announces
+// any records it broke. The first win on a level sets the bests quietly.
+func (g *Game) recordPersonalBest(e events.GameWon) {
+	if e.LevelID == "" || g.world.Assisted {
+		return
+	}
+	result := storage.PersonalBest{Moves: e.Moves, Time: e.Time, Points: e.Points}
+	if g.world.Relaxed {
+		result.Time = 0
+	}
+
+	previous, err := g.saveSystem.UpdatePersonalBest(e.LevelID, result)
+	if err != nil {
+		fmt.Println("Failed to record personal best:", err)
+		return
+	}
+	if previous.Moves == 0 {
+		return
+	}
+
+	var records []string
+	if result.Moves < previous.Moves {
+		records = append(records, "fewest moves")
+	}
+	if result.Time > 0 && previous.Time > 0 && result.Time < previous.Time {
+		records = append(records, "fastest time")
+	}
+	if result.Points > previous.Points {
+		records = append(records, "high score")
+	}
+	if len(records) > 0 {
+		g.recordBanner = "New record: " + strings.Join(records, ", ") + "!"
+		g.recordBannerAt = g.clock.Now()
+	}
+}
+
+// visibleRecordBanner returns the new-record banner, or "" while it blinks off
+func (g *Game) visibleRecordBanner() string {
+	elapsed := clock.Since(g.clock, g.recordBannerAt)
+	if elapsed < recordBannerFlash && (elapsed/recordBannerBlink)%2 == 1 {
+		return ""
+	}
+	return g.recordBanner
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// planBarShown reports whether the planning buttons are on screen
+func (g *Game) planBarShown() bool {
+	return g.world.State == StatePlaying && g.world.Board != nil && !g.world.GameWon && g.world.Correspondence == nil
+}
+
+// togglePlanning switches board clicks between building bridges and planning them.
+// Planned bridges stay when planning is switched off, until they are committed.
+func (g *Game) togglePlanning() {
+	if !g.planBarShown() {
+		return
+	}
+	g.planning = !g.planning
+}
+
+// planBridge plans a bridge on a sea tile, or drops the plan for a tile already
+// planned. Plans are not checked against the placement rules until they are
+// built, so a route can be sketched out from the far end.
+func (g *Game) planBridge(gridX, gridY int) {
+	tile := g.world.Board.GetTile(gridX, gridY)
+	if tile == nil || tile.Type != island.TileSea {
+		return
+	}
+	if !g.unplanBridge(gridX, gridY) {
+		g.plannedBridges = append(g.plannedBridges, island.Point{X: gridX, Y: gridY})
+	}
+}
+
+// unplanBridge drops the plan for a tile and reports whether there was one
+func (g *Game) unplanBridge(gridX, gridY int) bool {
+	planned := len(g.plannedBridges)
+	g.plannedBridges = slices.DeleteFunc(g.plannedBridges, func(p island.Point) bool {
+		return p.X == gridX && p.Y == gridY
+	})
+	return len(g.plannedBridges) < planned
+}
+
+// commitPlan builds the planned bridges as ordinary moves. A bridge the rules
+// don't allow yet is retried once others have been built, since a plan may list
+// the far end of a route first; those that never become valid stay planned.
+// Building stops once every island is connected.
+func (g *Game) commitPlan() {
+	board := g.world.Board
+	for built := true; built && len(g.plannedBridges) > 0; {
+		built = false
+		g.plannedBridges = slices.DeleteFunc(g.plannedBridges, func(p island.Point) bool {
+			if board.IsAllConnected() {
+				return false
+			}
+			if tile := board.GetTile(p.X, p.Y); tile == nil || tile.Type != island.TileSea {
+				return true // Built or changed since it was planned
+			}
+			if !board.CanBuildBridge(p.X, p.Y) {
+				return false
+			}
+			g.buildBridge(p.X, p.Y)
+			built = true
+			return true
+		})
+	}
+
+	if left := len(g.plannedBridges); left > 0 && !board.IsAllConnected() {
+		g.showCaptureMessage(fmt.Sprintf("%d planned bridges could not be built", left))
+		return
+	}
+	g.clearPlan()
+}
+
+// clearPlan forgets the plan, for a new or changed board
+func (g *Game) clearPlan() {
+	g.plannedBridges = nil
+	g.planning = false
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+)
+
+// Activity is what the player is doing, for showing outside the game
+type Activity struct {
+	Playing bool   // False until the first game starts
+	Mode    string // e.g. "Puzzle Mode"
+	Level   string // Level name; empty for mode games without a level
+	Moves   int
+	Result  string    // "won" or "lost" once the game is over
+	Since   time.Time // When the current game started
+}
+
+// String describes the activity in one line, e.g. "Playing Expert 2 — 14 moves"
+func (a Activity) String() string {
+	if !a.Playing {
+		return "In the menus"
+	}
+	subject := a.Level
+	if subject == "" {
+		subject = a.Mode
+	}
+	switch a.Result {
+	case "won":
+		return fmt.Sprintf("Won %s in %d moves", subject, a.Moves)
+	case "lost":
+		return fmt.Sprintf("Lost %s after %d moves", subject, a.Moves)
+	}
+	return fmt.Sprintf("Playing %s — %d moves", subject, a.Moves)
+}
+
+// PresenceProvider shows the player's activity elsewhere, such as Discord rich
+// presence or a Steam status. UpdateActivity is called from the game loop on
+// every change, so providers must not block and should coalesce updates if
+// their service is rate limited.
+type PresenceProvider interface {
+	UpdateActivity(activity Activity)
+}
+
+// NoPresence is the default provider; it reports nothing
+type NoPresence struct{}
+
+func (NoPresence) UpdateActivity(Activity) {}
+
+// presenceReporter turns gameplay events into activity updates
+type presenceReporter struct {
+	provider  PresenceProvider
+	activity  Activity
+	levelName func(levelID string) string
+}
+
+func newPresenceReporter(levelName func(levelID string) string) *presenceReporter {
+	return &presenceReporter{provider: NoPresence{}, levelName: levelName}
+}
+
+// Subscribe follows games on the bus
+func (p *presenceReporter) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, func(e events.GameStarted) {
+		p.activity = Activity{
+			Playing: true,
+			Mode:    LookupMode(ModeID(e.Mode)).Name(),
+			Since:   time.Now(),
+		}
+		if e.LevelID != "" {
+			p.activity.Level = p.levelName(e.LevelID)
+		}
+		p.report()
+	})
+	events.Subscribe(bus, func(e events.BridgeBuilt) {
+		p.activity.Moves = e.Moves
+		p.report()
+	})
+	events.Subscribe(bus, func(e events.GameWon) {
+		p.activity.Moves = e.Moves
+		p.activity.Result = "won"
+		p.report()
+	})
+	events.Subscribe(bus, func(e events.GameLost) {
+		p.activity.Moves = e.Moves
+		p.activity.Result = "lost"
+		p.report()
+	})
+}
+
+// SetProvider switches providers and tells the new one what the player is doing
+func (p *presenceReporter) SetProvider(provider PresenceProvider) {
+	if provider == nil {
+		provider = NoPresence{}
+	}
+	p.provider = provider
+	p.report()
+}
+
+func (p *presenceReporter) report() {
+	p.provider.UpdateActivity(p.activity)
+}
+
+// SetPresenceProvider reports the player's activity to provider from now on;
+// nil turns reporting off
+func (g *Game) SetPresenceProvider(provider PresenceProvider) {
+	g.presence.SetProvider(provider)
+}
+
+// presenceLevelName names the level being played, falling back to its ID
+func (g *Game) presenceLevelName(levelID string) string {
+	if g.currentLevel != nil && g.currentLevel.ID == levelID {
+		return g.currentLevel.Name
+	}
+	if level := g.levelManager.GetLevelByID(levelID); level != nil {
+		return level.Name
+	}
+	return levelID
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/cosmetics"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/quests"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// loadQuests replaces the quest log with the active profile's
+func (g *Game) loadQuests() {
+	g.quests.Reset()
+	// Quests are stored as the JSON string from SaveToJSON, like achievements
+	var data string
+	if err := g.saveSystem.LoadQuests(&data); err == nil {
+		if err := g.quests.LoadFromJSON(data); err != nil {
+			fmt.Println("Failed to load quests:", err)
+		}
+	}
+}
+
+func (g *Game) saveQuests() {
+	data, err := g.quests.SaveToJSON()
+	if err == nil {
+		err = g.saveSystem.SaveQuests(data)
+	}
+	if err != nil {
+		fmt.Println("Failed to save quests:", err)
+	}
+}
+
+// refreshQuests rotates quests whose day or week has ended and shows how many
+// are done beside the menu item
+func (g *Game) refreshQuests() {
+	if g.quests.Refresh(time.Now()) {
+		g.saveQuests()
+	}
+	done := 0
+	for _, quest := range g.quests.Quests {
+		if quest.Done() {
+			done++
+		}
+	}
+	g.mainMenu.SetItemDetail(questsMenuItem, fmt.Sprintf("%d/%d done", done, len(g.quests.Quests)))
+}
+
+// showQuests opens the quests panel
+func (g *Game) showQuests() {
+	g.refreshQuests()
+	_, dayEnds := quests.DayKey(time.Now())
+	_, weekEnds := levels.ISOWeek(time.Now())
+	g.questsUI.Show(g.quests, g.bridgePaint, formatTimeLeft(time.Until(dayEnds)), formatTimeLeft(time.Until(weekEnds)))
+}
+
+// subscribeQuests counts gameplay events towards the quests on offer
+func (g *Game) subscribeQuests() {
+	events.Subscribe(g.events, func(events.GameStarted) {
+		g.refreshQuests()
+	})
+	events.Subscribe(g.events, func(events.BridgeBuilt) {
+		g.quests.Record(quests.GoalBridges, 1)
+	})
+	events.Subscribe(g.events, func(e events.IslandsMerged) {
+		g.quests.Record(quests.GoalMerges, e.Merged)
+	})
+	events.Subscribe(g.events, func(e events.GameWon) {
+		g.quests.Record(quests.GoalWins, 1)
+		if e.IsPerfect {
+			g.quests.Record(quests.GoalPerfectWins, 1)
+		}
+		if e.Mutators > 0 {
+			g.quests.Record(quests.GoalMutatedWins, 1)
+		}
+		g.saveQuests()
+	})
+	events.Subscribe(g.events, func(e events.LevelCompleted) {
+		// Only installed levels have a difficulty
+		if level := g.levelManager.GetLevelByID(e.LevelID); level != nil && e.Stars == 3 {
+			g.quests.RecordThreeStars(level.Difficulty)
+			g.saveQuests()
+		}
+	})
+	g.quests.OnQuestCompleted(func(*quests.Quest) {
+		g.sound.Play(systems.SoundAchievement)
+	})
+}
+
+// pickBridgePaint saves the paint chosen in the quests panel and applies it
+func (g *Game) pickBridgePaint(id string) {
+	settings, _ := g.saveSystem.LoadSettings()
+	settings.BridgePaint = id
+	if err := g.saveSystem.SaveSettings(settings); err != nil {
+		fmt.Println("Failed to save the bridge paint:", err)
+	}
+	g.applySettings(settings)
+}
+
+// applyTheme draws the board in the theme's colours, with bridges in the
+// chosen paint once quest tokens have unlocked it
+func (g *Game) applyTheme() {
+	theme := g.theme
+	if paint, ok := cosmetics.Lookup(g.bridgePaint); ok && paint.Unlocked(g.quests.Tokens) {
+		theme.Bridge = paint.Color
+	}
+	g.render.SetTheme(theme)
+	g.showcase.render.SetTheme(theme)
+	g.thumbnails.SetTheme(theme)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/events"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/rating"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// maxRatingEntries is how many races the rating history keeps for the chart
+const maxRatingEntries = 100
+
+// ratingSubmission is a rated race sent to the online backend, which can keep
+// a rating of its own from the results
+type ratingSubmission struct {
+	Player         string  `json:"player"`
+	Opponent       string  `json:"opponent"`
+	OpponentRating int     `json:"opponent_rating"`
+	Won            bool    `json:"won"`
+	Margin         float64 `json:"margin"`
+	Rating         int     `json:"rating"` // The local rating after the race
+	Change         int     `json:"change"`
+}
+
+// rateRaceWin rates a race the player won, by how far behind the AI was
+func (g *Game) rateRaceWin(events.GameWon) {
+	if g.opponent == nil {
+		return
+	}
+	_, opponent := g.raceProgress()
+	g.rateRace(true, 1-opponent)
+}
+
+// rateRaceLoss rates a race the player lost, by how far ahead the AI was
+func (g *Game) rateRaceLoss(events.GameLost) {
+	if g.opponent == nil {
+		return
+	}
+	player, opponent := g.raceProgress()
+	g.rateRace(false, opponent-player)
+}
+
+// rateRace moves the player's rating after a race against the AI. Assisted
+// games are not rated, like they earn no stars.
+func (g *Game) rateRace(won bool, margin float64) {
+	g.ratingChange = nil
+	opponentRating := ai.SkillRating(g.opponent.Skill)
+	if opponentRating == 0 || g.world.Assisted {
+		return
+	}
+
+	history := g.loadRating()
+	before := history.Rating
+	history.Rating = rating.Apply(before, opponentRating, won, margin)
+	entry := storage.RatingEntry{
+		At:             time.Now(),
+		Rating:         history.Rating,
+		Change:         history.Rating - before,
+		Opponent:       ai.SkillName(g.opponent.Skill),
+		OpponentRating: opponentRating,
+		Won:            won,
+	}
+	history.Entries = append(history.Entries, entry)
+	if len(history.Entries) > maxRatingEntries {
+		history.Entries = history.Entries[len(history.Entries)-maxRatingEntries:]
+	}
+	if err := g.saveSystem.SaveRating(history); err != nil {
+		fmt.Println("Failed to save the rating:", err)
+	}
+	g.achievementUI.SetRatings(history.Entries)
+	g.ratingChange = &entry
+
+	if g.onlineURL != "" {
+		submission := ratingSubmission{
+			Player:         g.saveSystem.CurrentProfile().Name,
+			Opponent:       entry.Opponent,
+			OpponentRating: opponentRating,
+			Won:            won,
+			Margin:         margin,
+			Rating:         entry.Rating,
+			Change:         entry.Change,
+		}
+		if err := g.outbox.Enqueue(gamenet.KindRating, submission); err != nil {
+			fmt.Println("Failed to queue the rating:", err)
+		}
+	}
+}
+
+// loadRating returns the active profile's rating history, starting a profile
+// that never raced at rating.Initial
+func (g *Game) loadRating() *storage.RatingHistory {
+	history, err := g.saveSystem.LoadRating()
+	if err != nil {
+		history = &storage.RatingHistory{}
+	}
+	if history.Rating == 0 {
+		history.Rating = rating.Initial
+	}
+	return history
+}
+
+// showRatings hands the active profile's rating history to the stats view
+func (g *Game) showRatings() {
+	g.achievementUI.SetRatings(g.loadRating().Entries)
+}
+
+// ratingLine is the rating change shown with a race's result
+func (g *Game) ratingLine() string {
+	if g.ratingChange == nil {
+		return ""
+	}
+	return fmt.Sprintf("Rating %d (%+d)", g.ratingChange.Rating, g.ratingChange.Change)
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// autoAdvanceDelay is how long the victory screen stays before auto-advance
+// plays the recommended level
+const autoAdvanceDelay = 8 * time.Second
+
+// recordLevelResult remembers a level win for recommending the next level,
+// then recommends again. Assisted wins earn no stars, so they are not counted
+// as struggles.
+func (g *Game) recordLevelResult(e events.LevelCompleted) {
+	if !g.world.Assisted && g.levelManager.GetLevelByID(e.LevelID) != nil {
+		result := storage.LevelResult{Level: e.LevelID, Stars: e.Stars, Time: e.Time, Date: time.Now()}
+		if g.world.Relaxed {
+			result.Time = 0
+		}
+		if err := g.saveSystem.RecordLevelResult(result); err != nil {
+			fmt.Println("Failed to record the level result:", err)
+		}
+	}
+	g.refreshRecommendation()
+	g.nextLevelAt = g.clock.Now().Add(autoAdvanceDelay)
+}
+
+// refreshRecommendation picks the level to suggest from the active profile's
+// recent wins and shows it on level select
+func (g *Game) refreshRecommendation() {
+	recent := make([]levels.Result, 0)
+	for _, result := range g.saveSystem.RecentResults() {
+		recent = append(recent, levels.Result{LevelID: result.Level, Stars: result.Stars, Time: result.Time})
+	}
+	g.recommendation = nil
+	if recommendation, ok := g.levelManager.Recommend(recent); ok {
+		g.recommendation = &recommendation
+	}
+	g.levelSelectUI.SetRecommendation(g.recommendation)
+}
+
+// nextLevelShown reports whether the victory screen offers the recommended
+// level. Runs of levels, challenges and correspondence games carry on their own way.
+func (g *Game) nextLevelShown() bool {
+	return g.world.State == StatePlaying && g.world.GameWon && g.currentLevel != nil && g.recommendation != nil &&
+		g.world.Mode != ModeSpeedrun && g.world.Correspondence == nil && g.challengeStage() < 0
+}
+
+// updateNextLevel offers the recommended level after a win and, with
+// auto-advance on, plays it once the countdown runs out. Panels over the
+// victory screen start the countdown again.
+func (g *Game) updateNextLevel() {
+	if !g.nextLevelShown() {
+		g.nextLevelButton.Hide()
+		return
+	}
+	g.nextLevelButton.Show(g.recommendation.Reason)
+	if !g.autoAdvance {
+		g.nextLevelButton.SetCountdown(0)
+		return
+	}
+	if g.votePrompt.IsOpen() || g.saveLoadUI.IsOpen() || g.achievementUI.IsOpen() || g.helpOverlay.IsVisible() {
+		g.nextLevelAt = g.clock.Now().Add(autoAdvanceDelay)
+	}
+	left := -clock.Since(g.clock, g.nextLevelAt)
+	if left <= 0 {
+		g.playRecommended()
+		return
+	}
+	g.nextLevelButton.SetCountdown(int((left + time.Second - 1) / time.Second))
+}
+
+// playRecommended starts the recommended level
+func (g *Game) playRecommended() {
+	if g.recommendation == nil {
+		return
+	}
+	g.nextLevelButton.Hide()
+	g.startLevel(g.recommendation.Level)
+}
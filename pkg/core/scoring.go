@@ -0,0 +1,83 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scoring rewards connecting islands, playing optimal moves in a row and finishing quickly
+const (
+	pointsPerIsland      = 100 // Each island group merged into another
+	pointsPerOptimalMove = 10  // Each move that shortens the solution, times the combo multiplier
+	comboStep            = 2   // Consecutive optimal moves needed to raise the multiplier by one
+	maxComboMultiplier   = 5
+
+	timeBonusWindow    = 3 * time.Minute // Untimed games earn a bonus for finishing within this
+	timeBonusPerSecond = 5
+	timeLimitBonus     = 10 // Per second left on the clock in timed games
+	overtimePerSecond  = 10 // Lost per second past the limit in Time Attack overtime
+)
+
+// ComboMultiplier returns the multiplier the current run of optimal moves earns
+func (s Score) ComboMultiplier() int {
+	return min(1+s.Combo/comboStep, maxComboMultiplier)
+}
+
+// scoreMove awards the points for one move. merged is how many island groups the
+// move joined together; a move that is not optimal breaks the combo.
+func (s *Score) scoreMove(merged int, optimal bool) {
+	if optimal {
+		s.Combo++
+		s.BestCombo = max(s.BestCombo, s.Combo)
+	} else {
+		s.Combo = 0
+	}
+
+	multiplier := s.ComboMultiplier()
+	if merged > 0 {
+		s.IslandPoints += merged * pointsPerIsland
+		s.ComboPoints += merged * pointsPerIsland * (multiplier - 1)
+	}
+	if optimal {
+		s.ComboPoints += pointsPerOptimalMove * multiplier
+	}
+	s.Points = s.IslandPoints + s.ComboPoints + s.TimeBonus
+}
+
+// finishScore adds the time bonus once the game is won, then the mutators' share
+// of the total. Relaxed games have no clock to beat, so they earn no time bonus.
+func (s *Score) finishScore(timeLimit time.Duration, relaxed bool, multiplier float64) {
+	switch {
+	case relaxed:
+		s.TimeBonus = 0
+	case timeLimit > 0:
+		s.TimeBonus = int(max(timeLimit-s.Time, 0).Seconds()) * timeLimitBonus
+		s.Overtime = overtimePenalty(timeLimit, s.Time)
+	default:
+		s.TimeBonus = int(max(timeBonusWindow-s.Time, 0).Seconds()) * timeBonusPerSecond
+	}
+	base := max(s.IslandPoints+s.ComboPoints+s.TimeBonus-s.Overtime, 0)
+	s.MutatorBonus = int(float64(base) * (multiplier - 1))
+	s.Points = base + s.MutatorBonus
+}
+
+// overtimePenalty is what finishing at a time past the limit costs
+func overtimePenalty(timeLimit, elapsed time.Duration) int {
+	return int(max(elapsed-timeLimit, 0).Seconds()) * overtimePerSecond
+}
+
+// resultLines breaks the final score down for the victory screen
+func (s Score) resultLines() []string {
+	lines := []string{
+		fmt.Sprintf("Islands connected  %6d", s.IslandPoints),
+		fmt.Sprintf("Combos (best x%d)   %6d", min(1+s.BestCombo/comboStep, maxComboMultiplier), s.ComboPoints),
+		fmt.Sprintf("Time bonus         %6d", s.TimeBonus),
+	}
+	if s.Overtime > 0 {
+		lines = append(lines, fmt.Sprintf("Overtime           %6d", -s.Overtime))
+	}
+	if s.MutatorBonus > 0 {
+		lines = append(lines, fmt.Sprintf("Mutators           %6d", s.MutatorBonus))
+	}
+	return append(lines, fmt.Sprintf("Total score        %6d", s.Points))
+}
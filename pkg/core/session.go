@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// Play time is saved every sessionSaveInterval while playing, as browsers give
+// no reliable chance to save when the tab closes
+const sessionSaveInterval = 30 * time.Second
+
+// sessionFrameGap is the longest gap between frames counted as play; longer
+// gaps mean the game was suspended
+const sessionFrameGap = time.Second
+
+// playSession tracks the current sitting of play for the session summary
+type playSession struct {
+	played    time.Duration // Time played, not counting pauses
+	levels    int           // Levels completed
+	stars     int
+	unsaved   time.Duration // Play time not yet added to the profile's total
+	lastFrame time.Time
+}
+
+// trackPlayTime counts the time since the last frame as play unless the game
+// is paused, covered by the settings panel or in the background
+func (g *Game) trackPlayTime() {
+	now := time.Now()
+	playing := g.world.State != StatePaused && !g.saveLoadUI.IsOpen() && ebiten.IsFocused()
+	if elapsed := now.Sub(g.session.lastFrame); playing && !g.session.lastFrame.IsZero() && elapsed < sessionFrameGap {
+		g.session.played += elapsed
+		g.session.unsaved += elapsed
+	}
+	g.session.lastFrame = now
+
+	if g.session.unsaved >= sessionSaveInterval || (!playing && g.session.unsaved > 0) {
+		g.saveSession()
+	}
+}
+
+// saveSession adds the unsaved play time to the profile's total and records
+// the session so far as its latest
+func (g *Game) saveSession() {
+	if g.session.played == 0 {
+		return
+	}
+	err := g.saveSystem.RecordSession(g.session.unsaved, storage.SessionSummary{
+		Played: g.session.played,
+		Levels: g.session.levels,
+		Stars:  g.session.stars,
+		Ended:  time.Now(),
+	})
+	if err != nil {
+		fmt.Println("Failed to save play time:", err)
+		return
+	}
+	g.session.unsaved = 0
+}
+
+// showLastSession puts the profile's previous session on the main menu
+func (g *Game) showLastSession() {
+	g.mainMenu.Summary = ""
+	if last, ok := g.saveSystem.LastSession(); ok {
+		g.mainMenu.Summary = fmt.Sprintf("Last session: %s played, %d levels, %d stars", formatPlayTime(last.Played), last.Levels, last.Stars)
+	}
+}
+
+// formatPlayTime writes a duration as 45s, 12m or 1h 05m
+func formatPlayTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/share"
+)
+
+// shareCard sums up the game just won for the result card
+func (g *Game) shareCard() share.Card {
+	card := share.Card{
+		LevelName: g.world.GetModeName(),
+		ModeName:  g.world.GetModeName(),
+		Moves:     g.world.Score.Moves,
+		Time:      g.world.Score.Time,
+		Points:    g.world.Score.Points,
+		Board:     g.world.Board,
+		Player:    g.playerName(),
+	}
+	if g.currentLevel != nil {
+		card.LevelName = g.currentLevel.Name
+		card.Stars = g.wonStars
+		card.Rated = !g.world.Assisted
+	}
+	if g.world.Relaxed {
+		card.ModeName += " (Relaxed)"
+	}
+	return card
+}
+
+// exportShareCard renders the result card and copies it to the clipboard when
+// asked to and able, or saves it as a PNG
+func (g *Game) exportShareCard(copyCard bool) {
+	data, err := share.EncodePNG(g.shareCard(), g.render.Theme())
+	if err != nil {
+		fmt.Println("Share card failed:", err)
+		g.showCaptureMessage("Share card failed")
+		return
+	}
+
+	prefix := ""
+	if copyCard {
+		err := share.CopyPNG(data)
+		if err == nil {
+			g.showCaptureMessage("Copied the share card")
+			return
+		}
+		if !errors.Is(err, share.ErrCopyUnsupported) {
+			fmt.Println("Copying the share card failed:", err)
+		}
+		prefix = "Can't copy here. "
+	}
+
+	name := capture.FileName("png", time.Now())
+	if err := g.saveSystem.ExportFile(name, data); err != nil {
+		fmt.Println("Share card export failed:", err)
+		g.showCaptureMessage("Share card export failed")
+		return
+	}
+	g.showCaptureMessage(prefix + "Saved " + name)
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// Showcase pacing; a finished board stays up for a moment before the next one
+const (
+	showcaseStep    = 700 * time.Millisecond
+	showcaseRestart = 3 * time.Second
+	showcaseSize    = 8
+)
+
+// menuShowcase is a generated board behind the main menu that the solver
+// connects one bridge at a time, starting over on a new board once it is done
+type menuShowcase struct {
+	board    *island.Board
+	render   *systems.RenderSystem // Own camera, so the game's zoom and pan are untouched
+	clock    clock.Clock
+	rng      *rand.Rand
+	nextStep time.Time
+	animate  bool // Ambient animations setting; a still board otherwise
+}
+
+func newMenuShowcase(clk clock.Clock) *menuShowcase {
+	return &menuShowcase{
+		render:  systems.NewRenderSystem(clk),
+		clock:   clk,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		animate: true,
+	}
+}
+
+// SetEffects follows the graphics quality; without ambient animations the board stands still
+func (s *menuShowcase) SetEffects(ambient, reduced bool) {
+	s.animate = ambient
+	s.render.SetEffects(ambient, reduced)
+}
+
+// Update places the solver's next bridge when it is due, or loops to a new board
+func (s *menuShowcase) Update() {
+	now := s.clock.Now()
+	if now.Before(s.nextStep) {
+		return
+	}
+	if s.board == nil {
+		s.restart(now)
+		return
+	}
+	if !s.animate {
+		return
+	}
+	if s.board.IsAllConnected() {
+		s.restart(now)
+		return
+	}
+
+	move, ok := solver.NextMove(s.board)
+	if !ok {
+		s.restart(now)
+		return
+	}
+	s.board.BuildBridge(move.X, move.Y)
+	s.nextStep = now.Add(showcaseStep)
+	if s.board.IsAllConnected() {
+		s.nextStep = now.Add(showcaseRestart)
+	}
+}
+
+// restart generates the next board, keeping the old one if generation fails
+func (s *menuShowcase) restart(now time.Time) {
+	s.nextStep = now.Add(showcaseStep)
+	levelData, err := levels.Generate(s.rng, classicLevelOptions(showcaseSize, showcaseSize))
+	if err != nil {
+		fmt.Println("Failed to generate the menu showcase:", err)
+		return
+	}
+	s.board = levelData.NewBoard()
+	s.render.FrameBoard(s.board.Width, s.board.Height)
+}
+
+// Draw fills the screen with the board
+func (s *menuShowcase) Draw(screen *ebiten.Image) {
+	s.render.Draw(screen, s.board, false)
+}
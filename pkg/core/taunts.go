@@ -0,0 +1,64 @@
+package core
+
+import (
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/events"
+)
+
+// tauntLife is how long a taunt stays over the race bars
+const tauntLife = 3 * time.Second
+
+// raceTaunts is what the AI racer has said this race. Each moment is taunted
+// at most once, so a race swinging back and forth does not repeat itself.
+type raceTaunts struct {
+	said map[ai.Taunt]bool
+	line string
+	at   time.Time
+}
+
+// taunt has the AI say its line for a moment of the race, once per race
+func (g *Game) taunt(moment ai.Taunt) {
+	if g.opponent == nil || g.taunts.said[moment] {
+		return
+	}
+	if g.taunts.said == nil {
+		g.taunts.said = make(map[ai.Taunt]bool)
+	}
+	g.taunts.said[moment] = true
+	g.taunts.line = g.opponent.Taunt(moment)
+	g.taunts.at = g.clock.Now()
+}
+
+// updateTaunts has the AI taunt as the race turns: when one racer pulls well
+// ahead and when it nears the finish in front
+func (g *Game) updateTaunts() {
+	if g.opponent == nil || g.world.State != StatePlaying || g.world.GameWon {
+		return
+	}
+	player, opponent := g.raceProgress()
+	switch {
+	case opponent >= 0.75 && opponent > player:
+		g.taunt(ai.TauntClosing)
+	case opponent-player >= 0.25:
+		g.taunt(ai.TauntAhead)
+	case player-opponent >= 0.25:
+		g.taunt(ai.TauntBehind)
+	}
+}
+
+// tauntRaceEnd has the AI sum up the race it won or lost
+func (g *Game) tauntRaceEnd(e events.GameLost) {
+	if e.Reason == "ai_won" {
+		g.taunt(ai.TauntWon)
+	}
+}
+
+// tauntNow is the taunt to show over the race bars; muting emotes mutes taunts too
+func (g *Game) tauntNow() string {
+	if g.muteEmotes || g.taunts.line == "" || g.clock.Now().Sub(g.taunts.at) >= tauntLife {
+		return ""
+	}
+	return g.taunts.line
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/ai"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// timeAttackLevelOptions shapes the generated boards of Time Attack, small
+// enough to connect well within the time limit
+var timeAttackLevelOptions = levels.GenerateOptions{
+	Width:   7,
+	Height:  7,
+	Density: 0.12,
+	MinPar:  4,
+	MaxPar:  10,
+}
+
+// heartbeatWindow is how long before time runs out the heartbeat starts
+const heartbeatWindow = 10 * time.Second
+
+// startTimeAttack races the clock, and optionally the AI, on a freshly
+// generated board
+func (g *Game) startTimeAttack() {
+	source := g.newRandom()
+	levelData, err := levels.Generate(source.Stream("board"), timeAttackLevelOptions)
+	if err != nil {
+		fmt.Println("Failed to generate a Time Attack board:", err)
+		return
+	}
+	levelData.ID = fmt.Sprintf("time_attack_%d", source.Seed())
+	levelData.Name = "Time Attack"
+	g.startSeededLevel(levelData, ModeTimeAttack, source)
+
+	if g.world.Relaxed {
+		return
+	}
+	// Optionally race against the AI on the same board
+	if settings, err := g.saveSystem.LoadSettings(); err == nil && settings.AIOpponent > 0 {
+		g.raceOpponent(ai.Skill(settings.AIOpponent), ai.Personality(settings.AIPersonality))
+	}
+}
+
+// raceOpponent puts an AI on the board, its moves drawn from the game's seed
+func (g *Game) raceOpponent(skill ai.Skill, personality ai.Personality) {
+	g.opponent = ai.NewOpponent(g.world.Board, skill, g.clock)
+	g.opponent.Personality = personality
+	g.opponent.Seed(g.world.Random.Stream("opponent").Int63())
+	g.emotes = newAIEmotes(g.raceProgress, g.clock)
+	g.emoteBubbles = nil
+	g.emoteWheel.Close()
+	g.taunts = raceTaunts{}
+	g.taunt(ai.TauntStart)
+}
+
+// opponentName is the AI racer's skill and, when it has one, its personality
+func (g *Game) opponentName() string {
+	name := ai.SkillName(g.opponent.Skill)
+	if g.opponent.Skill != ai.SkillRandom {
+		name += " " + g.opponent.PlaysAs().String()
+	}
+	return name
+}
+
+// heartbeat beats once a second through the last seconds of a timed game
+func (g *Game) heartbeat() {
+	if g.world.Mode != ModeTimeAttack || g.world.TimeLimit <= 0 || g.world.GameWon {
+		return
+	}
+	left := g.world.TimeLimit - g.world.Score.Time
+	if left <= 0 || left > heartbeatWindow {
+		return
+	}
+	second := int((left + time.Second - 1) / time.Second)
+	if second != g.heartbeatSecond {
+		g.heartbeatSecond = second
+		g.sound.Play(systems.SoundHeartbeat)
+	}
+}
+
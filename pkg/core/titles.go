@@ -0,0 +1,32 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/achievements"
+)
+
+// wornTitle is the title chosen in the achievements panel, while its
+// achievement stays unlocked for the active profile
+func (g *Game) wornTitle() string {
+	if !g.achievementSys.HasTitle(g.title) {
+		return ""
+	}
+	return g.title
+}
+
+// playerName is the active profile's name with the title it wears, as shown
+// on the victory screen and share cards
+func (g *Game) playerName() string {
+	return achievements.TitledName(g.saveSystem.CurrentProfile().Name, g.wornTitle())
+}
+
+// pickTitle saves the title chosen in the achievements panel
+func (g *Game) pickTitle(title string) {
+	settings, _ := g.saveSystem.LoadSettings()
+	settings.Title = title
+	if err := g.saveSystem.SaveSettings(settings); err != nil {
+		fmt.Println("Failed to save the title:", err)
+	}
+	g.applySettings(settings)
+}
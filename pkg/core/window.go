@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// minWindowWidth and minWindowHeight keep a restored window large enough to use
+const (
+	minWindowWidth  = 320
+	minWindowHeight = 240
+)
+
+// RestoreWindow sizes and places the desktop window as it was when the game
+// last closed, and lets the game save that placement on close. Browsers manage
+// the page themselves, so it does nothing there.
+func (g *Game) RestoreWindow() {
+	if runtime.GOOS == "js" {
+		return
+	}
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true)
+	
+	state, err := g.saveSystem.LoadWindowState()
+	if err != nil || state.Width < minWindowWidth || state.Height < minWindowHeight {
+		ebiten.SetWindowSize(640, 480)
+		return
+	}
+	g.window = state
+	ebiten.SetWindowSize(state.Width, state.Height)
+	ebiten.SetWindowPosition(state.X, state.Y)
+	ebiten.SetFullscreen(state.Fullscreen)
+}
+
+// toggleFullscreen switches between the window and fullscreen, remembering the choice
+func (g *Game) toggleFullscreen() {
+	ebiten.SetFullscreen(!ebiten.IsFullscreen())
+	g.saveWindowState()
+}
+
+// saveWindowState records the window placement. While fullscreen the last
+// windowed size and position are kept, so leaving fullscreen restores them.
+func (g *Game) saveWindowState() {
+	if runtime.GOOS == "js" {
+		return
+	}
+	if g.window == nil {
+		g.window = &storage.WindowState{}
+	}
+	g.window.Fullscreen = ebiten.IsFullscreen()
+	if !g.window.Fullscreen {
+		g.window.Width, g.window.Height = ebiten.WindowSize()
+		g.window.X, g.window.Y = ebiten.WindowPosition()
+	}
+	if err := g.saveSystem.SaveWindowState(g.window); err != nil {
+		fmt.Println("Failed to save window placement:", err)
+	}
+}
@@ -2,26 +2,56 @@ package core
 
 import (
 	"time"
-	
+
 	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/random"
 )
 
 type World struct {
-	State     GameState
-	Mode      GameMode
-	Board     *island.Board
-	Score     Score
-	GameWon   bool
-	StartTime time.Time
-	TimeLimit time.Duration // For Time Attack mode
+	State          GameState
+	Mode           ModeID
+	Board          *island.Board
+	Score          Score
+	GameWon        bool
+	StartTime      time.Time
+	TimeLimit      time.Duration   // For Time Attack mode
+	Relaxed        bool            // Started in relaxed mode: no time limit, stars from moves only
+	Overtime       bool            // Time Attack goes on past the limit, costing points for every second over
+	Assisted       bool            // Move feedback rated a move; the game earns no stars
+	LineHints      *LineHints      // For Bridge Counts mode
+	Hazards        *Hazards        // For Storm mode
+	Zen            *Zen            // For Zen mode
+	Correspondence *Correspondence // For Correspondence mode
+	OnlineRace     *OnlineRace     // For Online Race mode
+	Rules          RuleSet         // Mutators chosen before the level started
+	Random         *random.Source  // Everything random in the game; its seed replays it
+}
+
+// LineHints are the bridge tiles a solution has in each row and column
+type LineHints struct {
+	Rows []int
+	Cols []int
 }
 
 type Score struct {
 	Moves       int
 	Time        time.Duration
 	IslandsLeft int
-	BestTime    time.Duration
+	BestTime    time.Duration // Personal bests on the level being played; zero when unknown
 	BestMoves   int
+	BestPoints  int
+
+	// Points are the running score; the rest is its breakdown for the results screen
+	Points       int
+	Combo        int // Consecutive optimal moves
+	BestCombo    int
+	IslandPoints int
+	ComboPoints  int
+	TimeBonus    int
+	Overtime     int // Points lost finishing past the limit in overtime
+	MutatorBonus int
+
+	MoveTimes []time.Duration // Game time of each move, for thinking-time statistics
 }
 
 // Methods for interface compliance
@@ -29,6 +59,14 @@ func (w *World) GetMode() int {
 	return int(w.Mode)
 }
 
+func (w *World) GetModeName() string {
+	return LookupMode(w.Mode).Name()
+}
+
+func (w *World) GetHUDExtras() []string {
+	return LookupMode(w.Mode).HUDExtras(w)
+}
+
 func (w *World) GetScore() interface {
 	GetMoves() int
 	GetTime() time.Duration
@@ -50,4 +88,4 @@ func (s Score) GetMoves() int {
 
 func (s Score) GetTime() time.Duration {
 	return s.Time
-}
\ No newline at end of file
+}
@@ -0,0 +1,89 @@
+package correspondence
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// codeVersion is the first byte of every code
+const codeVersion = 1
+
+var ErrInvalidCode = errors.New("invalid correspondence code")
+
+// Encode packs the game into a short URL-safe string: a version byte, the
+// ID, the level as a share code, the moves as tile indexes, then the names
+func (g *Game) Encode() (string, error) {
+	levelCode, err := levels.EncodeShareCode(g.Level)
+	if err != nil {
+		return "", err
+	}
+	board, _ := base64.RawURLEncoding.DecodeString(levelCode)
+	id, err := hex.DecodeString(g.ID)
+	if err != nil || len(id) != 4 {
+		return "", fmt.Errorf("correspondence: bad game ID %q", g.ID)
+	}
+
+	data := append([]byte{codeVersion}, id...)
+	data = binary.AppendUvarint(data, uint64(len(board)))
+	data = append(data, board...)
+	data = binary.AppendUvarint(data, uint64(len(g.Moves)))
+	for _, move := range g.Moves {
+		data = binary.AppendUvarint(data, uint64(move.Y*g.Level.Width+move.X))
+	}
+	data = append(data, g.Players[0]...)
+	data = append(data, 0)
+	data = append(data, g.Players[1]...)
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode unpacks a game from Encode and replays its moves
+func Decode(code string) (*Game, error) {
+	data, err := base64.RawURLEncoding.DecodeString(code)
+	if err != nil || len(data) < 5 {
+		return nil, ErrInvalidCode
+	}
+	if data[0] != codeVersion {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidCode, data[0])
+	}
+	id := hex.EncodeToString(data[1:5])
+	reader := bytes.NewReader(data[5:])
+
+	boardLen, err := binary.ReadUvarint(reader)
+	if err != nil || boardLen > uint64(reader.Len()) {
+		return nil, fmt.Errorf("%w: truncated", ErrInvalidCode)
+	}
+	board := make([]byte, boardLen)
+	reader.Read(board)
+	level, err := levels.DecodeShareCode(base64.RawURLEncoding.EncodeToString(board))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCode, err)
+	}
+	game := &Game{ID: id, Level: level, Board: level.NewBoard()}
+
+	count, err := binary.ReadUvarint(reader)
+	if err != nil || count > uint64(level.Width*level.Height) {
+		return nil, fmt.Errorf("%w: bad move count", ErrInvalidCode)
+	}
+	for i := uint64(0); i < count; i++ {
+		tile, err := binary.ReadUvarint(reader)
+		if err != nil || tile >= uint64(level.Width*level.Height) {
+			return nil, fmt.Errorf("%w: bad move %d", ErrInvalidCode, i+1)
+		}
+		if _, err := game.Play(int(tile)%level.Width, int(tile)/level.Width); err != nil {
+			return nil, fmt.Errorf("%w: move %d: %v", ErrInvalidCode, i+1, err)
+		}
+	}
+
+	names := make([]byte, reader.Len())
+	reader.Read(names)
+	first, second, _ := bytes.Cut(names, []byte{0})
+	game.SetPlayer(0, string(first))
+	game.SetPlayer(1, string(second))
+	return game, nil
+}
@@ -0,0 +1,110 @@
+// Package correspondence is a two-player game played a turn at a time, at the
+// players' leisure. They take turns building one bridge each on a shared
+// board, and every bridge scores for its builder a point for each island
+// group it joins. The game ends when every island is connected, or no bridge
+// can be built, and the higher score wins.
+//
+// A game travels between the players as a code holding the board, their names
+// and every move so far. Whoever receives one replays the moves, so a code
+// whose moves break the rules is refused.
+package correspondence
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// maxNameLen bounds the player names carried in a code
+const maxNameLen = 16
+
+// Draw is what Winner returns for a game that ended level
+const Draw = -1
+
+var ErrNotPlayable = errors.New("correspondence: bridge not allowed")
+
+// Game is a correspondence game as far as it has been played
+type Game struct {
+	ID      string // Random, so both players' copies can be matched up
+	Level   *levels.LevelData
+	Players [2]string // Names by seat; the second is empty until its player joins
+	Moves   []island.Point
+	Board   *island.Board // After every move
+	Scores  [2]int
+}
+
+// New starts a game on a level, with first to move
+func New(level *levels.LevelData, first string) (*Game, error) {
+	if err := level.Validate(); err != nil {
+		return nil, err
+	}
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	game := &Game{ID: hex.EncodeToString(id), Level: level, Board: level.NewBoard()}
+	game.SetPlayer(0, first)
+	return game, nil
+}
+
+// SetPlayer names the player in a seat, cut to fit in a code
+func (g *Game) SetPlayer(seat int, name string) {
+	if len(name) > maxNameLen {
+		name = name[:maxNameLen]
+	}
+	g.Players[seat] = name
+}
+
+// Turn is the seat to move next
+func (g *Game) Turn() int {
+	return len(g.Moves) % 2
+}
+
+// Over reports whether the game has ended
+func (g *Game) Over() bool {
+	if g.Board.IsSolved() {
+		return true
+	}
+	for y := 0; y < g.Board.Height; y++ {
+		for x := 0; x < g.Board.Width; x++ {
+			if g.Board.CanBuildBridge(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Winner is the seat with the higher score once the game is over, or Draw
+func (g *Game) Winner() int {
+	switch {
+	case !g.Over() || g.Scores[0] == g.Scores[1]:
+		return Draw
+	case g.Scores[0] > g.Scores[1]:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Play builds the bridge of the player whose turn it is and returns the
+// island groups it joined
+func (g *Game) Play(x, y int) (int, error) {
+	if g.Over() {
+		return 0, errors.New("correspondence: the game is over")
+	}
+	if !g.Board.CanBuildBridge(x, y) {
+		return 0, fmt.Errorf("%w at (%d, %d)", ErrNotPlayable, x, y)
+	}
+	seat := g.Turn()
+	groups := g.Board.IslandGroupCount()
+	g.Board.BuildBridge(x, y)
+	merged := groups - g.Board.IslandGroupCount()
+	g.Scores[seat] += merged
+	g.Moves = append(g.Moves, island.Point{X: x, Y: y})
+	return merged, nil
+}
@@ -0,0 +1,46 @@
+package cosmetics
+
+import "github.com/ponyo877/island-merge/pkg/assets"
+
+// Paint is a bridge colour the player can choose once they have earned
+// enough quest tokens. Tokens are not spent, so every paint stays unlocked.
+type Paint struct {
+	ID     string
+	Name   string
+	Color  assets.Color
+	Tokens int // Quest tokens needed to unlock it
+}
+
+// Paints are offered in order of the tokens they need
+var Paints = []Paint{
+	{ID: "coral", Name: "Coral", Color: assets.Color{R: 240, G: 128, B: 112, A: 255}, Tokens: 2},
+	{ID: "sandstone", Name: "Sandstone", Color: assets.Color{R: 214, G: 180, B: 120, A: 255}, Tokens: 5},
+	{ID: "jade", Name: "Jade", Color: assets.Color{R: 60, G: 160, B: 120, A: 255}, Tokens: 10},
+	{ID: "slate", Name: "Slate", Color: assets.Color{R: 90, G: 100, B: 120, A: 255}, Tokens: 20},
+	{ID: "gold", Name: "Gold", Color: assets.Color{R: 230, G: 190, B: 40, A: 255}, Tokens: 40},
+}
+
+// Lookup finds a paint by ID
+func Lookup(id string) (Paint, bool) {
+	for _, paint := range Paints {
+		if paint.ID == id {
+			return paint, true
+		}
+	}
+	return Paint{}, false
+}
+
+// Unlocked reports whether a paint is unlocked with the given tokens
+func (p Paint) Unlocked(tokens int) bool {
+	return tokens >= p.Tokens
+}
+
+// NextUnlock returns the cheapest paint still locked, and false once all are unlocked
+func NextUnlock(tokens int) (Paint, bool) {
+	for _, paint := range Paints {
+		if !paint.Unlocked(tokens) {
+			return paint, true
+		}
+	}
+	return Paint{}, false
+}
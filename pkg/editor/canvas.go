@@ -0,0 +1,123 @@
+package editor
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Canvas layout. The grid is drawn inside this area, zoomed and scrolled by the
+// editor's camera; tiles are fitted to the area between EditorMinTileSize and
+// EditorTileSize before zooming.
+const (
+	EditorViewWidth   = EditorGridWidth * EditorTileSize
+	EditorViewHeight  = 280 // Ends above the test mode and playtest lines
+	EditorMinTileSize = 8
+)
+
+// editorSizes are the board sizes Resize cycles through, smallest first
+var editorSizes = []image.Point{
+	{X: EditorGridWidth, Y: EditorGridHeight},
+	{X: 24, Y: 18},
+	{X: 32, Y: 24},
+}
+
+// Zoom scales the canvas by factor
+func (le *LevelEditor) Zoom(factor float64) {
+	le.camera.Zoom(factor)
+}
+
+// Pan scrolls the canvas by the given number of pixels
+func (le *LevelEditor) Pan(dx, dy float64) {
+	le.camera.Pan(dx, dy)
+}
+
+// fitView zooms and scrolls the canvas to show the whole board
+func (le *LevelEditor) fitView() {
+	le.camera.FrameBoard(le.Board.Width, le.Board.Height)
+}
+
+// resizeBoard switches to the next board size, keeping the tiles that still
+// fit; tiles added by growing the board are empty. Going from the largest size
+// back to the smallest asks first when it would crop tiles off the level.
+func (le *LevelEditor) resizeBoard() {
+	next := editorSizes[0]
+	for i, size := range editorSizes {
+		if size.X == le.Board.Width && size.Y == le.Board.Height {
+			next = editorSizes[(i+1)%len(editorSizes)]
+		}
+	}
+	if cropped := le.croppedTiles(next); cropped > 0 {
+		le.confirm.Confirm(fmt.Sprintf("Shrink the level to %dx%d?", next.X, next.Y), "Shrink", func() { le.resizeTo(next) },
+			fmt.Sprintf("%d tiles outside %dx%d will be removed.", cropped, next.X, next.Y))
+		return
+	}
+	le.resizeTo(next)
+}
+
+// croppedTiles counts the tiles that are not empty outside a board size
+func (le *LevelEditor) croppedTiles(size image.Point) int {
+	cropped := 0
+	for y := 0; y < le.Board.Height; y++ {
+		for x := 0; x < le.Board.Width; x++ {
+			if (x >= size.X || y >= size.Y) && le.Board.GetTile(x, y).Type != island.TileEmpty {
+				cropped++
+			}
+		}
+	}
+	return cropped
+}
+
+// resizeTo changes the board size, keeping the tiles that still fit
+func (le *LevelEditor) resizeTo(next image.Point) {
+	if le.IsPlaying {
+		le.testLevel()
+	}
+
+	resized := island.NewBoard(next.X, next.Y)
+	for y := 0; y < min(next.Y, le.Board.Height); y++ {
+		for x := 0; x < min(next.X, le.Board.Width); x++ {
+			resized.SetTile(x, y, le.Board.GetTile(x, y).Type)
+		}
+	}
+	le.setTool(le.Tool)
+	le.Playtest = nil
+	le.Board = resized
+	le.fitView()
+	le.Status = fmt.Sprintf("Resized the level to %dx%d", next.X, next.Y)
+}
+
+// canvas is the part of the screen the grid may be drawn on
+func (le *LevelEditor) canvas(screen *ebiten.Image) *ebiten.Image {
+	x, y, width, height := le.camera.Area()
+	return screen.SubImage(image.Rect(x, y, x+width, y+height)).(*ebiten.Image)
+}
+
+// tileAt returns the tile under a screen position, which may be off the board
+func (le *LevelEditor) tileAt(screenX, screenY int) (int, int) {
+	originX, originY := le.camera.Origin()
+	size := float64(le.camera.TileSize())
+	return int(math.Floor(float64(screenX-originX) / size)), int(math.Floor(float64(screenY-originY) / size))
+}
+
+// gridAt returns the board tile under a screen position and whether there is
+// one there, inside the canvas
+func (le *LevelEditor) gridAt(screenX, screenY int) (int, int, bool) {
+	x, y, width, height := le.camera.Area()
+	if screenX < x || screenY < y || screenX >= x+width || screenY >= y+height {
+		return 0, 0, false
+	}
+	gridX, gridY := le.tileAt(screenX, screenY)
+	return gridX, gridY, le.Board.GetTile(gridX, gridY) != nil
+}
+
+// tileRect returns the screen rectangle covering width by height tiles from a
+// board tile
+func (le *LevelEditor) tileRect(gridX, gridY, width, height int) (x, y, w, h float32) {
+	originX, originY := le.camera.Origin()
+	size := le.camera.TileSize()
+	return float32(originX + gridX*size), float32(originY + gridY*size), float32(width * size), float32(height * size)
+}
@@ -0,0 +1,410 @@
+package editor
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/island"
+	gamenet "github.com/ponyo877/island-merge/pkg/net"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// Co-editing shares the level with other authors through an online match.
+// Each edit is a delta, and the backend's order settles edits to the same
+// tile: the last one wins. The player's edits show at once and are found each
+// frame by comparing the board with how it was after the last frame; edits
+// from others to a tile the player changed since are skipped, as the player's
+// came later.
+
+const (
+	coEditBatch      = 64                     // Edits to more tiles than this at once send the whole level
+	coEditCursorRate = 150 * time.Millisecond // Least time between two cursor deltas
+	coEditChatLines  = 3                      // Chat lines shown under the canvas
+	coEditChatChars  = 30                     // Longest chat line the player may type
+)
+
+// Chat panel layout, under the Co-edit button beside the instructions
+const (
+	coEditChatX, coEditChatY = 380, 402
+	coEditChatWidth          = selectionBarX + selectionButtonW - coEditChatX + 5
+)
+
+// coEditColors mark the other authors' cursors, in the order they joined
+var coEditColors = []color.RGBA{
+	{230, 80, 80, 255},
+	{240, 150, 40, 255},
+	{170, 90, 210, 255},
+	{40, 110, 220, 255},
+}
+
+// Delta bodies
+type (
+	coEditTile struct {
+		X    int             `json:"x"`
+		Y    int             `json:"y"`
+		Type island.TileType `json:"type"`
+	}
+	coEditLevel struct {
+		Width  int                 `json:"width"`
+		Height int                 `json:"height"`
+		Tiles  [][]island.TileType `json:"tiles"`
+	}
+	coEditCursor struct {
+		X int `json:"x"` // -1 while the pointer is off the level
+		Y int `json:"y"`
+	}
+	coEditChat struct {
+		Text string `json:"text"`
+	}
+	coEditPlayer struct {
+		Name string `json:"name"`
+	}
+)
+
+// coAuthor is another author in the session
+type coAuthor struct {
+	name    string
+	color   color.RGBA
+	cursor  island.Point
+	onLevel bool
+}
+
+// coEdit is the editor's co-editing session
+type coEdit struct {
+	match   *gamenet.Match
+	known   *island.Board           // The board as sent and received, to find the player's edits
+	pending map[island.Point]string // Refs of the player's tile edits not seen back yet
+	level   string                  // Ref of the player's whole-level delta not seen back yet
+	authors map[string]*coAuthor    // By seat
+	chat    []string
+
+	typing     bool // Enter opened the chat line
+	draft      string
+	cursor     island.Point
+	cursorSent time.Time
+}
+
+// CoEditing reports whether the level is shared with other authors
+func (le *LevelEditor) CoEditing() bool {
+	return le.coEdit != nil
+}
+
+// toggleCoEdit asks the game to start a session for the level, or leaves the one in progress
+func (le *LevelEditor) toggleCoEdit() {
+	if le.coEdit != nil {
+		le.StopCoEdit()
+		le.Status = "Left the co-editing session"
+		return
+	}
+	le.events.Publish(events.CoEditRequested{})
+}
+
+// StartCoEdit shares the level through a match. The author who started the
+// session sends their level; the others take it on when it arrives.
+func (le *LevelEditor) StartCoEdit(match *gamenet.Match, name string, host bool) {
+	le.StopCoEdit()
+	le.coEdit = &coEdit{
+		match:   match,
+		known:   le.Board.Clone(),
+		pending: make(map[island.Point]string),
+		authors: make(map[string]*coAuthor),
+		cursor:  island.Point{X: -1, Y: -1},
+	}
+	le.sendCoEdit(gamenet.DeltaPlayer, coEditPlayer{Name: name})
+	if host {
+		le.sendLevel()
+	}
+}
+
+// StopCoEdit leaves the co-editing session, keeping the level as it is
+func (le *LevelEditor) StopCoEdit() {
+	if le.coEdit == nil {
+		return
+	}
+	le.coEdit.match.Close()
+	le.coEdit = nil
+}
+
+// Typing reports whether the player is writing a chat line, which takes the keyboard
+func (le *LevelEditor) Typing() bool {
+	return le.coEdit != nil && le.coEdit.typing
+}
+
+// HandleText types the chat line: Enter opens it, and sends it or closes it when empty
+func (le *LevelEditor) HandleText(text systems.TextInput) {
+	ce := le.coEdit
+	if ce == nil {
+		return
+	}
+	if !ce.typing {
+		ce.typing = text.Enter
+		return
+	}
+	for _, char := range text.Chars {
+		if char >= ' ' && char <= '~' && len(ce.draft) < coEditChatChars {
+			ce.draft += string(char)
+		}
+	}
+	if text.Backspace && ce.draft != "" {
+		ce.draft = ce.draft[:len(ce.draft)-1]
+	}
+	if text.Enter {
+		if line := strings.TrimSpace(ce.draft); line != "" {
+			le.sendCoEdit(gamenet.DeltaChat, coEditChat{Text: line})
+		}
+		ce.typing = false
+		ce.draft = ""
+	}
+}
+
+// syncCoEdit sends the player's edits and cursor, and applies what the other
+// authors sent. Called every frame.
+func (le *LevelEditor) syncCoEdit() {
+	ce := le.coEdit
+	if ce == nil {
+		return
+	}
+	ce.match.Update()
+	if ce.match.State() == gamenet.MatchLost {
+		le.Status = "Co-editing ended: " + ce.match.Err().Error()
+		le.StopCoEdit()
+		return
+	}
+
+	le.sendEdits()
+	for _, delta := range ce.match.Poll() {
+		le.receiveCoEdit(delta)
+	}
+	ce.known = le.Board.Clone()
+	le.sendCursor()
+}
+
+// sendEdits sends the tiles changed since the last frame, or the whole level
+// after a resize or a large paste
+func (le *LevelEditor) sendEdits() {
+	ce := le.coEdit
+	if le.Board.Width != ce.known.Width || le.Board.Height != ce.known.Height {
+		le.sendLevel()
+		return
+	}
+	var edits []coEditTile
+	for y := 0; y < le.Board.Height; y++ {
+		for x := 0; x < le.Board.Width; x++ {
+			if tile := le.Board.GetTile(x, y).Type; tile != ce.known.GetTile(x, y).Type {
+				edits = append(edits, coEditTile{X: x, Y: y, Type: tile})
+			}
+		}
+	}
+	if len(edits) > coEditBatch {
+		le.sendLevel()
+		return
+	}
+	for _, edit := range edits {
+		if ref, ok := le.sendCoEdit(gamenet.DeltaTile, edit); ok {
+			ce.pending[island.Point{X: edit.X, Y: edit.Y}] = ref
+		}
+	}
+}
+
+// sendLevel sends every tile of the level, overwriting the other authors' copies
+func (le *LevelEditor) sendLevel() {
+	ce := le.coEdit
+	level := coEditLevel{Width: le.Board.Width, Height: le.Board.Height, Tiles: make([][]island.TileType, le.Board.Height)}
+	for y := range level.Tiles {
+		level.Tiles[y] = make([]island.TileType, le.Board.Width)
+		for x := range level.Tiles[y] {
+			level.Tiles[y][x] = le.Board.GetTile(x, y).Type
+		}
+	}
+	if ref, ok := le.sendCoEdit(gamenet.DeltaLevel, level); ok {
+		ce.level = ref
+		clear(ce.pending) // The level covers them
+	}
+}
+
+// sendCursor tells the other authors which tile the pointer is over
+func (le *LevelEditor) sendCursor() {
+	ce := le.coEdit
+	cursor := island.Point{X: -1, Y: -1}
+	if x, y, ok := le.gridAt(le.hoverX, le.hoverY); ok && !le.IsPlaying {
+		cursor = island.Point{X: x, Y: y}
+	}
+	if cursor == ce.cursor || time.Since(ce.cursorSent) < coEditCursorRate {
+		return
+	}
+	if _, ok := le.sendCoEdit(gamenet.DeltaCursor, coEditCursor(cursor)); ok {
+		ce.cursor = cursor
+		ce.cursorSent = time.Now()
+	}
+}
+
+func (le *LevelEditor) sendCoEdit(kind string, body interface{}) (string, bool) {
+	ref, err := le.coEdit.match.Send(kind, body)
+	if err != nil {
+		fmt.Println("Failed to send to the co-editing session:", err)
+		return "", false
+	}
+	return ref, true
+}
+
+// receiveCoEdit applies a delta from the session. The player's own come back
+// too, confirming that the edit has its place in the order.
+func (le *LevelEditor) receiveCoEdit(delta gamenet.Delta) {
+	ce := le.coEdit
+	own := delta.Seat == ce.match.Seat()
+	switch delta.Kind {
+	case gamenet.DeltaTile:
+		var edit coEditTile
+		if json.Unmarshal(delta.Body, &edit) != nil {
+			return
+		}
+		at := island.Point{X: edit.X, Y: edit.Y}
+		if own {
+			if ce.pending[at] == delta.Ref {
+				delete(ce.pending, at)
+			}
+			return
+		}
+		if _, mine := ce.pending[at]; mine || ce.level != "" || le.Board.GetTile(edit.X, edit.Y) == nil {
+			return // The player's edit came later and wins
+		}
+		le.Board.SetTile(edit.X, edit.Y, edit.Type)
+		le.Playtest = nil
+	case gamenet.DeltaLevel:
+		var level coEditLevel
+		if json.Unmarshal(delta.Body, &level) != nil {
+			return
+		}
+		if own {
+			if ce.level == delta.Ref {
+				ce.level = ""
+			}
+			return
+		}
+		if ce.level == "" {
+			le.takeLevel(level)
+		}
+	case gamenet.DeltaPlayer:
+		var player coEditPlayer
+		if own || json.Unmarshal(delta.Body, &player) != nil {
+			return
+		}
+		author := le.author(delta.Seat)
+		author.name = player.Name
+		le.Status = author.name + " joined the co-editing session"
+	case gamenet.DeltaCursor:
+		var cursor coEditCursor
+		if own || json.Unmarshal(delta.Body, &cursor) != nil {
+			return
+		}
+		author := le.author(delta.Seat)
+		author.cursor = island.Point(cursor)
+		author.onLevel = cursor.X >= 0
+	case gamenet.DeltaChat:
+		var chat coEditChat
+		if json.Unmarshal(delta.Body, &chat) != nil {
+			return
+		}
+		name := "You"
+		if !own {
+			name = le.author(delta.Seat).name
+		}
+		ce.chat = append(ce.chat, name+": "+chat.Text)
+		if len(ce.chat) > coEditChatLines {
+			ce.chat = ce.chat[len(ce.chat)-coEditChatLines:]
+		}
+	}
+}
+
+// takeLevel replaces the level with another author's, keeping the player's
+// tile edits that have not been seen back yet
+func (le *LevelEditor) takeLevel(level coEditLevel) {
+	board := island.NewBoard(level.Width, level.Height)
+	for y := 0; y < min(level.Height, len(level.Tiles)); y++ {
+		for x := 0; x < min(level.Width, len(level.Tiles[y])); x++ {
+			board.SetTile(x, y, level.Tiles[y][x])
+		}
+	}
+	for at := range le.coEdit.pending {
+		if mine := le.Board.GetTile(at.X, at.Y); mine != nil && board.GetTile(at.X, at.Y) != nil {
+			board.SetTile(at.X, at.Y, mine.Type)
+		}
+	}
+	if board.Width != le.Board.Width || board.Height != le.Board.Height {
+		if le.IsPlaying {
+			le.testLevel()
+		}
+		le.setTool(le.Tool)
+		le.Board = board
+		le.fitView()
+	} else {
+		le.Board = board
+	}
+	le.Playtest = nil
+}
+
+// author returns another author by seat, adding them on their first delta
+func (le *LevelEditor) author(seat string) *coAuthor {
+	ce := le.coEdit
+	if author, ok := ce.authors[seat]; ok {
+		return author
+	}
+	author := &coAuthor{name: "Co-author", color: coEditColors[len(ce.authors)%len(coEditColors)]}
+	ce.authors[seat] = author
+	return author
+}
+
+// drawCoAuthors outlines the tile under each other author's pointer, with their name
+func (le *LevelEditor) drawCoAuthors(canvas *ebiten.Image) {
+	if le.coEdit == nil {
+		return
+	}
+	for _, author := range le.coEdit.authors {
+		if !author.onLevel || le.Board.GetTile(author.cursor.X, author.cursor.Y) == nil {
+			continue
+		}
+		x, y, w, h := le.tileRect(author.cursor.X, author.cursor.Y, 1, 1)
+		vector.StrokeRect(canvas, x, y, w, h, 3, author.color, false)
+		vector.DrawFilledRect(canvas, x, y-14, float32(len(author.name)*6+4), 14, author.color, false)
+		ebitenutil.DebugPrintAt(canvas, author.name, int(x)+2, int(y)-15)
+	}
+}
+
+// drawCoEditChat shows the session code, the latest chat lines and the line being typed
+func (le *LevelEditor) drawCoEditChat(screen *ebiten.Image) {
+	ce := le.coEdit
+	if ce == nil {
+		return
+	}
+	names := make([]string, 0, len(ce.authors))
+	for _, author := range ce.authors {
+		names = append(names, author.name)
+	}
+	slices.Sort(names)
+	header := "Co-editing with " + strings.Join(names, ", ")
+	switch state := ce.match.State(); {
+	case state == gamenet.MatchConnecting || state == gamenet.MatchReconnecting:
+		header = "Co-editing: connecting..."
+	case len(names) == 0:
+		header = "Co-editing code " + ce.match.ID()
+	}
+	lines := append([]string{header}, ce.chat...)
+	if ce.typing {
+		lines = append(lines, "> "+ce.draft+"_")
+	} else {
+		lines = append(lines, "Enter: chat")
+	}
+
+	vector.DrawFilledRect(screen, coEditChatX-5, coEditChatY-3, coEditChatWidth, float32(len(lines)*14+6), color.RGBA{225, 235, 245, 255}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, coEditChatX, coEditChatY+i*14)
+	}
+}
@@ -3,12 +3,18 @@ package editor
 import (
 	"encoding/json"
 	"fmt"
+	"image"
 	"image/color"
+	"slices"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/events"
 	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/systems"
+	"github.com/ponyo877/island-merge/pkg/ui"
 )
 
 type EditorMode int
@@ -34,7 +40,29 @@ type LevelEditor struct {
 	IsPlaying      bool
 	TestBoard      *island.Board // For testing the level
 	UIButtons      []*UIButton
-	OnLevelCreated func()        // Callback for achievement tracking
+	Status         string // Result of the last export, shown above the grid
+	Playtest       *Playtest // Recorded while testing; shown as a heatmap afterwards until the level is edited
+	events         *events.Bus
+	confirm        *ui.ConfirmDialog
+	hoverX, hoverY int
+	camera         *systems.Camera // Zooms and scrolls the grid
+	levelID        string          // Given on the first export; later exports save new versions
+	
+	templatesOpen bool
+	userTemplates []*Template // Saved by the player, oldest first
+	stamp         *Template   // Stamped by grid clicks instead of painting; nil when painting
+	selecting     bool        // Grid clicks mark the corners of an area to save as a template
+	selectFrom    *island.Point
+	
+	selectionButtons []*UIButton
+	selectMode       bool             // The Select tool is active
+	selection        *image.Rectangle // Selected tiles; nil when nothing is selected
+	dragFrom         *island.Point    // Tile a Select drag started on; nil when not dragging
+	dragTo           island.Point
+	dragMove         bool      // The drag moves the selection instead of selecting
+	clipboard        *Template // Copied tiles; pasting stamps it like a template
+	
+	coEdit *coEdit // The session sharing the level with other authors; nil when editing alone
 }
 
 type UIButton struct {
@@ -45,6 +73,7 @@ type UIButton struct {
 	Action   func()
 	Color    color.Color
 	Hovered  bool
+	Tooltip  string
 }
 
 const (
@@ -55,7 +84,7 @@ const (
 	EditorGridHeight = 12
 )
 
-func NewLevelEditor() *LevelEditor {
+func NewLevelEditor(bus *events.Bus) *LevelEditor {
 	board := island.NewBoard(EditorGridWidth, EditorGridHeight)
 	
 	editor := &LevelEditor{
@@ -64,47 +93,76 @@ func NewLevelEditor() *LevelEditor {
 		Tool:      ToolLand,
 		IsPlaying: false,
 		UIButtons: make([]*UIButton, 0),
+		events:    bus,
+		confirm:   ui.NewConfirmDialog(),
+		camera:    systems.NewCamera(EditorGridX, EditorGridY, EditorViewWidth, EditorViewHeight, EditorMinTileSize, EditorTileSize),
 	}
 	
 	editor.setupUI()
+	editor.setupSelectionBar()
 	return editor
 }
 
 func (le *LevelEditor) setupUI() {
 	buttonY := 20.0
-	buttonWidth := 80.0
+	buttonWidth := 62.0
 	buttonHeight := 30.0
-	spacing := 10.0
+	spacing := 6.0
 	
 	buttons := []struct {
-		text   string
-		color  color.Color
-		action func()
+		text    string
+		color   color.Color
+		action  func()
+		tooltip string
 	}{
-		{"Land", color.RGBA{139, 195, 74, 255}, func() { le.Tool = ToolLand }},
-		{"Sea", color.RGBA{64, 164, 223, 255}, func() { le.Tool = ToolSea }},
-		{"Empty", color.RGBA{200, 200, 200, 255}, func() { le.Tool = ToolEmpty }},
-		{"Clear", color.RGBA{255, 100, 100, 255}, func() { le.clearBoard() }},
-		{"Test", color.RGBA{100, 255, 100, 255}, func() { le.testLevel() }},
-		{"Export", color.RGBA{255, 255, 100, 255}, func() { le.exportLevel() }},
-		{"Back", color.RGBA{150, 150, 150, 255}, nil}, // Will be handled by parent
+		{"Land", color.RGBA{139, 195, 74, 255}, func() { le.setTool(ToolLand) }, "Paint island tiles"},
+		{"Sea", color.RGBA{64, 164, 223, 255}, func() { le.setTool(ToolSea) }, "Paint sea tiles where\nbridges can be built"},
+		{"Empty", color.RGBA{200, 200, 200, 255}, func() { le.setTool(ToolEmpty) }, "Paint impassable empty tiles"},
+		{"Clear", color.RGBA{255, 100, 100, 255}, func() { le.confirm.Confirm("Clear the level?", "Clear", le.clearBoard) }, "Reset every tile to empty"},
+		{"Test", color.RGBA{100, 255, 100, 255}, func() { le.testLevel() }, "Play the level in the editor;\nclick again to keep editing and\nsee a heatmap of the playtest"},
+		{"Templates", color.RGBA{255, 200, 100, 255}, func() { le.toggleTemplates() }, "Stamp island clusters, rings and\nother pieces, or save part of\nthe board as your own template"},
+		{"Export", color.RGBA{255, 255, 100, 255}, func() { le.exportLevel() }, "Export the level as JSON"},
+		{"Graph", color.RGBA{180, 160, 230, 255}, func() { le.exportGraph() }, "Export islands and bridges as a\nGraphviz DOT graph; the debug\nconsole also writes GraphML"},
+		{"Back", color.RGBA{150, 150, 150, 255}, nil, "Return to the main menu"}, // Will be handled by parent
 	}
 	
 	for i, btn := range buttons {
 		button := &UIButton{
-			Text:   btn.text,
-			X:      50 + float64(i)*(buttonWidth+spacing),
-			Y:      buttonY,
-			Width:  buttonWidth,
-			Height: buttonHeight,
-			Action: btn.action,
-			Color:  btn.color,
+			Text:    btn.text,
+			X:       10 + float64(i)*(buttonWidth+spacing),
+			Y:       buttonY,
+			Width:   buttonWidth,
+			Height:  buttonHeight,
+			Action:  btn.action,
+			Color:   btn.color,
+			Tooltip: btn.tooltip,
 		}
 		le.UIButtons = append(le.UIButtons, button)
 	}
 }
 
-func (le *LevelEditor) Update(mouseX, mouseY int, clicked bool) bool {
+// Update handles the pointer; down is whether the button is held, for dragging
+// a selection. It returns true when Back was clicked.
+func (le *LevelEditor) Update(mouseX, mouseY int, clicked, down bool) bool {
+	le.syncCoEdit()
+	
+	// An open confirmation takes every click until answered
+	if le.confirm.IsOpen() {
+		le.confirm.UpdateHover(mouseX, mouseY)
+		if clicked {
+			le.confirm.HandleClick(mouseX, mouseY)
+		}
+		return false
+	}
+	le.hoverX, le.hoverY = mouseX, mouseY
+	le.camera.Fit(le.Board.Width, le.Board.Height)
+	if le.templatesOpen {
+		if clicked {
+			le.handleTemplatesClick(mouseX, mouseY)
+		}
+		return false
+	}
+	
 	// Update UI buttons
 	backClicked := false
 	for i, btn := range le.UIButtons {
@@ -123,16 +181,28 @@ func (le *LevelEditor) Update(mouseX, mouseY int, clicked bool) bool {
 	if backClicked {
 		return true // Signal to return to menu
 	}
+	for _, btn := range le.selectionButtons {
+		btn.Hovered = float64(mouseX) >= btn.X && float64(mouseX) <= btn.X+btn.Width &&
+			float64(mouseY) >= btn.Y && float64(mouseY) <= btn.Y+btn.Height
+		if btn.Hovered && clicked {
+			btn.Action()
+		}
+	}
+	le.updateDrag(mouseX, mouseY, down)
 	
 	// Handle grid clicks
 	if clicked {
-		gridX := (mouseX - EditorGridX) / EditorTileSize
-		gridY := (mouseY - EditorGridY) / EditorTileSize
-		
-		if gridX >= 0 && gridX < EditorGridWidth && gridY >= 0 && gridY < EditorGridHeight {
-			if le.IsPlaying {
+		if gridX, gridY, ok := le.gridAt(mouseX, mouseY); ok {
+			switch {
+			case le.IsPlaying:
 				le.handleTestClick(gridX, gridY)
-			} else {
+			case le.stamp != nil:
+				le.place(le.stamp.Tiles, gridX, gridY)
+			case le.selectMode:
+				le.startDrag(gridX, gridY)
+			case le.selecting:
+				le.selectTile(gridX, gridY)
+			default:
 				le.paintTile(gridX, gridY)
 			}
 		}
@@ -141,18 +211,50 @@ func (le *LevelEditor) Update(mouseX, mouseY int, clicked bool) bool {
 	return false
 }
 
+// TooltipRegions describes the editor buttons for hover tooltips
+func (le *LevelEditor) TooltipRegions() []ui.TooltipRegion {
+	regions := make([]ui.TooltipRegion, 0, len(le.UIButtons)+len(le.selectionButtons))
+	for _, btn := range slices.Concat(le.UIButtons, le.selectionButtons) {
+		regions = append(regions, ui.TooltipRegion{
+			X:      int(btn.X),
+			Y:      int(btn.Y),
+			Width:  int(btn.Width),
+			Height: int(btn.Height),
+			Text:   btn.Tooltip,
+		})
+	}
+	return regions
+}
+
 func (le *LevelEditor) handleTestClick(x, y int) {
 	if le.TestBoard == nil {
 		return
 	}
 	
 	// Convert to game coordinates (test board uses smaller tiles)
-	if le.TestBoard.CanBuildBridge(x, y) {
+	built := le.TestBoard.CanBuildBridge(x, y)
+	if built {
 		le.TestBoard.BuildBridge(x, y)
 	}
+	if le.Playtest != nil {
+		le.Playtest.record(x, y, built, time.Now())
+	}
+}
+
+// setTool picks a paint tool, putting away any template being stamped or saved
+func (le *LevelEditor) setTool(tool Tool) {
+	le.Tool = tool
+	le.stamp = nil
+	le.selecting = false
+	le.selectFrom = nil
+	le.selectMode = false
+	le.selection = nil
+	le.dragFrom = nil
 }
 
 func (le *LevelEditor) paintTile(x, y int) {
+	// The heatmap describes the level as tested, so editing dismisses it
+	le.Playtest = nil
 	switch le.Tool {
 	case ToolLand:
 		le.Board.SetTile(x, y, island.TileLand)
@@ -164,6 +266,9 @@ func (le *LevelEditor) paintTile(x, y int) {
 }
 
 func (le *LevelEditor) clearBoard() {
+	le.Playtest = nil
+	le.selection = nil
+	le.levelID = "" // A cleared board is exported as a new level
 	for y := 0; y < le.Board.Height; y++ {
 		for x := 0; x < le.Board.Width; x++ {
 			le.Board.SetTile(x, y, island.TileEmpty)
@@ -187,6 +292,7 @@ func (le *LevelEditor) testLevel() {
 			}
 		}
 		le.IsPlaying = true
+		le.Playtest = newPlaytest(time.Now())
 	}
 }
 
@@ -202,10 +308,29 @@ func (le *LevelEditor) exportLevel() {
 	fmt.Println("Level exported:")
 	fmt.Println(string(jsonData))
 	
-	// Notify achievement system (this will be called from the game)
-	if le.OnLevelCreated != nil {
-		le.OnLevelCreated()
+	if le.levelID == "" {
+		le.levelID = fmt.Sprintf("custom_%d", time.Now().UnixNano())
+	}
+	le.events.Publish(events.LevelCreated{
+		LevelID: le.levelID,
+		Name:   levelData["name"].(string),
+		Width:  le.Board.Width,
+		Height: le.Board.Height,
+		Tiles:  levelData["tiles"].([][]int),
+	})
+}
+
+// CurrentBoard returns the board being tested, or the one being edited
+func (le *LevelEditor) CurrentBoard() *island.Board {
+	if le.IsPlaying && le.TestBoard != nil {
+		return le.TestBoard
 	}
+	return le.Board
+}
+
+// exportGraph asks the game to write the current board as a DOT graph
+func (le *LevelEditor) exportGraph() {
+	le.events.Publish(events.GraphExportRequested{Format: "dot"})
 }
 
 func (le *LevelEditor) createLevelData() map[string]interface{} {
@@ -237,16 +362,34 @@ func (le *LevelEditor) Draw(screen *ebiten.Image) {
 	
 	// Draw UI buttons
 	le.drawUI(screen)
+	if le.Status != "" {
+		ebitenutil.DebugPrintAt(screen, le.Status, 10, 70)
+	}
 	
-	// Draw grid
-	le.drawGrid(screen)
+	// Draw grid, clipped to the canvas
+	canvas := le.canvas(screen)
+	le.drawGrid(canvas)
+	if !le.IsPlaying && le.Playtest != nil {
+		le.Playtest.draw(canvas, le.tileRect)
+	}
+	if !le.IsPlaying {
+		le.drawSelection(canvas)
+		le.drawTemplatePreview(canvas)
+		le.drawCoAuthors(canvas)
+	}
 	
 	// Draw instructions
 	le.drawInstructions(screen)
+	le.drawCoEditChat(screen)
+	
+	if le.templatesOpen {
+		le.drawTemplates(screen)
+	}
+	le.confirm.Draw(screen)
 }
 
 func (le *LevelEditor) drawUI(screen *ebiten.Image) {
-	for _, btn := range le.UIButtons {
+	for _, btn := range slices.Concat(le.UIButtons, le.selectionButtons) {
 		// Button background
 		btnColor := btn.Color
 		if btn.Hovered {
@@ -290,6 +433,18 @@ func (le *LevelEditor) drawUI(screen *ebiten.Image) {
 }
 
 func (le *LevelEditor) getToolName() string {
+	switch {
+	case le.stamp != nil && le.stamp == le.clipboard:
+		return "Paste - click to place the copied tiles"
+	case le.stamp != nil:
+		return "Stamp " + le.stamp.Name
+	case le.selectMode:
+		return "Select - drag an area; drag inside it to move it"
+	case le.selecting && le.selectFrom == nil:
+		return "Save Selection - click the first corner"
+	case le.selecting:
+		return "Save Selection - click the opposite corner"
+	}
 	switch le.Tool {
 	case ToolLand:
 		return "Land"
@@ -307,11 +462,11 @@ func (le *LevelEditor) drawGrid(screen *ebiten.Image) {
 	if le.IsPlaying && le.TestBoard != nil {
 		board = le.TestBoard
 	}
+	le.camera.Fit(board.Width, board.Height)
 	
 	for y := 0; y < board.Height; y++ {
 		for x := 0; x < board.Width; x++ {
-			drawX := EditorGridX + x*EditorTileSize
-			drawY := EditorGridY + y*EditorTileSize
+			drawX, drawY, size, _ := le.tileRect(x, y, 1, 1)
 			
 			tile := board.GetTile(x, y)
 			tileColor := color.RGBA{200, 200, 200, 255} // Empty
@@ -330,8 +485,8 @@ func (le *LevelEditor) drawGrid(screen *ebiten.Image) {
 			// Draw tile
 			vector.DrawFilledRect(
 				screen,
-				float32(drawX), float32(drawY),
-				float32(EditorTileSize), float32(EditorTileSize),
+				drawX, drawY,
+				size, size,
 				tileColor,
 				false,
 			)
@@ -339,8 +494,8 @@ func (le *LevelEditor) drawGrid(screen *ebiten.Image) {
 			// Draw grid lines
 			vector.StrokeRect(
 				screen,
-				float32(drawX), float32(drawY),
-				float32(EditorTileSize), float32(EditorTileSize),
+				drawX, drawY,
+				size, size,
 				1,
 				color.RGBA{150, 150, 150, 255},
 				false,
@@ -354,14 +509,18 @@ func (le *LevelEditor) drawInstructions(screen *ebiten.Image) {
 		"Click tiles to paint with selected tool",
 		"Use Test button to play your level",
 		"Export saves level data to console",
+		"Zoom with the wheel or +/-, scroll with the arrow keys",
 	}
 	
 	for i, instruction := range instructions {
-		ebitenutil.DebugPrintAt(screen, instruction, 50, 450+i*15)
+		ebitenutil.DebugPrintAt(screen, instruction, 50, 420+i*15)
 	}
 	
 	if le.IsPlaying {
-		ebitenutil.DebugPrintAt(screen, "TEST MODE - Click Test again to return to editing", 50, 400)
+		ebitenutil.DebugPrintAt(screen, "TEST MODE - Click Test again to return to editing", 50, 385)
+	} else if le.Playtest != nil {
+		ebitenutil.DebugPrintAt(screen, le.Playtest.summary(), 50, 385)
+		ebitenutil.DebugPrintAt(screen, "Red: clicks  Numbers: first bridges  Seconds: pauses", 50, 400)
 	}
 }
 
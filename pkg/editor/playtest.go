@@ -0,0 +1,101 @@
+package editor
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+const (
+	playtestFirstBuilds = 5               // Bridges numbered on the heatmap, in the order built
+	playtestLongPause   = 3 * time.Second // Shortest pause before a click the heatmap labels
+)
+
+// Playtest records how a level was played in test mode: where the tester
+// clicked, the order bridges were built in and how long they paused before
+// each click. Long pauses and clicks on tiles that take no bridge show where a
+// level confuses players.
+type Playtest struct {
+	Clicks    map[island.Point]int
+	Builds    []island.Point
+	Pauses    map[island.Point]time.Duration // Longest pause before a click on each tile
+	lastClick time.Time
+}
+
+func newPlaytest(start time.Time) *Playtest {
+	return &Playtest{
+		Clicks:    make(map[island.Point]int),
+		Pauses:    make(map[island.Point]time.Duration),
+		lastClick: start,
+	}
+}
+
+// record notes a click on a tile at the given time and whether it built a bridge
+func (pt *Playtest) record(x, y int, built bool, at time.Time) {
+	point := island.Point{X: x, Y: y}
+	pt.Clicks[point]++
+	if pause := at.Sub(pt.lastClick); pause > pt.Pauses[point] {
+		pt.Pauses[point] = pause
+	}
+	pt.lastClick = at
+	if built {
+		pt.Builds = append(pt.Builds, point)
+	}
+}
+
+// longestPause finds the tile clicked after the longest pause
+func (pt *Playtest) longestPause() (island.Point, time.Duration) {
+	var at island.Point
+	var longest time.Duration
+	for point, pause := range pt.Pauses {
+		if pause > longest {
+			at, longest = point, pause
+		}
+	}
+	return at, longest
+}
+
+// summary describes the playtest in one line under the grid
+func (pt *Playtest) summary() string {
+	clicks := 0
+	for _, count := range pt.Clicks {
+		clicks += count
+	}
+	text := fmt.Sprintf("Playtest: %d clicks, %d bridges", clicks, len(pt.Builds))
+	if at, pause := pt.longestPause(); pause >= playtestLongPause {
+		text += fmt.Sprintf(", longest pause %ds before (%d,%d)", int(pause.Seconds()), at.X, at.Y)
+	}
+	return text
+}
+
+// draw overlays the heatmap on the editor grid: red for clicks, darker where
+// there were more, the first bridges numbered in the order built and long
+// pauses in seconds. tileRect places tiles on the screen.
+func (pt *Playtest) draw(screen *ebiten.Image, tileRect func(x, y, width, height int) (float32, float32, float32, float32)) {
+	most := 0
+	for _, count := range pt.Clicks {
+		most = max(most, count)
+	}
+	for point, count := range pt.Clicks {
+		alpha := uint8(40 + 160*count/most)
+		x, y, width, height := tileRect(point.X, point.Y, 1, 1)
+		vector.DrawFilledRect(screen, x, y, width, height, color.RGBA{220, 40, 40, alpha}, false)
+	}
+
+	for point, pause := range pt.Pauses {
+		if pause >= playtestLongPause {
+			label := fmt.Sprintf("%ds", int(pause.Seconds()))
+			x, y, _, height := tileRect(point.X, point.Y, 1, 1)
+			ebitenutil.DebugPrintAt(screen, label, int(x)+2, int(y+height)-14)
+		}
+	}
+	for i, point := range pt.Builds[:min(len(pt.Builds), playtestFirstBuilds)] {
+		x, y, _, _ := tileRect(point.X, point.Y, 1, 1)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", i+1), int(x)+2, int(y)+2)
+	}
+}
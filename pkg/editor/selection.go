@@ -0,0 +1,250 @@
+package editor
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// vacatedTile fills the tiles left behind by Cut and by moving a selection
+const vacatedTile = island.TileSea
+
+// Selection toolbar, a column right of the grid
+const (
+	selectionBarX       = EditorGridX + EditorViewWidth + 10
+	selectionBarY       = EditorGridY
+	selectionButtonW    = 62
+	selectionButtonH    = 26
+	selectionButtonStep = 30
+)
+
+var selectionColor = color.RGBA{0, 200, 230, 255}
+
+func (le *LevelEditor) setupSelectionBar() {
+	buttons := []struct {
+		text    string
+		action  func()
+		tooltip string
+	}{
+		{"Select", le.toggleSelect, "Drag over the grid to select an\narea; drag inside it to move it"},
+		{"Copy", le.copySelection, "Copy the selected tiles"},
+		{"Cut", le.cutSelection, "Copy the selected tiles and\nreplace them with sea"},
+		{"Paste", le.paste, "Click the grid to place the\ncopied tiles, as often as needed"},
+		{"Rotate", func() { le.transformClipboard(rotateTiles) }, "Turn the copied tiles a\nquarter clockwise"},
+		{"Flip H", func() { le.transformClipboard(flipTilesHorizontal) }, "Mirror the copied tiles left to right"},
+		{"Flip V", func() { le.transformClipboard(flipTilesVertical) }, "Mirror the copied tiles top to bottom"},
+		{"Fit", le.fitView, "Zoom and scroll to show the\nwhole level"},
+		{"Resize", le.resizeBoard, "Switch between 16x12, 24x18\nand 32x24 levels"},
+		{"Co-edit", le.toggleCoEdit, "Edit the level together online;\nclick again to edit alone"},
+	}
+	for i, btn := range buttons {
+		le.selectionButtons = append(le.selectionButtons, &UIButton{
+			Text:    btn.text,
+			X:       selectionBarX,
+			Y:       float64(selectionBarY + i*selectionButtonStep),
+			Width:   selectionButtonW,
+			Height:  selectionButtonH,
+			Action:  btn.action,
+			Color:   color.RGBA{150, 220, 235, 255},
+			Tooltip: btn.tooltip,
+		})
+	}
+}
+
+// toggleSelect switches between the Select tool and painting
+func (le *LevelEditor) toggleSelect() {
+	selecting := !le.selectMode
+	le.setTool(le.Tool)
+	le.selectMode = selecting
+}
+
+// tilesIn copies the board's tiles in a rectangle
+func (le *LevelEditor) tilesIn(rect image.Rectangle) [][]int {
+	tiles := make([][]int, rect.Dy())
+	for y := range tiles {
+		tiles[y] = make([]int, rect.Dx())
+		for x := range tiles[y] {
+			tiles[y][x] = int(le.Board.GetTile(rect.Min.X+x, rect.Min.Y+y).Type)
+		}
+	}
+	return tiles
+}
+
+// fill sets every tile in a rectangle to one type
+func (le *LevelEditor) fill(rect image.Rectangle, tile island.TileType) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			le.Board.SetTile(x, y, tile)
+		}
+	}
+}
+
+// place paints tiles with their top-left corner on a tile, clipped to the board
+func (le *LevelEditor) place(tiles [][]int, gridX, gridY int) {
+	le.Playtest = nil
+	for y, row := range tiles {
+		for x, tile := range row {
+			if tile != templateKeep && le.Board.GetTile(gridX+x, gridY+y) != nil {
+				le.Board.SetTile(gridX+x, gridY+y, island.TileType(tile))
+			}
+		}
+	}
+}
+
+func (le *LevelEditor) copySelection() {
+	if le.selection == nil {
+		le.Status = "Select an area to copy first"
+		return
+	}
+	le.clipboard = &Template{Name: "clipboard", Tiles: le.tilesIn(*le.selection)}
+	le.Status = "Copied the selection"
+}
+
+func (le *LevelEditor) cutSelection() {
+	if le.selection == nil {
+		le.Status = "Select an area to cut first"
+		return
+	}
+	le.copySelection()
+	le.Playtest = nil
+	le.fill(*le.selection, vacatedTile)
+	le.Status = "Cut the selection"
+}
+
+// paste stamps the clipboard on the next grid clicks
+func (le *LevelEditor) paste() {
+	if le.clipboard == nil {
+		le.Status = "Copy or cut an area first"
+		return
+	}
+	le.setTool(le.Tool)
+	le.stamp = le.clipboard
+}
+
+// transformClipboard rotates or flips the copied tiles, including while pasting them
+func (le *LevelEditor) transformClipboard(transform func([][]int) [][]int) {
+	if le.clipboard == nil {
+		le.Status = "Copy or cut an area first"
+		return
+	}
+	le.clipboard.Tiles = transform(le.clipboard.Tiles)
+}
+
+func rotateTiles(tiles [][]int) [][]int {
+	height, width := len(tiles), len(tiles[0])
+	rotated := make([][]int, width)
+	for y := range rotated {
+		rotated[y] = make([]int, height)
+		for x := range rotated[y] {
+			rotated[y][x] = tiles[height-1-x][y]
+		}
+	}
+	return rotated
+}
+
+func flipTilesHorizontal(tiles [][]int) [][]int {
+	flipped := make([][]int, len(tiles))
+	for y, row := range tiles {
+		flipped[y] = make([]int, len(row))
+		for x, tile := range row {
+			flipped[y][len(row)-1-x] = tile
+		}
+	}
+	return flipped
+}
+
+func flipTilesVertical(tiles [][]int) [][]int {
+	flipped := make([][]int, len(tiles))
+	for y, row := range tiles {
+		flipped[len(tiles)-1-y] = append([]int(nil), row...)
+	}
+	return flipped
+}
+
+// startDrag begins a drag with the Select tool: inside the selection it moves
+// the selection, anywhere else it selects a new area
+func (le *LevelEditor) startDrag(gridX, gridY int) {
+	point := island.Point{X: gridX, Y: gridY}
+	le.dragFrom = &point
+	le.dragTo = point
+	le.dragMove = le.selection != nil && image.Pt(gridX, gridY).In(*le.selection)
+	if !le.dragMove {
+		le.selection = nil
+	}
+}
+
+// updateDrag follows the pointer while the button is held and finishes the
+// drag when it is released
+func (le *LevelEditor) updateDrag(mouseX, mouseY int, down bool) {
+	if le.dragFrom == nil {
+		return
+	}
+	gridX, gridY := le.tileAt(mouseX, mouseY)
+	le.dragTo = island.Point{
+		X: max(0, min(gridX, le.Board.Width-1)),
+		Y: max(0, min(gridY, le.Board.Height-1)),
+	}
+	if down {
+		return
+	}
+
+	if le.dragMove {
+		le.moveSelection(le.dragTo.X-le.dragFrom.X, le.dragTo.Y-le.dragFrom.Y)
+	} else {
+		selection := le.dragRect()
+		le.selection = &selection
+	}
+	le.dragFrom = nil
+}
+
+// dragRect is the area between where the drag started and the pointer
+func (le *LevelEditor) dragRect() image.Rectangle {
+	from, to := le.dragFrom, le.dragTo
+	return image.Rect(min(from.X, to.X), min(from.Y, to.Y), max(from.X, to.X)+1, max(from.Y, to.Y)+1)
+}
+
+// moveSelection moves the selected tiles, leaving sea behind; tiles moved off
+// the board are lost
+func (le *LevelEditor) moveSelection(dx, dy int) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	tiles := le.tilesIn(*le.selection)
+	le.fill(*le.selection, vacatedTile)
+	moved := le.selection.Add(image.Pt(dx, dy))
+	le.place(tiles, moved.Min.X, moved.Min.Y)
+	moved = moved.Intersect(image.Rect(0, 0, le.Board.Width, le.Board.Height))
+	if moved.Empty() {
+		le.selection = nil
+	} else {
+		le.selection = &moved
+	}
+}
+
+// drawSelection outlines the selection, following a drag in progress
+func (le *LevelEditor) drawSelection(screen *ebiten.Image) {
+	var outline image.Rectangle
+	switch {
+	case le.dragFrom != nil && !le.dragMove:
+		outline = le.dragRect()
+	case le.selection == nil:
+		return
+	case le.dragFrom != nil:
+		outline = le.selection.Add(image.Pt(le.dragTo.X-le.dragFrom.X, le.dragTo.Y-le.dragFrom.Y))
+		for y, row := range le.tilesIn(*le.selection) {
+			for x, tile := range row {
+				if le.Board.GetTile(outline.Min.X+x, outline.Min.Y+y) != nil {
+					tileX, tileY, width, height := le.tileRect(outline.Min.X+x, outline.Min.Y+y, 1, 1)
+					vector.DrawFilledRect(screen, tileX, tileY, width, height, previewColor(island.TileType(tile)), false)
+				}
+			}
+		}
+	default:
+		outline = *le.selection
+	}
+	x, y, width, height := le.tileRect(outline.Min.X, outline.Min.Y, outline.Dx(), outline.Dy())
+	vector.StrokeRect(screen, x, y, width, height, 2, selectionColor, false)
+}
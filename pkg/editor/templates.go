@@ -0,0 +1,253 @@
+package editor
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// templateKeep marks template cells that leave the board's tile as it is
+const templateKeep = -1
+
+// Template is a piece of level stamped onto the editor board, top-left corner
+// first. Built-in templates have no ID.
+type Template struct {
+	ID    string
+	Name  string
+	Tiles [][]int // Rows of island.TileType, or templateKeep
+}
+
+// Size returns the template's width and height in tiles
+func (t *Template) Size() (int, int) {
+	if len(t.Tiles) == 0 {
+		return 0, 0
+	}
+	return len(t.Tiles[0]), len(t.Tiles)
+}
+
+// prefabTemplate builds a template from rows drawn with L for land, ~ for sea,
+// # for empty and . for tiles left alone
+func prefabTemplate(name string, rows ...string) *Template {
+	tiles := make([][]int, len(rows))
+	for y, row := range rows {
+		tiles[y] = make([]int, len(row))
+		for x, cell := range row {
+			tiles[y][x] = strings.IndexRune("#L~", cell) // templateKeep for anything else
+		}
+	}
+	return &Template{Name: name, Tiles: tiles}
+}
+
+// prefabs are the built-in templates
+var prefabs = []*Template{
+	prefabTemplate("Island cluster",
+		"LL~LL",
+		"L~~~L",
+		"~~L~~",
+		"L~~~L",
+		"LL~LL",
+	),
+	prefabTemplate("Ring",
+		"L~L~L",
+		"~~~~~",
+		"L~~~L",
+		"~~~~~",
+		"L~L~L",
+	),
+	prefabTemplate("Cross",
+		"..L..",
+		"..~..",
+		"L~L~L",
+		"..~..",
+		"..L..",
+	),
+	prefabTemplate("Archipelago",
+		"L~~L~~L",
+		"~~~~~~~",
+		"~L~~~L~",
+		"~~~~~~~",
+		"L~~L~~L",
+	),
+}
+
+// Templates panel layout
+const (
+	templatesX         = 150
+	templatesY         = 80
+	templatesWidth     = 340
+	templatesHeight    = 320
+	templateRowY       = templatesY + 35
+	templateRowHeight  = 22
+	maxTemplateRows    = 10
+	templateDeleteSize = 16
+	templateButtonY    = templatesY + templatesHeight - 40
+)
+
+// SetUserTemplates replaces the saved templates offered after the built-in ones
+func (le *LevelEditor) SetUserTemplates(saved []storage.EditorTemplate) {
+	le.userTemplates = le.userTemplates[:0]
+	for _, template := range saved {
+		le.userTemplates = append(le.userTemplates, &Template{ID: template.ID, Name: template.Name, Tiles: template.Tiles})
+	}
+}
+
+// listedTemplates are the templates in the panel: the built-in ones, then the
+// newest saved ones that fit
+func (le *LevelEditor) listedTemplates() []*Template {
+	listed := append([]*Template{}, prefabs...)
+	newest := le.userTemplates[max(0, len(le.userTemplates)-(maxTemplateRows-len(prefabs))):]
+	for i := len(newest) - 1; i >= 0; i-- {
+		listed = append(listed, newest[i])
+	}
+	return listed
+}
+
+// toggleTemplates opens or closes the templates panel
+func (le *LevelEditor) toggleTemplates() {
+	le.templatesOpen = !le.templatesOpen
+}
+
+// handleTemplatesClick handles a click while the panel is open; it takes every click
+func (le *LevelEditor) handleTemplatesClick(x, y int) {
+	for i, template := range le.listedTemplates() {
+		rowY := templateRowY + i*templateRowHeight
+		if template.ID != "" && inRect(x, y, templatesX+templatesWidth-30, rowY+2, templateDeleteSize, templateDeleteSize) {
+			le.confirm.Confirm(fmt.Sprintf("Delete the template %q?", template.Name), "Delete", func() {
+				le.events.Publish(events.TemplateDeleted{ID: template.ID})
+			})
+			return
+		}
+		if inRect(x, y, templatesX+10, rowY, templatesWidth-50, templateRowHeight-2) {
+			le.setTool(le.Tool)
+			le.stamp = template
+			le.templatesOpen = false
+			return
+		}
+	}
+	switch {
+	case inRect(x, y, templatesX+20, templateButtonY, 140, 28):
+		le.templatesOpen = false
+		if le.selection != nil {
+			le.saveTemplate(*le.selection)
+			return
+		}
+		le.setTool(le.Tool)
+		le.selecting = true
+	case inRect(x, y, templatesX+templatesWidth-120, templateButtonY, 100, 28),
+		!inRect(x, y, templatesX, templatesY, templatesWidth, templatesHeight):
+		le.templatesOpen = false
+	}
+}
+
+// selectTile marks one corner of the area to save; the second corner saves it
+func (le *LevelEditor) selectTile(gridX, gridY int) {
+	if le.selectFrom == nil {
+		le.selectFrom = &island.Point{X: gridX, Y: gridY}
+		return
+	}
+	from := le.selectFrom
+	le.selecting = false
+	le.selectFrom = nil
+	le.saveTemplate(image.Rect(min(from.X, gridX), min(from.Y, gridY), max(from.X, gridX)+1, max(from.Y, gridY)+1))
+}
+
+// saveTemplate saves the tiles in an area as a new template
+func (le *LevelEditor) saveTemplate(area image.Rectangle) {
+	name := fmt.Sprintf("Template %d", len(le.userTemplates)+1)
+	le.events.Publish(events.TemplateSaved{Name: name, Tiles: le.tilesIn(area)})
+	le.Status = fmt.Sprintf("Saved %s (%dx%d)", name, area.Dx(), area.Dy())
+}
+
+// drawTemplatePreview outlines the stamp or the selection under the pointer
+func (le *LevelEditor) drawTemplatePreview(screen *ebiten.Image) {
+	gridX, gridY, ok := le.gridAt(le.hoverX, le.hoverY)
+	if !ok {
+		return
+	}
+
+	if le.stamp != nil {
+		for y, row := range le.stamp.Tiles {
+			for x, tile := range row {
+				if tile == templateKeep || le.Board.GetTile(gridX+x, gridY+y) == nil {
+					continue
+				}
+				tileX, tileY, width, height := le.tileRect(gridX+x, gridY+y, 1, 1)
+				vector.DrawFilledRect(screen, tileX, tileY, width, height, previewColor(island.TileType(tile)), false)
+			}
+		}
+		return
+	}
+	if le.selecting {
+		fromX, fromY := gridX, gridY
+		if le.selectFrom != nil {
+			fromX, fromY = le.selectFrom.X, le.selectFrom.Y
+		}
+		left, top := min(fromX, gridX), min(fromY, gridY)
+		width, height := max(fromX, gridX)-left+1, max(fromY, gridY)-top+1
+		x, y, w, h := le.tileRect(left, top, width, height)
+		vector.StrokeRect(screen, x, y, w, h, 3, color.RGBA{255, 200, 0, 255}, false)
+	}
+}
+
+// previewColor is a tile's editor colour, see-through so the board shows beneath
+func previewColor(tile island.TileType) color.Color {
+	switch tile {
+	case island.TileLand:
+		return color.RGBA{139, 195, 74, 180}
+	case island.TileSea:
+		return color.RGBA{64, 164, 223, 180}
+	default:
+		return color.RGBA{200, 200, 200, 180}
+	}
+}
+
+// drawTemplates draws the templates panel
+func (le *LevelEditor) drawTemplates(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 100}, false)
+	vector.DrawFilledRect(screen, templatesX, templatesY, templatesWidth, templatesHeight, color.RGBA{250, 250, 250, 255}, false)
+	vector.StrokeRect(screen, templatesX, templatesY, templatesWidth, templatesHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Templates - pick one, then click the grid to stamp it", templatesX+10, templatesY+12)
+
+	for i, template := range le.listedTemplates() {
+		rowY := templateRowY + i*templateRowHeight
+		rowColor := color.RGBA{225, 225, 225, 255}
+		if inRect(le.hoverX, le.hoverY, templatesX+10, rowY, templatesWidth-50, templateRowHeight-2) {
+			rowColor = color.RGBA{200, 220, 250, 255}
+		}
+		vector.DrawFilledRect(screen, templatesX+10, float32(rowY), templatesWidth-50, templateRowHeight-2, rowColor, false)
+		width, height := template.Size()
+		label := fmt.Sprintf("%s  %dx%d", template.Name, width, height)
+		if template.ID == "" {
+			label += "  (built-in)"
+		}
+		ebitenutil.DebugPrintAt(screen, label, templatesX+16, rowY+4)
+		if template.ID != "" {
+			vector.DrawFilledRect(screen, templatesX+templatesWidth-30, float32(rowY+2), templateDeleteSize, templateDeleteSize, color.RGBA{220, 110, 110, 255}, false)
+			ebitenutil.DebugPrintAt(screen, "x", templatesX+templatesWidth-25, rowY+3)
+		}
+	}
+
+	le.drawPanelButton(screen, templatesX+20, templateButtonY, 140, "Save Selection", color.RGBA{255, 200, 100, 255})
+	le.drawPanelButton(screen, templatesX+templatesWidth-120, templateButtonY, 100, "Close", color.RGBA{180, 180, 180, 255})
+}
+
+func (le *LevelEditor) drawPanelButton(screen *ebiten.Image, x, y, width int, text string, bgColor color.RGBA) {
+	if inRect(le.hoverX, le.hoverY, x, y, width, 28) {
+		bgColor.R, bgColor.G, bgColor.B = uint8(min(255, int(bgColor.R)+30)), uint8(min(255, int(bgColor.G)+30)), uint8(min(255, int(bgColor.B)+30))
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), 28, bgColor, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(width), 28, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, text, x+(width-len(text)*6)/2, y+10)
+}
+
+func inRect(x, y, rx, ry, width, height int) bool {
+	return x >= rx && x <= rx+width && y >= ry && y <= ry+height
+}
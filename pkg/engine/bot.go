@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// Bot chooses moves for a headless game
+type Bot interface {
+	NextMove(e *Engine) (solver.Move, bool)
+}
+
+// SolverBot always plays the solver's next move
+type SolverBot struct{}
+
+func (SolverBot) NextMove(e *Engine) (solver.Move, bool) {
+	return solver.NextMove(e.Board)
+}
+
+// RandomBot plays a uniformly random valid move
+type RandomBot struct {
+	Rand *rand.Rand
+}
+
+func (b RandomBot) NextMove(e *Engine) (solver.Move, bool) {
+	moves := e.ValidMoves()
+	if len(moves) == 0 {
+		return solver.Move{}, false
+	}
+	return moves[b.Rand.Intn(len(moves))], true
+}
+
+// Run lets the bot play until the game ends, the bot gives up, or maxMoves is
+// reached (0 means no limit). Each move advances the clock by moveTime.
+func (e *Engine) Run(bot Bot, moveTime time.Duration, maxMoves int) State {
+	for !e.IsOver() {
+		if maxMoves > 0 && e.moves >= maxMoves {
+			break
+		}
+
+		move, ok := bot.NextMove(e)
+		if !ok {
+			break
+		}
+
+		e.Advance(moveTime)
+		if e.IsOver() {
+			break
+		}
+
+		if err := e.Apply(move.X, move.Y); err != nil {
+			break
+		}
+	}
+
+	return e.State()
+}
@@ -0,0 +1,128 @@
+// Package engine runs Island Merge games without any rendering or input
+// dependencies, so bots, balancing scripts and fuzzers can drive the rules directly.
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+var (
+	ErrInvalidMove = errors.New("engine: bridge cannot be built there")
+	ErrGameOver    = errors.New("engine: game is already over")
+)
+
+// State is a snapshot of a running game
+type State struct {
+	Moves        int
+	Elapsed      time.Duration
+	TimeLimit    time.Duration
+	IslandGroups int
+	Won          bool
+	Lost         bool
+	Stars        int
+}
+
+// Engine owns a board and applies moves to it under the normal game rules
+type Engine struct {
+	Level   *levels.LevelData
+	Board   *island.Board
+	History []solver.Move
+
+	moves     int
+	elapsed   time.Duration
+	timeLimit time.Duration
+	won       bool
+	lost      bool
+}
+
+// New creates a game from level data
+func New(level *levels.LevelData) *Engine {
+	e := &Engine{
+		Level:     level,
+		Board:     level.NewBoard(),
+		History:   make([]solver.Move, 0),
+		timeLimit: level.TimeLimit,
+	}
+	e.won = e.Board.IsSolved()
+	return e
+}
+
+// NewFromBoard creates a game from an existing board; the board is cloned
+func NewFromBoard(board *island.Board, timeLimit time.Duration) *Engine {
+	e := &Engine{
+		Board:     board.Clone(),
+		History:   make([]solver.Move, 0),
+		timeLimit: timeLimit,
+	}
+	e.won = e.Board.IsSolved()
+	return e
+}
+
+// Apply builds a bridge at (x, y)
+func (e *Engine) Apply(x, y int) error {
+	if e.IsOver() {
+		return ErrGameOver
+	}
+	if !e.Board.CanBuildBridge(x, y) {
+		return ErrInvalidMove
+	}
+
+	e.Board.BuildBridge(x, y)
+	e.moves++
+	e.History = append(e.History, solver.Move{X: x, Y: y})
+
+	if e.Board.IsSolved() {
+		e.won = true
+	}
+	return nil
+}
+
+// Advance moves the simulated clock forward and applies the time limit
+func (e *Engine) Advance(d time.Duration) {
+	if e.IsOver() {
+		return
+	}
+
+	e.elapsed += d
+	if e.timeLimit > 0 && e.elapsed >= e.timeLimit {
+		e.lost = true
+	}
+}
+
+func (e *Engine) IsOver() bool {
+	return e.won || e.lost
+}
+
+// ValidMoves lists every tile a bridge can currently be built on
+func (e *Engine) ValidMoves() []solver.Move {
+	moves := make([]solver.Move, 0)
+	for y := 0; y < e.Board.Height; y++ {
+		for x := 0; x < e.Board.Width; x++ {
+			if e.Board.CanBuildBridge(x, y) {
+				moves = append(moves, solver.Move{X: x, Y: y})
+			}
+		}
+	}
+	return moves
+}
+
+func (e *Engine) State() State {
+	state := State{
+		Moves:        e.moves,
+		Elapsed:      e.elapsed,
+		TimeLimit:    e.timeLimit,
+		IslandGroups: e.Board.IslandGroupCount(),
+		Won:          e.won,
+		Lost:         e.lost,
+	}
+
+	if e.won && e.Level != nil {
+		state.Stars = levels.CalculateStars(e.Level, e.moves, e.elapsed)
+	}
+	return state
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/replay"
+)
+
+// Claim is the result a finished game submits to a leaderboard
+type Claim struct {
+	Moves int
+	Time  time.Duration
+	Stars int
+}
+
+// Verify replays a game headlessly on a copy of its starting board and checks
+// that it supports the claimed result: every bridge can be built where it was,
+// the board ends solved, and the claimed moves, time and stars are no better
+// than the replay allows. level may be nil for games without one.
+//
+// Replays restart when a saved game is resumed, and repairs are not recorded,
+// so a claim may count more moves than its replay, never fewer. Each bridge a
+// resumed game starts with over the level's sea counts as one move.
+func Verify(r *replay.Replay, level *levels.LevelData, claim Claim) error {
+	if r == nil || r.Start == nil {
+		return errors.New("engine: no replay to verify")
+	}
+	moves := 0
+	if level != nil {
+		built, ok := startBridges(r.Start, level)
+		if !ok {
+			return fmt.Errorf("engine: replay starts on a different board than %q", level.ID)
+		}
+		moves = built
+	}
+
+	board := r.Start.Clone()
+	var last time.Duration
+	for i, move := range r.Moves {
+		if move.At < last {
+			return fmt.Errorf("engine: move %d goes back in time", i+1)
+		}
+		last = move.At
+
+		if move.Removed {
+			if !board.RemoveBridge(move.X, move.Y) {
+				return fmt.Errorf("engine: move %d removes a bridge that is not there", i+1)
+			}
+			if !move.Forced {
+				moves++
+			}
+			continue
+		}
+		if !board.CanBuildBridge(move.X, move.Y) {
+			return fmt.Errorf("engine: move %d builds a bridge at (%d, %d) the rules forbid", i+1, move.X, move.Y)
+		}
+		board.BuildBridge(move.X, move.Y)
+		moves++
+	}
+
+	if !board.IsSolved() {
+		return errors.New("engine: replay does not solve the board")
+	}
+	if claim.Moves < moves {
+		return fmt.Errorf("engine: claims %d moves, replay takes %d", claim.Moves, moves)
+	}
+	if claim.Time < last {
+		return fmt.Errorf("engine: claims %s, replay takes %s", claim.Time, last)
+	}
+	if level != nil && claim.Stars > levels.CalculateStars(level, claim.Moves, claim.Time) {
+		return fmt.Errorf("engine: claims %d stars for %d moves in %s", claim.Stars, claim.Moves, claim.Time)
+	}
+	return nil
+}
+
+// startBridges counts the bridges a board has over the level's sea, built in
+// a resumed game before its replay began. It reports false when the board is
+// not the level's layout.
+func startBridges(board *island.Board, level *levels.LevelData) (int, bool) {
+	start := level.NewBoard()
+	if board.Width != start.Width || board.Height != start.Height {
+		return 0, false
+	}
+	built := 0
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			got, want := board.GetTile(x, y).Type, start.GetTile(x, y).Type
+			switch {
+			case got == want:
+			case got == island.TileBridge && want == island.TileSea:
+				built++
+			default:
+				return 0, false
+			}
+		}
+	}
+	return built, true
+}
@@ -0,0 +1,51 @@
+// Package events provides the typed game-event bus that decouples gameplay from
+// achievements, saving and other subscribers.
+package events
+
+import (
+	"reflect"
+)
+
+// Bus delivers events to subscribers synchronously and in a deterministic order:
+// handlers run in subscription order, and events published from inside a handler
+// are queued and delivered after the current event has finished dispatching.
+type Bus struct {
+	handlers    map[reflect.Type][]func(any)
+	queue       []any
+	dispatching bool
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[reflect.Type][]func(any)),
+		queue:    make([]any, 0),
+	}
+}
+
+// Subscribe registers a handler for every event of type T
+func Subscribe[T any](bus *Bus, handler func(T)) {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	bus.handlers[eventType] = append(bus.handlers[eventType], func(event any) {
+		handler(event.(T))
+	})
+}
+
+// Publish delivers an event to all subscribers of its type
+func (b *Bus) Publish(event any) {
+	b.queue = append(b.queue, event)
+	if b.dispatching {
+		return
+	}
+
+	b.dispatching = true
+	defer func() { b.dispatching = false }()
+
+	for len(b.queue) > 0 {
+		next := b.queue[0]
+		b.queue = b.queue[1:]
+
+		for _, handler := range b.handlers[reflect.TypeOf(next)] {
+			handler(next)
+		}
+	}
+}
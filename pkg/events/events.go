@@ -0,0 +1,124 @@
+package events
+
+import (
+	"time"
+)
+
+// GameStarted is published when a new game or level begins
+type GameStarted struct {
+	Mode    int
+	LevelID string // Empty for mode games without level data
+}
+
+// BridgeBuilt is published after the player places a bridge
+type BridgeBuilt struct {
+	X, Y  int
+	Moves int
+	At    time.Duration // Game time of the move
+}
+
+// IslandsMerged is published after BridgeBuilt when the bridge joined separate
+// island groups; Merged is how many fewer groups there are
+type IslandsMerged struct {
+	X, Y   int
+	Merged int
+}
+
+// BridgeRepaired is published when a damaged bridge is restored to full health
+type BridgeRepaired struct {
+	X, Y int
+	At   time.Duration
+}
+
+// BridgeCollapsed is published when a hazard destroys a bridge
+type BridgeCollapsed struct {
+	X, Y int
+	At   time.Duration
+}
+
+// BridgeRemoved is published when a bridge tile is taken away
+type BridgeRemoved struct {
+	X, Y int
+	At   time.Duration
+}
+
+// GameWon is published once when all islands become connected
+type GameWon struct {
+	Mode         int
+	LevelID      string
+	Moves        int
+	Time         time.Duration
+	Points       int
+	MoveTimes    []time.Duration // Thinking time spent on each move
+	Mutators     int             // Mutators the game was played under
+	IsTimeAttack bool
+	IsPerfect    bool
+}
+
+// GameLost is published when a game ends without a win
+type GameLost struct {
+	Mode    int
+	LevelID string
+	Moves   int
+	Reason  string
+}
+
+// HintShown is published when the player asks for a hint
+type HintShown struct {
+	Mode    int
+	LevelID string
+	Moves   int
+}
+
+// LevelCompleted is published after a level's stars and progress are recorded
+type LevelCompleted struct {
+	LevelID string
+	Moves   int
+	Time    time.Duration
+	Stars   int
+}
+
+// LevelCreated is published when the editor exports a level. Exporting the
+// same level again keeps its ID, and saves a new version of it.
+type LevelCreated struct {
+	LevelID       string
+	Name          string
+	Width, Height int
+	Tiles         [][]int
+}
+
+// TemplateSaved is published when the editor saves part of its board as a template
+type TemplateSaved struct {
+	Name  string
+	Tiles [][]int
+}
+
+// TemplateDeleted is published when the editor deletes a saved template
+type TemplateDeleted struct {
+	ID string
+}
+
+// GraphExportRequested asks the game to export the editor's board as a graph
+type GraphExportRequested struct {
+	Format string // "dot" or "graphml"
+}
+
+// CoEditRequested asks the game to share the editor's level with other
+// authors through a new online session
+type CoEditRequested struct{}
+
+// SaveRequested asks the game to save the current session
+type SaveRequested struct{}
+
+// LoadRequested asks the game to restore the saved session
+type LoadRequested struct{}
+
+// TutorialRequested asks the game to (re)play the tutorial
+type TutorialRequested struct{}
+
+// ProfileSelectRequested asks the game to show the profile picker
+type ProfileSelectRequested struct{}
+
+// SaveDataImported tells the game that imported data replaced the active
+// profile's, so it reloads its achievements and progress
+type SaveDataImported struct{}
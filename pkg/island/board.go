@@ -13,12 +13,30 @@ type Tile struct {
 	Type TileType
 }
 
+// MaxBridgeHealth is the durability of a new or repaired bridge
+const MaxBridgeHealth = 3
+
+// BridgeDamage is attached to a bridge worn below MaxBridgeHealth; bridges
+// without it are at full health
+type BridgeDamage struct {
+	Health int `json:"health"`
+}
+
+func (BridgeDamage) Kind() ComponentKind { return "bridge_damage" }
+
+func init() {
+	RegisterComponent[BridgeDamage]()
+}
+
 type Board struct {
 	Width     int
 	Height    int
 	Tiles     []Tile
 	UnionFind *UnionFind
 	Islands   []int // Indices of land tiles
+	
+	components map[ComponentKind]map[int]Component // Per-tile extras by kind, then tile index
+	rules      Rules // Placement and win rules; nil plays by ConnectAll
 }
 
 func NewBoard(width, height int) *Board {
@@ -43,58 +61,144 @@ func (b *Board) GetTile(x, y int) *Tile {
 	return &b.Tiles[y*b.Width+x]
 }
 
+// SetTile replaces a tile, dropping any components it had
 func (b *Board) SetTile(x, y int, tileType TileType) {
 	if x < 0 || x >= b.Width || y < 0 || y >= b.Height {
 		return
 	}
 	idx := y*b.Width + x
 	b.Tiles[idx].Type = tileType
+	b.clearComponents(idx)
 	
 	if tileType == TileLand {
 		b.Islands = append(b.Islands, idx)
 	}
+	if tileType == TileLand || tileType == TileBridge {
+		b.connectNeighbors(x, y)
+	}
 }
 
-func (b *Board) CanBuildBridge(x, y int) bool {
-	tile := b.GetTile(x, y)
-	if tile == nil || tile.Type != TileSea {
-		return false
-	}
-	
-	// Check if adjacent to land or bridge
+// connects reports whether a tile joins the tiles it touches: bridges join
+// whatever they touch, and land merges with neighbouring land under MergeLand
+func connects(tile *Tile) bool {
+	return tile != nil && (tile.Type == TileLand || tile.Type == TileBridge)
+}
+
+// connectNeighbors unions a land or bridge tile with the land and bridges
+// around it. Land joins touching land only under rules that merge land.
+func (b *Board) connectNeighbors(x, y int) {
+	idx := y*b.Width + x
+	landOnly := b.Tiles[idx].Type == TileLand && !b.Rules().MergesLand()
 	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-	hasConnection := false
-	
 	for _, dir := range directions {
 		nx, ny := x+dir[0], y+dir[1]
 		neighbor := b.GetTile(nx, ny)
-		if neighbor != nil && (neighbor.Type == TileLand || neighbor.Type == TileBridge) {
-			hasConnection = true
-			break
+		if !connects(neighbor) || (landOnly && neighbor.Type == TileLand) {
+			continue
 		}
+		b.UnionFind.Union(idx, ny*b.Width+nx)
 	}
-	
-	return hasConnection
+}
+
+// RebuildConnectivity recomputes the union-find from the tiles, for boards whose
+// tiles changed in ways union-find cannot follow
+func (b *Board) RebuildConnectivity() {
+	b.UnionFind = NewUnionFind(b.Width * b.Height)
+	for y := 0; y < b.Height; y++ {
+		for x := 0; x < b.Width; x++ {
+			if connects(b.GetTile(x, y)) {
+				b.connectNeighbors(x, y)
+			}
+		}
+	}
+}
+
+// CanBuildBridge reports whether the board's rules allow a bridge at (x, y)
+func (b *Board) CanBuildBridge(x, y int) bool {
+	return b.Rules().CanPlace(b, x, y)
 }
 
 func (b *Board) BuildBridge(x, y int) {
-	if !b.CanBuildBridge(x, y) {
+	rules := b.Rules()
+	if !rules.CanPlace(b, x, y) {
 		return
 	}
 	
+	// Setting the tile connects it with adjacent land and bridges
 	b.SetTile(x, y, TileBridge)
-	idx := y*b.Width + x
-	
-	// Connect with adjacent land/bridges
-	directions := [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
-	for _, dir := range directions {
-		nx, ny := x+dir[0], y+dir[1]
-		neighbor := b.GetTile(nx, ny)
-		if neighbor != nil && (neighbor.Type == TileLand || neighbor.Type == TileBridge) {
-			nidx := ny*b.Width + nx
-			b.UnionFind.Union(idx, nidx)
+	rules.OnPlace(b, x, y)
+}
+
+// RemoveBridge turns a bridge back into sea, dropping its components, and reports
+// whether there was one. Union-find cannot split groups, so connectivity is
+// rebuilt from the remaining tiles.
+func (b *Board) RemoveBridge(x, y int) bool {
+	tile := b.GetTile(x, y)
+	if tile == nil || tile.Type != TileBridge {
+		return false
+	}
+	tile.Type = TileSea
+	b.clearComponents(y*b.Width + x)
+	b.RebuildConnectivity()
+	return true
+}
+
+// BridgeHealth returns a bridge's durability, or 0 for any other tile
+func (b *Board) BridgeHealth(x, y int) int {
+	tile := b.GetTile(x, y)
+	if tile == nil || tile.Type != TileBridge {
+		return 0
+	}
+	if damage, ok := ComponentAt[BridgeDamage](b, x, y); ok {
+		return damage.Health
+	}
+	return MaxBridgeHealth
+}
+
+// DamageBridge lowers a bridge's health. A bridge that runs out collapses into
+// the sea and the result reports true.
+func (b *Board) DamageBridge(x, y, amount int) bool {
+	health := b.BridgeHealth(x, y)
+	if health == 0 {
+		return false // Not a bridge
+	}
+	if health > amount {
+		b.SetComponent(x, y, BridgeDamage{Health: health - amount})
+		return false
+	}
+	return b.RemoveBridge(x, y)
+}
+
+// SetBridgeHealth sets a bridge's durability, e.g. when a saved game is loaded
+func (b *Board) SetBridgeHealth(x, y, health int) {
+	if tile := b.GetTile(x, y); tile == nil || tile.Type != TileBridge {
+		return
+	}
+	if health >= MaxBridgeHealth {
+		b.RemoveComponent(x, y, BridgeDamage{}.Kind())
+	} else {
+		b.SetComponent(x, y, BridgeDamage{Health: max(health, 1)})
+	}
+}
+
+// RepairBridge restores a damaged bridge to full health and reports whether it needed it
+func (b *Board) RepairBridge(x, y int) bool {
+	if _, damaged := ComponentAt[BridgeDamage](b, x, y); !damaged || b.BridgeHealth(x, y) == 0 {
+		return false
+	}
+	b.RemoveComponent(x, y, BridgeDamage{}.Kind())
+	return true
+}
+
+// Bridges returns the positions of every bridge tile in reading order
+func (b *Board) Bridges() []Point {
+	var bridges []Point
+	for idx, tile := range b.Tiles {
+		if tile.Type == TileBridge {
+			bridges = append(bridges, Point{X: idx % b.Width, Y: idx / b.Width})
 		}
 	}
+	return bridges
 }
 
 func (b *Board) IsAllConnected() bool {
@@ -113,6 +217,66 @@ func (b *Board) IsAllConnected() bool {
 	return true
 }
 
+// Clone returns a deep copy of the board, including its connectivity state
+func (b *Board) Clone() *Board {
+	tiles := make([]Tile, len(b.Tiles))
+	copy(tiles, b.Tiles)
+	islands := make([]int, len(b.Islands))
+	copy(islands, b.Islands)
+
+	return &Board{
+		Width:     b.Width,
+		Height:    b.Height,
+		Tiles:     tiles,
+		UnionFind: b.UnionFind.Clone(),
+		Islands:   islands,
+		components: b.cloneComponents(),
+		rules:      b.rules,
+	}
+}
+
+// ConnectedIslands reports how many islands, counting touching land tiles as one,
+// the largest connected group holds out of all the islands on the board
+func (b *Board) ConnectedIslands() (connected, total int) {
+	nodeOf := make([]int, len(b.Tiles))
+	for i := range nodeOf {
+		nodeOf[i] = -1
+	}
+	perGroup := make(map[int]int)
+	for _, idx := range b.Islands {
+		if b.Tiles[idx].Type != TileLand || nodeOf[idx] >= 0 {
+			continue
+		}
+		b.floodLand(idx, total, nodeOf)
+		total++
+		root := b.UnionFind.Find(idx)
+		perGroup[root]++
+		connected = max(connected, perGroup[root])
+	}
+	return connected, total
+}
+
+// GroupLand returns the land tiles in the same connected group as (x, y)
+func (b *Board) GroupLand(x, y int) []Point {
+	root := b.UnionFind.Find(y*b.Width + x)
+	var land []Point
+	for _, idx := range b.Islands {
+		if b.Tiles[idx].Type == TileLand && b.UnionFind.Find(idx) == root {
+			land = append(land, Point{X: idx % b.Width, Y: idx / b.Width})
+		}
+	}
+	return land
+}
+
+// IslandGroupCount returns how many separate groups the land tiles currently form
+func (b *Board) IslandGroupCount() int {
+	roots := make(map[int]bool)
+	for _, idx := range b.Islands {
+		roots[b.UnionFind.Find(idx)] = true
+	}
+	return len(roots)
+}
+
 // SetupLevel1 creates a simple level for MVP
 func (b *Board) SetupLevel1() {
 	// Clear board
@@ -132,5 +296,5 @@ func (b *Board) SetupLevel1() {
 	b.SetTile(2, 3, TileLand)
 	
 	// Reinitialize UnionFind for the new level
-	b.UnionFind = NewUnionFind(b.Width * b.Height)
+	b.RebuildConnectivity()
 }
\ No newline at end of file
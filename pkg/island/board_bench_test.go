@@ -0,0 +1,76 @@
+package island_test
+
+import (
+	"testing"
+
+	"github.com/ponyo877/island-merge/pkg/benchboards"
+)
+
+// BenchmarkBuildBridge plays a solution's moves in order, starting over on a
+// fresh board whenever it runs out
+func BenchmarkBuildBridge(b *testing.B) {
+	for _, size := range benchboards.Sizes {
+		b.Run(benchboards.Name(size), func(b *testing.B) {
+			_, moves := benchboards.Solved(size)
+			board := benchboards.Generate(size)
+			next := 0
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if next == len(moves) {
+					b.StopTimer()
+					board = benchboards.Generate(size)
+					next = 0
+					b.StartTimer()
+				}
+				move := moves[next]
+				board.BuildBridge(move.X, move.Y)
+				next++
+			}
+		})
+	}
+}
+
+// BenchmarkRemoveBridge removes a bridge from a connected board and puts it
+// back; removal rebuilds connectivity for the whole board
+func BenchmarkRemoveBridge(b *testing.B) {
+	for _, size := range benchboards.Sizes {
+		b.Run(benchboards.Name(size), func(b *testing.B) {
+			board, moves := benchboards.Solved(size)
+			move := moves[len(moves)/2]
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				board.RemoveBridge(move.X, move.Y)
+				b.StopTimer()
+				board.BuildBridge(move.X, move.Y)
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkIsAllConnected(b *testing.B) {
+	for _, solved := range []bool{false, true} {
+		state := "unsolved"
+		if solved {
+			state = "solved"
+		}
+		for _, size := range benchboards.Sizes {
+			b.Run(state+"/"+benchboards.Name(size), func(b *testing.B) {
+				board := benchboards.Generate(size)
+				if solved {
+					board, _ = benchboards.Solved(size)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					board.IsAllConnected()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package island
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ComponentKind names a kind of component and keys it in saves
+type ComponentKind string
+
+// Component is extra data a mechanic attaches to a tile, such as a bridge's
+// damage or an obstacle. A tile holds at most one component of each kind.
+// Components are values: set a new one to change it.
+type Component interface {
+	Kind() ComponentKind
+}
+
+// ComponentData is a component in serialized form
+type ComponentData struct {
+	X    int             `json:"x"`
+	Y    int             `json:"y"`
+	Kind ComponentKind   `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// componentDecoders restore each registered kind from JSON
+var componentDecoders = make(map[ComponentKind]func(data []byte) (Component, error))
+
+// RegisterComponent lets components of type T be restored by UnmarshalComponents.
+// Call it from an init function; T's zero value must report its kind.
+func RegisterComponent[T Component]() {
+	var zero T
+	componentDecoders[zero.Kind()] = func(data []byte) (Component, error) {
+		var c T
+		if len(data) == 0 {
+			return c, nil
+		}
+		err := json.Unmarshal(data, &c)
+		return c, err
+	}
+}
+
+// SetComponent attaches a component to a tile, replacing any of the same kind
+func (b *Board) SetComponent(x, y int, c Component) {
+	if b.GetTile(x, y) == nil {
+		return
+	}
+	if b.components == nil {
+		b.components = make(map[ComponentKind]map[int]Component)
+	}
+	tiles := b.components[c.Kind()]
+	if tiles == nil {
+		tiles = make(map[int]Component)
+		b.components[c.Kind()] = tiles
+	}
+	tiles[y*b.Width+x] = c
+}
+
+// Component returns a tile's component of the given kind
+func (b *Board) Component(x, y int, kind ComponentKind) (Component, bool) {
+	if b.GetTile(x, y) == nil {
+		return nil, false
+	}
+	c, ok := b.components[kind][y*b.Width+x]
+	return c, ok
+}
+
+// RemoveComponent detaches a tile's component of the given kind
+func (b *Board) RemoveComponent(x, y int, kind ComponentKind) {
+	if b.GetTile(x, y) == nil {
+		return
+	}
+	delete(b.components[kind], y*b.Width+x)
+}
+
+// clearComponents detaches every component from a tile
+func (b *Board) clearComponents(idx int) {
+	for _, tiles := range b.components {
+		delete(tiles, idx)
+	}
+}
+
+// ComponentAt returns a tile's component of type T
+func ComponentAt[T Component](b *Board, x, y int) (T, bool) {
+	var zero T
+	c, ok := b.Component(x, y, zero.Kind())
+	if !ok {
+		return zero, false
+	}
+	typed, ok := c.(T)
+	return typed, ok
+}
+
+// Placed is a component and the tile it is attached to
+type Placed[T Component] struct {
+	Point
+	Component T
+}
+
+// ComponentsOf returns every component of type T in reading order of their tiles
+func ComponentsOf[T Component](b *Board) []Placed[T] {
+	var zero T
+	tiles := b.components[zero.Kind()]
+	placed := make([]Placed[T], 0, len(tiles))
+	for _, idx := range sortedIndices(tiles) {
+		if c, ok := tiles[idx].(T); ok {
+			placed = append(placed, Placed[T]{Point{X: idx % b.Width, Y: idx / b.Width}, c})
+		}
+	}
+	return placed
+}
+
+func sortedIndices(tiles map[int]Component) []int {
+	indices := make([]int, 0, len(tiles))
+	for idx := range tiles {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// cloneComponents copies the component maps; the components themselves are values
+func (b *Board) cloneComponents() map[ComponentKind]map[int]Component {
+	if len(b.components) == 0 {
+		return nil
+	}
+	components := make(map[ComponentKind]map[int]Component, len(b.components))
+	for kind, tiles := range b.components {
+		copied := make(map[int]Component, len(tiles))
+		for idx, c := range tiles {
+			copied[idx] = c
+		}
+		components[kind] = copied
+	}
+	return components
+}
+
+// MarshalComponents serializes every component on the board, ordered by kind
+// and then by tile
+func (b *Board) MarshalComponents() ([]ComponentData, error) {
+	kinds := make([]string, 0, len(b.components))
+	for kind := range b.components {
+		kinds = append(kinds, string(kind))
+	}
+	sort.Strings(kinds)
+
+	var data []ComponentData
+	for _, kind := range kinds {
+		tiles := b.components[ComponentKind(kind)]
+		for _, idx := range sortedIndices(tiles) {
+			encoded, err := json.Marshal(tiles[idx])
+			if err != nil {
+				return nil, fmt.Errorf("component %s: %w", kind, err)
+			}
+			if string(encoded) == "{}" {
+				encoded = nil
+			}
+			data = append(data, ComponentData{X: idx % b.Width, Y: idx / b.Width, Kind: ComponentKind(kind), Data: encoded})
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalComponents attaches serialized components to the board. Components
+// of unknown kinds, off the board or that fail to decode are skipped and
+// reported together in the error; the rest are still attached.
+func (b *Board) UnmarshalComponents(data []ComponentData) error {
+	var errs []error
+	for _, d := range data {
+		decode, ok := componentDecoders[d.Kind]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown component kind %q", d.Kind))
+			continue
+		}
+		if b.GetTile(d.X, d.Y) == nil {
+			errs = append(errs, fmt.Errorf("component %s at (%d,%d) is off the board", d.Kind, d.X, d.Y))
+			continue
+		}
+		c, err := decode(d.Data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("component %s at (%d,%d): %w", d.Kind, d.X, d.Y, err))
+			continue
+		}
+		b.SetComponent(d.X, d.Y, c)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package island
+
+// Expand returns a copy of the board with margin tiles of sea added on every
+// side. Tiles, components and rules keep their places relative to each other.
+func (b *Board) Expand(margin int) *Board {
+	width, height := b.Width+2*margin, b.Height+2*margin
+	expanded := NewBoard(width, height)
+	shift := func(idx int) int {
+		return (idx/b.Width+margin)*width + idx%b.Width + margin
+	}
+
+	for idx, tile := range b.Tiles {
+		expanded.Tiles[shift(idx)] = tile
+	}
+	for _, idx := range b.Islands {
+		expanded.Islands = append(expanded.Islands, shift(idx))
+	}
+	for kind, tiles := range b.components {
+		for idx, c := range tiles {
+			if expanded.components == nil {
+				expanded.components = make(map[ComponentKind]map[int]Component)
+			}
+			if expanded.components[kind] == nil {
+				expanded.components[kind] = make(map[int]Component)
+			}
+			expanded.components[kind][shift(idx)] = c
+		}
+	}
+	expanded.rules = b.rules
+	expanded.RebuildConnectivity()
+	return expanded
+}
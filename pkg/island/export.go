@@ -0,0 +1,176 @@
+package island
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// NodeKind tells islands from bridge tiles in a BoardGraph
+type NodeKind string
+
+const (
+	NodeIsland NodeKind = "island"
+	NodeBridge NodeKind = "bridge"
+)
+
+// GraphNode is an island, a group of land tiles touching side by side, or a
+// single bridge tile
+type GraphNode struct {
+	ID    string
+	Kind  NodeKind
+	X, Y  int // First tile of an island, or the bridge tile
+	Tiles int
+}
+
+// BoardGraph is the board as a graph for analysis outside the game, with an
+// edge wherever two nodes touch side by side. Edges index into Nodes.
+type BoardGraph struct {
+	Width, Height int
+	Nodes         []GraphNode
+	Edges         [][2]int
+}
+
+// Graph builds the board's graph from its tiles alone, so it also works for
+// boards whose connectivity has not been computed, such as the editor's
+func (b *Board) Graph() BoardGraph {
+	graph := BoardGraph{Width: b.Width, Height: b.Height}
+	nodeOf := make([]int, len(b.Tiles))
+	for i := range nodeOf {
+		nodeOf[i] = -1
+	}
+
+	// Islands first, numbered in reading order of their first tile
+	for idx, tile := range b.Tiles {
+		if tile.Type != TileLand || nodeOf[idx] >= 0 {
+			continue
+		}
+		node := len(graph.Nodes)
+		tiles := b.floodLand(idx, node, nodeOf)
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:    fmt.Sprintf("island%d", node+1),
+			Kind:  NodeIsland,
+			X:     idx % b.Width,
+			Y:     idx / b.Width,
+			Tiles: tiles,
+		})
+	}
+	for idx, tile := range b.Tiles {
+		if tile.Type != TileBridge {
+			continue
+		}
+		nodeOf[idx] = len(graph.Nodes)
+		x, y := idx%b.Width, idx/b.Width
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:    fmt.Sprintf("bridge_%d_%d", x, y),
+			Kind:  NodeBridge,
+			X:     x,
+			Y:     y,
+			Tiles: 1,
+		})
+	}
+
+	// Each bridge links to the islands and bridges beside it; an island is
+	// linked to a bridge once however many of its tiles the bridge touches
+	seen := make(map[[2]int]bool)
+	for idx, tile := range b.Tiles {
+		if tile.Type != TileBridge {
+			continue
+		}
+		x, y := idx%b.Width, idx/b.Width
+		for _, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+			neighbor := b.GetTile(x+d[0], y+d[1])
+			if neighbor == nil || (neighbor.Type != TileLand && neighbor.Type != TileBridge) {
+				continue
+			}
+			edge := [2]int{nodeOf[idx], nodeOf[(y+d[1])*b.Width+x+d[0]]}
+			if edge[0] > edge[1] {
+				edge[0], edge[1] = edge[1], edge[0]
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				graph.Edges = append(graph.Edges, edge)
+			}
+		}
+	}
+	return graph
+}
+
+// floodLand marks the land tiles connected to start as node and returns how many there are
+func (b *Board) floodLand(start, node int, nodeOf []int) int {
+	nodeOf[start] = node
+	stack := []int{start}
+	count := 0
+	for len(stack) > 0 {
+		idx := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		count++
+		x, y := idx%b.Width, idx/b.Width
+		for _, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+			neighbor := b.GetTile(x+d[0], y+d[1])
+			next := (y+d[1])*b.Width + x + d[0]
+			if neighbor != nil && neighbor.Type == TileLand && nodeOf[next] < 0 {
+				nodeOf[next] = node
+				stack = append(stack, next)
+			}
+		}
+	}
+	return count
+}
+
+// IslandCount returns how many island nodes the graph has
+func (g BoardGraph) IslandCount() int {
+	count := 0
+	for _, node := range g.Nodes {
+		if node.Kind == NodeIsland {
+			count++
+		}
+	}
+	return count
+}
+
+// DOT writes the graph in Graphviz format. Nodes are pinned to their tiles, so
+// `neato -n` draws the graph over the board's layout.
+func (g BoardGraph) DOT() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "graph board {\n")
+	fmt.Fprintf(&buf, "  // %dx%d board: %d islands, %d bridge tiles\n", g.Width, g.Height, g.IslandCount(), len(g.Nodes)-g.IslandCount())
+	for _, node := range g.Nodes {
+		pos := fmt.Sprintf("%d,%d", node.X*72, (g.Height-1-node.Y)*72)
+		if node.Kind == NodeIsland {
+			size := fmt.Sprintf("%d tiles", node.Tiles)
+			if node.Tiles == 1 {
+				size = "1 tile"
+			}
+			fmt.Fprintf(&buf, "  %s [label=\"%s\\n%s\", shape=circle, style=filled, fillcolor=\"#8bc34a\", pos=\"%s\"];\n", node.ID, node.ID, size, pos)
+		} else {
+			fmt.Fprintf(&buf, "  %s [label=\"\", shape=square, width=0.2, style=filled, fillcolor=\"#8d6e63\", pos=\"%s\"];\n", node.ID, pos)
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&buf, "  %s -- %s;\n", g.Nodes[edge[0]].ID, g.Nodes[edge[1]].ID)
+	}
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// GraphML writes the graph as GraphML with the node kind, tile position and
+// tile count as attributes
+func (g BoardGraph) GraphML() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	buf.WriteString(`  <key id="kind" for="node" attr.name="kind" attr.type="string"/>` + "\n")
+	buf.WriteString(`  <key id="x" for="node" attr.name="x" attr.type="int"/>` + "\n")
+	buf.WriteString(`  <key id="y" for="node" attr.name="y" attr.type="int"/>` + "\n")
+	buf.WriteString(`  <key id="tiles" for="node" attr.name="tiles" attr.type="int"/>` + "\n")
+	fmt.Fprintf(&buf, "  <graph id=\"board\" edgedefault=\"undirected\">\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&buf, "    <node id=\"%s\"><data key=\"kind\">%s</data><data key=\"x\">%d</data><data key=\"y\">%d</data><data key=\"tiles\">%d</data></node>\n",
+			node.ID, node.Kind, node.X, node.Y, node.Tiles)
+	}
+	for i, edge := range g.Edges {
+		fmt.Fprintf(&buf, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\"/>\n", i, g.Nodes[edge[0]].ID, g.Nodes[edge[1]].ID)
+	}
+	buf.WriteString("  </graph>\n</graphml>\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,51 @@
+package island
+
+// Point is a tile coordinate on the board
+type Point struct {
+	X, Y int
+}
+
+// FirstIslandTile returns the position of the first land tile
+func (b *Board) FirstIslandTile() (Point, bool) {
+	if len(b.Islands) == 0 {
+		return Point{}, false
+	}
+	idx := b.Islands[0]
+	return Point{X: idx % b.Width, Y: idx / b.Width}, true
+}
+
+// NetworkLayers walks the land and bridge tiles connected to start, breadth first
+// over the four neighbours, and groups them by distance. Layer 0 holds start itself.
+func (b *Board) NetworkLayers(start Point) [][]Point {
+	if !b.isNetworkTile(start.X, start.Y) {
+		return nil
+	}
+
+	visited := make([]bool, b.Width*b.Height)
+	visited[start.Y*b.Width+start.X] = true
+
+	layers := [][]Point{{start}}
+	for {
+		var next []Point
+		for _, p := range layers[len(layers)-1] {
+			for _, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+				nx, ny := p.X+d[0], p.Y+d[1]
+				if !b.isNetworkTile(nx, ny) || visited[ny*b.Width+nx] {
+					continue
+				}
+				visited[ny*b.Width+nx] = true
+				next = append(next, Point{X: nx, Y: ny})
+			}
+		}
+		if len(next) == 0 {
+			return layers
+		}
+		layers = append(layers, next)
+	}
+}
+
+// isNetworkTile reports whether a tile carries the connected network: land or bridge
+func (b *Board) isNetworkTile(x, y int) bool {
+	tile := b.GetTile(x, y)
+	return tile != nil && (tile.Type == TileLand || tile.Type == TileBridge)
+}
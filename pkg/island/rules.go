@@ -0,0 +1,98 @@
+package island
+
+// Rules decide where bridges may be placed and when a board is solved. A level
+// or mode configures them with Board.SetRules; boards without any play by
+// ConnectAll. Rules can wrap other rules to add constraints, like BridgeBudget.
+type Rules interface {
+	// CanPlace reports whether a bridge may be built at (x, y)
+	CanPlace(b *Board, x, y int) bool
+	// OnPlace runs after a bridge is built at (x, y)
+	OnPlace(b *Board, x, y int)
+	// WinCondition reports whether the board is solved
+	WinCondition(b *Board) bool
+	// MergesLand reports whether land tiles that touch form one landmass
+	// without a bridge between them
+	MergesLand() bool
+}
+
+// ConnectAll are the classic rules: bridges go on sea next to land or another
+// bridge, and the board is solved once every island is connected
+type ConnectAll struct{}
+
+func (ConnectAll) CanPlace(b *Board, x, y int) bool {
+	tile := b.GetTile(x, y)
+	if tile == nil || tile.Type != TileSea {
+		return false
+	}
+	for _, dir := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+		if connects(b.GetTile(x+dir[0], y+dir[1])) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ConnectAll) OnPlace(b *Board, x, y int) {}
+
+func (ConnectAll) WinCondition(b *Board) bool {
+	return b.IsAllConnected()
+}
+
+// Under ConnectAll every land tile is an island of its own until bridged
+func (ConnectAll) MergesLand() bool { return false }
+
+// MergeLand joins land tiles that touch into one landmass on top of other
+// rules, so a bridge between two landmasses merges every tile of both
+type MergeLand struct {
+	Rules
+}
+
+func (MergeLand) MergesLand() bool { return true }
+
+// BridgeBudget caps how many bridges can stand at once on top of other rules
+type BridgeBudget struct {
+	Rules
+	Max int
+}
+
+func (r BridgeBudget) CanPlace(b *Board, x, y int) bool {
+	return len(b.Bridges()) < r.Max && r.Rules.CanPlace(b, x, y)
+}
+
+// Remaining is how many more bridges may be built
+func (r BridgeBudget) Remaining(b *Board) int {
+	return max(r.Max-len(b.Bridges()), 0)
+}
+
+// LevelRules are the rules a level asks for: ConnectAll, merging touching land
+// when mergeLand is set, under a bridge budget when budget is above zero
+func LevelRules(mergeLand bool, budget int) Rules {
+	var rules Rules = ConnectAll{}
+	if mergeLand {
+		rules = MergeLand{Rules: rules}
+	}
+	if budget > 0 {
+		rules = BridgeBudget{Rules: rules, Max: budget}
+	}
+	return rules
+}
+
+// SetRules changes the board's rules; nil restores ConnectAll. Connectivity
+// is rebuilt, since the rules decide whether touching land is joined.
+func (b *Board) SetRules(rules Rules) {
+	b.rules = rules
+	b.RebuildConnectivity()
+}
+
+// Rules returns the rules the board is played by
+func (b *Board) Rules() Rules {
+	if b.rules == nil {
+		return ConnectAll{}
+	}
+	return b.rules
+}
+
+// IsSolved reports whether the board meets its rules' win condition
+func (b *Board) IsSolved() bool {
+	return b.Rules().WinCondition(b)
+}
@@ -57,4 +57,17 @@ func (uf *UnionFind) Connected(x, y int) bool {
 
 func (uf *UnionFind) ComponentCount() int {
 	return uf.count
-}
\ No newline at end of file
+}
+// Clone returns an independent copy of the union-find structure
+func (uf *UnionFind) Clone() *UnionFind {
+	parent := make([]int, len(uf.parent))
+	rank := make([]int, len(uf.rank))
+	copy(parent, uf.parent)
+	copy(rank, uf.rank)
+
+	return &UnionFind{
+		parent: parent,
+		rank:   rank,
+		count:  uf.count,
+	}
+}
@@ -0,0 +1,92 @@
+// Package jsapi lets web pages embedding the wasm build drive the game from
+// JavaScript through a global IslandMerge object. Calls are queued and carried
+// out by the game loop, so they never race with a frame in progress.
+package jsapi
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// GlobalName is the JavaScript global the API is installed as
+const GlobalName = "IslandMerge"
+
+var ErrBusy = errors.New("too many requests waiting for the game")
+
+// Command is one request from the page. Exactly one of LevelID and Level is set.
+type Command struct {
+	LevelID string            // Start a level shipped with the game or an installed pack
+	Level   *levels.LevelData // Start a level supplied by the page
+	Done    func(err error)   // Reports the outcome back to the page
+}
+
+// Stats is the snapshot of the current game the page can read at any time
+type Stats struct {
+	State   string `json:"state"`
+	Mode    string `json:"mode"`
+	LevelID string `json:"levelId"`
+	Moves   int    `json:"moves"`
+	TimeMS  int64  `json:"timeMs"`
+	Islands int    `json:"islands"` // Island groups not yet connected to each other
+	Won     bool   `json:"won"`
+}
+
+// API queues page requests for the game loop and publishes its stats
+type API struct {
+	commands chan Command
+	mu       sync.Mutex // Guards stats and the page's listeners
+	stats    Stats
+	bridge   *jsBridge
+}
+
+// commandQueueSize bounds how many page requests can wait for the next frame
+const commandQueueSize = 16
+
+func New() *API {
+	return &API{commands: make(chan Command, commandQueueSize)}
+}
+
+// Install exposes the API to the page and forwards game events to page listeners.
+// It does nothing outside the browser.
+func (a *API) Install(bus *events.Bus) {
+	a.install(bus)
+}
+
+// Poll returns the requests made since the last call without blocking. Call it
+// once per frame and run each command's Done when it has been carried out.
+func (a *API) Poll() []Command {
+	var commands []Command
+	for {
+		select {
+		case command := <-a.commands:
+			commands = append(commands, command)
+		default:
+			return commands
+		}
+	}
+}
+
+// SetStats publishes the current game's stats for the page to read
+func (a *API) SetStats(stats Stats) {
+	a.mu.Lock()
+	a.stats = stats
+	a.mu.Unlock()
+}
+
+func (a *API) currentStats() Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+// enqueue hands a command to the game loop, failing it when the queue is full
+func (a *API) enqueue(command Command) {
+	select {
+	case a.commands <- command:
+	default:
+		command.Done(ErrBusy)
+	}
+}
@@ -0,0 +1,159 @@
+// +build js,wasm
+
+package jsapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"syscall/js"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// jsBridge holds the page's listeners and the functions exposed to it
+type jsBridge struct {
+	winListeners map[int]js.Value
+	nextListener int
+	funcs        []js.Func // Kept for the lifetime of the page
+}
+
+// install defines window.IslandMerge:
+//
+//	startLevel(id)          Promise; starts a built-in or installed level
+//	loadLevel(json)         Promise; starts a level from its JSON (string or object)
+//	onWin(callback)         calls callback({mode, levelId, moves, timeMs, perfect}); returns an unsubscribe function
+//	getStats()              {state, mode, levelId, moves, timeMs, islands, won}
+func (a *API) install(bus *events.Bus) {
+	a.bridge = &jsBridge{winListeners: make(map[int]js.Value)}
+
+	api := js.Global().Get("Object").New()
+	api.Set("startLevel", a.bridge.funcOf(a.startLevel))
+	api.Set("loadLevel", a.bridge.funcOf(a.loadLevel))
+	api.Set("onWin", a.bridge.funcOf(a.onWin))
+	api.Set("getStats", a.bridge.funcOf(a.getStats))
+	js.Global().Set(GlobalName, api)
+
+	events.Subscribe(bus, func(e events.GameWon) {
+		payload := map[string]interface{}{
+			"mode":    e.Mode,
+			"levelId": e.LevelID,
+			"moves":   e.Moves,
+			"timeMs":  e.Time.Milliseconds(),
+			"perfect": e.IsPerfect,
+		}
+		a.mu.Lock()
+		listeners := make([]js.Value, 0, len(a.bridge.winListeners))
+		for _, listener := range a.bridge.winListeners {
+			listeners = append(listeners, listener)
+		}
+		a.mu.Unlock()
+		// Listeners run outside the lock so they may call back into the API
+		for _, listener := range listeners {
+			listener.Invoke(payload)
+		}
+	})
+
+	// Let pages that loaded before the game finish wiring up
+	if ready := js.Global().Get("onIslandMergeReady"); ready.Type() == js.TypeFunction {
+		ready.Invoke(api)
+	}
+}
+
+// LaunchParams returns the query parameters of the page URL, e.g. level=expert_01
+func LaunchParams() url.Values {
+	search := js.Global().Get("location").Get("search").String()
+	params, err := url.ParseQuery(strings.TrimPrefix(search, "?"))
+	if err != nil {
+		return nil
+	}
+	return params
+}
+
+func (b *jsBridge) funcOf(fn func(args []js.Value) interface{}) js.Func {
+	f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return fn(args)
+	})
+	b.funcs = append(b.funcs, f)
+	return f
+}
+
+// promise queues a command and returns a Promise settled when the game loop has
+// carried it out
+func (a *API) promise(command Command) js.Value {
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		resolve, reject := args[0], args[1]
+		command.Done = func(err error) {
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(err.Error()))
+			} else {
+				resolve.Invoke()
+			}
+		}
+		a.enqueue(command)
+		return nil
+	})
+	defer executor.Release()
+	return js.Global().Get("Promise").New(executor)
+}
+
+// rejected returns a Promise that has already failed
+func rejected(err error) js.Value {
+	return js.Global().Get("Promise").Call("reject", js.Global().Get("Error").New(err.Error()))
+}
+
+func (a *API) startLevel(args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return rejected(fmt.Errorf("startLevel expects a level ID"))
+	}
+	return a.promise(Command{LevelID: args[0].String()})
+}
+
+func (a *API) loadLevel(args []js.Value) interface{} {
+	if len(args) < 1 {
+		return rejected(fmt.Errorf("loadLevel expects level JSON"))
+	}
+	data := args[0]
+	if data.Type() == js.TypeObject {
+		data = js.Global().Get("JSON").Call("stringify", data)
+	}
+	if data.Type() != js.TypeString {
+		return rejected(fmt.Errorf("loadLevel expects level JSON"))
+	}
+
+	level, err := levels.ParseLevel([]byte(data.String()))
+	if err != nil {
+		return rejected(err)
+	}
+	return a.promise(Command{Level: level})
+}
+
+func (a *API) onWin(args []js.Value) interface{} {
+	if len(args) < 1 || args[0].Type() != js.TypeFunction {
+		return js.Global().Get("Error").New("onWin expects a callback")
+	}
+
+	a.mu.Lock()
+	id := a.bridge.nextListener
+	a.bridge.nextListener++
+	a.bridge.winListeners[id] = args[0]
+	a.mu.Unlock()
+	return a.bridge.funcOf(func([]js.Value) interface{} {
+		a.mu.Lock()
+		delete(a.bridge.winListeners, id)
+		a.mu.Unlock()
+		return nil
+	})
+}
+
+func (a *API) getStats(args []js.Value) interface{} {
+	// Round-trip through JSON so the page gets a plain object with the documented keys
+	data, err := json.Marshal(a.currentStats())
+	if err != nil {
+		return nil
+	}
+	return js.Global().Get("JSON").Call("parse", string(data))
+}
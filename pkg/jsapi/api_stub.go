@@ -0,0 +1,19 @@
+// +build !js !wasm
+
+package jsapi
+
+import (
+	"net/url"
+
+	"github.com/ponyo877/island-merge/pkg/events"
+)
+
+// jsBridge holds page state; there is no page outside the browser
+type jsBridge struct{}
+
+func (a *API) install(bus *events.Bus) {}
+
+// LaunchParams returns nothing outside the browser, where there is no page URL
+func LaunchParams() url.Values {
+	return nil
+}
@@ -0,0 +1,85 @@
+package levels
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// challengeStages shapes the levels of a weekly challenge, easiest first.
+// Changing them changes the playlist of every week, so keep them fixed.
+var challengeStages = []struct {
+	options  GenerateOptions
+	mutators int // Mutators drawn for the stage
+}{
+	{GenerateOptions{Width: 6, Height: 6, Density: 0.15, MinPar: 3, MaxPar: 6}, 1},
+	{GenerateOptions{Width: 8, Height: 8, Density: 0.12, MinPar: 5, MaxPar: 10}, 1},
+	{GenerateOptions{Width: 10, Height: 10, Density: 0.1, MinPar: 8, MaxPar: 14}, 2},
+}
+
+// WeeklyChallenge is the week's playlist of generated levels, played in order,
+// each under its own mutators. Everyone gets the same playlist in the same week.
+type WeeklyChallenge struct {
+	Week   string    // ISO week, e.g. "2026-W42"
+	Ends   time.Time // Start of the next week, in UTC
+	Stages []ChallengeStage
+}
+
+// ChallengeStage is one level of a weekly challenge
+type ChallengeStage struct {
+	Level    *LevelData
+	Mutators []string
+}
+
+// ISOWeek returns the ISO week containing t in UTC, e.g. "2026-W42", and when it ends
+func ISOWeek(t time.Time) (string, time.Time) {
+	t = t.UTC()
+	year, week := t.ISOWeek()
+	// ISO weeks start on Monday
+	daysIntoWeek := (int(t.Weekday()) + 6) % 7
+	start := time.Date(t.Year(), t.Month(), t.Day()-daysIntoWeek, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("%d-W%02d", year, week), start.AddDate(0, 0, 7)
+}
+
+// NewWeeklyChallenge generates the playlist for the week containing t. Each
+// stage's mutators are drawn from mutators, which are IDs the caller understands.
+func NewWeeklyChallenge(t time.Time, mutators []string) (*WeeklyChallenge, error) {
+	week, ends := ISOWeek(t)
+	hash := fnv.New64a()
+	hash.Write([]byte(week))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	challenge := &WeeklyChallenge{Week: week, Ends: ends}
+	for i, stage := range challengeStages {
+		level, err := Generate(rng, stage.options)
+		if err != nil {
+			return nil, fmt.Errorf("challenge stage %d: %w", i+1, err)
+		}
+		level.ID = challenge.StageID(i)
+		level.Name = fmt.Sprintf("Challenge %s (%d/%d)", week, i+1, len(challengeStages))
+		level.Unlocked = true
+
+		var picked []string
+		for _, j := range rng.Perm(len(mutators))[:min(stage.mutators, len(mutators))] {
+			picked = append(picked, mutators[j])
+		}
+		challenge.Stages = append(challenge.Stages, ChallengeStage{Level: level, Mutators: picked})
+	}
+	return challenge, nil
+}
+
+// StageID is the level ID of a stage, which also keys its leaderboard
+func (c *WeeklyChallenge) StageID(stage int) string {
+	return fmt.Sprintf("challenge/%s/%d", c.Week, stage+1)
+}
+
+// Stage returns the index of the stage with the given level ID, or -1
+func (c *WeeklyChallenge) Stage(levelID string) int {
+	for i := range c.Stages {
+		if c.StageID(i) == levelID {
+			return i
+		}
+	}
+	return -1
+}
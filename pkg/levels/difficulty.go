@@ -0,0 +1,47 @@
+package levels
+
+import (
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+var difficultyLabels = map[Difficulty]string{
+	DifficultyBeginner:     "beginner",
+	DifficultyIntermediate: "intermediate",
+	DifficultyExpert:       "expert",
+	DifficultyMaster:       "master",
+}
+
+// Label returns the difficulty as custom levels store it, e.g. "expert"
+func (d Difficulty) Label() string {
+	return difficultyLabels[d]
+}
+
+// Scores at which each harder difficulty starts
+const (
+	intermediateScore = 12
+	expertScore       = 22
+	masterScore       = 35
+)
+
+// EstimateDifficulty rates a board from the solver's metrics. Longer solutions
+// count most; more bridgeable tiles per step, decoy islands and a larger board
+// add to the score. It reports false, with no rating, if the board is unsolvable.
+func EstimateDifficulty(board *island.Board) (Difficulty, bool) {
+	metrics, ok := solver.Measure(board)
+	if !ok {
+		return DifficultyBeginner, false
+	}
+
+	score := float64(metrics.OptimalMoves) + metrics.Branching/6 + 3*float64(metrics.Decoys) + float64(metrics.Area)/50
+	switch {
+	case score < intermediateScore:
+		return DifficultyBeginner, true
+	case score < expertScore:
+		return DifficultyIntermediate, true
+	case score < masterScore:
+		return DifficultyExpert, true
+	default:
+		return DifficultyMaster, true
+	}
+}
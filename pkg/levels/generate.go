@@ -0,0 +1,111 @@
+package levels
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+// ErrNoMatchingLevel is returned when no generated board met the constraints
+var ErrNoMatchingLevel = errors.New("no generated level matches the constraints")
+
+// GenerateOptions constrains a procedurally generated level
+type GenerateOptions struct {
+	Width, Height int
+	Density       float64 // Fraction of tiles that are land, at most 0.25
+	MinPar        int     // Fewest solver moves accepted; 0 for no minimum
+	MaxPar        int     // Most solver moves accepted; 0 for no maximum
+	MaxAttempts   int     // Boards tried before giving up; 0 uses 1000
+}
+
+// maxDensity is the most land that fits when no two islands may touch
+const maxDensity = 0.25
+
+// Generate builds a random solvable level of single-tile islands that never touch,
+// retrying until the solver's move count falls within the par range. Its par is
+// the solver's move count; ID and Name are left for the caller.
+func Generate(rng *rand.Rand, opts GenerateOptions) (*LevelData, error) {
+	if opts.Width < 3 || opts.Height < 3 {
+		return nil, fmt.Errorf("level must be at least 3x3, got %dx%d", opts.Width, opts.Height)
+	}
+	if opts.Density <= 0 || opts.Density > maxDensity {
+		return nil, fmt.Errorf("density must be above 0 and at most %.2f", maxDensity)
+	}
+	if opts.MaxPar > 0 && opts.MinPar > opts.MaxPar {
+		return nil, fmt.Errorf("minimum par %d is above maximum par %d", opts.MinPar, opts.MaxPar)
+	}
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1000
+	}
+
+	islands := max(2, int(math.Round(opts.Density*float64(opts.Width*opts.Height))))
+	for i := 0; i < attempts; i++ {
+		grid, ok := scatterIslands(rng, opts.Width, opts.Height, islands)
+		if !ok {
+			continue
+		}
+
+		level := &LevelData{
+			Width:      opts.Width,
+			Height:     opts.Height,
+			Grid:       grid,
+			Objectives: defaultObjectives(),
+		}
+		solution := solver.Solve(level.NewBoard())
+		par := len(solution.Moves)
+		if !solution.Solvable || par < opts.MinPar || (opts.MaxPar > 0 && par > opts.MaxPar) {
+			continue
+		}
+		level.OptimalMoves = par
+		return level, nil
+	}
+	return nil, fmt.Errorf("%w after %d attempts", ErrNoMatchingLevel, attempts)
+}
+
+// defaultObjectives is the single goal of levels made without an editor
+func defaultObjectives() []Objective {
+	return []Objective{{Type: "connect_all", Target: 1, Description: "Connect all islands"}}
+}
+
+// scatterIslands places single land tiles at random so that no two touch, even
+// diagonally, and reports whether all of them fit
+func scatterIslands(rng *rand.Rand, width, height, count int) ([][]island.TileType, bool) {
+	grid := make([][]island.TileType, height)
+	for y := range grid {
+		grid[y] = make([]island.TileType, width)
+		for x := range grid[y] {
+			grid[y][x] = island.TileSea
+		}
+	}
+
+	placed := 0
+	for _, idx := range rng.Perm(width * height) {
+		x, y := idx%width, idx/width
+		if touchesLand(grid, x, y) {
+			continue
+		}
+		grid[y][x] = island.TileLand
+		placed++
+		if placed == count {
+			return grid, true
+		}
+	}
+	return grid, false
+}
+
+func touchesLand(grid [][]island.TileType, x, y int) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			ny, nx := y+dy, x+dx
+			if ny >= 0 && ny < len(grid) && nx >= 0 && nx < len(grid[ny]) && grid[ny][nx] == island.TileLand {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package levels
+
+import (
+	"math/rand"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// GrowBoard surrounds a board with a ring of sea margin tiles wide and
+// scatters up to count single-tile islands in it. New islands never touch
+// other land or bridges, even diagonally, so a ring at least two tiles wide
+// leaves the sea connected and the grown board solvable whenever the old one
+// was. It returns the grown board and how many islands were placed.
+func GrowBoard(rng *rand.Rand, board *island.Board, margin, count int) (*island.Board, int) {
+	grown := board.Expand(margin)
+	placed := 0
+	for _, idx := range rng.Perm(grown.Width * grown.Height) {
+		if placed == count {
+			break
+		}
+		x, y := idx%grown.Width, idx/grown.Width
+		inOld := x >= margin && x < margin+board.Width && y >= margin && y < margin+board.Height
+		if inOld || touchesNetwork(grown, x, y) {
+			continue
+		}
+		grown.SetTile(x, y, island.TileLand)
+		placed++
+	}
+	return grown, placed
+}
+
+// touchesNetwork reports whether a tile or any of its eight neighbours is
+// land or a bridge
+func touchesNetwork(board *island.Board, x, y int) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			tile := board.GetTile(x+dx, y+dy)
+			if tile != nil && (tile.Type == island.TileLand || tile.Type == island.TileBridge) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package levels
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// maxImageSize bounds level images, so a photo picked by mistake is rejected
+// rather than turned into a huge board
+const maxImageSize = 64
+
+// ErrImageTooLarge is returned for images wider or taller than maxImageSize
+var ErrImageTooLarge = errors.New("level image is too large")
+
+// TileColors are the colors of each tile type in level images: the game's own
+// tile colors, with black for empty tiles. Transparent pixels are empty too.
+var TileColors = map[island.TileType]color.RGBA{
+	island.TileEmpty:  {0, 0, 0, 255},
+	island.TileLand:   {139, 195, 74, 255},
+	island.TileSea:    {64, 164, 223, 255},
+	island.TileBridge: {121, 85, 72, 255},
+}
+
+// LevelFromImage reads a level drawn one pixel per tile. Each pixel becomes the
+// tile type with the nearest color, so slightly different shades still work.
+// ID and Name are left for the caller.
+func LevelFromImage(img image.Image) (*LevelData, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxImageSize || height > maxImageSize {
+		return nil, fmt.Errorf("%w: %dx%d, at most %dx%d", ErrImageTooLarge, width, height, maxImageSize, maxImageSize)
+	}
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("level image is empty")
+	}
+
+	grid := make([][]island.TileType, height)
+	for y := range grid {
+		grid[y] = make([]island.TileType, width)
+		for x := range grid[y] {
+			grid[y][x] = nearestTile(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return &LevelData{
+		Width:      width,
+		Height:     height,
+		Grid:       grid,
+		Objectives: defaultObjectives(),
+	}, nil
+}
+
+// ParseLevelPNG reads a level from a PNG file drawn one pixel per tile
+func ParseLevelPNG(data []byte) (*LevelData, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return LevelFromImage(img)
+}
+
+// IsPNG reports whether data starts with the PNG signature
+func IsPNG(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n"))
+}
+
+// LevelImage draws a level one pixel per tile, the reverse of LevelFromImage
+func LevelImage(level *LevelData) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, level.Width, level.Height))
+	for y, row := range level.Grid {
+		for x, tile := range row {
+			img.Set(x, y, TileColors[tile])
+		}
+	}
+	return img
+}
+
+// EncodeLevelPNG writes a level as a PNG with one pixel per tile
+func EncodeLevelPNG(level *LevelData) ([]byte, error) {
+	if err := level.Validate(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, LevelImage(level)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// nearestTile maps a pixel to the tile type whose color is closest
+func nearestTile(c color.Color) island.TileType {
+	r, g, b, a := c.RGBA()
+	if a < 0x8000 {
+		return island.TileEmpty
+	}
+	// Undo premultiplied alpha so half-transparent brushes keep their hue
+	r, g, b = r*0xffff/a>>8, g*0xffff/a>>8, b*0xffff/a>>8
+
+	best, bestDist := island.TileEmpty, -1
+	for _, tile := range []island.TileType{island.TileEmpty, island.TileLand, island.TileSea, island.TileBridge} {
+		want := TileColors[tile]
+		dr, dg, db := int(r)-int(want.R), int(g)-int(want.G), int(b)-int(want.B)
+		if dist := dr*dr + dg*dg + db*db; bestDist < 0 || dist < bestDist {
+			best, bestDist = tile, dist
+		}
+	}
+	return best
+}
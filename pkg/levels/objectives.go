@@ -0,0 +1,86 @@
+package levels
+
+import (
+	"fmt"
+	"time"
+)
+
+// ObjectiveState is the progress of a game that objectives are checked against
+type ObjectiveState struct {
+	Connected int // Islands in the largest connected group
+	Islands   int
+	Moves     int
+	Time      time.Duration
+	Won       bool
+}
+
+// ObjectiveStatus says whether an objective is still open, achieved or out of reach
+type ObjectiveStatus int
+
+const (
+	ObjectiveInProgress ObjectiveStatus = iota
+	ObjectiveMet
+	ObjectiveFailed
+)
+
+// ObjectiveProgress is one objective's line on the checklist
+type ObjectiveProgress struct {
+	Text   string
+	Status ObjectiveStatus
+}
+
+// Evaluate checks the objective against the game so far. Limits such as
+// "min_bridges" and "time_limit" fail as soon as they are exceeded and are only
+// met once the game is won within them.
+func (o Objective) Evaluate(state ObjectiveState) ObjectiveProgress {
+	switch o.Type {
+	case "connect_all":
+		status := ObjectiveInProgress
+		if state.Won || (state.Islands > 0 && state.Connected == state.Islands) {
+			status = ObjectiveMet
+		}
+		return ObjectiveProgress{Text: fmt.Sprintf("Connect all islands %d/%d", state.Connected, state.Islands), Status: status}
+	case "min_bridges":
+		return ObjectiveProgress{
+			Text:   fmt.Sprintf("Bridges used %d/%d", state.Moves, o.Target),
+			Status: limitStatus(state.Moves > o.Target, state.Won),
+		}
+	case "time_limit":
+		limit := time.Duration(o.Target) * time.Second
+		return ObjectiveProgress{
+			Text:   fmt.Sprintf("Time %s/%s", clockText(state.Time), clockText(limit)),
+			Status: limitStatus(state.Time > limit, state.Won),
+		}
+	default:
+		status := ObjectiveInProgress
+		if state.Won {
+			status = ObjectiveMet
+		}
+		return ObjectiveProgress{Text: o.Description, Status: status}
+	}
+}
+
+// EvaluateObjectives checks every objective of a level, in order
+func EvaluateObjectives(objectives []Objective, state ObjectiveState) []ObjectiveProgress {
+	progress := make([]ObjectiveProgress, len(objectives))
+	for i, objective := range objectives {
+		progress[i] = objective.Evaluate(state)
+	}
+	return progress
+}
+
+func limitStatus(exceeded, won bool) ObjectiveStatus {
+	switch {
+	case exceeded:
+		return ObjectiveFailed
+	case won:
+		return ObjectiveMet
+	default:
+		return ObjectiveInProgress
+	}
+}
+
+// clockText shows a duration as m:ss
+func clockText(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,157 @@
+package levels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PackFormat identifies level pack files
+const PackFormat = "island-merge-pack"
+
+var ErrInvalidPack = errors.New("invalid level pack")
+
+// LevelPack is a shareable set of levels with metadata
+type LevelPack struct {
+	Format      string       `json:"format"`
+	Version     int          `json:"version"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Author      string       `json:"author,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Difficulty  Difficulty   `json:"difficulty,omitempty"`
+	UnlockLevel int          `json:"unlock_level,omitempty"` // Completed levels needed to unlock a built-in set
+	Levels      []*LevelData `json:"levels"`
+}
+
+// ParsePack decodes and validates a level pack
+func ParsePack(data []byte) (*LevelPack, error) {
+	var pack LevelPack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPack, err)
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
+
+// Validate checks the pack metadata and that every grid matches its declared size
+func (p *LevelPack) Validate() error {
+	if p.Format != PackFormat {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidPack, p.Format)
+	}
+	if p.ID == "" || p.Name == "" {
+		return fmt.Errorf("%w: id and name are required", ErrInvalidPack)
+	}
+	if len(p.Levels) == 0 {
+		return fmt.Errorf("%w: pack has no levels", ErrInvalidPack)
+	}
+
+	seen := make(map[string]bool)
+	for i, level := range p.Levels {
+		if level == nil || level.ID == "" {
+			return fmt.Errorf("%w: level %d has no id", ErrInvalidPack, i)
+		}
+		if seen[level.ID] {
+			return fmt.Errorf("%w: duplicate level id %q", ErrInvalidPack, level.ID)
+		}
+		seen[level.ID] = true
+
+		if err := level.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPack, err)
+		}
+	}
+	return nil
+}
+
+// ParseLevel decodes and validates a single level
+func ParseLevel(data []byte) (*LevelData, error) {
+	var level LevelData
+	if err := json.Unmarshal(data, &level); err != nil {
+		return nil, err
+	}
+	if err := level.Validate(); err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+// Validate checks that the grid matches the declared size
+func (ld *LevelData) Validate() error {
+	if ld.Width <= 0 || ld.Height <= 0 || len(ld.Grid) != ld.Height {
+		return fmt.Errorf("level %q grid does not match %dx%d", ld.ID, ld.Width, ld.Height)
+	}
+	for _, row := range ld.Grid {
+		if len(row) != ld.Width {
+			return fmt.Errorf("level %q grid does not match %dx%d", ld.ID, ld.Width, ld.Height)
+		}
+	}
+	if ld.BridgeBudget < 0 {
+		return fmt.Errorf("level %q has a negative bridge budget", ld.ID)
+	}
+	return nil
+}
+
+// LoadPack parses a level pack and adds it as a new level set
+func (lm *LevelManager) LoadPack(data []byte) (*LevelSet, error) {
+	pack, err := ParsePack(data)
+	if err != nil {
+		return nil, err
+	}
+	return lm.ImportPack(pack)
+}
+
+// ImportPack adds a validated pack as a level set. Level IDs are namespaced by the
+// pack ID so they never collide with built-in levels; importing the same pack
+// again replaces the earlier copy.
+func (lm *LevelManager) ImportPack(pack *LevelPack) (*LevelSet, error) {
+	if err := pack.Validate(); err != nil {
+		return nil, err
+	}
+
+	levelSet := lm.newLevelSet(pack, false)
+	for i, existing := range lm.LevelSets {
+		if existing.PackID == pack.ID {
+			lm.LevelSets[i] = levelSet
+			return levelSet, nil
+		}
+	}
+	lm.LevelSets = append(lm.LevelSets, levelSet)
+	return levelSet, nil
+}
+
+// newLevelSet builds a level set from a pack. Built-in packs keep their level IDs
+// and unlock rules; other packs are namespaced and unlock level by level.
+func (lm *LevelManager) newLevelSet(pack *LevelPack, builtin bool) *LevelSet {
+	levelSet := &LevelSet{
+		Name:        pack.Name,
+		Difficulty:  pack.Difficulty,
+		Description: pack.Description,
+		Levels:      make([]*LevelData, 0, len(pack.Levels)),
+	}
+	if builtin {
+		levelSet.UnlockLevel = pack.UnlockLevel
+	} else {
+		levelSet.PackID = pack.ID
+	}
+	if pack.Author != "" {
+		levelSet.Description = strings.TrimSpace(levelSet.Description + " by " + pack.Author)
+	}
+
+	prefix := pack.ID + "/"
+	for _, level := range pack.Levels {
+		imported := *level
+		if !builtin && !strings.HasPrefix(imported.ID, prefix) {
+			imported.ID = prefix + imported.ID
+		}
+		imported.Unlocked = false
+		imported.Completed = false
+		imported.Tampered = !builtin && !imported.SignatureValid()
+		levelSet.Levels = append(levelSet.Levels, &imported)
+	}
+	levelSet.Levels[0].Unlocked = true
+
+	return levelSet
+}
@@ -0,0 +1,174 @@
+package levels
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Result is how a won level went, latest results first when recommending
+type Result struct {
+	LevelID string
+	Stars   int
+	Time    time.Duration // Zero when the game was untimed
+}
+
+// Recommendation is the level suggested to play next, and why
+type Recommendation struct {
+	Level  *LevelData
+	Reason string // e.g. "You breezed through Beginner; try Island Chains"
+}
+
+const (
+	recommendWindow = 3               // Latest wins in a set that decide how it is going
+	breezeStars     = 2.5             // Average stars at or above which a set was breezed through
+	struggleStars   = 1.5             // and below which it was a struggle
+	breezeMovePace  = 5 * time.Second // Par time per optimal move on levels without a time limit
+)
+
+// Recommend suggests the next level from the player's recent wins. Breezing
+// through a set, with high stars inside par time, moves on to the next harder
+// set; struggling suggests polishing a low-starred level of the set; otherwise
+// the set's next level follows. It reports false when nothing is left to play.
+func (lm *LevelManager) Recommend(recent []Result) (Recommendation, bool) {
+	set := lm.latestSet(recent)
+	if set == nil {
+		if level := lm.nextPlayable(nil); level != nil {
+			return Recommendation{Level: level, Reason: fmt.Sprintf("New here? Start with %s", level.Name)}, true
+		}
+		return Recommendation{}, false
+	}
+
+	stars, fast, count := 0, true, 0
+	for _, result := range recent {
+		level := lm.GetLevelByID(result.LevelID)
+		if level == nil || !set.contains(level) {
+			continue
+		}
+		stars += result.Stars
+		if par := parTime(level); par > 0 && result.Time > par {
+			fast = false
+		}
+		if count++; count == recommendWindow {
+			break
+		}
+	}
+	average := float64(stars) / float64(count)
+
+	switch {
+	case average >= breezeStars && fast:
+		if next := lm.harderSet(set); next != nil {
+			return Recommendation{Level: lm.nextPlayable(next), Reason: fmt.Sprintf("You breezed through %s; try %s", set.label(), next.Name)}, true
+		}
+		if level := lm.nextPlayable(set); level != nil {
+			return Recommendation{Level: level, Reason: fmt.Sprintf("You're breezing along; try %s", level.Name)}, true
+		}
+	case average < struggleStars:
+		if level := lowestStarred(set); level != nil {
+			return Recommendation{Level: level, Reason: fmt.Sprintf("Tough going in %s; polish %s for more stars", set.label(), level.Name)}, true
+		}
+	}
+
+	if level := lm.nextPlayable(set); level != nil {
+		return Recommendation{Level: level, Reason: fmt.Sprintf("Keep going: try %s", level.Name)}, true
+	}
+	if level := lm.nextPlayable(nil); level != nil {
+		return Recommendation{Level: level, Reason: fmt.Sprintf("%s is done; try %s", set.label(), level.Name)}, true
+	}
+	for _, other := range lm.LevelSets {
+		if level := lowestStarred(other); level != nil {
+			return Recommendation{Level: level, Reason: fmt.Sprintf("Chase a third star on %s", level.Name)}, true
+		}
+	}
+	return Recommendation{}, false
+}
+
+// latestSet is the set of the most recently won installed level
+func (lm *LevelManager) latestSet(recent []Result) *LevelSet {
+	for _, result := range recent {
+		for _, set := range lm.LevelSets {
+			for _, level := range set.Levels {
+				if level.ID == result.LevelID {
+					return set
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// harderSet is the first set after a built-in one with a level to play, or
+// nil; packs have no harder set
+func (lm *LevelManager) harderSet(set *LevelSet) *LevelSet {
+	if set.PackID != "" {
+		return nil
+	}
+	for _, next := range lm.LevelSets {
+		if next.PackID == "" && next.Difficulty > set.Difficulty && lm.nextPlayable(next) != nil {
+			return next
+		}
+	}
+	return nil
+}
+
+// nextPlayable is the first unlocked level not yet completed, in the set or,
+// for a nil set, in any set
+func (lm *LevelManager) nextPlayable(set *LevelSet) *LevelData {
+	for _, candidate := range lm.LevelSets {
+		if set != nil && candidate != set {
+			continue
+		}
+		for _, level := range candidate.Levels {
+			if level.Unlocked && !level.Completed {
+				return level
+			}
+		}
+	}
+	return nil
+}
+
+// lowestStarred is the set's completed level with the fewest best stars, the
+// earliest on a tie, or nil when every completed level has all three
+func lowestStarred(set *LevelSet) *LevelData {
+	var lowest *LevelData
+	for _, level := range set.Levels {
+		if !level.Completed || level.BestScore == nil || level.BestScore.Stars >= 3 {
+			continue
+		}
+		if lowest == nil || level.BestScore.Stars < lowest.BestScore.Stars {
+			lowest = level
+		}
+	}
+	return lowest
+}
+
+// parTime is how quickly a level has to be won to count as breezed through:
+// half its time limit, as for three stars, or a pace per optimal move. It is
+// zero for levels with neither.
+func parTime(level *LevelData) time.Duration {
+	if level.TimeLimit > 0 {
+		return level.TimeLimit / 2
+	}
+	return time.Duration(level.OptimalMoves) * breezeMovePace
+}
+
+func (ls *LevelSet) contains(level *LevelData) bool {
+	for _, candidate := range ls.Levels {
+		if candidate == level {
+			return true
+		}
+	}
+	return false
+}
+
+// label names a built-in set by its difficulty, e.g. "Beginner", and a pack by its name
+func (ls *LevelSet) label() string {
+	if ls.PackID != "" {
+		return ls.Name
+	}
+	label := ls.Difficulty.Label()
+	if label == "" {
+		return ls.Name
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,90 @@
+package levels
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// shareCodeVersion is the first byte of every share code
+const shareCodeVersion = 1
+
+// maxShareNameLen bounds the level name carried in a share code
+const maxShareNameLen = 32
+
+var ErrInvalidShareCode = errors.New("invalid share code")
+
+// EncodeShareCode packs a level's grid and name into a short URL-safe string:
+// a version byte, the width and height, two bits per tile, then the name
+func EncodeShareCode(level *LevelData) (string, error) {
+	if err := level.Validate(); err != nil {
+		return "", err
+	}
+	if level.Width > 255 || level.Height > 255 {
+		return "", fmt.Errorf("level %q is too large to share", level.ID)
+	}
+
+	tiles := level.Width * level.Height
+	data := make([]byte, 3+(tiles+3)/4)
+	data[0], data[1], data[2] = shareCodeVersion, byte(level.Width), byte(level.Height)
+	for i := 0; i < tiles; i++ {
+		tile := level.Grid[i/level.Width][i%level.Width]
+		if tile > island.TileBridge {
+			return "", fmt.Errorf("level %q has an unknown tile %d", level.ID, tile)
+		}
+		data[3+i/4] |= byte(tile) << (2 * (i % 4))
+	}
+
+	name := level.Name
+	if len(name) > maxShareNameLen {
+		name = name[:maxShareNameLen]
+	}
+	data = append(data, name...)
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeShareCode unpacks a level from EncodeShareCode. The ID is derived from
+// the code, so the same shared level always has the same ID.
+func DecodeShareCode(code string) (*LevelData, error) {
+	data, err := base64.RawURLEncoding.DecodeString(code)
+	if err != nil || len(data) < 3 {
+		return nil, ErrInvalidShareCode
+	}
+	if data[0] != shareCodeVersion {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrInvalidShareCode, data[0])
+	}
+
+	width, height := int(data[1]), int(data[2])
+	tiles := width * height
+	packed := (tiles + 3) / 4
+	if tiles == 0 || len(data) < 3+packed {
+		return nil, fmt.Errorf("%w: truncated", ErrInvalidShareCode)
+	}
+
+	grid := make([][]island.TileType, height)
+	for y := range grid {
+		grid[y] = make([]island.TileType, width)
+		for x := range grid[y] {
+			i := y*width + x
+			grid[y][x] = island.TileType(data[3+i/4] >> (2 * (i % 4)) & 3)
+		}
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(code))
+	level := &LevelData{
+		ID:         fmt.Sprintf("shared_%08x", hash.Sum32()),
+		Name:       string(data[3+packed:]),
+		Width:      width,
+		Height:     height,
+		Grid:       grid,
+		Objectives: defaultObjectives(),
+	}
+	if level.Name == "" {
+		level.Name = "Shared Level"
+	}
+	return level, nil
+}
@@ -0,0 +1,35 @@
+package levels
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// ContentHash fingerprints what a player sees of a level: its name, author and
+// layout. IDs are left out, since importing a pack renames its levels.
+func ContentHash(level *LevelData) string {
+	content, _ := json.Marshal(struct {
+		Name   string
+		Author string
+		Width  int
+		Height int
+		Grid   [][]island.TileType
+	}{level.Name, level.Author, level.Width, level.Height, level.Grid})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign records the level's content hash, for players it is shared with to check
+func (ld *LevelData) Sign() {
+	ld.Signature = ContentHash(ld)
+}
+
+// SignatureValid reports whether the level is unchanged since it was signed.
+// Unsigned levels pass. The hash is not secret, so this catches levels edited
+// by hand or by other tools, not a deliberate forgery.
+func (ld *LevelData) SignatureValid() bool {
+	return ld.Signature == "" || ld.Signature == ContentHash(ld)
+}
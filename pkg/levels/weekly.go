@@ -0,0 +1,39 @@
+package levels
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidWeekly = errors.New("invalid weekly level")
+
+// WeeklyLevel is the curated level of the week served as JSON
+type WeeklyLevel struct {
+	Week  string     `json:"week"` // ISO week, e.g. "2026-W42"
+	Level *LevelData `json:"level"`
+}
+
+// ParseWeekly decodes and validates a level of the week. The level ID is replaced by
+// the week's leaderboard ID so scores from different weeks never mix.
+func ParseWeekly(data []byte) (*WeeklyLevel, error) {
+	var weekly WeeklyLevel
+	if err := json.Unmarshal(data, &weekly); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWeekly, err)
+	}
+	if weekly.Week == "" || weekly.Level == nil {
+		return nil, fmt.Errorf("%w: week and level are required", ErrInvalidWeekly)
+	}
+	if err := weekly.Level.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWeekly, err)
+	}
+
+	weekly.Level.ID = weekly.LeaderboardID()
+	weekly.Level.Unlocked = true
+	return &weekly, nil
+}
+
+// LeaderboardID identifies the week's high score slot
+func (w *WeeklyLevel) LeaderboardID() string {
+	return "weekly/" + w.Week
+}
@@ -0,0 +1,24 @@
+// Package mobile is the entry point for the Android and iOS builds. Bind it
+// with ebitenmobile and host the generated view in a native app:
+//
+//	ebitenmobile bind -target android -javapkg com.ponyo877.islandmerge -o islandmerge.aar ./pkg/mobile
+//	ebitenmobile bind -target ios -o IslandMerge.xcframework ./pkg/mobile
+package mobile
+
+import (
+	ebitenmobile "github.com/hajimehoshi/ebiten/v2/mobile"
+	"github.com/ponyo877/island-merge/pkg/core"
+	"github.com/ponyo877/island-merge/pkg/ui"
+)
+
+// uiScale enlarges text and touch targets, since phones show the whole 640x480
+// screen in a few inches
+const uiScale = 1.5
+
+func init() {
+	ui.SetScale(uiScale)
+	ebitenmobile.SetGame(core.NewGame())
+}
+
+// Dummy is exported so gomobile generates bindings for this package
+func Dummy() {}
@@ -0,0 +1,60 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPTransport posts each message to the backend URL as JSON. Any 2xx
+// response accepts it; other 4xx responses, apart from timeouts and rate
+// limits, reject it.
+type HTTPTransport struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewHTTPTransport(url string) *HTTPTransport {
+	return &HTTPTransport{
+		URL:    url,
+		Client: &http.Client{Timeout: sendTimeout},
+	}
+}
+
+func (t *HTTPTransport) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", msg.Kind, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("failed to send %s: %s", msg.Kind, resp.Status)
+	default:
+		return fmt.Errorf("%w: %s", ErrRejected, resp.Status)
+	}
+}
+
+// Close drops idle connections
+func (t *HTTPTransport) Close() error {
+	t.Client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,509 @@
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Online matches share a board between players over a WebSocket. Every change
+// is a Delta, which the backend numbers and sends to each player in the
+// match, its sender included; seeing its own delta come back is how a client
+// knows it arrived.
+//
+// The backend keeps a match's deltas, and the seat of a player who drops, for
+// a grace period. A client whose connection fails rejoins with its session
+// token and the last delta it saw, and the backend sends every delta since.
+// Deltas the client sent while it was away are sent again; the backend drops
+// any it already has by their Ref.
+//
+// Frames are JSON text:
+//
+//	client: {"type": "join", "match": "...", "token": "...", "since": 12}
+//	server: {"type": "welcome", "token": "...", "seat": "...", "deltas": [...]}
+//	client: {"type": "delta", "delta": {...}}
+//	server: {"type": "delta", "delta": {...}}
+//	server: {"type": "error", "error": "..."}  (the seat is gone or the match is over)
+
+// DefaultMatchGrace is how long a dropped player may take to rejoin; the
+// backend must hold seats at least this long
+const DefaultMatchGrace = time.Minute
+
+// Delta kinds the game sends
+const (
+	DeltaPlayer = "player" // The sender's name, sent on joining
+	DeltaBridge = "bridge" // A bridge built at the body's tile
+	DeltaRemove = "remove" // A bridge removed
+	DeltaEmote  = "emote"
+
+	// Co-editing a level
+	DeltaTile   = "tile"   // A tile painted on the level
+	DeltaLevel  = "level"  // The whole level, on starting a session or resizing
+	DeltaCursor = "cursor" // The tile under the sender's pointer
+	DeltaChat   = "chat"
+)
+
+// ErrSessionLost is wrapped by errors for matches that cannot be rejoined
+var ErrSessionLost = errors.New("net: match session lost")
+
+// matchBackoff spaces out rejoin attempts, several of which fit in the grace period
+var matchBackoff = Backoff{Base: time.Second, Max: 10 * time.Second}
+
+// Delta is one change to a match's board
+type Delta struct {
+	Seq  int             `json:"seq,omitempty"`  // Order the backend applied it in; 0 until it has
+	Ref  string          `json:"ref"`            // The sender's ID for it, so a resend is not applied twice
+	Seat string          `json:"seat,omitempty"` // Player who sent it, filled in by the backend
+	Kind string          `json:"kind"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+type matchFrame struct {
+	Type   string  `json:"type"`
+	Match  string  `json:"match,omitempty"`
+	Token  string  `json:"token,omitempty"`
+	Since  int     `json:"since,omitempty"`
+	Seat   string  `json:"seat,omitempty"`
+	Delta  *Delta  `json:"delta,omitempty"`
+	Deltas []Delta `json:"deltas,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// MatchState is how a match's connection is doing
+type MatchState int
+
+const (
+	MatchConnecting   MatchState = iota
+	MatchLive                    // Joined, deltas flow both ways
+	MatchReconnecting            // Dropped; rejoining within the grace period
+	MatchLost                    // Could not rejoin; Err says why
+	MatchClosed                  // Left by the player
+)
+
+// MatchSession is what a player needs to rejoin a match. It is kept in
+// storage, so a game restarted within the grace period can rejoin too.
+type MatchSession struct {
+	URL     string    `json:"url"`
+	Match   string    `json:"match"`
+	Token   string    `json:"token,omitempty"`   // Issued by the backend on the first join
+	Unsent  []Delta   `json:"unsent,omitempty"`  // Sent but not seen back yet
+	Dropped time.Time `json:"dropped,omitempty"` // When the connection was lost; zero while connected
+}
+
+// SessionStore keeps the current match session between launches. The game
+// loop writes it: Match.Update saves what the connection changed.
+type SessionStore interface {
+	SaveMatchSession(data interface{}) error
+	LoadMatchSession(target interface{}) error
+	DeleteMatchSession()
+}
+
+// unstoredSession is the SessionStore of matches that keep no session
+type unstoredSession struct{}
+
+func (unstoredSession) SaveMatchSession(interface{}) error { return nil }
+func (unstoredSession) LoadMatchSession(interface{}) error { return nil }
+func (unstoredSession) DeleteMatchSession()                {}
+
+// Match is the player's connection to one online match. It joins and
+// rejoins in the background, so the game loop never waits on the network:
+// Send queues a delta, Poll collects the ones that arrived and Update saves
+// the session. Storage is only touched from the game loop.
+type Match struct {
+	Grace time.Duration
+
+	store SessionStore
+	dial  func(ctx context.Context, url string) (wsConn, error)
+
+	mu       sync.Mutex
+	session  MatchSession
+	seat     string
+	seq      int     // Last delta received
+	received []Delta // Waiting for Poll
+	state    MatchState
+	err      error
+	dirty    bool // The session changed since Update last saved it
+	forget   bool // The match is lost; Update deletes the session
+	wake     chan struct{}
+	cancel   context.CancelFunc
+}
+
+// SupportsMatches reports whether a backend URL can carry online matches,
+// which need a WebSocket
+func SupportsMatches(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "ws" || u.Scheme == "wss")
+}
+
+// NewMatchID returns a random ID for a new match, for the players to share
+func NewMatchID() (string, error) {
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// JoinMatch connects to a match on the backend at url. A session stored for
+// the same match is rejoined. With a nil store the session lasts only as long
+// as the Match, for matches not worth rejoining after a restart.
+func JoinMatch(url, matchID string, store SessionStore) *Match {
+	if store == nil {
+		store = unstoredSession{}
+	}
+	var stored MatchSession
+	if err := store.LoadMatchSession(&stored); err != nil || stored.URL != url || stored.Match != matchID {
+		stored = MatchSession{URL: url, Match: matchID}
+	}
+	return startMatch(stored, store)
+}
+
+// StoredMatch returns the stored match session, if there is one to rejoin and
+// its grace period has not run out. An expired session is forgotten.
+func StoredMatch(store SessionStore) (MatchSession, bool) {
+	var stored MatchSession
+	if err := store.LoadMatchSession(&stored); err != nil || stored.Match == "" || stored.Token == "" {
+		return MatchSession{}, false
+	}
+	if !stored.Dropped.IsZero() && time.Since(stored.Dropped) > DefaultMatchGrace {
+		store.DeleteMatchSession()
+		return MatchSession{}, false
+	}
+	return stored, true
+}
+
+// ResumeMatch rejoins the stored match, if StoredMatch finds one. Every delta
+// is sent again from the start, to rebuild the board.
+func ResumeMatch(store SessionStore) (*Match, bool) {
+	stored, ok := StoredMatch(store)
+	if !ok {
+		return nil, false
+	}
+	return startMatch(stored, store), true
+}
+
+func startMatch(session MatchSession, store SessionStore) *Match {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Match{
+		Grace:   DefaultMatchGrace,
+		store:   store,
+		dial:    dialWebSocket,
+		session: session,
+		wake:    make(chan struct{}, 1),
+		cancel:  cancel,
+	}
+	go m.run(ctx)
+	return m
+}
+
+// ID is the match's ID on the backend
+func (m *Match) ID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.session.Match
+}
+
+// State reports how the connection is doing
+func (m *Match) State() MatchState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// Err is why a lost match could not be rejoined
+func (m *Match) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Seat is the player's seat, for telling their own deltas apart; empty until joined
+func (m *Match) Seat() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.seat
+}
+
+// Send queues a delta for the backend and returns its Ref. It is kept until
+// the backend sends it back, through any number of reconnects.
+func (m *Match) Send(kind string, body interface{}) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	ref := hex.EncodeToString(id)
+
+	m.mu.Lock()
+	if m.state == MatchLost || m.state == MatchClosed {
+		m.mu.Unlock()
+		return "", fmt.Errorf("net: match %s is over", m.session.Match)
+	}
+	m.session.Unsent = append(m.session.Unsent, Delta{Ref: ref, Kind: kind, Body: data})
+	m.dirty = true
+	m.mu.Unlock()
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+	return ref, nil
+}
+
+// Poll returns the deltas that arrived since the last call, in the backend's
+// order. They include the player's own, compare their Seat with Seat; after
+// ResumeMatch they start from the first move.
+func (m *Match) Poll() []Delta {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	received := m.received
+	m.received = nil
+	return received
+}
+
+// Update saves the session when the connection changed it, or forgets it once
+// the match is lost. Call it once per frame, from the game loop.
+func (m *Match) Update() {
+	m.mu.Lock()
+	session, dirty, forget := m.session, m.dirty, m.forget
+	m.dirty, m.forget = false, false
+	session.Unsent = slices.Clone(session.Unsent)
+	m.mu.Unlock()
+
+	switch {
+	case forget:
+		m.store.DeleteMatchSession()
+	case dirty:
+		if err := m.store.SaveMatchSession(session); err != nil {
+			fmt.Println("Failed to save the match session:", err)
+		}
+	}
+}
+
+// Close leaves the match and forgets its session. Call it from the game loop.
+func (m *Match) Close() {
+	m.cancel()
+	m.mu.Lock()
+	if m.state != MatchLost {
+		m.state = MatchClosed
+	}
+	m.dirty, m.forget = false, false
+	m.mu.Unlock()
+	m.store.DeleteMatchSession()
+}
+
+// run joins the match, and rejoins whenever the connection fails until the
+// grace period runs out
+func (m *Match) run(ctx context.Context) {
+	attempt := 0
+	for {
+		conn, err := m.join(ctx)
+		if err == nil {
+			attempt = 0
+			err = m.serve(ctx, conn)
+			conn.Close()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrSessionLost) {
+			m.lose(err)
+			return
+		}
+
+		if dropped := m.drop(err); time.Since(dropped) > m.Grace {
+			m.lose(fmt.Errorf("%w: could not rejoin within %s", ErrSessionLost, m.Grace))
+			return
+		}
+		attempt++
+		select {
+		case <-time.After(matchBackoff.Delay(attempt)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// join connects and takes the player's seat, picking up the deltas missed
+func (m *Match) join(ctx context.Context) (wsConn, error) {
+	joinCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	m.mu.Lock()
+	url := m.session.URL
+	join := matchFrame{Type: "join", Match: m.session.Match, Token: m.session.Token, Since: m.seq}
+	m.mu.Unlock()
+
+	conn, err := m.dial(joinCtx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	var welcome matchFrame
+	err = writeMatchFrame(joinCtx, conn, join)
+	if err == nil {
+		err = readMatchFrame(joinCtx, conn, &welcome)
+	}
+	if err == nil && welcome.Type != "welcome" {
+		err = fmt.Errorf("%w: %s", ErrSessionLost, welcome.Error)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == MatchClosed {
+		conn.Close()
+		return nil, context.Canceled // Left while joining
+	}
+	m.session.Token = welcome.Token
+	m.session.Dropped = time.Time{}
+	m.seat = welcome.Seat
+	m.state = MatchLive
+	for _, delta := range welcome.Deltas {
+		m.receiveLocked(delta)
+	}
+	m.saveLocked()
+	return conn, nil
+}
+
+// serve sends queued deltas and takes in the backend's until the connection
+// fails. Deltas not seen back yet are sent again on every new connection.
+func (m *Match) serve(ctx context.Context, conn wsConn) error {
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	frames := make(chan matchFrame)
+	failed := make(chan error, 1)
+	go func() {
+		for {
+			var frame matchFrame
+			if err := readMatchFrame(readCtx, conn, &frame); err != nil {
+				failed <- err
+				return
+			}
+			select {
+			case frames <- frame:
+			case <-readCtx.Done():
+				return
+			}
+		}
+	}()
+
+	written := make(map[string]bool)
+	for {
+		m.mu.Lock()
+		var outgoing []Delta
+		for _, delta := range m.session.Unsent {
+			if !written[delta.Ref] {
+				outgoing = append(outgoing, delta)
+			}
+		}
+		m.mu.Unlock()
+		for _, delta := range outgoing {
+			writeCtx, cancelWrite := context.WithTimeout(ctx, sendTimeout)
+			err := writeMatchFrame(writeCtx, conn, matchFrame{Type: "delta", Delta: &delta})
+			cancelWrite()
+			if err != nil {
+				return err
+			}
+			written[delta.Ref] = true
+		}
+
+		select {
+		case frame := <-frames:
+			switch {
+			case frame.Type == "error":
+				return fmt.Errorf("%w: %s", ErrSessionLost, frame.Error)
+			case frame.Type == "delta" && frame.Delta != nil:
+				m.mu.Lock()
+				if m.receiveLocked(*frame.Delta) {
+					m.saveLocked()
+				}
+				m.mu.Unlock()
+			}
+		case err := <-failed:
+			return err
+		case <-m.wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// receiveLocked takes in a delta once and reports whether it settled one of
+// the player's own
+func (m *Match) receiveLocked(delta Delta) bool {
+	if delta.Seq <= m.seq {
+		return false // Seen before the connection dropped
+	}
+	m.seq = delta.Seq
+	m.received = append(m.received, delta)
+	unsent := len(m.session.Unsent)
+	m.session.Unsent = slices.DeleteFunc(m.session.Unsent, func(d Delta) bool {
+		return d.Ref == delta.Ref
+	})
+	return len(m.session.Unsent) < unsent
+}
+
+// drop records a lost connection and returns when the match was first lost
+func (m *Match) drop(err error) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = MatchReconnecting
+	if m.session.Dropped.IsZero() {
+		fmt.Println("Match connection lost, rejoining:", err)
+		m.session.Dropped = time.Now()
+		m.saveLocked()
+	}
+	return m.session.Dropped
+}
+
+func (m *Match) lose(err error) {
+	fmt.Println("Match lost:", err)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == MatchClosed {
+		return // Left while the connection was failing
+	}
+	m.state = MatchLost
+	m.err = err
+	m.dirty, m.forget = false, true
+}
+
+// saveLocked marks the session for Update to save
+func (m *Match) saveLocked() {
+	if m.state == MatchClosed {
+		return // Left while a join was finishing
+	}
+	m.dirty = true
+}
+
+func writeMatchFrame(ctx context.Context, conn wsConn, frame matchFrame) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	return conn.WriteText(ctx, data)
+}
+
+func readMatchFrame(ctx context.Context, conn wsConn, frame *matchFrame) error {
+	data, err := conn.ReadText(ctx)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, frame); err != nil {
+		return fmt.Errorf("bad match frame: %w", err)
+	}
+	return nil
+}
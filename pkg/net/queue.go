@@ -0,0 +1,146 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	sendAttempts = 3   // Quick tries per send before the queue backs off
+	maxQueued    = 200 // Oldest messages are dropped beyond this
+)
+
+// Store keeps messages that have not been delivered yet between launches
+type Store interface {
+	SaveOutbox(data interface{}) error
+	LoadOutbox(target interface{}) error
+}
+
+// Pending is a queued message and how its delivery is going
+type Pending struct {
+	Message
+	Queued      time.Time `json:"queued"`
+	Attempts    int       `json:"attempts,omitempty"`     // Sends that failed so far
+	NextAttempt time.Time `json:"next_attempt,omitempty"` // When the backoff allows the next send
+}
+
+// Queue delivers messages in order in the background, so the game loop never
+// waits on the network. Messages are saved as soon as they are queued and
+// removed once the backend accepts them. While the backend is unreachable the
+// queue backs off between tries.
+type Queue struct {
+	store     Store
+	transport Transport
+	backoff   Backoff
+	pending   []Pending
+	sending   bool
+	results   chan error
+}
+
+func NewQueue(store Store) *Queue {
+	q := &Queue{
+		store:   store,
+		backoff: DefaultBackoff,
+		results: make(chan error, 1),
+	}
+	if err := store.LoadOutbox(&q.pending); err != nil {
+		q.pending = nil
+	}
+	return q
+}
+
+// SetTransport changes where messages go; nil holds them until one is set.
+// Messages waiting on a backoff are sent to the new transport straight away.
+func (q *Queue) SetTransport(transport Transport) {
+	if q.transport != nil {
+		// A send may still be using it
+		go q.transport.Close()
+	}
+	q.transport = transport
+	q.RetryNow()
+}
+
+// RetryNow lets the next message go without waiting out its backoff, e.g.
+// when the connection returns
+func (q *Queue) RetryNow() {
+	if len(q.pending) > 0 {
+		q.pending[0].NextAttempt = time.Time{}
+	}
+}
+
+// Pending returns how many messages are waiting to be delivered
+func (q *Queue) Pending() int {
+	return len(q.pending)
+}
+
+// Enqueue saves a message for delivery
+func (q *Queue) Enqueue(kind string, body interface{}) error {
+	msg, err := NewMessage(kind, body)
+	if err != nil {
+		return err
+	}
+	q.pending = append(q.pending, Pending{Message: msg, Queued: time.Now()})
+	if len(q.pending) > maxQueued && !q.sending {
+		q.pending = q.pending[len(q.pending)-maxQueued:]
+	}
+	q.save()
+	return nil
+}
+
+func (q *Queue) save() {
+	if err := q.store.SaveOutbox(q.pending); err != nil {
+		fmt.Println("Failed to save the outbox:", err)
+	}
+}
+
+// Update picks up a finished send and starts the next one when it is due.
+// Call it once per frame.
+func (q *Queue) Update() {
+	select {
+	case err := <-q.results:
+		q.sending = false
+		q.finish(err)
+	default:
+	}
+
+	if q.transport == nil || q.sending || len(q.pending) == 0 {
+		return
+	}
+	if time.Now().Before(q.pending[0].NextAttempt) {
+		return
+	}
+
+	msg := q.pending[0].Message
+	transport := q.transport
+	q.sending = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout*sendAttempts)
+		defer cancel()
+		q.results <- Retry(ctx, sendAttempts, retryBackoff, func(ctx context.Context) error {
+			return transport.Send(ctx, msg)
+		})
+	}()
+}
+
+// finish removes a delivered or rejected message from the front of the queue,
+// or backs off before sending it again
+func (q *Queue) finish(err error) {
+	if len(q.pending) == 0 {
+		return
+	}
+	switch {
+	case err == nil:
+		q.pending = q.pending[1:]
+	case errors.Is(err, ErrRejected):
+		fmt.Printf("Dropped %s %s: %v\n", q.pending[0].Kind, q.pending[0].ID, err)
+		q.pending = q.pending[1:]
+	default:
+		fmt.Println("Send failed, will retry:", err)
+		head := &q.pending[0]
+		head.Attempts++
+		head.NextAttempt = time.Now().Add(q.backoff.Delay(head.Attempts))
+	}
+	q.save()
+}
@@ -0,0 +1,50 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// Backoff spaces out retries. Each delay doubles from Base up to Max, plus up
+// to a quarter more at random so clients that lost the connection together do
+// not all retry at once.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+var (
+	// retryBackoff is for quick retries within one send, over a brief drop
+	retryBackoff = Backoff{Base: 500 * time.Millisecond, Max: 2 * time.Second}
+	// DefaultBackoff spaces out the queue's sends while the backend is unreachable
+	DefaultBackoff = Backoff{Base: 5 * time.Second, Max: 5 * time.Minute}
+)
+
+// Delay is how long to wait after the given failed attempt, counting from 1
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Base
+	for i := 1; i < attempt && delay < b.Max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.Max)
+	return delay + time.Duration(rand.Int63n(int64(delay)/4+1))
+}
+
+// Retry sends up to attempts times, waiting out the backoff in between. It
+// gives up early on rejected messages and when ctx is done.
+func Retry(ctx context.Context, attempts int, backoff Backoff, send func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = send(ctx); err == nil || errors.Is(err, ErrRejected) || attempt == attempts {
+			return err
+		}
+		select {
+		case <-time.After(backoff.Delay(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+// Package net carries score submissions and level uploads to the online
+// backend. A Transport delivers one message over HTTP or a WebSocket, and a
+// Queue keeps messages in storage until they are delivered, so nothing is lost
+// while the game is offline. A Match shares a level or board between players
+// in an online match and keeps them in it through dropped connections.
+package net
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	sendTimeout     = 10 * time.Second
+	maxResponseSize = 1 << 20 // Replies and match frames are small; refuse anything larger
+)
+
+// Message kinds the backend accepts
+const (
+	KindScore  = "score"  // A verified result for the online leaderboards
+	KindLevel  = "level"  // A level pack shared with other players
+	KindTurn   = "turn"   // A correspondence game after the sender's turn, for the backend to pass on
+	KindRating = "rating" // A rated race against the AI and the rating it left
+)
+
+// ErrRejected is wrapped by errors for messages the backend refused; sending
+// them again would fail the same way
+var ErrRejected = errors.New("net: backend rejected the message")
+
+// Message is one submission to the backend
+type Message struct {
+	ID   string          `json:"id"` // Lets the backend ignore a message delivered twice
+	Kind string          `json:"kind"`
+	Body json.RawMessage `json:"body"`
+}
+
+// NewMessage wraps a body of the given kind with a new random ID
+func NewMessage(kind string, body interface{}) (Message, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return Message{}, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return Message{}, err
+	}
+	return Message{ID: hex.EncodeToString(id), Kind: kind, Body: data}, nil
+}
+
+// Transport delivers messages to the backend
+type Transport interface {
+	// Send delivers a message and waits until the backend accepts it
+	Send(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// NewTransport picks a transport by the backend URL's scheme: http and https
+// post each message, ws and wss keep a WebSocket open
+func NewTransport(rawURL string) (Transport, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return NewHTTPTransport(rawURL), nil
+	case "ws", "wss":
+		return NewWebSocketTransport(rawURL), nil
+	}
+	return nil, fmt.Errorf("net: unsupported backend URL %q", rawURL)
+}
@@ -0,0 +1,97 @@
+package net
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var errClosed = errors.New("connection closed by the backend")
+
+// wsConn is an open WebSocket exchanging text messages. dialWebSocket opens
+// one over a raw connection on desktop and mobile, or through the browser.
+type wsConn interface {
+	WriteText(ctx context.Context, data []byte) error
+	ReadText(ctx context.Context) ([]byte, error)
+	Close() error
+}
+
+// wsReply is the backend's answer to a message sent over a WebSocket
+type wsReply struct {
+	ID    string `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"` // Why a message was rejected
+}
+
+// WebSocketTransport keeps one connection open and sends each message as a
+// JSON text frame, then waits for the reply with the same ID:
+// {"id": "...", "ok": true}, or "ok": false with an "error" to reject it. The
+// connection is opened on the first send and again after it fails.
+type WebSocketTransport struct {
+	URL  string
+	mu   sync.Mutex
+	conn wsConn
+}
+
+func NewWebSocketTransport(url string) *WebSocketTransport {
+	return &WebSocketTransport{URL: url}
+}
+
+func (t *WebSocketTransport) Send(ctx context.Context, msg Message) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn == nil {
+		conn, err := dialWebSocket(ctx, t.URL)
+		if err != nil {
+			return fmt.Errorf("failed to connect: %w", err)
+		}
+		t.conn = conn
+	}
+	err := t.exchange(ctx, msg)
+	if err != nil && !errors.Is(err, ErrRejected) {
+		// A connection that failed mid-message may be out of step; start over
+		t.conn.Close()
+		t.conn = nil
+	}
+	return err
+}
+
+func (t *WebSocketTransport) exchange(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if err := t.conn.WriteText(ctx, data); err != nil {
+		return fmt.Errorf("failed to send %s: %w", msg.Kind, err)
+	}
+
+	for {
+		data, err := t.conn.ReadText(ctx)
+		if err != nil {
+			return fmt.Errorf("no reply to %s: %w", msg.Kind, err)
+		}
+		var reply wsReply
+		if err := json.Unmarshal(data, &reply); err != nil || reply.ID != msg.ID {
+			continue // Not this message's reply, e.g. a late one to a send that timed out
+		}
+		if !reply.OK {
+			return fmt.Errorf("%w: %s", ErrRejected, reply.Error)
+		}
+		return nil
+	}
+}
+
+func (t *WebSocketTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
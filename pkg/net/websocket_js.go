@@ -0,0 +1,99 @@
+// +build js,wasm
+
+package net
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall/js"
+)
+
+// browserConn is a WebSocket opened by the browser. Its events arrive on the
+// JavaScript event loop and are handed over through channels.
+type browserConn struct {
+	ws        js.Value
+	messages  chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+	handlers  []js.Func
+}
+
+func dialWebSocket(ctx context.Context, rawURL string) (wsConn, error) {
+	constructor := js.Global().Get("WebSocket")
+	if constructor.IsUndefined() {
+		return nil, errors.New("websockets are not supported here")
+	}
+
+	c := &browserConn{
+		ws:       constructor.New(rawURL),
+		messages: make(chan []byte, 16),
+		closed:   make(chan struct{}),
+	}
+	opened := make(chan struct{})
+	c.on("open", func(js.Value) {
+		close(opened)
+	})
+	c.on("message", func(event js.Value) {
+		data := event.Get("data")
+		if data.Type() != js.TypeString {
+			return // Frames are JSON text
+		}
+		select {
+		case c.messages <- []byte(data.String()):
+		default: // Nobody is reading this many frames
+		}
+	})
+	// An error is always followed by close, which releases the handlers
+	c.on("close", func(js.Value) {
+		c.closeOnce.Do(func() { close(c.closed) })
+		for _, handler := range c.handlers {
+			handler.Release()
+		}
+	})
+
+	select {
+	case <-opened:
+		return c, nil
+	case <-c.closed:
+		return nil, errClosed
+	case <-ctx.Done():
+		c.Close()
+		return nil, ctx.Err()
+	}
+}
+
+func (c *browserConn) on(event string, handle func(js.Value)) {
+	handler := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		handle(args[0])
+		return nil
+	})
+	c.handlers = append(c.handlers, handler)
+	c.ws.Call("addEventListener", event, handler)
+}
+
+func (c *browserConn) WriteText(ctx context.Context, data []byte) error {
+	select {
+	case <-c.closed:
+		return errClosed
+	default:
+	}
+	c.ws.Call("send", string(data))
+	return nil
+}
+
+func (c *browserConn) ReadText(ctx context.Context) ([]byte, error) {
+	select {
+	case message := <-c.messages:
+		return message, nil
+	case <-c.closed:
+		return nil, errClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *browserConn) Close() error {
+	c.ws.Call("close")
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package profiler times the game's systems each frame so the frame budget can
+// be broken down in an overlay. It measures wall-clock time and does nothing
+// while disabled, so it can stay wired into the game loop.
+package profiler
+
+import "time"
+
+// Phase is the half of the game loop a measurement belongs to
+type Phase int
+
+const (
+	Update Phase = iota
+	Draw
+	PhaseCount
+)
+
+// Section is a system whose time is measured
+type Section int
+
+const (
+	Input     Section = iota // Polling and shortcuts
+	Board                    // Bridge building, rules, timers and win checks
+	Animation                // Animations and particles
+	Render                   // The board and its highlights
+	UI                       // Menus, panels, HUD and hover
+	Other                    // Background downloads, uploads and file polling
+	SectionCount
+)
+
+// SectionNames label the sections, indexed by Section
+var SectionNames = [SectionCount]string{"Input", "Board", "Animation", "Render", "UI", "Other"}
+
+// HistorySize is how many frames are kept
+const HistorySize = 120
+
+// Frame holds the time each section took in one frame
+type Frame [PhaseCount][SectionCount]time.Duration
+
+// Total is the frame's measured time
+func (f *Frame) Total() time.Duration {
+	var total time.Duration
+	for phase := range f {
+		for _, d := range f[phase] {
+			total += d
+		}
+	}
+	return total
+}
+
+// Profiler records section timings for the last HistorySize frames. Enter
+// starts timing a section and stops the one running; Exit stops it.
+type Profiler struct {
+	enabled bool
+	frames  [HistorySize]Frame
+	current int // Index of the frame being recorded
+	count   int // Frames recorded, up to HistorySize
+
+	running bool
+	phase   Phase
+	section Section
+	start   time.Time
+}
+
+func New() *Profiler {
+	return &Profiler{}
+}
+
+// SetEnabled starts or stops profiling; starting again clears the history
+func (p *Profiler) SetEnabled(enabled bool) {
+	if enabled && !p.enabled {
+		p.frames = [HistorySize]Frame{}
+		p.count = 0
+	}
+	p.enabled = enabled
+	p.running = false
+}
+
+func (p *Profiler) Enabled() bool {
+	return p.enabled
+}
+
+// BeginFrame starts recording a new frame; call it at the start of each update
+func (p *Profiler) BeginFrame() {
+	if !p.enabled {
+		return
+	}
+	p.Exit()
+	p.current = (p.current + 1) % HistorySize
+	p.frames[p.current] = Frame{}
+	p.count = min(p.count+1, HistorySize)
+}
+
+// Enter stops timing the running section and starts timing another. Time spent
+// in a section more than once a frame adds up.
+func (p *Profiler) Enter(phase Phase, section Section) {
+	if !p.enabled {
+		return
+	}
+	now := time.Now()
+	p.stop(now)
+	p.running = true
+	p.phase, p.section, p.start = phase, section, now
+}
+
+// Exit stops timing the running section
+func (p *Profiler) Exit() {
+	if !p.enabled {
+		return
+	}
+	p.stop(time.Now())
+}
+
+func (p *Profiler) stop(now time.Time) {
+	if p.running {
+		p.frames[p.current][p.phase][p.section] += now.Sub(p.start)
+		p.running = false
+	}
+}
+
+// Frames returns the recorded frames, oldest first. The frame being recorded
+// is left out.
+func (p *Profiler) Frames() []Frame {
+	if p.count < 2 {
+		return nil
+	}
+	frames := make([]Frame, 0, p.count-1)
+	for i := p.count - 1; i >= 1; i-- {
+		frames = append(frames, p.frames[(p.current-i+HistorySize)%HistorySize])
+	}
+	return frames
+}
+
+// Average is the mean time of each section over the recorded frames
+func (p *Profiler) Average() Frame {
+	var average Frame
+	frames := p.Frames()
+	if len(frames) == 0 {
+		return average
+	}
+	for _, frame := range frames {
+		for phase := range frame {
+			for section, d := range frame[phase] {
+				average[phase][section] += d
+			}
+		}
+	}
+	for phase := range average {
+		for section := range average[phase] {
+			average[phase][section] /= time.Duration(len(frames))
+		}
+	}
+	return average
+}
@@ -0,0 +1,208 @@
+package quests
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// Goal is what a quest counts
+type Goal int
+
+const (
+	GoalBridges     Goal = iota // Bridges built
+	GoalWins                    // Games won
+	GoalMerges                  // Island groups joined
+	GoalPerfectWins             // Games won in the fewest moves
+	GoalMutatedWins             // Games won under at least one mutator
+	GoalThreeStars              // Three-star results on levels of the quest's difficulty
+)
+
+// Period is how long a quest lasts before it is replaced
+type Period int
+
+const (
+	Daily Period = iota
+	Weekly
+)
+
+// Quest is one small goal, offered for a day or a week. Tokens are earned
+// when it is completed and unlock cosmetics.
+type Quest struct {
+	ID          string            `json:"id"`
+	Period      Period            `json:"period"`
+	Goal        Goal              `json:"goal"`
+	Difficulty  levels.Difficulty `json:"difficulty,omitempty"` // Levels that count for GoalThreeStars
+	Description string            `json:"description"`
+	Target      int               `json:"target"`
+	Progress    int               `json:"progress"`
+	Tokens      int               `json:"tokens"`
+}
+
+// Done reports whether the quest is complete
+func (q *Quest) Done() bool {
+	return q.Progress >= q.Target
+}
+
+// Quests offered at once
+const (
+	dailyQuests  = 3
+	weeklyQuests = 2
+)
+
+// dailyPool and weeklyPool are the quests drawn from; changing them changes
+// what every player is offered, so only add to the end
+var dailyPool = []Quest{
+	{ID: "daily_bridges", Goal: GoalBridges, Target: 15, Tokens: 1, Description: "Build 15 bridges today"},
+	{ID: "daily_wins", Goal: GoalWins, Target: 3, Tokens: 1, Description: "Win 3 games today"},
+	{ID: "daily_merges", Goal: GoalMerges, Target: 20, Tokens: 1, Description: "Join islands 20 times today"},
+	{ID: "daily_perfect", Goal: GoalPerfectWins, Target: 1, Tokens: 1, Description: "Win a game in the fewest moves today"},
+	{ID: "daily_beginner_stars", Goal: GoalThreeStars, Difficulty: levels.DifficultyBeginner, Target: 1, Tokens: 1, Description: "3-star any Beginner level today"},
+	{ID: "daily_mutated", Goal: GoalMutatedWins, Target: 1, Tokens: 1, Description: "Win a game with a mutator today"},
+}
+
+var weeklyPool = []Quest{
+	{ID: "weekly_bridges", Goal: GoalBridges, Target: 100, Tokens: 3, Description: "Build 100 bridges this week"},
+	{ID: "weekly_wins", Goal: GoalWins, Target: 15, Tokens: 3, Description: "Win 15 games this week"},
+	{ID: "weekly_intermediate_stars", Goal: GoalThreeStars, Difficulty: levels.DifficultyIntermediate, Target: 1, Tokens: 3, Description: "3-star any Intermediate level this week"},
+	{ID: "weekly_expert_stars", Goal: GoalThreeStars, Difficulty: levels.DifficultyExpert, Target: 1, Tokens: 3, Description: "3-star any Expert level this week"},
+	{ID: "weekly_mutated", Goal: GoalMutatedWins, Target: 3, Tokens: 3, Description: "Win 3 games with mutators this week"},
+	{ID: "weekly_perfect", Goal: GoalPerfectWins, Target: 5, Tokens: 3, Description: "Win 5 games in the fewest moves this week"},
+}
+
+// Log holds the quests on offer and the tokens earned so far. Quests rotate
+// at midnight UTC and at the start of each ISO week, and everyone is offered
+// the same ones on the same day.
+type Log struct {
+	Day    string   `json:"day"`  // e.g. "2026-10-16", UTC
+	Week   string   `json:"week"` // ISO week, e.g. "2026-W42"
+	Quests []*Quest `json:"quests"`
+	Tokens int      `json:"tokens"` // Earned from every quest completed
+
+	listeners []func(*Quest)
+}
+
+// NewLog creates an empty log; Refresh fills it
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Reset forgets the quests and tokens; completion listeners stay registered
+func (l *Log) Reset() {
+	l.Day, l.Week, l.Quests, l.Tokens = "", "", nil, 0
+}
+
+// OnQuestCompleted registers a callback run as each quest is completed
+func (l *Log) OnQuestCompleted(callback func(*Quest)) {
+	l.listeners = append(l.listeners, callback)
+}
+
+// DayKey returns the UTC day containing t and when it ends
+func DayKey(t time.Time) (string, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return start.Format("2006-01-02"), start.AddDate(0, 0, 1)
+}
+
+// Refresh replaces the quests of a day or week that has ended, keeping the
+// progress of the ones still running, and reports whether any were replaced
+func (l *Log) Refresh(now time.Time) bool {
+	day, _ := DayKey(now)
+	week, _ := levels.ISOWeek(now)
+	if day == l.Day && week == l.Week {
+		return false
+	}
+
+	kept := make([]*Quest, 0, dailyQuests+weeklyQuests)
+	if day == l.Day {
+		kept = append(kept, l.period(Daily)...)
+	} else {
+		kept = append(kept, draw(dailyPool, dailyQuests, day, Daily)...)
+	}
+	if week == l.Week {
+		kept = append(kept, l.period(Weekly)...)
+	} else {
+		kept = append(kept, draw(weeklyPool, weeklyQuests, week, Weekly)...)
+	}
+	l.Day, l.Week, l.Quests = day, week, kept
+	return true
+}
+
+// period returns the quests of one period, in the order offered
+func (l *Log) period(period Period) []*Quest {
+	quests := make([]*Quest, 0)
+	for _, quest := range l.Quests {
+		if quest.Period == period {
+			quests = append(quests, quest)
+		}
+	}
+	return quests
+}
+
+// draw picks count quests from a pool, seeded by the period's key
+func draw(pool []Quest, count int, key string, period Period) []*Quest {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64())))
+
+	drawn := make([]*Quest, 0, count)
+	for _, i := range rng.Perm(len(pool))[:min(count, len(pool))] {
+		quest := pool[i]
+		quest.Period = period
+		drawn = append(drawn, &quest)
+	}
+	return drawn
+}
+
+// Record adds to the progress of every unfinished quest counting goal
+func (l *Log) Record(goal Goal, amount int) {
+	for _, quest := range l.Quests {
+		if quest.Goal == goal && quest.Goal != GoalThreeStars {
+			l.advance(quest, amount)
+		}
+	}
+}
+
+// RecordThreeStars counts a three-star result on a level of the given difficulty
+func (l *Log) RecordThreeStars(difficulty levels.Difficulty) {
+	for _, quest := range l.Quests {
+		if quest.Goal == GoalThreeStars && quest.Difficulty == difficulty {
+			l.advance(quest, 1)
+		}
+	}
+}
+
+func (l *Log) advance(quest *Quest, amount int) {
+	if quest.Done() {
+		return
+	}
+	quest.Progress = min(quest.Target, quest.Progress+amount)
+	if quest.Done() {
+		l.Tokens += quest.Tokens
+		for _, callback := range l.listeners {
+			callback(quest)
+		}
+	}
+}
+
+// SaveToJSON encodes the log for storage
+func (l *Log) SaveToJSON() (string, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// LoadFromJSON restores a log saved by SaveToJSON
+func (l *Log) LoadFromJSON(jsonStr string) error {
+	var saved Log
+	if err := json.Unmarshal([]byte(jsonStr), &saved); err != nil {
+		return err
+	}
+	l.Day, l.Week, l.Quests, l.Tokens = saved.Day, saved.Week, saved.Quests, saved.Tokens
+	return nil
+}
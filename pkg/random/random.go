@@ -0,0 +1,46 @@
+// Package random derives everything random in a game from a single seed, so
+// the game can be played again exactly from the seed on its results screen.
+package random
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Source is the seed of one game. Each use of randomness draws from a stream
+// of its own, so drawing more numbers for one use never changes another.
+type Source struct {
+	seed int64
+}
+
+// New returns the source for a seed
+func New(seed int64) *Source {
+	return &Source{seed: seed}
+}
+
+// FromClock returns a source with a fresh seed, for games nobody asked to repeat
+func FromClock() *Source {
+	return New(time.Now().UnixNano())
+}
+
+// Seed is the number that reproduces the game
+func (s *Source) Seed() int64 {
+	return s.seed
+}
+
+// Stream returns the generator for a named use, such as "board"
+func (s *Source) Stream(name string) *rand.Rand {
+	return s.StreamAt(name, 0)
+}
+
+// StreamAt returns the generator for step n of a named use, such as the
+// hazards after move n. Steps do not depend on each other, so a resumed game
+// rolls the same numbers as one played without a break.
+func (s *Source) StreamAt(name string, n int64) *rand.Rand {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	binary.Write(hash, binary.LittleEndian, n)
+	return rand.New(rand.NewSource(s.seed ^ int64(hash.Sum64())))
+}
@@ -0,0 +1,36 @@
+// Package rating scores race results with an Elo-style rating. A win against
+// a higher-rated opponent gains more than one against a lower-rated one, and a
+// clear win or loss moves the rating further than a close one.
+package rating
+
+import "math"
+
+const (
+	Initial = 1200 // Rating of a player's first race
+	Floor   = 100  // Losses never take a rating below this
+
+	kFactor = 32 // An even race won by a usual margin moves the rating by half this
+)
+
+// Expected is the chance a player rated player beats one rated opponent
+func Expected(player, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-player)/400))
+}
+
+// Change is how far a race moves the player's rating. Margin runs from 0 for
+// a race decided at the line to 1 for one the loser barely started, and scales
+// the change from half to one and a half times the usual.
+func Change(player, opponent int, won bool, margin float64) int {
+	score := 0.0
+	if won {
+		score = 1
+	}
+	margin = math.Max(0, math.Min(1, margin))
+	k := kFactor * (0.5 + margin)
+	return int(math.Round(k * (score - Expected(player, opponent))))
+}
+
+// Apply returns the player's rating after a race, never below Floor
+func Apply(player, opponent int, won bool, margin float64) int {
+	return max(Floor, player+Change(player, opponent, won, margin))
+}
@@ -0,0 +1,97 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Vote is a level vote as the sharing backend receives it. Levels are named by
+// share code, since level IDs are only meaningful on the device that made them.
+type Vote struct {
+	ShareCode  string `json:"share_code"`
+	Up         bool   `json:"up"`
+	Difficulty int    `json:"difficulty,omitempty"` // 1 (easy) to 5 (hard); 0 when not estimated
+}
+
+// VotePoster sends level votes to the sharing backend in the background. Start
+// a post, then Poll each frame; one post is in flight at a time.
+type VotePoster struct {
+	URL     string
+	Client  *http.Client
+	posting bool
+	results chan error
+}
+
+func NewVotePoster(url string) *VotePoster {
+	return &VotePoster{
+		URL:     url,
+		Client:  &http.Client{Timeout: fetchTimeout},
+		results: make(chan error, 1),
+	}
+}
+
+// Enabled reports whether a backend URL is configured
+func (p *VotePoster) Enabled() bool {
+	return p.URL != ""
+}
+
+// Busy reports whether a post is in flight
+func (p *VotePoster) Busy() bool {
+	return p.posting
+}
+
+// Start posts votes unless the poster is disabled or already busy, and reports
+// whether it did
+func (p *VotePoster) Start(votes []Vote) bool {
+	if !p.Enabled() || p.posting || len(votes) == 0 {
+		return false
+	}
+	p.posting = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+		p.results <- p.Post(ctx, votes)
+	}()
+	return true
+}
+
+// Poll reports whether a post has finished, and its outcome, without blocking
+func (p *VotePoster) Poll() (bool, error) {
+	select {
+	case err := <-p.results:
+		p.posting = false
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+// Post sends votes as {"votes": [...]} and waits for the backend to accept them
+func (p *VotePoster) Post(ctx context.Context, votes []Vote) error {
+	body, err := json.Marshal(struct {
+		Votes []Vote `json:"votes"`
+	}{votes})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post level votes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post level votes: %s", resp.Status)
+	}
+	return nil
+}
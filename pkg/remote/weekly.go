@@ -0,0 +1,99 @@
+// Package remote downloads optional online content such as the level of the week
+// and sends level votes to the sharing backend.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+const (
+	fetchTimeout = 10 * time.Second
+	maxBodySize  = 1 << 20 // Level files are small; refuse anything larger
+)
+
+// WeeklyResult is the outcome of one download
+type WeeklyResult struct {
+	Data   []byte // Raw JSON, kept so it can be cached as served
+	Weekly *levels.WeeklyLevel
+	Err    error
+}
+
+// WeeklyFetcher downloads the level of the week in the background so the game loop
+// never waits on the network. Start a download, then Poll each frame.
+type WeeklyFetcher struct {
+	URL     string
+	Client  *http.Client
+	results chan WeeklyResult
+}
+
+func NewWeeklyFetcher(url string) *WeeklyFetcher {
+	return &WeeklyFetcher{
+		URL:     url,
+		Client:  &http.Client{Timeout: fetchTimeout},
+		results: make(chan WeeklyResult, 1),
+	}
+}
+
+// Enabled reports whether a download URL is configured
+func (f *WeeklyFetcher) Enabled() bool {
+	return f.URL != ""
+}
+
+// Start begins a download unless the fetcher is disabled
+func (f *WeeklyFetcher) Start() {
+	if !f.Enabled() {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+
+		data, weekly, err := f.Fetch(ctx)
+		f.results <- WeeklyResult{Data: data, Weekly: weekly, Err: err}
+	}()
+}
+
+// Poll returns a finished download, if any, without blocking
+func (f *WeeklyFetcher) Poll() (WeeklyResult, bool) {
+	select {
+	case result := <-f.results:
+		return result, true
+	default:
+		return WeeklyResult{}, false
+	}
+}
+
+// Fetch downloads and parses the level of the week
+func (f *WeeklyFetcher) Fetch(ctx context.Context) ([]byte, *levels.WeeklyLevel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download weekly level: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to download weekly level: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read weekly level: %w", err)
+	}
+
+	weekly, err := levels.ParseWeekly(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, weekly, nil
+}
@@ -0,0 +1,44 @@
+// Package replay records the bridges built in a game, in order, on a copy of
+// its starting board. It has no rendering dependencies, so the headless engine
+// can verify replays and the capture package can render them.
+package replay
+
+import (
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Move is one bridge placement or demolition in a replay
+type Move struct {
+	X, Y    int
+	Removed bool
+	Forced  bool          // Removed by the game, like a bridge collapsing in a storm, not by the player
+	At      time.Duration // Game time of the move; zero when unknown
+}
+
+// Replay is a starting board plus the bridges built on it, in order
+type Replay struct {
+	Start *island.Board
+	Moves []Move
+}
+
+// New starts recording from a copy of the board
+func New(start *island.Board) *Replay {
+	return &Replay{Start: start.Clone()}
+}
+
+// Record appends a bridge placement made at game time at
+func (r *Replay) Record(x, y int, at time.Duration) {
+	r.Moves = append(r.Moves, Move{X: x, Y: y, At: at})
+}
+
+// RecordRemoval appends a bridge demolition made at game time at
+func (r *Replay) RecordRemoval(x, y int, at time.Duration) {
+	r.Moves = append(r.Moves, Move{X: x, Y: y, Removed: true, At: at})
+}
+
+// RecordCollapse appends a bridge the game destroyed at game time at
+func (r *Replay) RecordCollapse(x, y int, at time.Duration) {
+	r.Moves = append(r.Moves, Move{X: x, Y: y, Removed: true, Forced: true, At: at})
+}
@@ -0,0 +1,146 @@
+// Package share renders result cards for posting a win outside the game.
+package share
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/assets"
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// Card size, the common link preview size of social networks
+const (
+	CardWidth  = 1200
+	CardHeight = 630
+)
+
+// Card layout: the board on the left, the result on the right
+const (
+	boardArea       = 540
+	boardLeft       = 45
+	boardTop        = (CardHeight - boardArea) / 2
+	textLeft        = boardLeft + boardArea + 55
+	textWidth       = CardWidth - textLeft - 45
+	glyphWidth      = 6 // Debug font glyph size before scaling
+	glyphHeight     = 16
+	starRadius      = 34
+	starSpacing     = 84
+	maxNameLength   = textWidth / (glyphWidth * 5)
+	maxPlayerLength = textWidth / (glyphWidth * 2)
+)
+
+// ErrCopyUnsupported is returned where nothing can be put on the clipboard
+var ErrCopyUnsupported = errors.New("copying is not supported here")
+
+// Card is the result of a won game
+type Card struct {
+	LevelName string
+	ModeName  string
+	Moves     int
+	Time      time.Duration
+	Points    int
+	Stars     int  // 0 to 3
+	Rated     bool // Stars are drawn only for levels that award them
+	Board     *island.Board
+	Player    string // Player's name and worn title; empty leaves it off
+}
+
+// Render draws the card on a new offscreen image in the board's theme
+func Render(card Card, theme assets.Theme) *ebiten.Image {
+	img := ebiten.NewImage(CardWidth, CardHeight)
+	img.Fill(theme.Background)
+
+	drawBoard(img, card.Board, theme)
+
+	ink := color.RGBA{60, 60, 60, 255}
+	drawText(img, "ISLAND MERGE", textLeft, 60, 3, color.RGBA{120, 120, 120, 255})
+	drawText(img, truncate(card.LevelName, maxNameLength), textLeft, 120, 5, ink)
+	drawText(img, card.ModeName, textLeft, 205, 3, color.RGBA{120, 120, 120, 255})
+
+	lines := []string{
+		fmt.Sprintf("Moves %d", card.Moves),
+		fmt.Sprintf("Time  %02d:%02d", int(card.Time.Minutes()), int(card.Time.Seconds())%60),
+		fmt.Sprintf("Score %d", card.Points),
+	}
+	for i, line := range lines {
+		drawText(img, line, textLeft, 275+i*70, 4, ink)
+	}
+
+	if card.Rated {
+		for i := 0; i < 3; i++ {
+			col := color.RGBA{200, 200, 200, 255}
+			if i < card.Stars {
+				col = color.RGBA{255, 200, 0, 255}
+			}
+			systems.DrawStar(img, float32(textLeft+starRadius+i*starSpacing), 540, starRadius, col)
+		}
+	}
+	drawText(img, truncate(card.Player, maxPlayerLength), textLeft, 588, 2, color.RGBA{120, 120, 120, 255})
+	return img
+}
+
+// EncodePNG renders the card and encodes it as a PNG
+func EncodePNG(card Card, theme assets.Theme) ([]byte, error) {
+	img := Render(card, theme)
+	defer img.Deallocate()
+	return capture.EncodePNG(capture.Screenshot(img))
+}
+
+// drawBoard draws the board as flat tiles, as large as fits the board area and
+// centred in it
+func drawBoard(img *ebiten.Image, board *island.Board, theme assets.Theme) {
+	vector.DrawFilledRect(img, boardLeft-6, boardTop-6, boardArea+12, boardArea+12, theme.Grid, false)
+	if board == nil || board.Width == 0 || board.Height == 0 {
+		return
+	}
+
+	tileSize := float32(boardArea / max(board.Width, board.Height))
+	left := boardLeft + (boardArea-tileSize*float32(board.Width))/2
+	top := boardTop + (boardArea-tileSize*float32(board.Height))/2
+	gap := max(tileSize/16, 1)
+	colors := map[island.TileType]color.Color{
+		island.TileSea:    theme.Sea,
+		island.TileLand:   theme.Land,
+		island.TileBridge: theme.Bridge,
+	}
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			col, ok := colors[board.GetTile(x, y).Type]
+			if !ok {
+				continue
+			}
+			vector.DrawFilledRect(img, left+float32(x)*tileSize, top+float32(y)*tileSize, tileSize-gap, tileSize-gap, col, false)
+		}
+	}
+}
+
+// drawText prints with the debug font, scaled up and tinted
+func drawText(img *ebiten.Image, text string, x, y int, scale float64, col color.Color) {
+	if text == "" {
+		return
+	}
+	glyphs := ebiten.NewImage(len(text)*glyphWidth, glyphHeight)
+	defer glyphs.Deallocate()
+	ebitenutil.DebugPrint(glyphs, text)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(col)
+	img.DrawImage(glyphs, op)
+}
+
+func truncate(text string, length int) string {
+	if len(text) <= length {
+		return text
+	}
+	return text[:length-3] + "..."
+}
@@ -0,0 +1,62 @@
+// +build js,wasm
+
+package share
+
+import "syscall/js"
+
+// CopyPNG puts a PNG image on the clipboard. The browser writes it in the
+// background and may still refuse, which is reported on the console.
+func CopyPNG(data []byte) error {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	itemType := js.Global().Get("ClipboardItem")
+	if clipboard.IsUndefined() || itemType.IsUndefined() {
+		return ErrCopyUnsupported
+	}
+
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	blob := js.Global().Get("Blob").New([]interface{}{array}, map[string]interface{}{"type": "image/png"})
+	item := itemType.New(map[string]interface{}{"image/png": blob})
+
+	var onDone, onError js.Func
+	release := func() {
+		onDone.Release()
+		onError.Release()
+	}
+	onDone = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		release()
+		return nil
+	})
+	onError = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		js.Global().Get("console").Call("warn", "Copying the share card failed:", args[0])
+		release()
+		return nil
+	})
+	clipboard.Call("write", []interface{}{item}).Call("then", onDone, onError)
+	return nil
+}
+
+// CopyText puts text on the clipboard, written in the background like CopyPNG
+func CopyText(text string) error {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		return ErrCopyUnsupported
+	}
+
+	var onDone, onError js.Func
+	release := func() {
+		onDone.Release()
+		onError.Release()
+	}
+	onDone = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		release()
+		return nil
+	})
+	onError = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		js.Global().Get("console").Call("warn", "Copying text failed:", args[0])
+		release()
+		return nil
+	})
+	clipboard.Call("writeText", text).Call("then", onDone, onError)
+	return nil
+}
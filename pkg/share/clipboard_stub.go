@@ -0,0 +1,13 @@
+// +build !js !wasm
+
+package share
+
+// CopyPNG is only available in the browser; elsewhere cards are saved instead
+func CopyPNG(data []byte) error {
+	return ErrCopyUnsupported
+}
+
+// CopyText is only available in the browser too
+func CopyText(text string) error {
+	return ErrCopyUnsupported
+}
@@ -0,0 +1,84 @@
+package solver
+
+import (
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Distance finds the fewest bridges that would join two tiles. Land and
+// bridges are crossed for free, sea tiles cost one bridge each, and empty tiles
+// cannot be crossed. It returns the sea tiles to bridge, ordered from the first
+// tile to the second, and false if no route exists. Placement rules are ignored,
+// as in Solve.
+func Distance(board *island.Board, from, to Move) ([]Move, bool) {
+	start, end := board.GetTile(from.X, from.Y), board.GetTile(to.X, to.Y)
+	if start == nil || end == nil || start.Type == island.TileEmpty || end.Type == island.TileEmpty {
+		return nil, false
+	}
+
+	// Dijkstra with one bucket per bridge count, since entering a tile costs 0 or 1
+	size := board.Width * board.Height
+	dist := make([]int, size)
+	prev := make([]int, size)
+	for idx := range dist {
+		dist[idx] = -1
+		prev[idx] = -1
+	}
+	startIdx, endIdx := from.Y*board.Width+from.X, to.Y*board.Width+to.X
+	dist[startIdx] = crossingCost(start)
+	buckets := make([][]int, dist[startIdx]+1)
+	buckets[dist[startIdx]] = []int{startIdx}
+
+	for d := 0; d < len(buckets); d++ {
+		for i := 0; i < len(buckets[d]); i++ {
+			current := buckets[d][i]
+			if dist[current] != d {
+				continue // Reached more cheaply since it was queued
+			}
+			if current == endIdx {
+				return seaOnPath(board, prev, endIdx), true
+			}
+			cx, cy := current%board.Width, current/board.Width
+			for _, dir := range directions {
+				nx, ny := cx+dir[0], cy+dir[1]
+				neighbor := board.GetTile(nx, ny)
+				if neighbor == nil || neighbor.Type == island.TileEmpty {
+					continue
+				}
+				nidx := ny*board.Width + nx
+				nd := d + crossingCost(neighbor)
+				if dist[nidx] >= 0 && dist[nidx] <= nd {
+					continue
+				}
+				dist[nidx] = nd
+				prev[nidx] = current
+				for len(buckets) <= nd {
+					buckets = append(buckets, nil)
+				}
+				buckets[nd] = append(buckets[nd], nidx)
+			}
+		}
+	}
+	return nil, false
+}
+
+// seaOnPath lists the sea tiles on the route ending at end, from its start
+func seaOnPath(board *island.Board, prev []int, end int) []Move {
+	path := []Move{}
+	for idx := end; idx != -1; idx = prev[idx] {
+		if board.Tiles[idx].Type == island.TileSea {
+			path = append(path, Move{X: idx % board.Width, Y: idx / board.Width})
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// crossingCost is how many bridges crossing a tile takes
+func crossingCost(tile *island.Tile) int {
+	if tile.Type == island.TileSea {
+		return 1
+	}
+	return 0
+}
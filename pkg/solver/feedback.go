@@ -0,0 +1,133 @@
+package solver
+
+import (
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// MoveQuality rates a bridge placement for the move feedback learning aid
+type MoveQuality int
+
+const (
+	MoveOptimal  MoveQuality = iota // On an optimal path: the solution got a move shorter
+	MoveNeutral                     // Brings the network closer to another island, but not by the best route
+	MoveWasteful                    // Leads nowhere useful
+)
+
+// Analyzer rates moves as they are played. It remembers how many bridges the
+// board still needs, so each rating only analyses the board after the move.
+type Analyzer struct {
+	remaining int
+}
+
+// NewAnalyzer starts analysing a board from its current state
+func NewAnalyzer(board *island.Board) *Analyzer {
+	return &Analyzer{remaining: remainingMoves(board)}
+}
+
+// Rate classifies a bridge about to be built on board, which is not modified
+func (a *Analyzer) Rate(board *island.Board, move Move) MoveQuality {
+	after := board.Clone()
+	after.BuildBridge(move.X, move.Y)
+
+	remaining := remainingMoves(after)
+	before := a.remaining
+	a.remaining = remaining
+	if remaining >= 0 && (before < 0 || remaining < before) {
+		return MoveOptimal
+	}
+
+	// Not on an optimal path: is the network at least heading somewhere?
+	gapBefore := networkGap(board, adjacentRoots(board, move))
+	gapAfter := networkGap(after, adjacentRoots(after, move))
+	if gapAfter >= 0 && (gapBefore < 0 || gapAfter < gapBefore) {
+		return MoveNeutral
+	}
+	return MoveWasteful
+}
+
+// remainingMoves estimates the bridges still needed, or -1 if unsolvable. The
+// heuristic is grown from every island group and the shortest result is kept, so
+// a bridge that already helps one group is not missed because another group was
+// picked as the starting point.
+func remainingMoves(board *island.Board) int {
+	best := -1
+	seen := make(map[int]bool)
+	for _, idx := range board.Islands {
+		root := board.UnionFind.Find(idx)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+
+		solution := solveFrom(board, idx)
+		if solution.Solvable && (best < 0 || len(solution.Moves) < best) {
+			best = len(solution.Moves)
+		}
+	}
+	if len(seen) == 0 {
+		return 0
+	}
+	return best
+}
+
+// adjacentRoots returns the union-find roots of the land and bridge tiles that
+// touch the move's tile, including the tile itself once it is a bridge
+func adjacentRoots(board *island.Board, move Move) map[int]bool {
+	roots := make(map[int]bool)
+	if tile := board.GetTile(move.X, move.Y); tile != nil && tile.Type == island.TileBridge {
+		roots[board.UnionFind.Find(move.Y*board.Width+move.X)] = true
+	}
+	for _, dir := range directions {
+		nx, ny := move.X+dir[0], move.Y+dir[1]
+		tile := board.GetTile(nx, ny)
+		if tile != nil && (tile.Type == island.TileLand || tile.Type == island.TileBridge) {
+			roots[board.UnionFind.Find(ny*board.Width+nx)] = true
+		}
+	}
+	return roots
+}
+
+// networkGap returns how many sea tiles separate the groups with the given
+// roots from the nearest other land or bridge, or -1 if nothing can be reached
+func networkGap(board *island.Board, roots map[int]bool) int {
+	size := board.Width * board.Height
+	dist := make([]int, size)
+	queue := make([]int, 0, size)
+
+	for idx := 0; idx < size; idx++ {
+		dist[idx] = -1
+		tile := board.Tiles[idx]
+		if (tile.Type == island.TileLand || tile.Type == island.TileBridge) && roots[board.UnionFind.Find(idx)] {
+			dist[idx] = 0
+			queue = append(queue, idx)
+		}
+	}
+
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		cx, cy := current%board.Width, current/board.Width
+		fromNetwork := board.Tiles[current].Type != island.TileSea
+
+		for _, dir := range directions {
+			neighbor := board.GetTile(cx+dir[0], cy+dir[1])
+			nidx := (cy+dir[1])*board.Width + cx + dir[0]
+			if neighbor == nil || dist[nidx] >= 0 {
+				continue
+			}
+
+			switch neighbor.Type {
+			case island.TileSea:
+				dist[nidx] = dist[current] + 1
+				queue = append(queue, nidx)
+			case island.TileLand, island.TileBridge:
+				// Land touching the network directly is part of the same island
+				if fromNetwork {
+					continue
+				}
+				return dist[current]
+			}
+		}
+	}
+
+	return -1
+}
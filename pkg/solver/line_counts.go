@@ -0,0 +1,26 @@
+package solver
+
+import "github.com/ponyo877/island-merge/pkg/island"
+
+// LineCounts returns how many of the moves fall in each row and each column,
+// the clues of the Bridge Counts mode
+func LineCounts(moves []Move, width, height int) (rows, cols []int) {
+	rows, cols = make([]int, height), make([]int, width)
+	for _, move := range moves {
+		rows[move.Y]++
+		cols[move.X]++
+	}
+	return rows, cols
+}
+
+// BridgeLineCounts returns how many bridge tiles the board has in each row and column
+func BridgeLineCounts(board *island.Board) (rows, cols []int) {
+	rows, cols = make([]int, board.Height), make([]int, board.Width)
+	for idx, tile := range board.Tiles {
+		if tile.Type == island.TileBridge {
+			rows[idx/board.Width]++
+			cols[idx%board.Width]++
+		}
+	}
+	return rows, cols
+}
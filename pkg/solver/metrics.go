@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Metrics describes how hard a board is to solve
+type Metrics struct {
+	OptimalMoves int     // Bridges the shortest solution found needs
+	Branching    float64 // Sea tiles the growing network can bridge, on average per step of the solution
+	Area         int     // Tiles on the board
+	Decoys       int     // Island groups whose nearest neighbour is not the way the solution joins them
+}
+
+// Measure works out the board's metrics by replaying the solver, and reports
+// whether the board can be solved at all
+func Measure(board *island.Board) (Metrics, bool) {
+	metrics := Metrics{Area: board.Width * board.Height}
+	if len(board.Islands) == 0 {
+		return metrics, true
+	}
+	metrics.OptimalMoves = remainingMoves(board)
+	if metrics.OptimalMoves < 0 {
+		return metrics, false
+	}
+
+	// One land tile stands for each island group, since roots change as bridges join them
+	groups := make([]int, 0)
+	nearest := make(map[int]int)
+	seen := make(map[int]bool)
+	for _, idx := range board.Islands {
+		root := board.UnionFind.Find(idx)
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		groups = append(groups, idx)
+		nearest[idx] = networkGap(board, map[int]bool{root: true})
+	}
+
+	work := board.Clone()
+	start := board.Islands[0]
+	steps, options := 0, 0
+	for !work.IsAllConnected() {
+		options += frontierSize(work, start)
+		steps++
+
+		path := shortestConnection(work, start)
+		if path == nil {
+			return metrics, false
+		}
+		outside := make([]int, 0)
+		for _, idx := range groups {
+			if !work.UnionFind.Connected(start, idx) {
+				outside = append(outside, idx)
+			}
+		}
+		for _, move := range path {
+			if !work.CanBuildBridge(move.X, move.Y) {
+				return metrics, false // Over the board's bridge budget
+			}
+			work.BuildBridge(move.X, move.Y)
+		}
+		// A shorter bridge elsewhere lures the player away from the one that counts
+		for _, idx := range outside {
+			if work.UnionFind.Connected(start, idx) && nearest[idx] >= 0 && nearest[idx] < len(path) {
+				metrics.Decoys++
+			}
+		}
+	}
+	if steps > 0 {
+		metrics.Branching = float64(options) / float64(steps)
+	}
+	return metrics, true
+}
+
+// frontierSize counts the sea tiles next to the network that holds root where
+// a bridge can be built
+func frontierSize(board *island.Board, root int) int {
+	count := 0
+	for idx, tile := range board.Tiles {
+		if tile.Type != island.TileSea {
+			continue
+		}
+		x, y := idx%board.Width, idx/board.Width
+		if !board.CanBuildBridge(x, y) {
+			continue
+		}
+		for _, dir := range directions {
+			nx, ny := x+dir[0], y+dir[1]
+			neighbor := board.GetTile(nx, ny)
+			if neighbor != nil && (neighbor.Type == island.TileLand || neighbor.Type == island.TileBridge) && board.UnionFind.Connected(root, ny*board.Width+nx) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
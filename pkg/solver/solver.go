@@ -0,0 +1,165 @@
+package solver
+
+import (
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Move is a single bridge placement
+type Move struct {
+	X, Y int
+}
+
+// Solution is an ordered list of bridge placements that connects every island
+type Solution struct {
+	Moves    []Move
+	Solvable bool
+}
+
+var directions = [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+
+// Solve finds a near-optimal bridge sequence for the board without modifying it.
+// It grows the network that contains the first island by repeatedly bridging
+// the shortest stretch of sea to the nearest unconnected land (a Steiner tree
+// heuristic), so every move in the result is valid when played in order.
+func Solve(board *island.Board) *Solution {
+	if len(board.Islands) == 0 {
+		return &Solution{Moves: []Move{}, Solvable: true}
+	}
+	return solveFrom(board, board.Islands[0])
+}
+
+// solveFrom runs the Steiner heuristic growing the network that holds start
+func solveFrom(board *island.Board, start int) *Solution {
+	work := board.Clone()
+	solution := &Solution{Moves: []Move{}}
+
+	for !work.IsAllConnected() {
+		path := shortestConnection(work, start)
+		if path == nil {
+			return solution
+		}
+
+		for _, move := range path {
+			// A plan the board's rules refuse, such as one over a bridge budget, is no solution
+			if !work.CanBuildBridge(move.X, move.Y) {
+				return solution
+			}
+			work.BuildBridge(move.X, move.Y)
+			solution.Moves = append(solution.Moves, move)
+		}
+	}
+
+	solution.Solvable = true
+	return solution
+}
+
+// NextMove returns the first move of a near-optimal solution
+func NextMove(board *island.Board) (Move, bool) {
+	solution := Solve(board)
+	if !solution.Solvable || len(solution.Moves) == 0 {
+		return Move{}, false
+	}
+	return solution.Moves[0], true
+}
+
+// OptimalMoves returns the number of bridges the solver needs, or -1 if unsolvable
+func OptimalMoves(board *island.Board) int {
+	solution := Solve(board)
+	if !solution.Solvable {
+		return -1
+	}
+	return len(solution.Moves)
+}
+
+// shortestConnection runs a BFS over sea tiles from the network that holds the
+// root tile and returns the sea tiles leading to the nearest land or bridge
+// tile outside that network, ordered from the network outwards. Among equally
+// short paths it prefers the one whose last tile joins the most other networks.
+func shortestConnection(board *island.Board, root int) []Move {
+	size := board.Width * board.Height
+	prev := make([]int, size)
+	depth := make([]int, size)
+	visited := make([]bool, size)
+	queue := make([]int, 0, size)
+
+	for idx := 0; idx < size; idx++ {
+		prev[idx] = -1
+		tile := board.Tiles[idx]
+		if (tile.Type == island.TileLand || tile.Type == island.TileBridge) && board.UnionFind.Connected(root, idx) {
+			visited[idx] = true
+			queue = append(queue, idx)
+		}
+	}
+
+	best, bestJoins := -1, 0
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
+		if best >= 0 && depth[current] > depth[best] {
+			break // Every path as short as the best one has been seen
+		}
+		cx, cy := current%board.Width, current/board.Width
+		fromNetwork := board.Tiles[current].Type != island.TileSea
+
+		for _, dir := range directions {
+			nx, ny := cx+dir[0], cy+dir[1]
+			neighbor := board.GetTile(nx, ny)
+			if neighbor == nil {
+				continue
+			}
+			nidx := ny*board.Width + nx
+			if visited[nidx] {
+				continue
+			}
+
+			switch neighbor.Type {
+			case island.TileSea:
+				visited[nidx] = true
+				prev[nidx] = current
+				depth[nidx] = depth[current] + 1
+				queue = append(queue, nidx)
+			case island.TileLand, island.TileBridge:
+				// Reaching another network straight from our own needs no bridge
+				if fromNetwork {
+					continue
+				}
+				if joins := joinedNetworks(board, root, cx, cy); joins > bestJoins {
+					best, bestJoins = current, joins
+				}
+			}
+		}
+	}
+
+	if best < 0 {
+		return nil
+	}
+	return tracePath(board, prev, best)
+}
+
+// joinedNetworks counts the networks other than root's that a bridge at x, y would touch
+func joinedNetworks(board *island.Board, root, x, y int) int {
+	roots := make(map[int]bool)
+	for _, dir := range directions {
+		nx, ny := x+dir[0], y+dir[1]
+		tile := board.GetTile(nx, ny)
+		if tile == nil || (tile.Type != island.TileLand && tile.Type != island.TileBridge) {
+			continue
+		}
+		if nidx := ny*board.Width + nx; !board.UnionFind.Connected(root, nidx) {
+			roots[board.UnionFind.Find(nidx)] = true
+		}
+	}
+	return len(roots)
+}
+
+func tracePath(board *island.Board, prev []int, end int) []Move {
+	path := []Move{}
+	for idx := end; idx != -1 && board.Tiles[idx].Type == island.TileSea; idx = prev[idx] {
+		path = append(path, Move{X: idx % board.Width, Y: idx / board.Width})
+	}
+
+	// Reverse so the path starts next to the existing network
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
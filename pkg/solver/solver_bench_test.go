@@ -0,0 +1,22 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/ponyo877/island-merge/pkg/benchboards"
+	"github.com/ponyo877/island-merge/pkg/solver"
+)
+
+func BenchmarkSolve(b *testing.B) {
+	for _, size := range benchboards.Sizes {
+		b.Run(benchboards.Name(size), func(b *testing.B) {
+			board := benchboards.Generate(size)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				solver.Solve(board)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package storage
+
+// ChallengeProgress is the active profile's results in one week's challenge
+type ChallengeProgress struct {
+	Stages []ChallengeStageResult `json:"stages"`
+}
+
+// ChallengeStageResult is the best result on one stage of a challenge
+type ChallengeStageResult struct {
+	Won    bool `json:"won"`
+	Points int  `json:"points"`
+}
+
+// Total is the combined score: the best score of every stage added up
+func (cp ChallengeProgress) Total() int {
+	total := 0
+	for _, stage := range cp.Stages {
+		total += stage.Points
+	}
+	return total
+}
+
+// Completed counts the stages won
+func (cp ChallengeProgress) Completed() int {
+	completed := 0
+	for _, stage := range cp.Stages {
+		if stage.Won {
+			completed++
+		}
+	}
+	return completed
+}
+
+// NextStage is the first stage not yet won, or -1 once all of them are
+func (cp ChallengeProgress) NextStage(stages int) int {
+	for i := 0; i < stages; i++ {
+		if i >= len(cp.Stages) || !cp.Stages[i].Won {
+			return i
+		}
+	}
+	return -1
+}
+
+// ChallengeFor returns the progress in a week's challenge; weeks not yet played have none
+func (ss *SaveSystem) ChallengeFor(week string) ChallengeProgress {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return ChallengeProgress{}
+	}
+	return progress.Challenges[week]
+}
+
+// RecordChallengeStage marks a stage of a week's challenge won, keeping its best
+// score, and returns the updated progress
+func (ss *SaveSystem) RecordChallengeStage(week string, stage, points int) (ChallengeProgress, error) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return ChallengeProgress{}, err
+	}
+	if progress.Challenges == nil {
+		progress.Challenges = make(map[string]ChallengeProgress)
+	}
+
+	challenge := progress.Challenges[week]
+	for len(challenge.Stages) <= stage {
+		challenge.Stages = append(challenge.Stages, ChallengeStageResult{})
+	}
+	result := &challenge.Stages[stage]
+	result.Won = true
+	result.Points = max(result.Points, points)
+
+	progress.Challenges[week] = challenge
+	return challenge, ss.SaveProgress(progress)
+}
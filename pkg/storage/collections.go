@@ -0,0 +1,327 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// DefaultCollectionID holds custom levels that have not been filed into a folder
+const DefaultCollectionID = "unsorted"
+
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// LevelCollection is a user-named folder of custom levels, in display order
+type LevelCollection struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	LevelIDs []string `json:"level_ids"`
+}
+
+// LoadCollections returns all collections, reconciled against the stored custom levels:
+// levels missing from every collection are appended to the default one, and
+// references to deleted levels are dropped.
+func (ss *SaveSystem) LoadCollections() ([]LevelCollection, error) {
+	var collections []LevelCollection
+	if err := ss.storage.Get(SaveKeyCollections, &collections); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+
+	customLevels, err := ss.LoadCustomLevels()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(customLevels))
+	for _, level := range customLevels {
+		exists[level.ID] = true
+	}
+
+	hasDefault := false
+	filed := make(map[string]bool)
+	for i := range collections {
+		if collections[i].ID == DefaultCollectionID {
+			hasDefault = true
+		}
+		kept := collections[i].LevelIDs[:0]
+		for _, id := range collections[i].LevelIDs {
+			if exists[id] && !filed[id] {
+				kept = append(kept, id)
+				filed[id] = true
+			}
+		}
+		collections[i].LevelIDs = kept
+	}
+
+	if !hasDefault {
+		collections = append([]LevelCollection{{ID: DefaultCollectionID, Name: "Unsorted"}}, collections...)
+	}
+
+	for _, level := range customLevels {
+		if !filed[level.ID] {
+			for i := range collections {
+				if collections[i].ID == DefaultCollectionID {
+					collections[i].LevelIDs = append(collections[i].LevelIDs, level.ID)
+				}
+			}
+		}
+	}
+
+	return collections, nil
+}
+
+// SaveCollections stores the collection layout
+func (ss *SaveSystem) SaveCollections(collections []LevelCollection) error {
+	return ss.set(SaveKeyCollections, collections)
+}
+
+// CreateCollection adds an empty collection with the given name
+func (ss *SaveSystem) CreateCollection(name string) (*LevelCollection, error) {
+	collections, err := ss.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	collection := LevelCollection{
+		ID:   fmt.Sprintf("collection_%d", time.Now().UnixNano()),
+		Name: name,
+	}
+	collections = append(collections, collection)
+
+	if err := ss.SaveCollections(collections); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// DeleteCollection removes a collection; its levels fall back to the default collection
+func (ss *SaveSystem) DeleteCollection(collectionID string) error {
+	if collectionID == DefaultCollectionID {
+		return fmt.Errorf("cannot delete default collection")
+	}
+
+	collections, err := ss.LoadCollections()
+	if err != nil {
+		return err
+	}
+
+	for i, collection := range collections {
+		if collection.ID == collectionID {
+			collections = append(collections[:i], collections[i+1:]...)
+			// Reload so orphaned levels are refiled before saving
+			if err := ss.SaveCollections(collections); err != nil {
+				return err
+			}
+			collections, err = ss.LoadCollections()
+			if err != nil {
+				return err
+			}
+			return ss.SaveCollections(collections)
+		}
+	}
+	return ErrCollectionNotFound
+}
+
+// MoveCustomLevel moves a level into a collection at index, removing it from wherever it was.
+// An index past the end appends.
+func (ss *SaveSystem) MoveCustomLevel(levelID, collectionID string, index int) error {
+	collections, err := ss.LoadCollections()
+	if err != nil {
+		return err
+	}
+
+	target := -1
+	for i := range collections {
+		if collections[i].ID == collectionID {
+			target = i
+		}
+		for j, id := range collections[i].LevelIDs {
+			if id == levelID {
+				collections[i].LevelIDs = append(collections[i].LevelIDs[:j], collections[i].LevelIDs[j+1:]...)
+				break
+			}
+		}
+	}
+	if target < 0 {
+		return ErrCollectionNotFound
+	}
+
+	ids := collections[target].LevelIDs
+	if index < 0 {
+		index = 0
+	}
+	if index > len(ids) {
+		index = len(ids)
+	}
+	ids = append(ids, "")
+	copy(ids[index+1:], ids[index:])
+	ids[index] = levelID
+	collections[target].LevelIDs = ids
+
+	return ss.SaveCollections(collections)
+}
+
+// LevelData converts a custom level to the format shared with built-in levels and packs
+func (cl *CustomLevel) LevelData() *levels.LevelData {
+	grid := make([][]island.TileType, cl.Height)
+	for y := range grid {
+		grid[y] = make([]island.TileType, cl.Width)
+		for x := range grid[y] {
+			if y < len(cl.Tiles) && x < len(cl.Tiles[y]) {
+				grid[y][x] = island.TileType(cl.Tiles[y][x])
+			}
+		}
+	}
+
+	return &levels.LevelData{
+		ID:          cl.ID,
+		Name:        cl.Name,
+		Description: cl.Description,
+		Author:      cl.Author,
+		Signature:   cl.Signature,
+		Width:       cl.Width,
+		Height:      cl.Height,
+		Grid:        grid,
+		Unlocked:    true,
+	}
+}
+
+// CustomLevelFromData converts a pack or imported level into a stored custom level
+func CustomLevelFromData(level *levels.LevelData) CustomLevel {
+	tiles := make([][]int, len(level.Grid))
+	for y, row := range level.Grid {
+		tiles[y] = make([]int, len(row))
+		for x, tile := range row {
+			tiles[y][x] = int(tile)
+		}
+	}
+
+	return CustomLevel{
+		ID:          level.ID,
+		Name:        level.Name,
+		Description: level.Description,
+		Author:      level.Author,
+		Signature:   level.Signature,
+		CreatedAt:   time.Now(),
+		Width:       level.Width,
+		Height:      level.Height,
+		Tiles:       tiles,
+	}
+}
+
+// ExportCollection encodes a collection and its levels as a level pack. Levels
+// made on this device are signed with the active profile's name and recorded
+// as published; shared levels keep their author and signature.
+func (ss *SaveSystem) ExportCollection(collectionID string) ([]byte, error) {
+	collections, err := ss.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	customLevels, err := ss.LoadCustomLevels()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]CustomLevel, len(customLevels))
+	for _, level := range customLevels {
+		byID[level.ID] = level
+	}
+
+	for _, collection := range collections {
+		if collection.ID != collectionID {
+			continue
+		}
+
+		author := ss.CurrentProfile().Name
+		pack := levels.LevelPack{
+			Format:  levels.PackFormat,
+			Version: 1,
+			ID:      collection.ID,
+			Name:    collection.Name,
+			Author:  author,
+			Levels:  make([]*levels.LevelData, 0, len(collection.LevelIDs)),
+		}
+		for _, id := range collection.LevelIDs {
+			level := byID[id]
+			levelData := level.LevelData()
+			if level.Author == "" {
+				levelData.Author = author
+				levelData.Sign()
+				if err := ss.recordPublished(levelData); err != nil {
+					fmt.Println("Failed to record published level:", err)
+				}
+			}
+			pack.Levels = append(pack.Levels, levelData)
+		}
+		return json.MarshalIndent(pack, "", "  ")
+	}
+	return nil, ErrCollectionNotFound
+}
+
+// ExportCollectionFile writes a collection's level pack to a file and returns its name
+func (ss *SaveSystem) ExportCollectionFile(collectionID string) (string, error) {
+	data, err := ss.ExportCollection(collectionID)
+	if err != nil {
+		return "", err
+	}
+
+	var pack levels.LevelPack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return "", err
+	}
+
+	fileName := packFileName(pack.Name)
+	if err := ss.storage.WriteFile(fileName, data); err != nil {
+		return "", fmt.Errorf("failed to write level pack: %w", err)
+	}
+	return fileName, nil
+}
+
+// ImportLevelPack stores every level in a pack and files them into a new
+// collection. Level IDs are namespaced by the collection, so importing a pack
+// again, or one of your own exports, never replaces or moves existing levels.
+func (ss *SaveSystem) ImportLevelPack(data []byte) (*LevelCollection, error) {
+	pack, err := levels.ParsePack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := ss.CreateCollection(pack.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, packLevel := range pack.Levels {
+		level := CustomLevelFromData(packLevel)
+		level.ID = collection.ID + "/" + level.ID[strings.LastIndex(level.ID, "/")+1:] // Drop an earlier import's namespace
+		if err := ss.SaveCustomLevel(&level); err != nil {
+			return nil, fmt.Errorf("failed to import custom level %s: %w", level.ID, err)
+		}
+		if err := ss.MoveCustomLevel(level.ID, collection.ID, i); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// packFileName turns a collection name into a safe file name
+func packFileName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ', r == '-', r == '_':
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("level_pack")
+	}
+	return b.String() + ".islandpack.json"
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "time"
+
+// CorrespondenceGame is a turn-based game against another player, as last
+// played or received
+type CorrespondenceGame struct {
+	ID       string    `json:"id"`
+	Code     string    `json:"code"`               // The whole game, as sent between the players
+	Seat     int       `json:"seat"`               // Which of the two players this profile is
+	Opponent string    `json:"opponent,omitempty"` // Empty until they have played
+	YourTurn bool      `json:"your_turn"`
+	Over     bool      `json:"over,omitempty"`
+	Result   string    `json:"result,omitempty"` // How a finished game ended, e.g. "Won 5-3"
+	Updated  time.Time `json:"updated"`
+}
+
+// SaveCorrespondence stores the active profile's correspondence games
+func (ss *SaveSystem) SaveCorrespondence(games []CorrespondenceGame) error {
+	return ss.set(ss.key(SaveKeyCorrespondence), games)
+}
+
+// LoadCorrespondence returns the active profile's correspondence games
+func (ss *SaveSystem) LoadCorrespondence() ([]CorrespondenceGame, error) {
+	var games []CorrespondenceGame
+	if err := ss.storage.Get(ss.key(SaveKeyCorrespondence), &games); err != nil {
+		return nil, err
+	}
+	return games, nil
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"time"
+)
+
+// CrashReport describes a panic in the game loop, kept until the next launch
+type CrashReport struct {
+	Time  time.Time         `json:"time"`
+	Error string            `json:"error"`
+	Stack string            `json:"stack"`
+	State *CurrentGameState `json:"state,omitempty"` // Game in progress when it crashed, if any
+	// Offered is set once the next launch has offered to restore the auto-save
+	Offered bool `json:"offered,omitempty"`
+}
+
+// SaveCrashReport stores a crash report for the active profile
+func (ss *SaveSystem) SaveCrashReport(report *CrashReport) error {
+	return ss.set(ss.key(SaveKeyCrashReport), report)
+}
+
+// LoadCrashReport returns the crash report left by the last session, if any
+func (ss *SaveSystem) LoadCrashReport() (*CrashReport, error) {
+	var report CrashReport
+	if err := ss.storage.Get(ss.key(SaveKeyCrashReport), &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
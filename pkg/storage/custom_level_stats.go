@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrLevelNotFound = errors.New("custom level not found")
+
+// CustomTagPresets are the tags offered in the custom level browser
+var CustomTagPresets = []string{"favorite", "relaxing", "tricky", "speedrun", "large"}
+
+// updateCustomLevel loads a custom level, applies update and saves it back
+func (ss *SaveSystem) updateCustomLevel(levelID string, update func(*CustomLevel)) error {
+	levels, err := ss.LoadCustomLevels()
+	if err != nil {
+		return err
+	}
+
+	for i := range levels {
+		if levels[i].ID == levelID {
+			update(&levels[i])
+			return ss.set(SaveKeyCustomLevels, levels)
+		}
+	}
+	return ErrLevelNotFound
+}
+
+// RateCustomLevel sets a level's local rating from 1 to 5; 0 clears it
+func (ss *SaveSystem) RateCustomLevel(levelID string, rating int) error {
+	if rating < 0 || rating > 5 {
+		return fmt.Errorf("rating must be between 0 and 5, got %d", rating)
+	}
+	return ss.updateCustomLevel(levelID, func(level *CustomLevel) {
+		level.Rating = rating
+	})
+}
+
+// ToggleCustomLevelTag adds the tag to a level, or removes it if already present
+func (ss *SaveSystem) ToggleCustomLevelTag(levelID, tag string) error {
+	return ss.updateCustomLevel(levelID, func(level *CustomLevel) {
+		for i, existing := range level.Tags {
+			if existing == tag {
+				level.Tags = append(level.Tags[:i], level.Tags[i+1:]...)
+				return
+			}
+		}
+		level.Tags = append(level.Tags, tag)
+	})
+}
+
+// RecordCustomLevelPlay counts a started attempt
+func (ss *SaveSystem) RecordCustomLevelPlay(levelID string) error {
+	return ss.updateCustomLevel(levelID, func(level *CustomLevel) {
+		level.PlayCount++
+	})
+}
+
+// RecordCustomLevelCompletion counts a win and keeps the best result
+func (ss *SaveSystem) RecordCustomLevelCompletion(levelID string, score ScoreData) error {
+	return ss.updateCustomLevel(levelID, func(level *CustomLevel) {
+		level.Completions++
+		if level.BestMoves == 0 || score.Moves < level.BestMoves ||
+			(score.Moves == level.BestMoves && score.Time < level.BestTime) {
+			level.BestMoves = score.Moves
+			level.BestTime = score.Time
+		}
+	})
+}
+
+// DifficultyLabel returns the level's difficulty, estimated from its size when unset
+func (cl *CustomLevel) DifficultyLabel() string {
+	if cl.Difficulty != "" {
+		return cl.Difficulty
+	}
+
+	size := cl.Width
+	if cl.Height > size {
+		size = cl.Height
+	}
+	switch {
+	case size <= 8:
+		return "beginner"
+	case size <= 15:
+		return "intermediate"
+	case size <= 20:
+		return "expert"
+	default:
+		return "master"
+	}
+}
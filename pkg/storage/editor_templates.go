@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"slices"
+	"time"
+)
+
+// EditorTemplate is part of a level saved from the editor to stamp onto other
+// levels. Templates are shared by every profile, like custom levels.
+type EditorTemplate struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Tiles   [][]int   `json:"tiles"` // Rows of tile types
+	Created time.Time `json:"created"`
+}
+
+// LoadEditorTemplates returns the saved templates, oldest first
+func (ss *SaveSystem) LoadEditorTemplates() ([]EditorTemplate, error) {
+	var templates []EditorTemplate
+	if err := ss.storage.Get(SaveKeyTemplates, &templates); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// SaveEditorTemplate adds a template, or replaces the one with the same ID
+func (ss *SaveSystem) SaveEditorTemplate(template EditorTemplate) error {
+	templates, err := ss.LoadEditorTemplates()
+	if err != nil {
+		return err
+	}
+	if i := slices.IndexFunc(templates, func(t EditorTemplate) bool { return t.ID == template.ID }); i >= 0 {
+		templates[i] = template
+	} else {
+		templates = append(templates, template)
+	}
+	return ss.set(SaveKeyTemplates, templates)
+}
+
+// DeleteEditorTemplate removes a saved template
+func (ss *SaveSystem) DeleteEditorTemplate(id string) error {
+	templates, err := ss.LoadEditorTemplates()
+	if err != nil {
+		return err
+	}
+	templates = slices.DeleteFunc(templates, func(t EditorTemplate) bool { return t.ID == id })
+	return ss.set(SaveKeyTemplates, templates)
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// MaxLevelRevisions is how many earlier versions of each custom level are kept
+const MaxLevelRevisions = 10
+
+// LevelRevision is an earlier version of a custom level's layout, kept when the
+// level is saved with different tiles. Revisions are shared by every profile,
+// like custom levels.
+type LevelRevision struct {
+	Width   int       `json:"width"`
+	Height  int       `json:"height"`
+	Tiles   [][]int   `json:"tiles"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// loadAllHistory returns every level's revisions by level ID
+func (ss *SaveSystem) loadAllHistory() (map[string][]LevelRevision, error) {
+	history := make(map[string][]LevelRevision)
+	if err := ss.storage.Get(SaveKeyLevelHistory, &history); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return history, nil
+}
+
+// LoadLevelHistory returns a custom level's earlier versions, newest first
+func (ss *SaveSystem) LoadLevelHistory(levelID string) ([]LevelRevision, error) {
+	history, err := ss.loadAllHistory()
+	if err != nil {
+		return nil, err
+	}
+	return history[levelID], nil
+}
+
+// addRevision keeps a level's layout as its newest revision, dropping the
+// oldest beyond MaxLevelRevisions
+func (ss *SaveSystem) addRevision(level CustomLevel) error {
+	history, err := ss.loadAllHistory()
+	if err != nil {
+		return err
+	}
+	revision := LevelRevision{Width: level.Width, Height: level.Height, Tiles: level.Tiles, SavedAt: time.Now()}
+	revisions := slices.Insert(history[level.ID], 0, revision)
+	history[level.ID] = revisions[:min(len(revisions), MaxLevelRevisions)]
+	return ss.set(SaveKeyLevelHistory, history)
+}
+
+// deleteHistory drops a deleted level's revisions
+func (ss *SaveSystem) deleteHistory(levelID string) error {
+	history, err := ss.loadAllHistory()
+	if err != nil {
+		return err
+	}
+	if _, ok := history[levelID]; !ok {
+		return nil
+	}
+	delete(history, levelID)
+	return ss.set(SaveKeyLevelHistory, history)
+}
+
+// RollbackCustomLevel restores one of a level's revisions, by index from
+// newest. The replaced layout becomes the newest revision, so a rollback can
+// itself be rolled back.
+func (ss *SaveSystem) RollbackCustomLevel(levelID string, index int) error {
+	revisions, err := ss.LoadLevelHistory(levelID)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(revisions) {
+		return fmt.Errorf("no revision %d of level %s", index, levelID)
+	}
+	saved, err := ss.LoadCustomLevels()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(saved, func(level CustomLevel) bool { return level.ID == levelID })
+	if i < 0 {
+		return ErrLevelNotFound
+	}
+
+	level := saved[i]
+	revision := revisions[index]
+	level.Width, level.Height, level.Tiles = revision.Width, revision.Height, revision.Tiles
+	level.Difficulty = ""
+	if difficulty, ok := levels.EstimateDifficulty(level.LevelData().NewBoard()); ok {
+		level.Difficulty = difficulty.Label()
+	}
+	return ss.SaveCustomLevel(&level)
+}
+
+// DiffTiles returns the positions whose tiles differ between two layouts,
+// including positions only one of them covers
+func DiffTiles(a, b [][]int) map[island.Point]bool {
+	changed := make(map[island.Point]bool)
+	for y := 0; y < max(len(a), len(b)); y++ {
+		for x := 0; x < max(rowLength(a, y), rowLength(b, y)); x++ {
+			if x >= rowLength(a, y) || x >= rowLength(b, y) || a[y][x] != b[y][x] {
+				changed[island.Point{X: x, Y: y}] = true
+			}
+		}
+	}
+	return changed
+}
+
+func rowLength(tiles [][]int, y int) int {
+	if y >= len(tiles) {
+		return 0
+	}
+	return len(tiles[y])
+}
+
+// sameTiles reports whether two layouts are identical
+func sameTiles(a, b [][]int) bool {
+	return len(DiffTiles(a, b)) == 0
+}
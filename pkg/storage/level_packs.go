@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// packDir is where desktop builds pick up level packs dropped in by the player
+const packDir = "packs"
+
+// SaveLevelPack stores an imported level pack so it is loaded on the next start
+func (ss *SaveSystem) SaveLevelPack(packID string, data []byte) error {
+	packs := make(map[string]json.RawMessage)
+	if err := ss.storage.Get(SaveKeyLevelPacks, &packs); err != nil && err != ErrNotFound {
+		return err
+	}
+	packs[packID] = json.RawMessage(data)
+	return ss.set(SaveKeyLevelPacks, packs)
+}
+
+// LoadLevelPacks returns the raw data of every stored pack plus any pack files in the packs directory
+func (ss *SaveSystem) LoadLevelPacks() [][]byte {
+	var result [][]byte
+
+	packs := make(map[string]json.RawMessage)
+	if err := ss.storage.Get(SaveKeyLevelPacks, &packs); err == nil {
+		ids := make([]string, 0, len(packs))
+		for id := range packs {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			result = append(result, packs[id])
+		}
+	}
+
+	return append(result, ss.storage.ReadFiles(packDir)...)
+}
+
+// PackDirectory returns the folder scanned for pack files, or "" when there is none
+func (ss *SaveSystem) PackDirectory() string {
+	return ss.storage.Dir(packDir)
+}
+
+// RequestLevelPackUpload asks the user to pick a pack file. The result arrives later
+// through PollUploadedPacks. Returns ErrFilePickerUnsupported where there is no picker.
+func (ss *SaveSystem) RequestLevelPackUpload() error {
+	return ss.storage.OpenFile(func(data []byte) {
+		select {
+		case ss.uploads <- data:
+		default: // Drop uploads the game loop has not caught up with
+		}
+	})
+}
+
+// PollUploadedPacks returns packs picked since the last call without blocking
+func (ss *SaveSystem) PollUploadedPacks() [][]byte {
+	var packs [][]byte
+	for {
+		select {
+		case data := <-ss.uploads:
+			packs = append(packs, data)
+		default:
+			return packs
+		}
+	}
+}
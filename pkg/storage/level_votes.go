@@ -0,0 +1,67 @@
+package storage
+
+import "time"
+
+// LevelVote is the active profile's verdict on a shared level
+type LevelVote struct {
+	LevelID    string    `json:"level_id"`
+	ShareCode  string    `json:"share_code,omitempty"` // Identifies the level to the sharing backend
+	Up         bool      `json:"up"`
+	Difficulty int       `json:"difficulty,omitempty"` // 1 (easy) to 5 (hard); 0 when not estimated
+	Date       time.Time `json:"date"`
+	Posted     bool      `json:"posted,omitempty"` // Accepted by the sharing backend
+}
+
+// LevelVoteFor returns the vote cast on a level, or false if there is none
+func (ss *SaveSystem) LevelVoteFor(levelID string) (LevelVote, bool) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return LevelVote{}, false
+	}
+	vote, ok := progress.LevelVotes[levelID]
+	return vote, ok
+}
+
+// SaveLevelVote records a vote, replacing any earlier vote on the same level
+func (ss *SaveSystem) SaveLevelVote(vote LevelVote) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+	if progress.LevelVotes == nil {
+		progress.LevelVotes = make(map[string]LevelVote)
+	}
+	progress.LevelVotes[vote.LevelID] = vote
+	return ss.SaveProgress(progress)
+}
+
+// UnpostedLevelVotes returns the votes the sharing backend has not accepted yet
+func (ss *SaveSystem) UnpostedLevelVotes() []LevelVote {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return nil
+	}
+	var votes []LevelVote
+	for _, vote := range progress.LevelVotes {
+		if !vote.Posted && vote.ShareCode != "" {
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
+// MarkLevelVotesPosted flags votes as accepted by the sharing backend. A vote
+// changed since it was sent stays unposted.
+func (ss *SaveSystem) MarkLevelVotesPosted(votes []LevelVote) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+	for _, sent := range votes {
+		if vote, ok := progress.LevelVotes[sent.LevelID]; ok && vote.Date.Equal(sent.Date) {
+			vote.Posted = true
+			progress.LevelVotes[sent.LevelID] = vote
+		}
+	}
+	return ss.SaveProgress(progress)
+}
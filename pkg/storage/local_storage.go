@@ -14,17 +14,40 @@ func NewLocalStorage() *LocalStorage {
 	return &LocalStorage{}
 }
 
-// Set stores a value in localStorage
-func (ls *LocalStorage) Set(key string, value interface{}) error {
+// localStorageQuota is roughly how much localStorage most browsers allow a site
+const localStorageQuota = 5 << 20
+
+// Set stores a value in localStorage. It returns ErrQuotaExceeded when the
+// browser has no room left for it.
+func (ls *LocalStorage) Set(key string, value interface{}) (err error) {
 	jsonData, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
 	
+	// setItem throws rather than failing quietly
+	defer func() {
+		if r := recover(); r != nil {
+			err = storageException(r)
+		}
+	}()
 	js.Global().Get("localStorage").Call("setItem", key, string(jsonData))
 	return nil
 }
 
+// storageException turns an exception thrown by localStorage into an error
+func storageException(r interface{}) error {
+	jsErr, ok := r.(js.Error)
+	if !ok {
+		panic(r)
+	}
+	switch jsErr.Get("name").String() {
+	case "QuotaExceededError", "NS_ERROR_DOM_QUOTA_REACHED":
+		return ErrQuotaExceeded
+	}
+	return jsErr
+}
+
 // Get retrieves a value from localStorage
 func (ls *LocalStorage) Get(key string, target interface{}) error {
 	localStorage := js.Global().Get("localStorage")
@@ -43,6 +66,20 @@ func (ls *LocalStorage) Remove(key string) {
 	js.Global().Get("localStorage").Call("removeItem", key)
 }
 
+// Size returns how many bytes a key takes, or 0 if it does not exist
+func (ls *LocalStorage) Size(key string) int {
+	item := js.Global().Get("localStorage").Call("getItem", key)
+	if item.IsNull() {
+		return 0
+	}
+	return len(item.String())
+}
+
+// Quota is roughly how many bytes can be stored in all
+func (ls *LocalStorage) Quota() int {
+	return localStorageQuota
+}
+
 // Exists checks if a key exists in localStorage
 func (ls *LocalStorage) Exists(key string) bool {
 	localStorage := js.Global().Get("localStorage")
@@ -55,6 +92,69 @@ func (ls *LocalStorage) Clear() {
 	js.Global().Get("localStorage").Call("clear")
 }
 
+// WriteFile offers data to the user as a browser download
+func (ls *LocalStorage) WriteFile(name string, data []byte) error {
+	document := js.Global().Get("document")
+	
+	array := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(array, data)
+	blob := js.Global().Get("Blob").New([]interface{}{array}, map[string]interface{}{"type": "application/json"})
+	url := js.Global().Get("URL").Call("createObjectURL", blob)
+	
+	link := document.Call("createElement", "a")
+	link.Set("href", url)
+	link.Set("download", name)
+	document.Get("body").Call("appendChild", link)
+	link.Call("click")
+	document.Get("body").Call("removeChild", link)
+	js.Global().Get("URL").Call("revokeObjectURL", url)
+	return nil
+}
+
+// ReadFiles has no directory to read in the browser
+func (ls *LocalStorage) ReadFiles(dir string) [][]byte {
+	return nil
+}
+
+// Dir has no meaning in the browser and returns an empty path
+func (ls *LocalStorage) Dir(dir string) string {
+	return ""
+}
+
+// OpenFile shows the browser file picker and calls onLoad with the chosen file's contents.
+// onLoad runs on the JavaScript event loop, not the game loop.
+func (ls *LocalStorage) OpenFile(onLoad func([]byte)) error {
+	document := js.Global().Get("document")
+	input := document.Call("createElement", "input")
+	input.Set("type", "file")
+	input.Set("accept", ".json,.png,application/json,image/png")
+	
+	var onChange js.Func
+	onChange = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer onChange.Release()
+		files := input.Get("files")
+		if files.Get("length").Int() == 0 {
+			return nil
+		}
+		
+		// Read raw bytes rather than text so images survive
+		var onData js.Func
+		onData = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			defer onData.Release()
+			array := js.Global().Get("Uint8Array").New(args[0])
+			data := make([]byte, array.Get("length").Int())
+			js.CopyBytesToGo(data, array)
+			onLoad(data)
+			return nil
+		})
+		files.Index(0).Call("arrayBuffer").Call("then", onData)
+		return nil
+	})
+	input.Call("addEventListener", "change", onChange)
+	input.Call("click")
+	return nil
+}
+
 // GetKeys returns all keys in localStorage that match a prefix
 func (ls *LocalStorage) GetKeys(prefix string) []string {
 	localStorage := js.Global().Get("localStorage")
@@ -71,7 +171,20 @@ func (ls *LocalStorage) GetKeys(prefix string) []string {
 	return keys
 }
 
+// Online reports whether the browser has a network connection. Saves always go
+// to localStorage, so only downloads and uploads need one.
+func (ls *LocalStorage) Online() bool {
+	navigator := js.Global().Get("navigator")
+	if navigator.IsUndefined() {
+		return true
+	}
+	online := navigator.Get("onLine")
+	return online.IsUndefined() || online.Bool()
+}
+
 var ErrNotFound = &StorageError{"key not found"}
+var ErrFilePickerUnsupported = &StorageError{"file picker not supported"}
+var ErrQuotaExceeded = &StorageError{"storage is full"}
 
 type StorageError struct {
 	Message string
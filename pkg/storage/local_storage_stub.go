@@ -4,8 +4,11 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
+	"syscall"
 )
 
 // LocalStorage provides a file-based storage for non-WebAssembly builds
@@ -14,9 +17,7 @@ type LocalStorage struct {
 }
 
 func NewLocalStorage() *LocalStorage {
-	// Use a local data directory
-	homeDir, _ := os.UserHomeDir()
-	dataDir := filepath.Join(homeDir, ".island-merge")
+	dataDir := defaultDataDir()
 	os.MkdirAll(dataDir, 0755)
 	
 	return &LocalStorage{
@@ -24,6 +25,23 @@ func NewLocalStorage() *LocalStorage {
 	}
 }
 
+// defaultDataDir is ~/.island-merge on the desktop. Phones give apps no writable
+// home directory: iOS apps keep data under Application Support, and on Android
+// gomobile points TMPDIR at the app's cache directory, whose sibling "files" is
+// the private storage the system does not clear.
+func defaultDataDir() string {
+	switch runtime.GOOS {
+	case "ios":
+		if dir, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(dir, "island-merge")
+		}
+	case "android":
+		return filepath.Join(filepath.Dir(os.TempDir()), "files", "island-merge")
+	}
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".island-merge")
+}
+
 // Set stores a value in a local file
 func (ls *LocalStorage) Set(key string, value interface{}) error {
 	jsonData, err := json.Marshal(value)
@@ -32,7 +50,13 @@ func (ls *LocalStorage) Set(key string, value interface{}) error {
 	}
 	
 	filePath := filepath.Join(ls.dataDir, key+".json")
-	return os.WriteFile(filePath, jsonData, 0644)
+	if err := os.WriteFile(filePath, jsonData, 0644); err != nil {
+		if errors.Is(err, syscall.ENOSPC) {
+			return ErrQuotaExceeded
+		}
+		return err
+	}
+	return nil
 }
 
 // Get retrieves a value from a local file
@@ -56,6 +80,20 @@ func (ls *LocalStorage) Remove(key string) {
 	os.Remove(filePath)
 }
 
+// Size returns how many bytes a key takes, or 0 if it does not exist
+func (ls *LocalStorage) Size(key string) int {
+	info, err := os.Stat(filepath.Join(ls.dataDir, key+".json"))
+	if err != nil {
+		return 0
+	}
+	return int(info.Size())
+}
+
+// Quota is 0, as files are limited only by the disk
+func (ls *LocalStorage) Quota() int {
+	return 0
+}
+
 // Exists checks if a key file exists
 func (ls *LocalStorage) Exists(key string) bool {
 	filePath := filepath.Join(ls.dataDir, key+".json")
@@ -69,6 +107,43 @@ func (ls *LocalStorage) Clear() {
 	os.MkdirAll(ls.dataDir, 0755)
 }
 
+// WriteFile writes an exported file into the exports folder of the data directory
+func (ls *LocalStorage) WriteFile(name string, data []byte) error {
+	exportDir := filepath.Join(ls.dataDir, "exports")
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(exportDir, name), data, 0644)
+}
+
+// ReadFiles returns the contents of every .json file in a subdirectory of the data directory
+func (ls *LocalStorage) ReadFiles(dir string) [][]byte {
+	files, err := filepath.Glob(filepath.Join(ls.dataDir, dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+	
+	var contents [][]byte
+	for _, file := range files {
+		if data, err := os.ReadFile(file); err == nil {
+			contents = append(contents, data)
+		}
+	}
+	return contents
+}
+
+// Dir returns the path of a subdirectory of the data directory, creating it if needed
+func (ls *LocalStorage) Dir(dir string) string {
+	path := filepath.Join(ls.dataDir, dir)
+	os.MkdirAll(path, 0755)
+	return path
+}
+
+// OpenFile is not available outside the browser; files are read from the data directory instead
+func (ls *LocalStorage) OpenFile(onLoad func([]byte)) error {
+	return ErrFilePickerUnsupported
+}
+
 // GetKeys returns all keys that match a prefix
 func (ls *LocalStorage) GetKeys(prefix string) []string {
 	var keys []string
@@ -87,7 +162,14 @@ func (ls *LocalStorage) GetKeys(prefix string) []string {
 	return keys
 }
 
+// Online always reports true; desktop builds let downloads fail on their own
+func (ls *LocalStorage) Online() bool {
+	return true
+}
+
 var ErrNotFound = &StorageError{"key not found"}
+var ErrFilePickerUnsupported = &StorageError{"file picker not supported"}
+var ErrQuotaExceeded = &StorageError{"storage is full"}
 
 type StorageError struct {
 	Message string
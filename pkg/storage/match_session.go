@@ -0,0 +1,17 @@
+package storage
+
+// SaveMatchSession stores what the active profile needs to rejoin an online
+// match, so a player who drops or restarts the game can take their seat back
+func (ss *SaveSystem) SaveMatchSession(data interface{}) error {
+	return ss.set(ss.key(SaveKeyMatchSession), data)
+}
+
+// LoadMatchSession loads the online match to rejoin, if any
+func (ss *SaveSystem) LoadMatchSession(target interface{}) error {
+	return ss.storage.Get(ss.key(SaveKeyMatchSession), target)
+}
+
+// DeleteMatchSession forgets the match once it is over or left
+func (ss *SaveSystem) DeleteMatchSession() {
+	ss.remove(ss.key(SaveKeyMatchSession))
+}
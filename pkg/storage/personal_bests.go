@@ -0,0 +1,51 @@
+package storage
+
+import "time"
+
+// PersonalBest is the active profile's best result on one level. Each field is
+// kept on its own, so the fewest moves and the fastest time may come from
+// different games.
+type PersonalBest struct {
+	Moves  int           `json:"moves"`
+	Time   time.Duration `json:"time,omitempty"` // Zero until a timed game is won
+	Points int           `json:"points,omitempty"`
+}
+
+// PersonalBestFor returns a level's personal best, or false if it has never been won
+func (ss *SaveSystem) PersonalBestFor(level string) (PersonalBest, bool) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return PersonalBest{}, false
+	}
+	best, ok := progress.PersonalBests[level]
+	return best, ok
+}
+
+// UpdatePersonalBest merges a winning result into a level's personal best and
+// returns the best as it was before. result.Time should be zero for games
+// played without a clock.
+func (ss *SaveSystem) UpdatePersonalBest(level string, result PersonalBest) (PersonalBest, error) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return PersonalBest{}, err
+	}
+	if progress.PersonalBests == nil {
+		progress.PersonalBests = make(map[string]PersonalBest)
+	}
+
+	previous, ok := progress.PersonalBests[level]
+	best := previous
+	if !ok || result.Moves < best.Moves {
+		best.Moves = result.Moves
+	}
+	if result.Time > 0 && (best.Time == 0 || result.Time < best.Time) {
+		best.Time = result.Time
+	}
+	best.Points = max(best.Points, result.Points)
+
+	if ok && best == previous {
+		return previous, nil
+	}
+	progress.PersonalBests[level] = best
+	return previous, ss.SaveProgress(progress)
+}
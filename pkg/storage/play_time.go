@@ -0,0 +1,34 @@
+package storage
+
+import "time"
+
+// SessionSummary is what one sitting of play achieved
+type SessionSummary struct {
+	Played time.Duration `json:"played"` // Time played, not counting pauses
+	Levels int           `json:"levels"` // Levels completed
+	Stars  int           `json:"stars"`
+	Ended  time.Time     `json:"ended"`
+}
+
+// RecordSession adds play time to the active profile's total and keeps the
+// session it belongs to as the latest. Sessions are recorded as they go, so
+// played is only the time since the last call.
+func (ss *SaveSystem) RecordSession(played time.Duration, session SessionSummary) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+	progress.TotalPlayTime += played
+	progress.LastPlayed = session.Ended
+	progress.LastSession = &session
+	return ss.SaveProgress(progress)
+}
+
+// LastSession returns the active profile's latest session, if it has played one
+func (ss *SaveSystem) LastSession() (SessionSummary, bool) {
+	progress, err := ss.LoadProgress()
+	if err != nil || progress.LastSession == nil {
+		return SessionSummary{}, false
+	}
+	return *progress.LastSession, true
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultProfileID is the profile that owns data saved before profiles existed;
+// its keys are not prefixed
+const DefaultProfileID = "default"
+
+// maxProfiles keeps the profile picker on one screen
+const maxProfiles = 6
+
+var (
+	ErrProfileNotFound  = errors.New("profile not found")
+	ErrProfileName      = errors.New("profile name is empty")
+	ErrTooManyProfiles  = fmt.Errorf("at most %d profiles", maxProfiles)
+	ErrProfileProtected = errors.New("the default and active profiles cannot be deleted")
+)
+
+// profileScopedKeys are stored separately for every profile; custom levels,
+// collections, level packs, editor templates and the weekly level are shared
+// by the device
+var profileScopedKeys = []string{SaveKeyGameState, SaveKeyAchievements, SaveKeySettings, SaveKeyProgress, SaveKeyCrashReport, SaveKeyZenSession, SaveKeyPublished, SaveKeyQuests, SaveKeyMatchSession, SaveKeyCorrespondence, SaveKeyRating}
+
+// sharedKeys hold the data every profile on the device shares. The profile
+// index and the window placement are not among them: they belong to the
+// device rather than to the players' data.
+var sharedKeys = []string{SaveKeyCustomLevels, SaveKeyLevelHistory, SaveKeyCollections, SaveKeyLevelPacks, SaveKeyWeeklyLevel, SaveKeyAnalytics, SaveKeyOutbox, SaveKeyTemplates}
+
+// Profile is a named player on this device
+type Profile struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type profileIndex struct {
+	Profiles []Profile `json:"profiles"`
+	LastUsed string    `json:"last_used"`
+}
+
+// key scopes a storage key to the active profile
+func (ss *SaveSystem) key(base string) string {
+	if ss.profileID == "" || ss.profileID == DefaultProfileID {
+		return base
+	}
+	return profileKey(ss.profileID, base)
+}
+
+// dataKeys are the keys of the active profile's data and the shared data
+func (ss *SaveSystem) dataKeys() []string {
+	keys := make([]string, 0, len(profileScopedKeys)+len(sharedKeys))
+	for _, base := range profileScopedKeys {
+		keys = append(keys, ss.key(base))
+	}
+	return append(keys, sharedKeys...)
+}
+
+func profileKey(profileID, base string) string {
+	return "island_merge_profile_" + profileID + "." + base
+}
+
+// loadProfileIndex returns the stored profiles, always including the default one
+func (ss *SaveSystem) loadProfileIndex() profileIndex {
+	var index profileIndex
+	if err := ss.storage.Get(SaveKeyProfiles, &index); err != nil && err != ErrNotFound {
+		fmt.Println("Failed to load profiles:", err)
+	}
+	for _, profile := range index.Profiles {
+		if profile.ID == DefaultProfileID {
+			return index
+		}
+	}
+	index.Profiles = append([]Profile{{ID: DefaultProfileID, Name: "Player 1"}}, index.Profiles...)
+	return index
+}
+
+// Profiles lists the players on this device, default first
+func (ss *SaveSystem) Profiles() []Profile {
+	return ss.loadProfileIndex().Profiles
+}
+
+// CurrentProfile returns the active profile
+func (ss *SaveSystem) CurrentProfile() Profile {
+	for _, profile := range ss.Profiles() {
+		if profile.ID == ss.profileID {
+			return profile
+		}
+	}
+	return Profile{ID: DefaultProfileID, Name: "Player 1"}
+}
+
+// CreateProfile adds a profile; it starts with default settings and no progress
+func (ss *SaveSystem) CreateProfile(name string) (*Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrProfileName
+	}
+
+	index := ss.loadProfileIndex()
+	if len(index.Profiles) >= maxProfiles {
+		return nil, ErrTooManyProfiles
+	}
+
+	profile := Profile{
+		ID:        fmt.Sprintf("profile_%d", time.Now().UnixNano()),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	index.Profiles = append(index.Profiles, profile)
+	if err := ss.set(SaveKeyProfiles, index); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// SwitchProfile makes a profile active; it is remembered for the next launch
+func (ss *SaveSystem) SwitchProfile(profileID string) error {
+	index := ss.loadProfileIndex()
+	for _, profile := range index.Profiles {
+		if profile.ID == profileID {
+			ss.profileID = profileID
+			index.LastUsed = profileID
+			return ss.set(SaveKeyProfiles, index)
+		}
+	}
+	return ErrProfileNotFound
+}
+
+// DeleteProfile removes a profile and everything saved under it
+func (ss *SaveSystem) DeleteProfile(profileID string) error {
+	if profileID == DefaultProfileID || profileID == ss.profileID {
+		return ErrProfileProtected
+	}
+
+	index := ss.loadProfileIndex()
+	for i, profile := range index.Profiles {
+		if profile.ID != profileID {
+			continue
+		}
+		for _, base := range profileScopedKeys {
+			ss.remove(profileKey(profileID, base))
+		}
+		index.Profiles = append(index.Profiles[:i], index.Profiles[i+1:]...)
+		return ss.set(SaveKeyProfiles, index)
+	}
+	return ErrProfileNotFound
+}
+
+// RecordLevelCompletion marks a level completed for the active profile and adds
+// the attempt to its leaderboard, unless it was assisted or failed verification
+func (ss *SaveSystem) RecordLevelCompletion(score Score) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+
+	completed := false
+	for _, id := range progress.CompletedLevels {
+		if id == score.Level {
+			completed = true
+			break
+		}
+	}
+	if !completed {
+		progress.CompletedLevels = append(progress.CompletedLevels, score.Level)
+		if err := ss.SaveProgress(progress); err != nil {
+			return err
+		}
+	}
+
+	if score.Assisted || score.Unverified {
+		return nil
+	}
+	score.PlayerID = ss.CurrentProfile().Name
+	return ss.RecordHighScore(score)
+}
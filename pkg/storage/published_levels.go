@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// PublishedLevel is a level the active profile made and exported in a level
+// pack, with the signature it went out with
+type PublishedLevel struct {
+	LevelID     string    `json:"level_id"`
+	Name        string    `json:"name"`
+	Author      string    `json:"author"`
+	Signature   string    `json:"signature"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// LoadPublishedLevels returns the active profile's published levels, most
+// recently published first
+func (ss *SaveSystem) LoadPublishedLevels() ([]PublishedLevel, error) {
+	var published []PublishedLevel
+	if err := ss.storage.Get(ss.key(SaveKeyPublished), &published); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return published, nil
+}
+
+// recordPublished puts a level at the top of the published list, replacing an
+// earlier export of it
+func (ss *SaveSystem) recordPublished(level *levels.LevelData) error {
+	published, err := ss.LoadPublishedLevels()
+	if err != nil {
+		return err
+	}
+	published = slices.DeleteFunc(published, func(p PublishedLevel) bool { return p.LevelID == level.ID })
+	published = slices.Insert(published, 0, PublishedLevel{
+		LevelID:     level.ID,
+		Name:        level.Name,
+		Author:      level.Author,
+		Signature:   level.Signature,
+		PublishedAt: time.Now(),
+	})
+	return ss.set(ss.key(SaveKeyPublished), published)
+}
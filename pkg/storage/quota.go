@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// savePrefix starts every key the game stores
+const savePrefix = "island_merge_"
+
+// leaderboardKeepAge is how long FreeSpace keeps leaderboard entries other
+// than each leaderboard's best
+const leaderboardKeepAge = 30 * 24 * time.Hour
+
+// KeyUsage is the space one kind of saved data takes
+type KeyUsage struct {
+	Name  string // The key without its prefix, or "other profiles"
+	Bytes int
+}
+
+// Cleanup is what FreeSpace removed
+type Cleanup struct {
+	Bytes  int // Space freed
+	Scores int // Leaderboard entries removed
+}
+
+// set writes a key, remembering writes that did not fit in storage
+func (ss *SaveSystem) set(key string, value interface{}) error {
+	err := ss.storage.Set(key, value)
+	if err == ErrQuotaExceeded {
+		ss.failed[key] = true
+	} else if err == nil {
+		delete(ss.failed, key)
+	}
+	return err
+}
+
+// remove deletes a key; a write to it that did not fit no longer matters
+func (ss *SaveSystem) remove(key string) {
+	ss.storage.Remove(key)
+	delete(ss.failed, key)
+}
+
+// StorageFull reports whether data the game tried to save did not fit. It
+// stays set until those keys are saved again or removed.
+func (ss *SaveSystem) StorageFull() bool {
+	return len(ss.failed) > 0
+}
+
+// StorageQuota is roughly how many bytes can be stored, or 0 without a limit
+func (ss *SaveSystem) StorageQuota() int {
+	return ss.storage.Quota()
+}
+
+// StorageUsage lists the space taken by each kind of saved data, largest
+// first. The data of profiles other than the active one is summed together.
+func (ss *SaveSystem) StorageUsage() []KeyUsage {
+	active := make(map[string]string, len(profileScopedKeys)+len(sharedKeys))
+	for _, base := range profileScopedKeys {
+		active[ss.key(base)] = strings.TrimPrefix(base, savePrefix)
+	}
+	for _, base := range sharedKeys {
+		active[base] = strings.TrimPrefix(base, savePrefix)
+	}
+
+	totals := make(map[string]int)
+	for _, key := range ss.storage.GetKeys(savePrefix) {
+		name, ok := active[key]
+		if !ok {
+			name = strings.TrimPrefix(key, savePrefix)
+			if strings.HasPrefix(key, savePrefix+"profile_") || isProfileKey(key) {
+				name = "other profiles"
+			}
+		}
+		totals[name] += ss.storage.Size(key)
+	}
+
+	usage := make([]KeyUsage, 0, len(totals))
+	for name, bytes := range totals {
+		usage = append(usage, KeyUsage{Name: name, Bytes: bytes})
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Bytes != usage[j].Bytes {
+			return usage[i].Bytes > usage[j].Bytes
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	return usage
+}
+
+// isProfileKey reports whether a key is one of the default profile's own
+func isProfileKey(key string) bool {
+	for _, base := range profileScopedKeys {
+		if key == base {
+			return true
+		}
+	}
+	return false
+}
+
+// FreeSpace removes saved data that is safe to lose: the crash report once
+// its game has been offered for restoring, the cached level of the week,
+// which is downloaded again, and the active profile's leaderboard entries
+// older than 30 days, except each leaderboard's best.
+func (ss *SaveSystem) FreeSpace() (Cleanup, error) {
+	before := ss.usedBytes()
+
+	if report, err := ss.LoadCrashReport(); err == nil && report.Offered {
+		ss.remove(ss.key(SaveKeyCrashReport))
+	}
+	ss.remove(SaveKeyWeeklyLevel)
+	scores, err := ss.pruneHighScores(time.Now().Add(-leaderboardKeepAge))
+
+	return Cleanup{Bytes: before - ss.usedBytes(), Scores: scores}, err
+}
+
+// pruneHighScores drops leaderboard entries from before cutoff, keeping the
+// best of every leaderboard. It returns how many were dropped.
+func (ss *SaveSystem) pruneHighScores(cutoff time.Time) (int, error) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return 0, err
+	}
+
+	sortScores(progress.HighScores)
+	kept := make([]Score, 0, len(progress.HighScores))
+	for _, entry := range progress.HighScores {
+		best := true
+		for _, other := range kept {
+			if sameLeaderboard(other, entry.Level, entry.Mode, entry.Relaxed) {
+				best = false
+				break
+			}
+		}
+		if best || entry.Date.After(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+
+	removed := len(progress.HighScores) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	progress.HighScores = kept
+	return removed, ss.SaveProgress(progress)
+}
+
+// usedBytes is the space taken by everything the game stores
+func (ss *SaveSystem) usedBytes() int {
+	total := 0
+	for _, key := range ss.storage.GetKeys(savePrefix) {
+		total += ss.storage.Size(key)
+	}
+	return total
+}
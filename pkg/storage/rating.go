@@ -0,0 +1,34 @@
+package storage
+
+import "time"
+
+// RatingEntry is the rating after one rated race
+type RatingEntry struct {
+	At             time.Time `json:"at"`
+	Rating         int       `json:"rating"`
+	Change         int       `json:"change"`
+	Opponent       string    `json:"opponent"` // AI skill raced, e.g. "Hard"
+	OpponentRating int       `json:"opponent_rating"`
+	Won            bool      `json:"won"`
+}
+
+// RatingHistory is a profile's race rating and how it got there, oldest first
+type RatingHistory struct {
+	Rating  int           `json:"rating"`
+	Entries []RatingEntry `json:"entries,omitempty"`
+}
+
+// SaveRating stores the active profile's race rating
+func (ss *SaveSystem) SaveRating(history *RatingHistory) error {
+	return ss.set(ss.key(SaveKeyRating), history)
+}
+
+// LoadRating returns the active profile's race rating; a profile that never
+// raced has a zero rating
+func (ss *SaveSystem) LoadRating() (*RatingHistory, error) {
+	var history RatingHistory
+	if err := ss.storage.Get(ss.key(SaveKeyRating), &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
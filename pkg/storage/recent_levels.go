@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"slices"
+	"time"
+)
+
+// maxRecentLevels is how many recently played levels the main menu offers
+const maxRecentLevels = 3
+
+// RecordRecentLevel puts a level first among the active profile's recently
+// played levels, dropping the oldest once there are too many
+func (ss *SaveSystem) RecordRecentLevel(levelID string) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+	recent := slices.DeleteFunc(progress.RecentLevels, func(id string) bool { return id == levelID })
+	recent = append([]string{levelID}, recent...)
+	if len(recent) > maxRecentLevels {
+		recent = recent[:maxRecentLevels]
+	}
+	progress.RecentLevels = recent
+	return ss.SaveProgress(progress)
+}
+
+// RecentLevels returns the IDs of the active profile's recently played levels, latest first
+func (ss *SaveSystem) RecentLevels() []string {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return nil
+	}
+	return progress.RecentLevels
+}
+
+// maxRecentResults is how many level wins are kept for recommending the next level
+const maxRecentResults = 10
+
+// LevelResult is how a won level went, for recommending the next level
+type LevelResult struct {
+	Level string        `json:"level"`
+	Stars int           `json:"stars"`
+	Time  time.Duration `json:"time,omitempty"` // Zero for relaxed games, which are not timed
+	Date  time.Time     `json:"date"`
+}
+
+// RecordLevelResult puts a level win first among the active profile's recent
+// results, dropping the oldest once there are too many
+func (ss *SaveSystem) RecordLevelResult(result LevelResult) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+	results := append([]LevelResult{result}, progress.RecentResults...)
+	if len(results) > maxRecentResults {
+		results = results[:maxRecentResults]
+	}
+	progress.RecentResults = results
+	return ss.SaveProgress(progress)
+}
+
+// RecentResults returns the active profile's latest level wins, latest first
+func (ss *SaveSystem) RecentResults() []LevelResult {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return nil
+	}
+	return progress.RecentResults
+}
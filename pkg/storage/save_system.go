@@ -1,18 +1,42 @@
 package storage
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/ponyo877/island-merge/pkg/island"
 )
 
 const (
-	SaveKeyGameState     = "island_merge_game_state"
-	SaveKeyAchievements  = "island_merge_achievements"
-	SaveKeySettings      = "island_merge_settings"
-	SaveKeyCustomLevels  = "island_merge_custom_levels"
-	SaveKeyProgress      = "island_merge_progress"
+	SaveKeyGameState      = "island_merge_game_state"
+	SaveKeyAchievements   = "island_merge_achievements"
+	SaveKeySettings       = "island_merge_settings"
+	SaveKeyCustomLevels   = "island_merge_custom_levels"
+	SaveKeyProgress       = "island_merge_progress"
+	SaveKeyCollections    = "island_merge_collections"
+	SaveKeyLevelPacks     = "island_merge_level_packs"
+	SaveKeyWeeklyLevel    = "island_merge_weekly_level"
+	SaveKeyProfiles       = "island_merge_profiles"
+	SaveKeyAnalytics      = "island_merge_analytics"
+	SaveKeyCrashReport    = "island_merge_crash_report"
+	SaveKeyWindow         = "island_merge_window"
+	SaveKeyZenSession     = "island_merge_zen_session"
+	SaveKeyTemplates      = "island_merge_editor_templates"
+	SaveKeyLevelHistory   = "island_merge_level_history"
+	SaveKeyPublished      = "island_merge_published_levels"
+	SaveKeyQuests         = "island_merge_quests"
+	SaveKeyOutbox         = "island_merge_outbox"
+	SaveKeyMatchSession   = "island_merge_match_session"
+	SaveKeyCorrespondence = "island_merge_correspondence"
+	SaveKeyRating         = "island_merge_rating"
 )
 
+// SaveDataVersion is written into exported save data. Imports must share its
+// major version.
+const SaveDataVersion = "1.0"
+
 // GameSaveData represents the complete saved game state
 type GameSaveData struct {
 	Version       string                 `json:"version"`
@@ -22,6 +46,7 @@ type GameSaveData struct {
 	Settings      *GameSettings          `json:"settings"`
 	Progress      *GameProgress          `json:"progress"`
 	CustomLevels  []CustomLevel          `json:"custom_levels,omitempty"`
+	Collections   []LevelCollection      `json:"collections,omitempty"`
 }
 
 // CurrentGameState stores the state of an ongoing game
@@ -32,21 +57,69 @@ type CurrentGameState struct {
 	StartTime   time.Time     `json:"start_time"`
 	TimeLimit   time.Duration `json:"time_limit,omitempty"`
 	GameWon     bool          `json:"game_won"`
+	Relaxed     bool          `json:"relaxed,omitempty"`
+	Assisted    bool          `json:"assisted,omitempty"`
+	Overtime    bool          `json:"overtime,omitempty"`
+	Seed        int64         `json:"seed,omitempty"`    // Everything random in the game comes from it
+	Hazards     *HazardData   `json:"hazards,omitempty"` // Storm mode weather
+	Zen         *ZenData      `json:"zen,omitempty"`     // Zen mode rings
+	Mutators    []string      `json:"mutators,omitempty"`
+}
+
+// HazardData is the saved state of Storm mode
+type HazardData struct {
+	Seed       int64 `json:"seed,omitempty"` // Only in saves older than CurrentGameState.Seed
+	MoveBudget int   `json:"move_budget"`
+}
+
+// ZenData is the saved state of Zen mode
+type ZenData struct {
+	Seed  int64 `json:"seed,omitempty"` // Only in saves older than CurrentGameState.Seed
+	Rings int   `json:"rings"`
+}
+
+// GameSeed is the seed of the saved game. Older saves kept it only for the
+// modes that needed one.
+func (s *CurrentGameState) GameSeed() int64 {
+	switch {
+	case s.Seed != 0:
+		return s.Seed
+	case s.Hazards != nil:
+		return s.Hazards.Seed
+	case s.Zen != nil:
+		return s.Zen.Seed
+	}
+	return 0
 }
 
 // BoardData represents the game board state
 type BoardData struct {
-	Width   int     `json:"width"`
-	Height  int     `json:"height"`
-	Tiles   [][]int `json:"tiles"`
-	Islands []int   `json:"islands"`
+	Width        int                    `json:"width"`
+	Height       int                    `json:"height"`
+	Tiles        [][]int                `json:"tiles"`
+	Islands      []int                  `json:"islands"`
+	Health       [][]int                `json:"health,omitempty"`        // Bridge durability in saves from before components
+	Components   []island.ComponentData `json:"components,omitempty"`    // Per-tile extras such as bridge damage
+	BridgeBudget int                    `json:"bridge_budget,omitempty"` // Most bridges standing at once; 0 for no limit
+	MergeLand    bool                   `json:"merge_land,omitempty"`    // Touching land tiles form one island
 }
 
 // ScoreData represents the current score
 type ScoreData struct {
-	Moves    int           `json:"moves"`
-	Time     time.Duration `json:"time"`
-	BestTime time.Duration `json:"best_time,omitempty"`
+	Moves      int           `json:"moves"`
+	Time       time.Duration `json:"time"`
+	BestTime   time.Duration `json:"best_time,omitempty"`
+	BestMoves  int           `json:"best_moves,omitempty"`
+	BestPoints int           `json:"best_points,omitempty"`
+
+	Points       int `json:"points,omitempty"`
+	Combo        int `json:"combo,omitempty"`
+	BestCombo    int `json:"best_combo,omitempty"`
+	IslandPoints int `json:"island_points,omitempty"`
+	ComboPoints  int `json:"combo_points,omitempty"`
+	TimeBonus    int `json:"time_bonus,omitempty"`
+
+	MoveTimes []time.Duration `json:"move_times,omitempty"`
 }
 
 // GameSettings stores user preferences
@@ -57,6 +130,133 @@ type GameSettings struct {
 	ShowTutorial     bool    `json:"show_tutorial"`
 	AutoSave         bool    `json:"auto_save"`
 	PreferredMode    int     `json:"preferred_mode"`
+	AIOpponent       int     `json:"ai_opponent"` // 0: off, otherwise ai.Skill for Time Attack races
+	AIPersonality    int     `json:"ai_personality,omitempty"` // ai.Personality of the Time Attack AI
+	WeeklyLevelURL   string  `json:"weekly_level_url,omitempty"` // Level of the week source; empty disables it
+	AnalyticsEnabled bool    `json:"analytics_enabled"` // Opt-in anonymous gameplay statistics
+	AnalyticsURL     string  `json:"analytics_url,omitempty"` // Where statistics are posted; empty keeps them local
+	LevelVoteURL     string  `json:"level_vote_url,omitempty"` // Sharing backend that collects level votes; empty keeps them local
+	OnlineURL        string  `json:"online_url,omitempty"` // Backend for score submissions, level uploads and online matches, over http(s) or ws(s); empty keeps the game offline
+	KeyBindings      map[string]string `json:"key_bindings,omitempty"` // Control name to input name; missing controls use defaults
+	TargetTPS        int     `json:"target_tps,omitempty"` // Updates per second; 0 means DefaultTPS
+	DisableAmbient   bool    `json:"disable_ambient_animations"`
+	ReducedEffects   bool    `json:"reduced_effects"`
+	PowerSaving      bool    `json:"power_saving"` // Overrides the graphics options for low-end devices and batteries
+	RelaxedMode      bool    `json:"relaxed_mode"` // No time limits; stars come from moves only
+	MoveFeedback     bool    `json:"move_feedback"` // Rate each bridge; games played with it earn no stars
+	DisableVSync     bool    `json:"disable_vsync"` // Draw as fast as possible instead of at the display's refresh rate
+	ClassicWidth     int     `json:"classic_width,omitempty"` // Last board size picked for Classic; 0 uses the default
+	ClassicHeight    int     `json:"classic_height,omitempty"`
+	Mutators         []string `json:"mutators,omitempty"` // Mutators last picked before a level
+	TimeAttackOvertime bool  `json:"time_attack_overtime"` // Play on past Time Attack's limit for fewer points
+	IdlePause        int     `json:"idle_pause,omitempty"` // Seconds without input before a timed level pauses; 0 means DefaultIdlePause, negative never
+	AutoSaveMoves    int     `json:"auto_save_moves,omitempty"` // Moves between auto-saves; 0 means DefaultAutoSaveMoves
+	LaunchInto       int     `json:"launch_into,omitempty"` // What the game opens on: LaunchMenu, LaunchPreferredMode or LaunchLastLevel
+	ModeDefaults     map[int]ModeDefaults `json:"mode_defaults,omitempty"` // Per-mode overrides by mode ID; missing modes follow the settings above
+	DoNotDisturb     bool    `json:"do_not_disturb"` // Hold achievement notifications until the level ends
+	BridgePaint      string  `json:"bridge_paint,omitempty"` // Cosmetic bridge colour by paint ID; empty uses the theme's
+	Title            string  `json:"title,omitempty"` // Achievement title worn beside the player's name; empty wears none
+	MuteEmotes       bool    `json:"mute_emotes"` // Hide the opponent's emotes in races
+	AutoAdvance      bool    `json:"auto_advance"` // Play the recommended level a few seconds after each win
+}
+
+// Launch targets for GameSettings.LaunchInto
+const (
+	LaunchMenu = iota
+	LaunchPreferredMode // Start a game of PreferredMode
+	LaunchLastLevel     // Replay the most recently played level
+)
+
+// ModeDefaults overrides settings for games of one mode; nil fields follow
+// the global setting
+type ModeDefaults struct {
+	Timers       *bool `json:"timers,omitempty"`
+	MoveFeedback *bool `json:"move_feedback,omitempty"`
+}
+
+// Relaxed reports whether games of the mode play without timers, given the
+// global relaxed mode setting
+func (d ModeDefaults) Relaxed(global bool) bool {
+	if d.Timers != nil {
+		return !*d.Timers
+	}
+	return global
+}
+
+// Feedback reports whether games of the mode rate each bridge, given the
+// global move feedback setting
+func (d ModeDefaults) Feedback(global bool) bool {
+	if d.MoveFeedback != nil {
+		return *d.MoveFeedback
+	}
+	return global
+}
+
+// DefaultTPS is the update rate used unless the player picks another
+const DefaultTPS = 60
+
+// DefaultAutoSaveMoves is how many moves are made between auto-saves unless
+// the player picks another count
+const DefaultAutoSaveMoves = 5
+
+// AutoSaveEvery is how many moves are made between auto-saves
+func (s *GameSettings) AutoSaveEvery() int {
+	if s.AutoSaveMoves <= 0 {
+		return DefaultAutoSaveMoves
+	}
+	return s.AutoSaveMoves
+}
+
+// DefaultIdlePause is how many seconds a timed level waits for input before
+// pausing itself, unless the player picks another time
+const DefaultIdlePause = 60
+
+// IdlePauseAfter is how long a timed level waits for input before pausing
+// itself, or 0 if it never does
+func (s *GameSettings) IdlePauseAfter() time.Duration {
+	switch {
+	case s.IdlePause < 0:
+		return 0
+	case s.IdlePause == 0:
+		return DefaultIdlePause * time.Second
+	}
+	return time.Duration(s.IdlePause) * time.Second
+}
+
+// powerSavingTPS caps the update rate in power-saving mode
+const powerSavingTPS = 30
+
+// GraphicsQuality is the effective graphics configuration after power saving is applied
+type GraphicsQuality struct {
+	TPS               int
+	AmbientAnimations bool
+	ReducedEffects    bool
+	RunWhenUnfocused  bool
+	VSync             bool
+}
+
+// Graphics resolves the graphics options, letting power saving override the rest
+func (s *GameSettings) Graphics() GraphicsQuality {
+	quality := GraphicsQuality{
+		TPS:               s.TargetTPS,
+		AmbientAnimations: !s.DisableAmbient,
+		ReducedEffects:    s.ReducedEffects,
+		RunWhenUnfocused:  true,
+		VSync:             !s.DisableVSync,
+	}
+	if quality.TPS <= 0 {
+		quality.TPS = DefaultTPS
+	}
+	if s.PowerSaving {
+		if quality.TPS > powerSavingTPS {
+			quality.TPS = powerSavingTPS
+		}
+		quality.AmbientAnimations = false
+		quality.ReducedEffects = true
+		quality.RunWhenUnfocused = false
+		quality.VSync = true
+	}
+	return quality
 }
 
 // GameProgress tracks overall game progress
@@ -66,6 +266,13 @@ type GameProgress struct {
 	TotalPlayTime     time.Duration `json:"total_play_time"`
 	LastPlayed        time.Time `json:"last_played"`
 	UnlockedModes     []int     `json:"unlocked_modes"`
+	PersonalBests     map[string]PersonalBest `json:"personal_bests,omitempty"`
+	Challenges        map[string]ChallengeProgress `json:"challenges,omitempty"` // Weekly challenges by ISO week
+	Speedruns         map[string]SpeedrunRecord `json:"speedruns,omitempty"` // Speedrun records by level set
+	LevelVotes        map[string]LevelVote `json:"level_votes,omitempty"` // Votes on shared levels by level ID
+	LastSession       *SessionSummary `json:"last_session,omitempty"` // The latest sitting of play
+	RecentLevels      []string `json:"recent_levels,omitempty"` // Level IDs last played, latest first
+	RecentResults     []LevelResult `json:"recent_results,omitempty"` // Latest level wins, latest first, for recommending the next level
 }
 
 // Score represents a high score entry
@@ -74,8 +281,14 @@ type Score struct {
 	Mode      int           `json:"mode"`
 	Moves     int           `json:"moves"`
 	Time      time.Duration `json:"time"`
+	Points    int           `json:"points,omitempty"`
 	Date      time.Time     `json:"date"`
 	PlayerID  string        `json:"player_id,omitempty"`
+	PlayerTitle string      `json:"player_title,omitempty"` // Title the player wore when the score was set
+	Stars     int           `json:"stars,omitempty"`
+	Relaxed   bool          `json:"relaxed,omitempty"` // Played without timers; ranked separately
+	Assisted  bool          `json:"assisted,omitempty"` // Played with move feedback; kept off leaderboards
+	Unverified bool         `json:"unverified,omitempty"` // Its replay did not support the result; kept off leaderboards
 }
 
 // CustomLevel represents a user-created level
@@ -84,34 +297,55 @@ type CustomLevel struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
-	Author      string    `json:"author,omitempty"`
+	Author      string    `json:"author,omitempty"`    // Empty for levels made on this device
+	Signature   string    `json:"signature,omitempty"` // Kept from the pack a shared level came in
 	Width       int       `json:"width"`
 	Height      int       `json:"height"`
 	Tiles       [][]int   `json:"tiles"`
 	Difficulty  string    `json:"difficulty,omitempty"`
 	Tags        []string  `json:"tags,omitempty"`
+	
+	// Local workshop stats
+	Rating      int           `json:"rating,omitempty"` // 1-5, 0 when unrated
+	PlayCount   int           `json:"play_count,omitempty"`
+	Completions int           `json:"completions,omitempty"`
+	BestMoves   int           `json:"best_moves,omitempty"`
+	BestTime    time.Duration `json:"best_time,omitempty"`
 }
 
 // SaveSystem manages all save/load operations
 type SaveSystem struct {
-	storage *LocalStorage
+	storage     *LocalStorage
+	uploads     chan []byte     // Level packs picked by the user, delivered asynchronously
+	saveUploads chan []byte     // Save data files picked by the user, delivered asynchronously
+	profileID   string          // Active profile; scopes game state, achievements, settings and progress
+	failed      map[string]bool // Keys whose last write did not fit in storage
 }
 
+// NewSaveSystem opens storage with the profile that was used last
 func NewSaveSystem() *SaveSystem {
-	return &SaveSystem{
-		storage: NewLocalStorage(),
+	ss := &SaveSystem{
+		storage:     NewLocalStorage(),
+		uploads:     make(chan []byte, 4),
+		saveUploads: make(chan []byte, 1),
+		profileID:   DefaultProfileID,
+		failed:      make(map[string]bool),
+	}
+	if lastUsed := ss.loadProfileIndex().LastUsed; lastUsed != "" {
+		ss.profileID = lastUsed
 	}
+	return ss
 }
 
 // SaveGameState saves the current game state
 func (ss *SaveSystem) SaveGameState(gameState *CurrentGameState) error {
-	return ss.storage.Set(SaveKeyGameState, gameState)
+	return ss.set(ss.key(SaveKeyGameState), gameState)
 }
 
 // LoadGameState loads the saved game state
 func (ss *SaveSystem) LoadGameState() (*CurrentGameState, error) {
 	var gameState CurrentGameState
-	err := ss.storage.Get(SaveKeyGameState, &gameState)
+	err := ss.storage.Get(ss.key(SaveKeyGameState), &gameState)
 	if err != nil {
 		return nil, err
 	}
@@ -120,33 +354,65 @@ func (ss *SaveSystem) LoadGameState() (*CurrentGameState, error) {
 
 // HasSavedGame checks if there's a saved game
 func (ss *SaveSystem) HasSavedGame() bool {
-	return ss.storage.Exists(SaveKeyGameState)
+	return ss.storage.Exists(ss.key(SaveKeyGameState))
 }
 
 // DeleteSavedGame removes the saved game state
 func (ss *SaveSystem) DeleteSavedGame() {
-	ss.storage.Remove(SaveKeyGameState)
+	ss.remove(ss.key(SaveKeyGameState))
 }
 
 // SaveAchievements saves achievement data
 func (ss *SaveSystem) SaveAchievements(achievements interface{}) error {
-	return ss.storage.Set(SaveKeyAchievements, achievements)
+	return ss.set(ss.key(SaveKeyAchievements), achievements)
+}
+
+// SaveQuests saves the active profile's quests and quest tokens
+func (ss *SaveSystem) SaveQuests(quests interface{}) error {
+	return ss.set(ss.key(SaveKeyQuests), quests)
+}
+
+// LoadQuests loads the active profile's quests and quest tokens
+func (ss *SaveSystem) LoadQuests(target interface{}) error {
+	return ss.storage.Get(ss.key(SaveKeyQuests), target)
+}
+
+// SaveAnalytics stores gameplay events that have not been uploaded yet; they are
+// anonymous and shared by all profiles
+func (ss *SaveSystem) SaveAnalytics(data interface{}) error {
+	return ss.set(SaveKeyAnalytics, data)
+}
+
+// LoadAnalytics loads gameplay events that have not been uploaded yet
+func (ss *SaveSystem) LoadAnalytics(target interface{}) error {
+	return ss.storage.Get(SaveKeyAnalytics, target)
+}
+
+// SaveOutbox stores score submissions and level uploads the online backend
+// has not accepted yet; they are sent whichever profile is active
+func (ss *SaveSystem) SaveOutbox(data interface{}) error {
+	return ss.set(SaveKeyOutbox, data)
+}
+
+// LoadOutbox loads submissions the online backend has not accepted yet
+func (ss *SaveSystem) LoadOutbox(target interface{}) error {
+	return ss.storage.Get(SaveKeyOutbox, target)
 }
 
 // LoadAchievements loads achievement data
 func (ss *SaveSystem) LoadAchievements(target interface{}) error {
-	return ss.storage.Get(SaveKeyAchievements, target)
+	return ss.storage.Get(ss.key(SaveKeyAchievements), target)
 }
 
 // SaveSettings saves game settings
 func (ss *SaveSystem) SaveSettings(settings *GameSettings) error {
-	return ss.storage.Set(SaveKeySettings, settings)
+	return ss.set(ss.key(SaveKeySettings), settings)
 }
 
 // LoadSettings loads game settings
 func (ss *SaveSystem) LoadSettings() (*GameSettings, error) {
 	var settings GameSettings
-	err := ss.storage.Get(SaveKeySettings, &settings)
+	err := ss.storage.Get(ss.key(SaveKeySettings), &settings)
 	if err != nil {
 		// Return default settings if none found
 		return ss.GetDefaultSettings(), nil
@@ -168,13 +434,13 @@ func (ss *SaveSystem) GetDefaultSettings() *GameSettings {
 
 // SaveProgress saves game progress
 func (ss *SaveSystem) SaveProgress(progress *GameProgress) error {
-	return ss.storage.Set(SaveKeyProgress, progress)
+	return ss.set(ss.key(SaveKeyProgress), progress)
 }
 
 // LoadProgress loads game progress
 func (ss *SaveSystem) LoadProgress() (*GameProgress, error) {
 	var progress GameProgress
-	err := ss.storage.Get(SaveKeyProgress, &progress)
+	err := ss.storage.Get(ss.key(SaveKeyProgress), &progress)
 	if err != nil {
 		// Return default progress if none found
 		return &GameProgress{
@@ -198,6 +464,11 @@ func (ss *SaveSystem) SaveCustomLevel(level *CustomLevel) error {
 	found := false
 	for i, existingLevel := range levels {
 		if existingLevel.ID == level.ID {
+			if !sameTiles(existingLevel.Tiles, level.Tiles) {
+				if err := ss.addRevision(existingLevel); err != nil {
+					fmt.Println("Failed to keep level revision:", err)
+				}
+			}
 			levels[i] = *level
 			found = true
 			break
@@ -208,7 +479,7 @@ func (ss *SaveSystem) SaveCustomLevel(level *CustomLevel) error {
 		levels = append(levels, *level)
 	}
 	
-	return ss.storage.Set(SaveKeyCustomLevels, levels)
+	return ss.set(SaveKeyCustomLevels, levels)
 }
 
 // LoadCustomLevels loads all custom levels
@@ -235,14 +506,23 @@ func (ss *SaveSystem) DeleteCustomLevel(levelID string) error {
 			newLevels = append(newLevels, level)
 		}
 	}
+	if err := ss.deleteHistory(levelID); err != nil {
+		fmt.Println("Failed to delete level history:", err)
+	}
 	
-	return ss.storage.Set(SaveKeyCustomLevels, newLevels)
+	return ss.set(SaveKeyCustomLevels, newLevels)
+}
+
+// ExportFile hands a generated file to the user: a download in the browser,
+// a file in the exports folder on desktop
+func (ss *SaveSystem) ExportFile(name string, data []byte) error {
+	return ss.storage.WriteFile(name, data)
 }
 
 // ExportSaveData exports all save data as JSON
 func (ss *SaveSystem) ExportSaveData() (*GameSaveData, error) {
 	saveData := &GameSaveData{
-		Version: "1.0",
+		Version: SaveDataVersion,
 		SavedAt: time.Now(),
 	}
 	
@@ -272,9 +552,66 @@ func (ss *SaveSystem) ExportSaveData() (*GameSaveData, error) {
 		saveData.CustomLevels = levels
 	}
 	
+	// Load collections
+	if collections, err := ss.LoadCollections(); err == nil {
+		saveData.Collections = collections
+	}
+	
 	return saveData, nil
 }
 
+// ParseSaveData reads an exported save data file, rejecting files from
+// incompatible versions and files with nothing to import
+func ParseSaveData(data []byte) (*GameSaveData, error) {
+	var saveData GameSaveData
+	if err := json.Unmarshal(data, &saveData); err != nil {
+		return nil, fmt.Errorf("not a save data file: %w", err)
+	}
+	if saveData.Version == "" {
+		return nil, fmt.Errorf("not a save data file: no version")
+	}
+	if majorVersion(saveData.Version) != majorVersion(SaveDataVersion) {
+		return nil, fmt.Errorf("unsupported save data version %s", saveData.Version)
+	}
+	if saveData.CurrentGame == nil && saveData.Achievements == nil && saveData.Settings == nil &&
+		saveData.Progress == nil && saveData.CustomLevels == nil && saveData.Collections == nil {
+		return nil, fmt.Errorf("the save data file is empty")
+	}
+	return &saveData, nil
+}
+
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+// RequestSaveDataUpload asks the user to pick an exported save data file. The
+// file arrives later through PollUploadedSaveData. Returns
+// ErrFilePickerUnsupported where there is no picker.
+func (ss *SaveSystem) RequestSaveDataUpload() error {
+	return ss.storage.OpenFile(ss.ReceiveSaveData)
+}
+
+// ReceiveSaveData delivers a save data file that arrived some other way, such
+// as dropped on the window, through PollUploadedSaveData. It may be called
+// from any goroutine.
+func (ss *SaveSystem) ReceiveSaveData(data []byte) {
+	select {
+	case ss.saveUploads <- data:
+	default: // A file is already waiting
+	}
+}
+
+// PollUploadedSaveData returns a save data file picked since the last call
+func (ss *SaveSystem) PollUploadedSaveData() ([]byte, bool) {
+	select {
+	case data := <-ss.saveUploads:
+		return data, true
+	default:
+		return nil, false
+	}
+}
+
 // ImportSaveData imports save data from JSON
 func (ss *SaveSystem) ImportSaveData(saveData *GameSaveData) error {
 	if saveData.CurrentGame != nil {
@@ -309,25 +646,46 @@ func (ss *SaveSystem) ImportSaveData(saveData *GameSaveData) error {
 		}
 	}
 	
+	if saveData.Collections != nil {
+		if err := ss.SaveCollections(saveData.Collections); err != nil {
+			return fmt.Errorf("failed to import collections: %w", err)
+		}
+	}
+	
 	return nil
 }
 
-// ClearAllData removes all saved data
-func (ss *SaveSystem) ClearAllData() {
-	ss.storage.Remove(SaveKeyGameState)
-	ss.storage.Remove(SaveKeyAchievements)
-	ss.storage.Remove(SaveKeySettings)
-	ss.storage.Remove(SaveKeyCustomLevels)
-	ss.storage.Remove(SaveKeyProgress)
+// ClearedData is what ClearAllData removed, by storage key, kept so the
+// clear can be undone
+type ClearedData map[string]json.RawMessage
+
+// ClearAllData removes the active profile's data and everything shared by the
+// device. It returns the removed data for RestoreData.
+func (ss *SaveSystem) ClearAllData() ClearedData {
+	cleared := make(ClearedData)
+	for _, key := range ss.dataKeys() {
+		var data json.RawMessage
+		if err := ss.storage.Get(key, &data); err == nil {
+			cleared[key] = data
+		}
+		ss.remove(key)
+	}
+	return cleared
 }
 
-// GetStorageUsage returns information about storage usage
-func (ss *SaveSystem) GetStorageUsage() map[string]bool {
-	return map[string]bool{
-		"game_state":    ss.storage.Exists(SaveKeyGameState),
-		"achievements":  ss.storage.Exists(SaveKeyAchievements),
-		"settings":      ss.storage.Exists(SaveKeySettings),
-		"custom_levels": ss.storage.Exists(SaveKeyCustomLevels),
-		"progress":      ss.storage.Exists(SaveKeyProgress),
+// RestoreData puts back everything a ClearAllData call removed
+func (ss *SaveSystem) RestoreData(cleared ClearedData) error {
+	for key, data := range cleared {
+		if err := ss.set(key, data); err != nil {
+			return fmt.Errorf("restoring %s: %w", key, err)
+		}
 	}
-}
\ No newline at end of file
+	return nil
+}
+
+// Offline reports whether the game is running without a network connection.
+// Progress is still saved locally; only the level of the week and analytics
+// uploads wait for the connection to return.
+func (ss *SaveSystem) Offline() bool {
+	return !ss.storage.Online()
+}
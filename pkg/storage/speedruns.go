@@ -0,0 +1,67 @@
+package storage
+
+import "time"
+
+// SpeedrunRecord is the active profile's record for speedruns of one level set
+type SpeedrunRecord struct {
+	Splits []time.Duration `json:"splits,omitempty"` // Run time after each level in the fastest complete run
+	Golds  []time.Duration `json:"golds,omitempty"`  // Fastest time ever spent on each level during a run
+}
+
+// Best is the time of the fastest complete run, or zero before one is finished
+func (r SpeedrunRecord) Best() time.Duration {
+	if len(r.Splits) == 0 {
+		return 0
+	}
+	return r.Splits[len(r.Splits)-1]
+}
+
+// SpeedrunRecordFor returns the record for a level set; sets never run have none
+func (ss *SaveSystem) SpeedrunRecordFor(set string) SpeedrunRecord {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return SpeedrunRecord{}
+	}
+	return progress.Speedruns[set]
+}
+
+// UpdateSpeedrun merges the time spent on each level of a run into a set's
+// golds and, when the run is complete and faster, makes its splits the new
+// personal best. It returns the record as it was before.
+func (ss *SaveSystem) UpdateSpeedrun(set string, segments []time.Duration, complete bool) (SpeedrunRecord, error) {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return SpeedrunRecord{}, err
+	}
+	if progress.Speedruns == nil {
+		progress.Speedruns = make(map[string]SpeedrunRecord)
+	}
+
+	previous := progress.Speedruns[set]
+	record := SpeedrunRecord{
+		Splits: previous.Splits,
+		Golds:  append([]time.Duration(nil), previous.Golds...),
+	}
+	for i, segment := range segments {
+		if i >= len(record.Golds) {
+			record.Golds = append(record.Golds, segment)
+		} else if segment < record.Golds[i] {
+			record.Golds[i] = segment
+		}
+	}
+
+	if complete {
+		var total time.Duration
+		splits := make([]time.Duration, len(segments))
+		for i, segment := range segments {
+			total += segment
+			splits[i] = total
+		}
+		if previous.Best() == 0 || total < previous.Best() {
+			record.Splits = splits
+		}
+	}
+
+	progress.Speedruns[set] = record
+	return previous, ss.SaveProgress(progress)
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// leaderboardSize is how many scores each leaderboard keeps
+const leaderboardSize = 5
+
+// CachedWeeklyLevel is the last downloaded level of the week, stored as served
+type CachedWeeklyLevel struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// SaveWeeklyLevel caches a downloaded level of the week
+func (ss *SaveSystem) SaveWeeklyLevel(data []byte) error {
+	return ss.set(SaveKeyWeeklyLevel, CachedWeeklyLevel{
+		FetchedAt: time.Now(),
+		Data:      json.RawMessage(data),
+	})
+}
+
+// LoadWeeklyLevel returns the cached level of the week
+func (ss *SaveSystem) LoadWeeklyLevel() (*CachedWeeklyLevel, error) {
+	var cached CachedWeeklyLevel
+	if err := ss.storage.Get(SaveKeyWeeklyLevel, &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// RecordHighScore adds a score to its leaderboard, keeping only the best entries. Each
+// level has a table per mode, and relaxed scores have tables of their own. Higher
+// points rank first, then fewer moves, then faster times.
+func (ss *SaveSystem) RecordHighScore(score Score) error {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return err
+	}
+
+	progress.HighScores = append(progress.HighScores, score)
+
+	board := make([]Score, 0)
+	others := make([]Score, 0, len(progress.HighScores))
+	for _, entry := range progress.HighScores {
+		if sameLeaderboard(entry, score.Level, score.Mode, score.Relaxed) {
+			board = append(board, entry)
+		} else {
+			others = append(others, entry)
+		}
+	}
+
+	sortScores(board)
+	if len(board) > leaderboardSize {
+		board = board[:leaderboardSize]
+	}
+
+	progress.HighScores = append(others, board...)
+	return ss.SaveProgress(progress)
+}
+
+// HighScoresFor returns a level's timed or relaxed leaderboard for one mode, best first
+func (ss *SaveSystem) HighScoresFor(level string, mode int, relaxed bool) []Score {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return nil
+	}
+
+	var board []Score
+	for _, entry := range progress.HighScores {
+		if sameLeaderboard(entry, level, mode, relaxed) {
+			board = append(board, entry)
+		}
+	}
+	sortScores(board)
+	return board
+}
+
+// LevelHighScores returns every recorded score for a level across all its leaderboards
+func (ss *SaveSystem) LevelHighScores(level string) []Score {
+	progress, err := ss.LoadProgress()
+	if err != nil {
+		return nil
+	}
+
+	var scores []Score
+	for _, entry := range progress.HighScores {
+		if entry.Level == level {
+			scores = append(scores, entry)
+		}
+	}
+	return scores
+}
+
+func sameLeaderboard(entry Score, level string, mode int, relaxed bool) bool {
+	return entry.Level == level && entry.Mode == mode && entry.Relaxed == relaxed
+}
+
+func sortScores(scores []Score) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Points != scores[j].Points {
+			return scores[i].Points > scores[j].Points
+		}
+		if scores[i].Moves != scores[j].Moves {
+			return scores[i].Moves < scores[j].Moves
+		}
+		return scores[i].Time < scores[j].Time
+	})
+}
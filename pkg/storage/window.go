@@ -0,0 +1,25 @@
+package storage
+
+// WindowState is the desktop window's placement, shared by all profiles since it
+// belongs to the machine rather than the player
+type WindowState struct {
+	X          int  `json:"x"`
+	Y          int  `json:"y"`
+	Width      int  `json:"width"`
+	Height     int  `json:"height"`
+	Fullscreen bool `json:"fullscreen"`
+}
+
+// SaveWindowState remembers the window placement for the next launch
+func (ss *SaveSystem) SaveWindowState(state *WindowState) error {
+	return ss.set(SaveKeyWindow, state)
+}
+
+// LoadWindowState returns the window placement from the last launch
+func (ss *SaveSystem) LoadWindowState() (*WindowState, error) {
+	var state WindowState
+	if err := ss.storage.Get(SaveKeyWindow, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
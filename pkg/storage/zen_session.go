@@ -0,0 +1,21 @@
+package storage
+
+// SaveZenSession stores the active profile's Zen game. It is kept apart from
+// the saved game so other games never overwrite it.
+func (ss *SaveSystem) SaveZenSession(state *CurrentGameState) error {
+	return ss.set(ss.key(SaveKeyZenSession), state)
+}
+
+// LoadZenSession returns the Zen game to resume, if any
+func (ss *SaveSystem) LoadZenSession() (*CurrentGameState, error) {
+	var state CurrentGameState
+	if err := ss.storage.Get(ss.key(SaveKeyZenSession), &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// DeleteZenSession drops the Zen game so the next one starts from a small board
+func (ss *SaveSystem) DeleteZenSession() {
+	ss.remove(ss.key(SaveKeyZenSession))
+}
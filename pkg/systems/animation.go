@@ -1,17 +1,26 @@
 package systems
 
 import (
+	"math"
 	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
 )
 
 type AnimationType int
 
 const (
-	AnimationBridgeBuild AnimationType = iota
-	AnimationTileHover
+	AnimationTileHover AnimationType = iota
 	AnimationVictory
+	AnimationVictoryPath // Data holds the network as [][]island.Point BFS layers
+	AnimationStarReveal  // X is the star index within the victory stars
+	AnimationTween       // Not drawn; drives a value read through Value()
+	AnimationIslandMerge // X, Y is the joining bridge; Data holds the merged land as []island.Point
 )
 
+// EasingFunc maps linear progress in [0,1] to eased progress
+type EasingFunc func(t float64) float64
+
 type Animation struct {
 	Type       AnimationType
 	X, Y       int
@@ -19,45 +28,177 @@ type Animation struct {
 	Duration   time.Duration
 	Progress   float64
 	Data       interface{}
+	Easing     EasingFunc // Nil means linear
+	
+	onComplete []func()
+	next       []*Animation
+	started    bool
+	done       bool
+}
+
+// NewAnimation creates an animation that is not running yet; start it with
+// AnimationSystem.Play or chain it after another with Then
+func NewAnimation(animType AnimationType, x, y int, duration time.Duration) *Animation {
+	return &Animation{
+		Type:     animType,
+		X:        x,
+		Y:        y,
+		Duration: duration,
+	}
+}
+
+// WithEasing selects the easing applied by Value
+func (a *Animation) WithEasing(easing EasingFunc) *Animation {
+	a.Easing = easing
+	return a
+}
+
+// WithData attaches extra drawing data
+func (a *Animation) WithData(data interface{}) *Animation {
+	a.Data = data
+	return a
+}
+
+// OnComplete registers a callback run once the animation finishes
+func (a *Animation) OnComplete(fn func()) *Animation {
+	a.onComplete = append(a.onComplete, fn)
+	return a
+}
+
+// Then starts next when this animation finishes and returns next, so
+// sequences read a.Then(b).Then(c). Several animations chained on the same
+// one start together.
+func (a *Animation) Then(next *Animation) *Animation {
+	a.next = append(a.next, next)
+	return next
+}
+
+// Value returns the eased progress: 0 before the animation starts and 1 after it ends
+func (a *Animation) Value() float64 {
+	switch {
+	case a.done:
+		return 1
+	case !a.started:
+		return 0
+	case a.Easing != nil:
+		return a.Easing(a.Progress)
+	default:
+		return a.Progress
+	}
+}
+
+// IsRunning reports whether the animation has started and not yet finished
+func (a *Animation) IsRunning() bool {
+	return a.started && !a.done
+}
+
+// IsDone reports whether the animation has finished
+func (a *Animation) IsDone() bool {
+	return a.done
 }
 
 type AnimationSystem struct {
 	animations []*Animation
+	particles  *ParticlePool
+	clock      clock.Clock
+	lastUpdate time.Time
 }
 
-func NewAnimationSystem() *AnimationSystem {
+// NewAnimationSystem creates an animation system timed by clk; pausing or
+// scaling that clock pauses or speeds up every animation and particle
+func NewAnimationSystem(clk clock.Clock) *AnimationSystem {
 	return &AnimationSystem{
 		animations: make([]*Animation, 0),
+		particles:  NewParticlePool(),
+		clock:      clk,
 	}
 }
 
-func (as *AnimationSystem) AddAnimation(animType AnimationType, x, y int, duration time.Duration) {
-	anim := &Animation{
-		Type:      animType,
-		X:         x,
-		Y:         y,
-		StartTime: time.Now(),
-		Duration:  duration,
-		Progress:  0,
-	}
+func (as *AnimationSystem) AddAnimation(animType AnimationType, x, y int, duration time.Duration) *Animation {
+	return as.Play(NewAnimation(animType, x, y, duration))
+}
+
+// AddAnimationWithData adds an animation that needs extra data to draw
+func (as *AnimationSystem) AddAnimationWithData(animType AnimationType, x, y int, duration time.Duration, data interface{}) *Animation {
+	return as.Play(NewAnimation(animType, x, y, duration).WithData(data))
+}
+
+// Play starts an animation now; animations chained on it with Then start as it finishes
+func (as *AnimationSystem) Play(anim *Animation) *Animation {
+	anim.StartTime = as.clock.Now()
+	anim.Progress = 0
+	anim.started = true
+	anim.done = false
 	as.animations = append(as.animations, anim)
+	return anim
 }
 
 func (as *AnimationSystem) Update() {
-	now := time.Now()
+	now := as.clock.Now()
+	
+	// Particles step by real elapsed time, capped so a stalled frame doesn't teleport them
+	if !as.lastUpdate.IsZero() {
+		as.particles.Update(math.Min(now.Sub(as.lastUpdate).Seconds(), 0.1))
+	}
+	as.lastUpdate = now
 	
-	// Update animations and remove completed ones
-	activeAnimations := make([]*Animation, 0)
+	// Update animations and remove completed ones, reusing the slice
+	var finished []*Animation
+	activeAnimations := as.animations[:0]
 	for _, anim := range as.animations {
 		elapsed := now.Sub(anim.StartTime)
-		anim.Progress = float64(elapsed) / float64(anim.Duration)
+		anim.Progress = 1.0
+		if anim.Duration > 0 {
+			anim.Progress = math.Min(float64(elapsed)/float64(anim.Duration), 1.0)
+		}
 		
 		if anim.Progress < 1.0 {
 			activeAnimations = append(activeAnimations, anim)
+		} else {
+			finished = append(finished, anim)
 		}
 	}
 	
+	for i := len(activeAnimations); i < len(as.animations); i++ {
+		as.animations[i] = nil
+	}
 	as.animations = activeAnimations
+	
+	// Callbacks run after the list is settled so they may add animations of their own
+	for _, anim := range finished {
+		anim.done = true
+		for _, fn := range anim.onComplete {
+			fn()
+		}
+		for _, next := range anim.next {
+			as.Play(next)
+		}
+	}
+}
+
+// Clear stops every animation without running completion callbacks
+func (as *AnimationSystem) Clear() {
+	for i := range as.animations {
+		as.animations[i] = nil
+	}
+	as.animations = as.animations[:0]
+}
+
+// SetReducedEffects thins out particle effects for low-end devices
+func (as *AnimationSystem) SetReducedEffects(reduced bool) {
+	if reduced {
+		as.particles.SetDensity(reducedParticleDensity)
+	} else {
+		as.particles.SetDensity(1)
+	}
+}
+
+// reducedParticleDensity is the share of particles kept in reduced-effects mode
+const reducedParticleDensity = 0.25
+
+// Particles returns the pooled particle effects
+func (as *AnimationSystem) Particles() *ParticlePool {
+	return as.particles
 }
 
 func (as *AnimationSystem) GetAnimations() []*Animation {
@@ -65,6 +206,14 @@ func (as *AnimationSystem) GetAnimations() []*Animation {
 }
 
 // Easing functions for smooth animations
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
 func EaseOutCubic(t float64) float64 {
 	t = t - 1
 	return t*t*t + 1
@@ -76,4 +225,12 @@ func EaseInOutCubic(t float64) float64 {
 	}
 	t = 2*t - 2
 	return 1 + t*t*t/2
+}
+
+// EaseOutBack overshoots slightly before settling, for pop-in effects
+func EaseOutBack(t float64) float64 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	t = t - 1
+	return 1 + c3*t*t*t + c1*t*t
 }
\ No newline at end of file
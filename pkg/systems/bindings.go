@@ -0,0 +1,186 @@
+package systems
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Control is a rebindable gameplay action
+type Control string
+
+const (
+	ControlBuild    Control = "build"
+	ControlDemolish Control = "demolish"
+	ControlHint     Control = "hint"
+	ControlPause    Control = "pause"
+	ControlPlan     Control = "plan"
+	ControlZoomIn   Control = "zoom_in"
+	ControlZoomOut  Control = "zoom_out"
+	ControlPanUp    Control = "pan_up"
+	ControlPanDown  Control = "pan_down"
+	ControlPanLeft  Control = "pan_left"
+	ControlPanRight Control = "pan_right"
+)
+
+// Controls lists the rebindable controls in display order
+var Controls = []Control{
+	ControlBuild, ControlDemolish, ControlHint, ControlPause, ControlPlan,
+	ControlZoomIn, ControlZoomOut, ControlPanUp, ControlPanDown, ControlPanLeft, ControlPanRight,
+}
+
+var controlLabels = map[Control]string{
+	ControlBuild:    "Build bridge",
+	ControlDemolish: "Demolish bridge",
+	ControlHint:     "Show hint",
+	ControlPause:    "Pause",
+	ControlPlan:     "Planning mode",
+	ControlZoomIn:   "Zoom in",
+	ControlZoomOut:  "Zoom out",
+	ControlPanUp:    "Pan up",
+	ControlPanDown:  "Pan down",
+	ControlPanLeft:  "Pan left",
+	ControlPanRight: "Pan right",
+}
+
+// Label returns the control's name for the settings screen
+func (c Control) Label() string {
+	return controlLabels[c]
+}
+
+// Mouse inputs use these names; keys use ebiten key names such as "P" or "ArrowUp"
+const (
+	InputMouseLeft   = "MouseLeft"
+	InputMouseRight  = "MouseRight"
+	InputMouseMiddle = "MouseMiddle"
+)
+
+var mouseInputs = map[string]ebiten.MouseButton{
+	InputMouseLeft:   ebiten.MouseButtonLeft,
+	InputMouseRight:  ebiten.MouseButtonRight,
+	InputMouseMiddle: ebiten.MouseButtonMiddle,
+}
+
+// reservedInputs are fixed shortcuts that controls may not be bound to
+var reservedInputs = map[string]string{
+	ebiten.KeyF12.String():       "Screenshot",
+	ebiten.KeyF3.String():        "Board inspector",
+	ebiten.KeyF4.String():        "Profiler",
+	ebiten.KeyF11.String():       "Fullscreen",
+	ebiten.KeyG.String():         "Save solution GIF",
+	ebiten.KeyT.String():         "Save speedrun splits",
+	ebiten.KeyC.String():         "Save or copy share card",
+	ebiten.KeyH.String():         "Help",
+	ebiten.KeyM.String():         "Measure",
+	ebiten.KeyBackquote.String(): "Developer console",
+	ebiten.KeyEscape.String():    "Cancel",
+}
+
+// Bindings maps each control to the input that triggers it
+type Bindings map[Control]string
+
+// DefaultBindings returns the out-of-the-box controls
+func DefaultBindings() Bindings {
+	return Bindings{
+		ControlBuild:    InputMouseLeft,
+		ControlDemolish: InputMouseRight,
+		ControlHint:     ebiten.KeyN.String(),
+		ControlPause:    ebiten.KeyP.String(),
+		ControlPlan:     ebiten.KeyB.String(),
+		ControlZoomIn:   ebiten.KeyEqual.String(),
+		ControlZoomOut:  ebiten.KeyMinus.String(),
+		ControlPanUp:    ebiten.KeyArrowUp.String(),
+		ControlPanDown:  ebiten.KeyArrowDown.String(),
+		ControlPanLeft:  ebiten.KeyArrowLeft.String(),
+		ControlPanRight: ebiten.KeyArrowRight.String(),
+	}
+}
+
+// BindingsFromSettings overlays saved bindings on the defaults, ignoring unknown
+// controls and inputs so an outdated settings file can't leave a control unbound
+func BindingsFromSettings(saved map[string]string) Bindings {
+	bindings := DefaultBindings()
+	for name, input := range saved {
+		control := Control(name)
+		if _, known := controlLabels[control]; !known || !IsValidInput(input) {
+			continue
+		}
+		bindings[control] = input
+	}
+	return bindings
+}
+
+// Settings converts the bindings to the form stored in GameSettings
+func (b Bindings) Settings() map[string]string {
+	saved := make(map[string]string, len(b))
+	for control, input := range b {
+		saved[string(control)] = input
+	}
+	return saved
+}
+
+// Conflict reports what already uses input, other than control itself: another
+// control's label or a fixed shortcut. It returns "" when the input is free.
+func (b Bindings) Conflict(control Control, input string) string {
+	if name, ok := reservedInputs[input]; ok {
+		return name
+	}
+	for _, other := range Controls {
+		if other != control && b[other] == input {
+			return other.Label()
+		}
+	}
+	return ""
+}
+
+// IsValidInput reports whether input names a mouse button or keyboard key
+func IsValidInput(input string) bool {
+	if _, ok := mouseInputs[input]; ok {
+		return true
+	}
+	_, ok := parseKey(input)
+	return ok
+}
+
+func parseKey(name string) (ebiten.Key, bool) {
+	var key ebiten.Key
+	if err := key.UnmarshalText([]byte(name)); err != nil {
+		return 0, false
+	}
+	return key, true
+}
+
+// isInputJustPressed reports whether the named input went down this frame
+func isInputJustPressed(input string) bool {
+	if button, ok := mouseInputs[input]; ok {
+		return inpututil.IsMouseButtonJustPressed(button)
+	}
+	if key, ok := parseKey(input); ok {
+		return inpututil.IsKeyJustPressed(key)
+	}
+	return false
+}
+
+// isInputPressed reports whether the named input is held down
+func isInputPressed(input string) bool {
+	if button, ok := mouseInputs[input]; ok {
+		return ebiten.IsMouseButtonPressed(button)
+	}
+	if key, ok := parseKey(input); ok {
+		return ebiten.IsKeyPressed(key)
+	}
+	return false
+}
+
+// justPressedInput returns the first mouse button or key that went down this frame
+func justPressedInput() (string, bool) {
+	for _, name := range []string{InputMouseLeft, InputMouseRight, InputMouseMiddle} {
+		if inpututil.IsMouseButtonJustPressed(mouseInputs[name]) {
+			return name, true
+		}
+	}
+	keys := inpututil.AppendJustPressedKeys(nil)
+	if len(keys) > 0 {
+		return keys[0].String(), true
+	}
+	return "", false
+}
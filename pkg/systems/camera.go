@@ -0,0 +1,131 @@
+package systems
+
+import (
+	"math"
+)
+
+// Camera maps a board onto an area of the screen. The board's tiles are sized
+// to fit the area, within limits, then scaled by the zoom and shifted by the
+// pan. The game board and the level editor each have one.
+type Camera struct {
+	areaX, areaY          int // Screen position of the area's top-left corner
+	areaWidth, areaHeight int
+	minTile, maxTile      int // Limits of the fitted tile size, before zooming
+
+	viewportX, viewportY float64 // Pan, in pixels from the area's corner
+	zoom                 float64
+	tileSize             int
+}
+
+// NewCamera creates a camera for a screen area, fitting tiles between minTile
+// and maxTile pixels before zooming
+func NewCamera(x, y, width, height, minTile, maxTile int) *Camera {
+	return &Camera{
+		areaX: x, areaY: y,
+		areaWidth: width, areaHeight: height,
+		minTile: minTile, maxTile: maxTile,
+		zoom:     1.0,
+		tileSize: maxTile,
+	}
+}
+
+// fittedTileSize is the tile size that fits a board of the given size in the area
+func (c *Camera) fittedTileSize(boardWidth, boardHeight int) int {
+	optimalSize := min(c.areaWidth/boardWidth, c.areaHeight/boardHeight)
+	return max(c.minTile, min(optimalSize, c.maxTile))
+}
+
+// Fit sizes tiles for a board of the given size at the current zoom and
+// reports whether the tile size changed
+func (c *Camera) Fit(boardWidth, boardHeight int) bool {
+	newSize := max(1, int(float64(c.fittedTileSize(boardWidth, boardHeight))*c.zoom))
+	if newSize == c.tileSize {
+		return false
+	}
+	c.tileSize = newSize
+	return true
+}
+
+// TileSize returns the on-screen size of a board tile
+func (c *Camera) TileSize() int {
+	return c.tileSize
+}
+
+// Origin returns the screen position of the board's top-left corner after panning
+func (c *Camera) Origin() (int, int) {
+	return c.areaX + int(c.viewportX), c.areaY + int(c.viewportY)
+}
+
+// Area returns the screen area the board is shown in
+func (c *Camera) Area() (x, y, width, height int) {
+	return c.areaX, c.areaY, c.areaWidth, c.areaHeight
+}
+
+// Zoom scales the board by factor, within MinZoom and MaxZoom
+func (c *Camera) Zoom(factor float64) {
+	c.zoom = math.Max(MinZoom, math.Min(MaxZoom, c.zoom*factor))
+}
+
+// Pan moves the board on screen by the given number of pixels
+func (c *Camera) Pan(dx, dy float64) {
+	c.viewportX += dx
+	c.viewportY += dy
+}
+
+// FrameBoard zooms out, no further than MinZoom, until a board of the given
+// size fits the area, and centres it there
+func (c *Camera) FrameBoard(boardWidth, boardHeight int) {
+	fitted := float64(c.fittedTileSize(boardWidth, boardHeight))
+	fit := math.Min(float64(c.areaWidth)/(fitted*float64(boardWidth)), float64(c.areaHeight)/(fitted*float64(boardHeight)))
+	c.zoom = math.Max(MinZoom, math.Min(1.0, fit))
+	size := math.Max(1, math.Floor(fitted*c.zoom))
+	c.viewportX = (float64(c.areaWidth) - size*float64(boardWidth)) / 2
+	c.viewportY = (float64(c.areaHeight) - size*float64(boardHeight)) / 2
+}
+
+// CenterOn pans the board so the given board position, in tiles, is at the
+// centre of the area
+func (c *Camera) CenterOn(gridX, gridY float64) {
+	c.viewportX, c.viewportY = c.centeredViewport(gridX, gridY)
+}
+
+// centeredViewport is the pan that puts a board position at the area's centre
+func (c *Camera) centeredViewport(gridX, gridY float64) (float64, float64) {
+	size := float64(c.tileSize)
+	return float64(c.areaWidth)/2 - gridX*size, float64(c.areaHeight)/2 - gridY*size
+}
+
+// VisibleTiles returns the part of the board, in tiles, that a screen of the
+// given size shows. It may reach past the board's edges.
+func (c *Camera) VisibleTiles(screenWidth, screenHeight int) (minX, minY, maxX, maxY float64) {
+	size := float64(c.tileSize)
+	originX, originY := c.Origin()
+	minX = float64(-originX) / size
+	minY = float64(-originY) / size
+	return minX, minY, minX + float64(screenWidth)/size, minY + float64(screenHeight)/size
+}
+
+// Reset restores the default zoom and position
+func (c *Camera) Reset() {
+	c.zoom = 1.0
+	c.viewportX, c.viewportY = 0, 0
+}
+
+// TileCenter returns the screen position of a tile's centre
+func (c *Camera) TileCenter(gridX, gridY int) (float64, float64) {
+	originX, originY := c.Origin()
+	half := float64(c.tileSize) / 2
+	return float64(originX+gridX*c.tileSize) + half, float64(originY+gridY*c.tileSize) + half
+}
+
+// ScreenToGrid converts a screen position to board coordinates, or -1, -1
+// left of or above the board
+func (c *Camera) ScreenToGrid(screenX, screenY int) (int, int) {
+	originX, originY := c.Origin()
+	gridX := screenX - originX
+	gridY := screenY - originY
+	if gridX < 0 || gridY < 0 {
+		return -1, -1
+	}
+	return gridX / c.tileSize, gridY / c.tileSize
+}
@@ -0,0 +1,48 @@
+package systems
+
+import (
+	"math"
+	"time"
+)
+
+// focusDuration is how long an animated focus takes to reach its target
+const focusDuration = 450 * time.Millisecond
+
+// cameraFocus glides the viewport from where it was to a target pan
+type cameraFocus struct {
+	fromX, fromY float64
+	toX, toY     float64
+	start        time.Time
+}
+
+// FocusOn glides the camera until the given board position, in tiles, is at
+// the centre of the grid area. With reduced effects it jumps there at once.
+// Panning or zooming by hand stops the glide.
+func (rs *RenderSystem) FocusOn(gridX, gridY float64) {
+	toX, toY := rs.camera.centeredViewport(gridX, gridY)
+	if rs.reducedEffects {
+		rs.focus = nil
+		rs.camera.viewportX, rs.camera.viewportY = toX, toY
+		return
+	}
+	rs.focus = &cameraFocus{
+		fromX: rs.camera.viewportX, fromY: rs.camera.viewportY,
+		toX: toX, toY: toY,
+		start: rs.clock.Now(),
+	}
+}
+
+// stepFocus moves the viewport along the glide, easing out towards the target
+func (rs *RenderSystem) stepFocus() {
+	f := rs.focus
+	if f == nil {
+		return
+	}
+	t := math.Min(1, float64(rs.clock.Now().Sub(f.start))/float64(focusDuration))
+	eased := 1 - math.Pow(1-t, 3)
+	rs.camera.viewportX = f.fromX + (f.toX-f.fromX)*eased
+	rs.camera.viewportY = f.fromY + (f.toY-f.fromY)*eased
+	if t >= 1 {
+		rs.focus = nil
+	}
+}
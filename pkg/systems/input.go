@@ -1,6 +1,8 @@
 package systems
 
 import (
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
@@ -16,27 +18,246 @@ type Action struct {
 	X, Y int
 }
 
+// PointerState is the mouse state sampled once per frame, whether or not anything was clicked
+type PointerState struct {
+	X, Y             int
+	WheelX, WheelY   float64
+	LeftDown         bool
+	LeftJustPressed  bool
+	LeftJustReleased bool
+	RightJustPressed bool
+	DoubleClick      bool // The left button or a finger clicked twice in the same spot
+	Touch            bool // From a finger, which has no hover between touches
+}
+
+// A second click this soon and this close to the first makes a double click
+const (
+	doubleClickTime = 300 * time.Millisecond
+	doubleClickSlop = 6
+)
+
+// TextInput is the typing sampled once per frame, for text boxes such as profile names
+type TextInput struct {
+	Chars     []rune
+	Backspace bool
+	Enter     bool
+	Up, Down  bool // Arrow keys, for recalling earlier lines or moving through lists
+	Left, Right bool
+}
+
 type InputSystem struct {
-	MouseX, MouseY int
+	pointer           PointerState
+	text              TextInput
+	helpPressed       bool
+	consolePressed    bool
+	inspectorPressed  bool
+	profilerPressed   bool
+	fullscreenPressed bool
+	screenshotPressed bool
+	replayPressed     bool
+	splitsPressed     bool
+	cardPressed       bool
+	cardCopyPressed   bool
+	measureHeld       bool
+	bindings          Bindings
+	touch             touchState
+	touchMode         bool // A finger was used last; the mouse takes over once it moves
+	tapped            bool
+	longPressed       bool
+	cursorX, cursorY  int // Mouse position last frame, to notice it moving after touch input
+	lastClickAt       time.Time
+	lastClickX        int
+	lastClickY        int
+	active            bool // Any input this frame
 }
 
 func NewInputSystem() *InputSystem {
-	return &InputSystem{}
+	return &InputSystem{bindings: DefaultBindings()}
+}
+
+// SetBindings replaces the control bindings
+func (is *InputSystem) SetBindings(bindings Bindings) {
+	is.bindings = bindings
+}
+
+// IsControlJustPressed reports whether the control's input went down this frame
+func (is *InputSystem) IsControlJustPressed(control Control) bool {
+	return isInputJustPressed(is.bindings[control])
+}
+
+// IsControlPressed reports whether the control's input is held down
+func (is *InputSystem) IsControlPressed(control Control) bool {
+	return isInputPressed(is.bindings[control])
+}
+
+// CaptureInput returns the key or mouse button pressed this frame, for rebinding controls
+func (is *InputSystem) CaptureInput() (string, bool) {
+	return justPressedInput()
 }
 
+// Update samples the pointer and keyboard for this frame and returns a click action, if any
 func (is *InputSystem) Update() *Action {
+	action := is.sample()
+	is.detectDoubleClick()
+	return action
+}
+
+// detectDoubleClick marks a click that closely follows another in the same
+// spot; a third click starts over rather than making a second double click
+func (is *InputSystem) detectDoubleClick() {
+	if !is.pointer.LeftJustPressed {
+		return
+	}
+	dx, dy := is.pointer.X-is.lastClickX, is.pointer.Y-is.lastClickY
+	if time.Since(is.lastClickAt) <= doubleClickTime && dx*dx+dy*dy <= doubleClickSlop*doubleClickSlop {
+		is.pointer.DoubleClick = true
+		is.lastClickAt = time.Time{}
+		return
+	}
+	is.lastClickAt = time.Now()
+	is.lastClickX, is.lastClickY = is.pointer.X, is.pointer.Y
+}
+
+func (is *InputSystem) sample() *Action {
+	// Any key, finger, button, wheel turn or mouse movement counts as activity
+	cursorX, cursorY := ebiten.CursorPosition()
+	wheelX, wheelY := ebiten.Wheel()
+	is.active = len(inpututil.AppendJustPressedKeys(nil)) > 0 || len(ebiten.AppendTouchIDs(nil)) > 0 ||
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) || ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) ||
+		wheelX != 0 || wheelY != 0 || cursorX != is.cursorX || cursorY != is.cursorY
+	
+	// Handle keyboard shortcuts
+	is.screenshotPressed = inpututil.IsKeyJustPressed(ebiten.KeyF12)
+	is.replayPressed = inpututil.IsKeyJustPressed(ebiten.KeyG)
+	is.splitsPressed = inpututil.IsKeyJustPressed(ebiten.KeyT)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	is.cardPressed = inpututil.IsKeyJustPressed(ebiten.KeyC) && !shift
+	is.cardCopyPressed = inpututil.IsKeyJustPressed(ebiten.KeyC) && shift
+	is.helpPressed = inpututil.IsKeyJustPressed(ebiten.KeyH)
+	is.consolePressed = inpututil.IsKeyJustPressed(ebiten.KeyBackquote)
+	is.inspectorPressed = inpututil.IsKeyJustPressed(ebiten.KeyF3)
+	is.profilerPressed = inpututil.IsKeyJustPressed(ebiten.KeyF4)
+	is.fullscreenPressed = inpututil.IsKeyJustPressed(ebiten.KeyF11)
+	is.measureHeld = ebiten.IsKeyPressed(ebiten.KeyM)
+	is.text = TextInput{
+		Chars:     ebiten.AppendInputChars(nil),
+		Backspace: inpututil.IsKeyJustPressed(ebiten.KeyBackspace),
+		Enter:     inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter),
+		Up:        inpututil.IsKeyJustPressed(ebiten.KeyArrowUp),
+		Down:      inpututil.IsKeyJustPressed(ebiten.KeyArrowDown),
+		Left:      inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft),
+		Right:     inpututil.IsKeyJustPressed(ebiten.KeyArrowRight),
+	}
+	for _, char := range is.text.Chars {
+		if char == '?' {
+			is.helpPressed = true
+		}
+	}
+
+	if action, ok := is.sampleTouch(); ok {
+		is.touchMode = true
+		return action
+	}
+	
+	// Sample pointer state every frame so hover tracks the mouse continuously
+	x, y := ebiten.CursorPosition()
+	moved := x != is.cursorX || y != is.cursorY
+	is.cursorX, is.cursorY = x, y
+	if is.touchMode && !moved && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		// Between touches nothing is hovered
+		is.pointer = PointerState{X: -1, Y: -1, Touch: true}
+		return nil
+	}
+	is.touchMode = false
+	is.pointer = PointerState{
+		X:                x,
+		Y:                y,
+		WheelX:           wheelX,
+		WheelY:           wheelY,
+		LeftDown:         ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft),
+		LeftJustPressed:  inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+		LeftJustReleased: inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft),
+		RightJustPressed: inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight),
+	}
+
 	// Handle mouse clicks
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
+	if is.pointer.LeftJustPressed {
 		return &Action{
 			Type: ActionClick,
 			X:    x,
 			Y:    y,
 		}
 	}
-	
-	// Update mouse position for potential hover effects
-	is.MouseX, is.MouseY = ebiten.CursorPosition()
-	
+
 	return nil
-}
\ No newline at end of file
+}
+
+// Active reports whether the player did anything during the last Update
+func (is *InputSystem) Active() bool {
+	return is.active
+}
+
+// Pointer returns the pointer state sampled by the last Update
+func (is *InputSystem) Pointer() PointerState {
+	return is.pointer
+}
+
+// Text returns the typing sampled by the last Update
+func (is *InputSystem) Text() TextInput {
+	return is.text
+}
+
+// IsHelpPressed reports whether H or ? was pressed this frame
+func (is *InputSystem) IsHelpPressed() bool {
+	return is.helpPressed
+}
+
+// IsConsolePressed reports whether the backquote key was pressed this frame
+func (is *InputSystem) IsConsolePressed() bool {
+	return is.consolePressed
+}
+
+// IsInspectorPressed reports whether F3 was pressed this frame
+func (is *InputSystem) IsInspectorPressed() bool {
+	return is.inspectorPressed
+}
+
+// IsProfilerPressed reports whether F4 was pressed this frame
+func (is *InputSystem) IsProfilerPressed() bool {
+	return is.profilerPressed
+}
+
+// IsFullscreenPressed reports whether F11 was pressed this frame
+func (is *InputSystem) IsFullscreenPressed() bool {
+	return is.fullscreenPressed
+}
+
+// IsScreenshotPressed reports whether F12 was pressed this frame
+func (is *InputSystem) IsScreenshotPressed() bool {
+	return is.screenshotPressed
+}
+
+// IsReplayCapturePressed reports whether G was pressed this frame
+func (is *InputSystem) IsReplayCapturePressed() bool {
+	return is.replayPressed
+}
+
+// IsSplitsExportPressed reports whether T was pressed this frame
+func (is *InputSystem) IsSplitsExportPressed() bool {
+	return is.splitsPressed
+}
+
+// IsShareCardPressed reports whether C was pressed this frame
+func (is *InputSystem) IsShareCardPressed() bool {
+	return is.cardPressed
+}
+
+// IsShareCardCopyPressed reports whether Shift+C was pressed this frame
+func (is *InputSystem) IsShareCardCopyPressed() bool {
+	return is.cardCopyPressed
+}
+
+// IsMeasureHeld reports whether M is held down for the measuring tool
+func (is *InputSystem) IsMeasureHeld() bool {
+	return is.measureHeld
+}
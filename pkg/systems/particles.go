@@ -0,0 +1,183 @@
+package systems
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// maxParticles caps the pool; new particles reuse the oldest slot once it is full
+const maxParticles = 512
+
+// Particle is a single pooled effect particle in screen coordinates
+type Particle struct {
+	Active   bool
+	X, Y     float64
+	VX, VY   float64 // Pixels per second
+	Gravity  float64 // Pixels per second squared
+	Life     float64 // Seconds remaining
+	MaxLife  float64
+	Size     float32
+	Color    color.RGBA
+	Square   bool // Drawn as a rectangle instead of a circle
+	Rotation float64
+	Spin     float64
+}
+
+// Alpha returns the particle's fade factor from 1 (new) to 0 (expired)
+func (p *Particle) Alpha() float64 {
+	if p.MaxLife <= 0 {
+		return 0
+	}
+	return p.Life / p.MaxLife
+}
+
+// ParticlePool owns a fixed array of particles so effects never allocate per frame
+type ParticlePool struct {
+	particles [maxParticles]Particle
+	next      int
+	density   float64 // Fraction of requested particles actually emitted
+	rng       *rand.Rand
+}
+
+func NewParticlePool() *ParticlePool {
+	return &ParticlePool{
+		density: 1,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Seed makes the effects that follow repeatable, for debugging
+func (pp *ParticlePool) Seed(seed int64) {
+	pp.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetDensity scales how many particles each effect emits, e.g. 0.25 for reduced effects
+func (pp *ParticlePool) SetDensity(density float64) {
+	pp.density = math.Max(0, math.Min(1, density))
+}
+
+// scaled applies the density to a requested count, keeping at least one particle
+func (pp *ParticlePool) scaled(count int) int {
+	n := int(float64(count) * pp.density)
+	if n < 1 && pp.density > 0 {
+		n = 1
+	}
+	return n
+}
+
+// spawn returns a free slot, or the oldest one when every slot is in use
+func (pp *ParticlePool) spawn() *Particle {
+	for i := 0; i < maxParticles; i++ {
+		idx := (pp.next + i) % maxParticles
+		if !pp.particles[idx].Active {
+			pp.next = (idx + 1) % maxParticles
+			return &pp.particles[idx]
+		}
+	}
+	p := &pp.particles[pp.next]
+	pp.next = (pp.next + 1) % maxParticles
+	return p
+}
+
+// Update moves the particles and retires expired ones
+func (pp *ParticlePool) Update(dt float64) {
+	for i := range pp.particles {
+		p := &pp.particles[i]
+		if !p.Active {
+			continue
+		}
+		p.Life -= dt
+		if p.Life <= 0 {
+			p.Active = false
+			continue
+		}
+		p.VY += p.Gravity * dt
+		p.X += p.VX * dt
+		p.Y += p.VY * dt
+		p.Rotation += p.Spin * dt
+	}
+}
+
+// Particles exposes the pool for drawing; inactive entries must be skipped
+func (pp *ParticlePool) Particles() []Particle {
+	return pp.particles[:]
+}
+
+// EmitSplash throws water droplets up and out from a point, as when a bridge lands on the sea
+func (pp *ParticlePool) EmitSplash(x, y float64, count int) {
+	for i := 0; i < pp.scaled(count); i++ {
+		angle := -math.Pi/2 + (pp.rng.Float64()-0.5)*math.Pi*0.9
+		speed := 60 + pp.rng.Float64()*90
+		life := 0.5 + pp.rng.Float64()*0.3
+
+		p := pp.spawn()
+		*p = Particle{
+			Active:  true,
+			X:       x + (pp.rng.Float64()-0.5)*10,
+			Y:       y,
+			VX:      math.Cos(angle) * speed,
+			VY:      math.Sin(angle) * speed,
+			Gravity: 320,
+			Life:    life,
+			MaxLife: life,
+			Size:    float32(2 + pp.rng.Float64()*2),
+			Color:   color.RGBA{180, 225, 255, 255},
+		}
+	}
+}
+
+// EmitConfetti drops colourful confetti across the given width from above y
+func (pp *ParticlePool) EmitConfetti(x, y, width float64, count int) {
+	colors := []color.RGBA{
+		{255, 87, 34, 255},
+		{255, 215, 0, 255},
+		{76, 175, 80, 255},
+		{33, 150, 243, 255},
+		{156, 39, 176, 255},
+	}
+	for i := 0; i < pp.scaled(count); i++ {
+		life := 2 + pp.rng.Float64()*1.5
+
+		p := pp.spawn()
+		*p = Particle{
+			Active:   true,
+			X:        x + pp.rng.Float64()*width,
+			Y:        y - pp.rng.Float64()*60,
+			VX:       (pp.rng.Float64() - 0.5) * 80,
+			VY:       40 + pp.rng.Float64()*80,
+			Gravity:  60,
+			Life:     life,
+			MaxLife:  life,
+			Size:     float32(3 + pp.rng.Float64()*3),
+			Color:    colors[pp.rng.Intn(len(colors))],
+			Square:   true,
+			Rotation: pp.rng.Float64() * math.Pi,
+			Spin:     (pp.rng.Float64() - 0.5) * 10,
+		}
+	}
+}
+
+// EmitDust puffs slow brown dust around a point, as when a bridge is demolished
+func (pp *ParticlePool) EmitDust(x, y float64, count int) {
+	for i := 0; i < pp.scaled(count); i++ {
+		angle := pp.rng.Float64() * 2 * math.Pi
+		speed := 10 + pp.rng.Float64()*30
+		life := 0.6 + pp.rng.Float64()*0.6
+
+		p := pp.spawn()
+		*p = Particle{
+			Active:  true,
+			X:       x,
+			Y:       y,
+			VX:      math.Cos(angle) * speed,
+			VY:      math.Sin(angle)*speed - 15,
+			Gravity: -10,
+			Life:    life,
+			MaxLife: life,
+			Size:    float32(3 + pp.rng.Float64()*4),
+			Color:   color.RGBA{141, 110, 99, 200},
+		}
+	}
+}
@@ -2,6 +2,7 @@ package systems
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 
 	"math"
@@ -10,6 +11,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/assets"
+	"github.com/ponyo877/island-merge/pkg/clock"
 	"github.com/ponyo877/island-merge/pkg/island"
 )
 
@@ -20,22 +23,36 @@ const (
 	GridOffsetY = 120
 	MaxGridWidth = 400  // Maximum grid display width
 	MaxGridHeight = 300 // Maximum grid display height
+	
+	// Camera limits; zoom scales the fitted tile size
+	MinZoom = 0.5
+	MaxZoom = 2.0
 )
 
 type RenderSystem struct {
 	// Cache for tile images
 	tileImages map[island.TileType]*ebiten.Image
-	currentTileSize int
-	viewportX, viewportY float64
-	zoom float64
+	camera *Camera
+	clock clock.Clock
+	ambient bool // Animate idle scenery such as the sea shimmer
+	reducedEffects bool // Skip flashes and secondary effect layers
+	theme assets.Theme // Board colours
+	focus *cameraFocus // Animated pan in progress; nil while the camera is still
 }
 
-func NewRenderSystem() *RenderSystem {
+// NewRenderSystem creates a render system whose ambient animations follow clk
+func NewRenderSystem(clk clock.Clock) *RenderSystem {
 	rs := &RenderSystem{
 		tileImages:      make(map[island.TileType]*ebiten.Image),
-		currentTileSize: MaxTileSize,
-		zoom:           1.0,
+		camera:          NewCamera(GridOffsetX, GridOffsetY, MaxGridWidth, MaxGridHeight, MinTileSize, MaxTileSize),
+		clock:          clk,
+		ambient:        true,
+	}
+	theme, err := assets.LoadTheme()
+	if err != nil {
+		fmt.Println("Using the default theme:", err)
 	}
+	rs.theme = theme
 	rs.initTileImages()
 	return rs
 }
@@ -51,9 +68,9 @@ func (rs *RenderSystem) createTileImages(size int) {
 	
 	// Create simple colored tiles
 	colors := map[island.TileType]color.Color{
-		island.TileSea:    color.RGBA{64, 164, 223, 255},   // Blue
-		island.TileLand:   color.RGBA{139, 195, 74, 255},   // Green
-		island.TileBridge: color.RGBA{121, 85, 72, 255},    // Brown
+		island.TileSea:    rs.theme.Sea,
+		island.TileLand:   rs.theme.Land,
+		island.TileBridge: rs.theme.Bridge,
 	}
 	
 	for tileType, col := range colors {
@@ -63,30 +80,77 @@ func (rs *RenderSystem) createTileImages(size int) {
 	}
 }
 
-func (rs *RenderSystem) calculateTileSize(boardWidth, boardHeight int) int {
-	// Calculate optimal tile size to fit the board in the available space
-	maxWidthTileSize := MaxGridWidth / boardWidth
-	maxHeightTileSize := MaxGridHeight / boardHeight
-	
-	optimalSize := min(maxWidthTileSize, maxHeightTileSize)
-	
-	// Clamp to min/max tile sizes
-	if optimalSize < MinTileSize {
-		return MinTileSize
-	}
-	if optimalSize > MaxTileSize {
-		return MaxTileSize
+func (rs *RenderSystem) updateTileSize(boardWidth, boardHeight int) {
+	if rs.camera.Fit(boardWidth, boardHeight) {
+		rs.createTileImages(rs.camera.tileSize)
 	}
-	
-	return optimalSize
 }
 
-func (rs *RenderSystem) updateTileSize(boardWidth, boardHeight int) {
-	newSize := rs.calculateTileSize(boardWidth, boardHeight)
-	if newSize != rs.currentTileSize {
-		rs.currentTileSize = newSize
-		rs.createTileImages(newSize)
-	}
+// Theme returns the colours the board is drawn in
+func (rs *RenderSystem) Theme() assets.Theme {
+	return rs.theme
+}
+
+// SetTheme recolours the board, e.g. when theme.json is edited in dev mode
+func (rs *RenderSystem) SetTheme(theme assets.Theme) {
+	rs.theme = theme
+	rs.createTileImages(rs.camera.tileSize)
+}
+
+// SetEffects selects ambient animations and reduced effects
+func (rs *RenderSystem) SetEffects(ambient, reduced bool) {
+	rs.ambient = ambient
+	rs.reducedEffects = reduced
+}
+
+// originX is the screen position of the board's left edge after panning
+func (rs *RenderSystem) originX() int {
+	x, _ := rs.camera.Origin()
+	return x
+}
+
+// originY is the screen position of the board's top edge after panning
+func (rs *RenderSystem) originY() int {
+	_, y := rs.camera.Origin()
+	return y
+}
+
+// Zoom scales the board by factor, within MinZoom and MaxZoom
+func (rs *RenderSystem) Zoom(factor float64) {
+	rs.focus = nil
+	rs.camera.Zoom(factor)
+}
+
+// Pan moves the board on screen by the given number of pixels
+func (rs *RenderSystem) Pan(dx, dy float64) {
+	rs.focus = nil
+	rs.camera.Pan(dx, dy)
+}
+
+// FrameBoard zooms out, no further than MinZoom, until a board of the given
+// size fits the grid area, and centres it there
+func (rs *RenderSystem) FrameBoard(boardWidth, boardHeight int) {
+	rs.focus = nil
+	rs.camera.FrameBoard(boardWidth, boardHeight)
+}
+
+// CenterOn pans the board so the given board position, in tiles, is at the
+// centre of the grid area
+func (rs *RenderSystem) CenterOn(gridX, gridY float64) {
+	rs.focus = nil
+	rs.camera.CenterOn(gridX, gridY)
+}
+
+// VisibleTiles returns the part of the board, in tiles, that a screen of the
+// given size shows. It may reach past the board's edges.
+func (rs *RenderSystem) VisibleTiles(screenWidth, screenHeight int) (minX, minY, maxX, maxY float64) {
+	return rs.camera.VisibleTiles(screenWidth, screenHeight)
+}
+
+// ResetView restores the default zoom and position
+func (rs *RenderSystem) ResetView() {
+	rs.focus = nil
+	rs.camera.Reset()
 }
 
 func min(a, b int) int {
@@ -96,21 +160,19 @@ func min(a, b int) int {
 	return b
 }
 
-func (rs *RenderSystem) Draw(screen *ebiten.Image, board *island.Board, moves int, gameWon bool) {
+func (rs *RenderSystem) Draw(screen *ebiten.Image, board *island.Board, gameWon bool) {
 	// Clear screen
-	screen.Fill(color.RGBA{240, 240, 240, 255})
+	screen.Fill(rs.theme.Background)
 	
 	// Update tile size based on board dimensions
 	if board != nil {
 		rs.updateTileSize(board.Width, board.Height)
 	}
+	rs.stepFocus()
 	
 	// Draw board
 	rs.drawBoard(screen, board)
 	
-	// Draw UI
-	rs.drawUI(screen, moves)
-	
 	// Draw victory message if won
 	if gameWon {
 		rs.drawVictory(screen)
@@ -123,16 +185,23 @@ func (rs *RenderSystem) DrawHover(screen *ebiten.Image, board *island.Board, mou
 	}
 	
 	// Convert mouse to grid coordinates
-	gridX := (mouseX - GridOffsetX) / rs.currentTileSize
-	gridY := (mouseY - GridOffsetY) / rs.currentTileSize
+	gridX, gridY := rs.ScreenToGrid(mouseX, mouseY)
+	rs.DrawHoverTile(screen, board, gridX, gridY)
+}
+
+// DrawHoverTile highlights a tile as hovered when a bridge can be built on it
+func (rs *RenderSystem) DrawHoverTile(screen *ebiten.Image, board *island.Board, gridX, gridY int) {
+	if board == nil {
+		return
+	}
 	
 	// Check if hover is valid
 	if board.CanBuildBridge(gridX, gridY) {
-		x := GridOffsetX + gridX*rs.currentTileSize
-		y := GridOffsetY + gridY*rs.currentTileSize
+		x := rs.originX() + gridX*rs.camera.tileSize
+		y := rs.originY() + gridY*rs.camera.tileSize
 		
 		// Draw hover highlight
-		highlight := ebiten.NewImage(rs.currentTileSize, rs.currentTileSize)
+		highlight := ebiten.NewImage(rs.camera.tileSize, rs.camera.tileSize)
 		highlight.Fill(color.RGBA{255, 255, 255, 64})
 		
 		opt := &ebiten.DrawImageOptions{}
@@ -143,7 +212,7 @@ func (rs *RenderSystem) DrawHover(screen *ebiten.Image, board *island.Board, mou
 		vector.StrokeRect(
 			screen,
 			float32(x), float32(y),
-			float32(rs.currentTileSize), float32(rs.currentTileSize),
+			float32(rs.camera.tileSize), float32(rs.camera.tileSize),
 			2,
 			color.RGBA{255, 255, 255, 128},
 			false,
@@ -151,6 +220,60 @@ func (rs *RenderSystem) DrawHover(screen *ebiten.Image, board *island.Board, mou
 	}
 }
 
+// BoardBounds returns the screen rectangle the board occupies
+func (rs *RenderSystem) BoardBounds(board *island.Board) image.Rectangle {
+	if board == nil {
+		return image.Rectangle{}
+	}
+	return image.Rect(
+		rs.originX(), rs.originY(),
+		rs.originX()+board.Width*rs.camera.tileSize, rs.originY()+board.Height*rs.camera.tileSize,
+	)
+}
+
+// TileSize returns the on-screen size of a board tile
+func (rs *RenderSystem) TileSize() int {
+	return rs.camera.tileSize
+}
+
+// TileCenter returns the screen position of a tile's centre
+func (rs *RenderSystem) TileCenter(gridX, gridY int) (float64, float64) {
+	return rs.camera.TileCenter(gridX, gridY)
+}
+
+// ScreenToGrid converts a screen position to board coordinates using the current tile size
+func (rs *RenderSystem) ScreenToGrid(screenX, screenY int) (int, int) {
+	return rs.camera.ScreenToGrid(screenX, screenY)
+}
+
+// DrawTileHighlight outlines a single board tile, e.g. to guide the player
+func (rs *RenderSystem) DrawTileHighlight(screen *ebiten.Image, gridX, gridY int, col color.RGBA) {
+	x := float32(rs.originX() + gridX*rs.camera.tileSize)
+	y := float32(rs.originY() + gridY*rs.camera.tileSize)
+	size := float32(rs.camera.tileSize)
+	
+	fill := col
+	fill.A = col.A / 3
+	vector.DrawFilledRect(screen, x, y, size, size, fill, false)
+	vector.StrokeRect(screen, x, y, size, size, 3, col, false)
+}
+
+// DrawPlannedBridge draws a translucent bridge on a tile planned but not yet built
+func (rs *RenderSystem) DrawPlannedBridge(screen *ebiten.Image, gridX, gridY int) {
+	img, ok := rs.tileImages[island.TileBridge]
+	if !ok {
+		return
+	}
+	x := rs.originX() + gridX*rs.camera.tileSize
+	y := rs.originY() + gridY*rs.camera.tileSize
+
+	opt := &ebiten.DrawImageOptions{}
+	opt.GeoM.Translate(float64(x), float64(y))
+	opt.ColorScale.ScaleAlpha(0.45)
+	screen.DrawImage(img, opt)
+	vector.StrokeRect(screen, float32(x)+1, float32(y)+1, float32(rs.camera.tileSize)-2, float32(rs.camera.tileSize)-2, 1, color.RGBA{255, 255, 255, 160}, false)
+}
+
 func (rs *RenderSystem) drawBoard(screen *ebiten.Image, board *island.Board) {
 	if board == nil {
 		return
@@ -165,29 +288,69 @@ func (rs *RenderSystem) drawBoard(screen *ebiten.Image, board *island.Board) {
 			
 			// Draw tile
 			opt := &ebiten.DrawImageOptions{}
-			opt.GeoM.Translate(float64(GridOffsetX+x*rs.currentTileSize), float64(GridOffsetY+y*rs.currentTileSize))
+			opt.GeoM.Translate(float64(rs.originX()+x*rs.camera.tileSize), float64(rs.originY()+y*rs.camera.tileSize))
 			
 			if img, ok := rs.tileImages[tile.Type]; ok {
 				screen.DrawImage(img, opt)
 			}
 			
+			if tile.Type == island.TileSea && rs.ambient {
+				rs.drawSeaShimmer(screen, x, y)
+			}
+			if health := board.BridgeHealth(x, y); tile.Type == island.TileBridge && health < island.MaxBridgeHealth {
+				rs.drawBridgeDamage(screen, x, y, island.MaxBridgeHealth-health)
+			}
+			
 			// Draw grid lines
 			rs.drawGridLines(screen, x, y)
 		}
 	}
 }
 
+// bridgeCracks are crack lines across a tile in fractions of its size; a bridge
+// shows one more for each point of health it has lost
+var bridgeCracks = [][4]float32{
+	{0.2, 0.25, 0.55, 0.6},
+	{0.75, 0.15, 0.45, 0.8},
+	{0.15, 0.8, 0.85, 0.55},
+}
+
+// drawBridgeDamage darkens a damaged bridge and cracks it
+func (rs *RenderSystem) drawBridgeDamage(screen *ebiten.Image, x, y, lost int) {
+	size := float32(rs.camera.tileSize)
+	left := float32(rs.originX() + x*rs.camera.tileSize)
+	top := float32(rs.originY() + y*rs.camera.tileSize)
+	vector.DrawFilledRect(screen, left, top, size, size, color.RGBA{0, 0, 0, uint8(min(lost, 3) * 40)}, false)
+	
+	width := float32(math.Max(1, float64(size)/16))
+	for _, crack := range bridgeCracks[:min(lost, len(bridgeCracks))] {
+		vector.StrokeLine(screen, left+crack[0]*size, top+crack[1]*size, left+crack[2]*size, top+crack[3]*size, width, color.RGBA{40, 25, 20, 255}, false)
+	}
+}
+
+// drawSeaShimmer draws a faint light band drifting across a sea tile
+func (rs *RenderSystem) drawSeaShimmer(screen *ebiten.Image, x, y int) {
+	t := float64(rs.clock.Now().UnixNano()) / float64(time.Second)
+	phase := t*1.5 + float64(x)*0.8 + float64(y)*0.6
+	alpha := uint8(18 + 18*math.Sin(phase))
+	
+	size := float32(rs.camera.tileSize)
+	bandY := float32(rs.originY()+y*rs.camera.tileSize) + size*float32(0.5+0.25*math.Sin(phase*0.5))
+	bandHeight := float32(math.Max(1, float64(size)/12))
+	vector.DrawFilledRect(screen, float32(rs.originX()+x*rs.camera.tileSize), bandY, size, bandHeight, color.RGBA{255, 255, 255, alpha}, false)
+}
+
 func (rs *RenderSystem) drawGridLines(screen *ebiten.Image, x, y int) {
-	gridColor := color.RGBA{200, 200, 200, 255}
+	gridColor := rs.theme.Grid
 	lineWidth := float32(1)
 	
 	// Horizontal line
 	vector.StrokeLine(
 		screen,
-		float32(GridOffsetX+x*rs.currentTileSize),
-		float32(GridOffsetY+y*rs.currentTileSize),
-		float32(GridOffsetX+(x+1)*rs.currentTileSize),
-		float32(GridOffsetY+y*rs.currentTileSize),
+		float32(rs.originX()+x*rs.camera.tileSize),
+		float32(rs.originY()+y*rs.camera.tileSize),
+		float32(rs.originX()+(x+1)*rs.camera.tileSize),
+		float32(rs.originY()+y*rs.camera.tileSize),
 		lineWidth,
 		gridColor,
 		false,
@@ -196,29 +359,16 @@ func (rs *RenderSystem) drawGridLines(screen *ebiten.Image, x, y int) {
 	// Vertical line
 	vector.StrokeLine(
 		screen,
-		float32(GridOffsetX+x*rs.currentTileSize),
-		float32(GridOffsetY+y*rs.currentTileSize),
-		float32(GridOffsetX+x*rs.currentTileSize),
-		float32(GridOffsetY+(y+1)*rs.currentTileSize),
+		float32(rs.originX()+x*rs.camera.tileSize),
+		float32(rs.originY()+y*rs.camera.tileSize),
+		float32(rs.originX()+x*rs.camera.tileSize),
+		float32(rs.originY()+(y+1)*rs.camera.tileSize),
 		lineWidth,
 		gridColor,
 		false,
 	)
 }
 
-func (rs *RenderSystem) drawUI(screen *ebiten.Image, moves int) {
-	// Draw title
-	ebitenutil.DebugPrintAt(screen, "Island Merge", 10, 10)
-	
-	// Draw moves counter
-	movesText := fmt.Sprintf("Moves: %d", moves)
-	ebitenutil.DebugPrintAt(screen, movesText, 10, 30)
-	
-	// Draw instructions
-	ebitenutil.DebugPrintAt(screen, "Click on sea tiles to build bridges", 10, 50)
-	ebitenutil.DebugPrintAt(screen, "Connect all islands to win!", 10, 70)
-}
-
 func (rs *RenderSystem) drawVictory(screen *ebiten.Image) {
 	// Draw semi-transparent overlay
 	overlay := ebiten.NewImage(640, 480)
@@ -234,40 +384,202 @@ func (rs *RenderSystem) drawVictory(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, msg, x, y)
 }
 
+// DrawPauseOverlay dims the board while the game is paused, with the reason
+// and an optional hint on how to resume
+func (rs *RenderSystem) DrawPauseOverlay(screen *ebiten.Image, msg, hint string) {
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	
+	ebitenutil.DebugPrintAt(screen, msg, 320-len(msg)*3, 232)
+	if hint != "" {
+		ebitenutil.DebugPrintAt(screen, hint, 320-len(hint)*3, 252)
+	}
+}
+
 func (rs *RenderSystem) DrawAnimations(screen *ebiten.Image, animations []*Animation) {
 	for _, anim := range animations {
 		switch anim.Type {
-		case AnimationBridgeBuild:
-			rs.drawBridgeBuildAnimation(screen, anim)
 		case AnimationVictory:
 			rs.drawVictoryAnimation(screen, anim)
+		case AnimationVictoryPath:
+			rs.drawVictoryPathAnimation(screen, anim)
+		case AnimationStarReveal:
+			rs.drawStarRevealAnimation(screen, anim)
+		case AnimationIslandMerge:
+			rs.drawIslandMergeAnimation(screen, anim)
+		}
+	}
+}
+
+// DrawTravelers draws the traffic system's travelers as small dots walking the network
+func (rs *RenderSystem) DrawTravelers(screen *ebiten.Image, travelers []*Traveler) {
+	size := float64(rs.camera.tileSize)
+	radius := float32(math.Max(2, size/10))
+	for _, t := range travelers {
+		tx, ty := t.Position()
+		x := float64(rs.originX()) + (tx+0.5)*size
+		y := float64(rs.originY()) + (ty+0.5)*size
+		vector.DrawFilledCircle(screen, float32(x), float32(y), radius+1, color.RGBA{60, 40, 30, 200}, false)
+		vector.DrawFilledCircle(screen, float32(x), float32(y), radius, color.RGBA{255, 240, 200, 255}, false)
+	}
+}
+
+// DrawParticles draws the active particles, fading them out over their lifetime
+func (rs *RenderSystem) DrawParticles(screen *ebiten.Image, particles []Particle) {
+	for i := range particles {
+		p := &particles[i]
+		if !p.Active {
+			continue
 		}
+		
+		fade := p.Alpha()
+		c := p.Color
+		c.R = uint8(float64(c.R) * fade)
+		c.G = uint8(float64(c.G) * fade)
+		c.B = uint8(float64(c.B) * fade)
+		c.A = uint8(float64(c.A) * fade)
+		
+		if p.Square {
+			// Flip the width with the rotation so confetti appears to tumble
+			w := p.Size * float32(math.Abs(math.Cos(p.Rotation)))
+			vector.DrawFilledRect(screen, float32(p.X)-w/2, float32(p.Y)-p.Size/2, w, p.Size, c, false)
+			continue
+		}
+		vector.DrawFilledCircle(screen, float32(p.X), float32(p.Y), p.Size, c, false)
 	}
 }
 
-func (rs *RenderSystem) drawBridgeBuildAnimation(screen *ebiten.Image, anim *Animation) {
-	// Calculate position
-	x := float64(GridOffsetX + anim.X*rs.currentTileSize + rs.currentTileSize/2)
-	y := float64(GridOffsetY + anim.Y*rs.currentTileSize + rs.currentTileSize/2)
+// drawIslandMergeAnimation flashes the land that a bridge just joined together
+// and sends a ring out from the bridge
+func (rs *RenderSystem) drawIslandMergeAnimation(screen *ebiten.Image, anim *Animation) {
+	fade := 1 - anim.Progress
+	size := float32(rs.camera.tileSize)
+	if land, ok := anim.Data.([]island.Point); ok {
+		for _, p := range land {
+			x := float32(rs.originX() + p.X*rs.camera.tileSize)
+			y := float32(rs.originY() + p.Y*rs.camera.tileSize)
+			vector.DrawFilledRect(screen, x, y, size, size, color.RGBA{255, 255, 220, uint8(160 * fade)}, false)
+		}
+	}
 	
-	// Easing animation
-	progress := EaseOutCubic(anim.Progress)
+	if rs.reducedEffects {
+		return
+	}
+	cx, cy := rs.TileCenter(anim.X, anim.Y)
+	radius := size * float32(0.5+2*EaseOutCubic(anim.Progress))
+	vector.StrokeCircle(screen, float32(cx), float32(cy), radius, 3, color.RGBA{255, 215, 0, uint8(255 * fade)}, false)
+}
+
+// drawVictoryPathAnimation sends a glowing pulse out along the connected network,
+// one BFS layer at a time, leaving the visited tiles softly lit behind it
+func (rs *RenderSystem) drawVictoryPathAnimation(screen *ebiten.Image, anim *Animation) {
+	layers, ok := anim.Data.([][]island.Point)
+	if !ok || len(layers) == 0 {
+		return
+	}
 	
-	// Expanding circle effect
-	radius := float32(progress * float64(rs.currentTileSize) * 0.8)
-	alpha := uint8((1.0 - progress) * 200)
+	// The wave front travels past the last layer so the pulse fades out at the end
+	const trail = 4.0
+	front := anim.Progress * (float64(len(layers)) + trail)
+	size := float32(rs.camera.tileSize)
 	
-	// Draw expanding circle
-	vector.DrawFilledCircle(
-		screen,
-		float32(x), float32(y),
-		radius,
-		color.RGBA{121, 85, 72, alpha},
-		false,
-	)
+	for i, layer := range layers {
+		distance := front - float64(i)
+		if distance < 0 {
+			break
+		}
+		
+		// Bright at the wave front, settling to a steady glow behind it
+		glow := 0.35 + 0.65*math.Exp(-distance)
+		alpha := uint8(200 * glow)
+		
+		for _, p := range layer {
+			x := float32(rs.originX() + p.X*rs.camera.tileSize)
+			y := float32(rs.originY() + p.Y*rs.camera.tileSize)
+			vector.DrawFilledRect(screen, x, y, size, size, color.RGBA{255, 215, 0, alpha / 2}, false)
+			
+			if distance < 1 && !rs.reducedEffects {
+				// Pulse ring on the tiles the wave is reaching right now
+				radius := size * float32(0.3+0.4*distance)
+				vector.StrokeCircle(screen, x+size/2, y+size/2, radius, 2, color.RGBA{255, 255, 200, alpha}, false)
+			}
+		}
+	}
+}
+
+// Victory stars sit in a row under the victory message
+const (
+	victoryStarCount   = 3
+	victoryStarSpacing = 48
+	victoryStarY       = 290
+	victoryStarRadius  = 16
+)
+
+func victoryStarX(index int) float32 {
+	return float32(320 + (index-(victoryStarCount-1)/2)*victoryStarSpacing)
+}
+
+// DrawVictoryStars draws the star slots under the victory message, filling the
+// first revealed ones; stars still popping in are drawn by their animation
+func (rs *RenderSystem) DrawVictoryStars(screen *ebiten.Image, revealed int) {
+	for i := 0; i < victoryStarCount; i++ {
+		col := color.RGBA{90, 90, 90, 200}
+		if i < revealed {
+			col = color.RGBA{255, 215, 0, 255}
+		}
+		DrawStar(screen, victoryStarX(i), victoryStarY, victoryStarRadius, col)
+	}
+}
+
+func (rs *RenderSystem) drawStarRevealAnimation(screen *ebiten.Image, anim *Animation) {
+	scale := anim.Value()
+	if scale <= 0 {
+		return
+	}
+	DrawStar(screen, victoryStarX(anim.X), victoryStarY, float32(victoryStarRadius*scale), color.RGBA{255, 235, 120, 255})
+}
+
+// whitePixel is the source texture for filled vector paths
+var whitePixel = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// DrawStar fills a five-pointed star centred on (cx, cy)
+func DrawStar(screen *ebiten.Image, cx, cy, radius float32, col color.RGBA) {
+	var path vector.Path
+	for i := 0; i < 10; i++ {
+		r := radius
+		if i%2 == 1 {
+			r = radius * 0.45
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		x := cx + r*float32(math.Cos(angle))
+		y := cy + r*float32(math.Sin(angle))
+		if i == 0 {
+			path.MoveTo(x, y)
+		} else {
+			path.LineTo(x, y)
+		}
+	}
+	path.Close()
+	
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	for i := range vertices {
+		vertices[i].ColorR = float32(col.R) / 255
+		vertices[i].ColorG = float32(col.G) / 255
+		vertices[i].ColorB = float32(col.B) / 255
+		vertices[i].ColorA = float32(col.A) / 255
+	}
+	screen.DrawTriangles(vertices, indices, whitePixel, &ebiten.DrawTrianglesOptions{AntiAlias: true})
 }
 
 func (rs *RenderSystem) drawVictoryAnimation(screen *ebiten.Image, anim *Animation) {
+	// The full-screen flash is the heaviest and most distracting effect
+	if rs.reducedEffects {
+		return
+	}
+	
 	// Pulsing victory effect
 	progress := anim.Progress
 	pulse := math.Sin(progress * math.Pi * 4) * 0.1 + 1.0
@@ -283,50 +595,3 @@ func (rs *RenderSystem) drawVictoryAnimation(screen *ebiten.Image, anim *Animati
 	
 	screen.DrawImage(overlay, opt)
 }
-
-func (rs *RenderSystem) DrawGameMode(screen *ebiten.Image, world interface{}) {
-	// Type assertion to avoid circular import
-	type gameWorld interface {
-		GetMode() int
-		GetScore() interface {
-			GetMoves() int
-			GetTime() time.Duration
-		}
-		GetTimeLimit() time.Duration
-		GetState() int
-	}
-	
-	if w, ok := world.(gameWorld); ok {
-		mode := w.GetMode()
-		score := w.GetScore()
-		
-		// Draw mode-specific UI
-		var modeText string
-		switch mode {
-		case 0: // ModeClassic
-			modeText = "Classic Mode"
-		case 1: // ModeTimeAttack
-			modeText = "Time Attack"
-			// Draw timer
-			remaining := w.GetTimeLimit() - score.GetTime()
-			if remaining < 0 {
-				remaining = 0
-			}
-			timerText := fmt.Sprintf("Time: %02d:%02d", 
-				int(remaining.Minutes()), int(remaining.Seconds())%60)
-			ebitenutil.DebugPrintAt(screen, timerText, 450, 10)
-		case 2: // ModePuzzle
-			modeText = "Puzzle Mode"
-		}
-		
-		ebitenutil.DebugPrintAt(screen, modeText, 450, 30)
-		
-		// Draw score
-		scoreText := fmt.Sprintf("Moves: %d", score.GetMoves())
-		ebitenutil.DebugPrintAt(screen, scoreText, 450, 50)
-		
-		timeText := fmt.Sprintf("Time: %02d:%02d", 
-			int(score.GetTime().Minutes()), int(score.GetTime().Seconds())%60)
-		ebitenutil.DebugPrintAt(screen, timeText, 450, 70)
-	}
-}
\ No newline at end of file
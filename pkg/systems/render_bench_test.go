@@ -0,0 +1,81 @@
+// +build render
+
+package systems_test
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/ponyo877/island-merge/pkg/benchboards"
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// BenchmarkRenderFrame draws a whole frame of a half-built board: the board,
+// the hover outline and a highlighted tile
+func BenchmarkRenderFrame(b *testing.B) {
+	for _, size := range benchboards.Sizes {
+		b.Run(benchboards.Name(size), func(b *testing.B) {
+			board := benchboards.Generate(size)
+			_, moves := benchboards.Solved(size)
+			for _, move := range moves[:len(moves)/2] {
+				board.BuildBridge(move.X, move.Y)
+			}
+			screen := ebiten.NewImage(640, 480)
+			defer screen.Deallocate()
+			render := systems.NewRenderSystem(clock.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				render.Draw(screen, board, false)
+				render.DrawHover(screen, board, 320, 240)
+				render.DrawTileHighlight(screen, moves[0].X, moves[0].Y, color.RGBA{255, 215, 0, 255})
+				// Reading a pixel waits for the GPU, so the timing covers the drawing itself
+				screen.At(0, 0)
+			}
+		})
+	}
+}
+
+// benchHarness runs the tests during the first Draw, where images can be
+// drawn and read back, then stops the game loop
+type benchHarness struct {
+	m    *testing.M
+	code int
+	done bool
+}
+
+func (h *benchHarness) Update() error {
+	if h.done {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (h *benchHarness) Draw(screen *ebiten.Image) {
+	if h.done {
+		return
+	}
+	h.code = h.m.Run()
+	h.done = true
+}
+
+func (h *benchHarness) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return 640, 480
+}
+
+// TestMain runs everything inside the game loop; drawing needs a display like cmd/golden
+func TestMain(m *testing.M) {
+	harness := &benchHarness{m: m}
+	ebiten.SetWindowTitle("Island Merge benchmarks")
+	if err := ebiten.RunGame(harness); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	os.Exit(harness.code)
+}
@@ -0,0 +1,112 @@
+package systems
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+const sampleRate = 44100
+
+// Sound names a sound effect
+type Sound int
+
+const (
+	SoundIslandsMerged Sound = iota
+	SoundHeartbeat
+	SoundAchievement
+)
+
+// note is one tone of a synthesized effect
+type note struct {
+	freq     float64
+	start    time.Duration
+	duration time.Duration
+}
+
+// soundNotes describe each effect; they are synthesized at startup so the game
+// ships without audio files
+var soundNotes = map[Sound][]note{
+	// A rising two-note chime
+	SoundIslandsMerged: {
+		{freq: 659.25, start: 0, duration: 180 * time.Millisecond},
+		{freq: 880.00, start: 90 * time.Millisecond, duration: 260 * time.Millisecond},
+	},
+	// A low lub-dub
+	SoundHeartbeat: {
+		{freq: 98.00, start: 0, duration: 140 * time.Millisecond},
+		{freq: 82.41, start: 190 * time.Millisecond, duration: 180 * time.Millisecond},
+	},
+	// A bright rising arpeggio
+	SoundAchievement: {
+		{freq: 1046.50, start: 0, duration: 160 * time.Millisecond},
+		{freq: 1318.51, start: 80 * time.Millisecond, duration: 160 * time.Millisecond},
+		{freq: 1567.98, start: 160 * time.Millisecond, duration: 360 * time.Millisecond},
+	},
+}
+
+// SoundSystem plays short sound effects when sound is enabled in the settings
+type SoundSystem struct {
+	context *audio.Context
+	samples map[Sound][]byte
+	enabled bool
+}
+
+func NewSoundSystem() *SoundSystem {
+	ss := &SoundSystem{
+		context: audio.NewContext(sampleRate),
+		samples: make(map[Sound][]byte),
+		enabled: true,
+	}
+	for sound, notes := range soundNotes {
+		ss.samples[sound] = synthesize(notes)
+	}
+	return ss
+}
+
+// SetEnabled follows the Sound Effects setting
+func (ss *SoundSystem) SetEnabled(enabled bool) {
+	ss.enabled = enabled
+}
+
+// Play starts a sound effect; effects overlap rather than cut each other off
+func (ss *SoundSystem) Play(sound Sound) {
+	if !ss.enabled {
+		return
+	}
+	samples, ok := ss.samples[sound]
+	if !ok {
+		return
+	}
+	ss.context.NewPlayerFromBytes(samples).Play()
+}
+
+// synthesize mixes sine tones with a quick attack and exponential decay into
+// 16-bit little-endian stereo PCM
+func synthesize(notes []note) []byte {
+	var length time.Duration
+	for _, n := range notes {
+		length = max(length, n.start+n.duration)
+	}
+	frames := int(length.Seconds() * sampleRate)
+	mix := make([]float64, frames)
+	for _, n := range notes {
+		first := int(n.start.Seconds() * sampleRate)
+		count := int(n.duration.Seconds() * sampleRate)
+		for i := 0; i < count && first+i < frames; i++ {
+			t := float64(i) / sampleRate
+			envelope := math.Min(1, t/0.005) * math.Exp(-6*t/n.duration.Seconds())
+			mix[first+i] += 0.3 * envelope * math.Sin(2*math.Pi*n.freq*t)
+		}
+	}
+
+	pcm := make([]byte, frames*4)
+	for i, v := range mix {
+		sample := int16(math.Max(-1, math.Min(1, v)) * math.MaxInt16)
+		binary.LittleEndian.PutUint16(pcm[i*4:], uint16(sample))
+		binary.LittleEndian.PutUint16(pcm[i*4+2:], uint16(sample))
+	}
+	return pcm
+}
@@ -0,0 +1,85 @@
+package systems
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Touch gestures, in screen pixels
+const (
+	tapSlop           = 10 // A finger that moves further is dragging, not tapping
+	touchScrollPixels = 20 // Drag distance that scrolls as far as one wheel notch
+	longPressTime     = 500 * time.Millisecond
+)
+
+// touchState follows the first finger on the screen; other fingers are ignored
+type touchState struct {
+	id          ebiten.TouchID
+	active      bool
+	start       time.Time
+	startX      int
+	startY      int
+	lastY       int
+	moved       bool // Left the tap slop, so lifting the finger is not a tap
+	longPressed bool
+}
+
+// sampleTouch turns the first finger into pointer state. Touch screens have no
+// hover, so a tap clicks where the finger lifts, a long press stands in for the
+// right button and a drag scrolls like the wheel. It reports false when no
+// finger is down or lifting, leaving the pointer to the mouse.
+func (is *InputSystem) sampleTouch() (*Action, bool) {
+	is.tapped, is.longPressed = false, false
+	t := &is.touch
+
+	if !t.active {
+		ids := inpututil.AppendJustPressedTouchIDs(nil)
+		if len(ids) == 0 {
+			return nil, false
+		}
+		x, y := ebiten.TouchPosition(ids[0])
+		*t = touchState{id: ids[0], active: true, start: time.Now(), startX: x, startY: y, lastY: y}
+		is.pointer = PointerState{X: x, Y: y, LeftDown: true, Touch: true}
+		return nil, true
+	}
+
+	if inpututil.IsTouchJustReleased(t.id) {
+		t.active = false
+		x, y := inpututil.TouchPositionInPreviousTick(t.id)
+		is.pointer = PointerState{X: x, Y: y, LeftJustReleased: true, Touch: true}
+		if t.moved || t.longPressed {
+			return nil, true
+		}
+		is.tapped = true
+		is.pointer.LeftJustPressed = true
+		return &Action{Type: ActionClick, X: x, Y: y}, true
+	}
+
+	x, y := ebiten.TouchPosition(t.id)
+	dx, dy := x-t.startX, y-t.startY
+	if dx*dx+dy*dy > tapSlop*tapSlop {
+		t.moved = true
+	}
+	is.pointer = PointerState{X: x, Y: y, LeftDown: true, Touch: true}
+	if t.moved {
+		is.pointer.WheelY = float64(y-t.lastY) / touchScrollPixels
+	} else if !t.longPressed && time.Since(t.start) >= longPressTime {
+		t.longPressed = true
+		is.longPressed = true
+	}
+	t.lastY = y
+	return nil, true
+}
+
+// IsTapped reports whether a finger tapped the screen this frame
+func (is *InputSystem) IsTapped() bool {
+	return is.tapped
+}
+
+// IsLongPressed reports whether a finger has just been held still long enough
+// to count as a long press
+func (is *InputSystem) IsLongPressed() bool {
+	return is.longPressed
+}
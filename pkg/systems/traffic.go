@@ -0,0 +1,203 @@
+package systems
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+const (
+	maxTravelers     = 16
+	travelerSpeed    = 3.0 // Tiles per second
+	spawnInterval    = 700 * time.Millisecond
+	throughputWindow = time.Minute // Deliveries counted for the per-minute rate
+)
+
+// Traveler walks a path of land and bridge tiles from one island to another
+type Traveler struct {
+	Path     []island.Point
+	Progress float64 // Tiles walked along Path
+}
+
+// Position returns the traveler's position in tiles, between two path tiles
+func (t *Traveler) Position() (float64, float64) {
+	i := int(t.Progress)
+	if i >= len(t.Path)-1 {
+		last := t.Path[len(t.Path)-1]
+		return float64(last.X), float64(last.Y)
+	}
+	f := t.Progress - float64(i)
+	a, b := t.Path[i], t.Path[i+1]
+	return float64(a.X) + f*float64(b.X-a.X), float64(a.Y) + f*float64(b.Y-a.Y)
+}
+
+// TrafficStats summarises the traffic on the network
+type TrafficStats struct {
+	Active    int // Travelers on the way
+	Delivered int // Travelers that have arrived since the board was set
+	PerMinute int // Arrivals in the last minute
+}
+
+// TrafficSystem sends travelers between islands that bridges have connected.
+// It is cosmetic, but its stats are there for modes that want to reward a busy
+// network.
+type TrafficSystem struct {
+	clock      clock.Clock
+	rng        *rand.Rand
+	enabled    bool
+	board      *island.Board
+	travelers  []*Traveler
+	arrivals   []time.Time
+	delivered  int
+	lastUpdate time.Time
+	nextSpawn  time.Time
+}
+
+func NewTrafficSystem(clk clock.Clock) *TrafficSystem {
+	return &TrafficSystem{
+		clock:   clk,
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		enabled: true,
+	}
+}
+
+// Seed makes the traffic that follows repeatable, for debugging
+func (ts *TrafficSystem) Seed(seed int64) {
+	ts.rng = rand.New(rand.NewSource(seed))
+}
+
+// SetEnabled turns the simulation on or off; turning it off clears the travelers
+func (ts *TrafficSystem) SetEnabled(enabled bool) {
+	ts.enabled = enabled
+	if !enabled {
+		ts.travelers = nil
+	}
+}
+
+// Reset clears the travelers and stats, e.g. when a new game starts
+func (ts *TrafficSystem) Reset() {
+	ts.travelers = nil
+	ts.arrivals = nil
+	ts.delivered = 0
+	ts.board = nil
+}
+
+// Travelers returns the travelers on the way
+func (ts *TrafficSystem) Travelers() []*Traveler {
+	return ts.travelers
+}
+
+func (ts *TrafficSystem) Stats() TrafficStats {
+	return TrafficStats{Active: len(ts.travelers), Delivered: ts.delivered, PerMinute: len(ts.arrivals)}
+}
+
+// Update moves travelers along and sends out new ones. Call it once per frame.
+func (ts *TrafficSystem) Update(board *island.Board) {
+	now := ts.clock.Now()
+	dt := 0.0
+	if !ts.lastUpdate.IsZero() {
+		dt = math.Min(now.Sub(ts.lastUpdate).Seconds(), 0.1)
+	}
+	ts.lastUpdate = now
+	if board != ts.board {
+		ts.Reset()
+		ts.board = board
+	}
+	if !ts.enabled || board == nil {
+		return
+	}
+
+	// Walk, dropping travelers whose way was cut by a removed bridge
+	kept := ts.travelers[:0]
+	for _, t := range ts.travelers {
+		t.Progress += dt * travelerSpeed
+		if !pathOpen(board, t.Path, int(t.Progress)) {
+			continue
+		}
+		if t.Progress >= float64(len(t.Path)-1) {
+			ts.delivered++
+			ts.arrivals = append(ts.arrivals, now)
+			continue
+		}
+		kept = append(kept, t)
+	}
+	ts.travelers = kept
+
+	for len(ts.arrivals) > 0 && now.Sub(ts.arrivals[0]) > throughputWindow {
+		ts.arrivals = ts.arrivals[1:]
+	}
+
+	if now.Before(ts.nextSpawn) || len(ts.travelers) >= maxTravelers {
+		return
+	}
+	ts.nextSpawn = now.Add(spawnInterval)
+	if path := ts.randomRoute(board); path != nil {
+		ts.travelers = append(ts.travelers, &Traveler{Path: path})
+	}
+}
+
+// pathOpen reports whether the rest of a path, from tile from on, is still walkable
+func pathOpen(board *island.Board, path []island.Point, from int) bool {
+	for _, p := range path[min(from, len(path)):] {
+		tile := board.GetTile(p.X, p.Y)
+		if tile == nil || (tile.Type != island.TileLand && tile.Type != island.TileBridge) {
+			return false
+		}
+	}
+	return true
+}
+
+// randomRoute picks a land tile and walks to a random tile of another island
+// in the same network, or returns nil when no such island exists
+func (ts *TrafficSystem) randomRoute(board *island.Board) []island.Point {
+	if len(board.Islands) < 2 {
+		return nil
+	}
+	start := board.Islands[ts.rng.Intn(len(board.Islands))]
+
+	// Breadth-first over land and bridges, noting which tiles are on other islands
+	size := board.Width * board.Height
+	prev := make([]int, size)
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[start] = start
+	queue := []int{start}
+	var targets []int
+	for head := 0; head < len(queue); head++ {
+		idx := queue[head]
+		x, y := idx%board.Width, idx/board.Width
+		for _, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+			nx, ny := x+d[0], y+d[1]
+			tile := board.GetTile(nx, ny)
+			next := ny*board.Width + nx
+			if tile == nil || prev[next] >= 0 || (tile.Type != island.TileLand && tile.Type != island.TileBridge) {
+				continue
+			}
+			prev[next] = idx
+			queue = append(queue, next)
+			// Land first reached over a bridge is a destination, nearly always another island
+			if tile.Type == island.TileLand && board.Tiles[idx].Type == island.TileBridge {
+				targets = append(targets, next)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var path []island.Point
+	for idx := targets[ts.rng.Intn(len(targets))]; ; idx = prev[idx] {
+		path = append(path, island.Point{X: idx % board.Width, Y: idx / board.Width})
+		if idx == start {
+			break
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
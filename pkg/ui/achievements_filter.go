@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/achievements"
+)
+
+// achievementSort orders the achievement list
+type achievementSort int
+
+const (
+	achievementSortCategory achievementSort = iota // By category, then the most points first
+	achievementSortPoints
+	achievementSortProgress // Closest to unlocking first, unlocked ones last
+	achievementSortRecent   // Most recently unlocked first
+	achievementSortCount
+)
+
+var achievementSortNames = []string{"Category", "Points", "Progress", "Recent"}
+
+// achievementShow filters the list by unlock status
+type achievementShow int
+
+const (
+	achievementShowAll achievementShow = iota
+	achievementShowUnlocked
+	achievementShowLocked
+	achievementShowCount
+)
+
+var achievementShowNames = []string{"All", "Unlocked", "Locked"}
+
+// Achievement panel layout above the list: a progress bar per category, which
+// filters the list when clicked, then the sort and show buttons
+const (
+	achievementBarsX     = 120
+	achievementBarsY     = 112
+	achievementBarStep   = 100
+	achievementBarWidth  = 92
+	achievementToolbarY  = 140
+	achievementListY     = 170
+	achievementSortWidth = 130
+	achievementShowWidth = 110
+)
+
+// listedAchievements returns the achievements the panel shows, filtered and sorted
+func (aui *AchievementsUI) listedAchievements() []*achievements.Achievement {
+	listed := make([]*achievements.Achievement, 0)
+	for _, achievement := range aui.achievementSystem.GetAchievements() {
+		if aui.categoryFilter != nil && achievement.Category != *aui.categoryFilter {
+			continue
+		}
+		if (aui.showMode == achievementShowUnlocked && !achievement.Unlocked) ||
+			(aui.showMode == achievementShowLocked && achievement.Unlocked) {
+			continue
+		}
+		listed = append(listed, achievement)
+	}
+
+	// Definition order first, so ties keep a steady place
+	sort.Slice(listed, func(i, j int) bool { return listed[i].ID < listed[j].ID })
+	less := map[achievementSort]func(a, b *achievements.Achievement) bool{
+		achievementSortCategory: func(a, b *achievements.Achievement) bool {
+			if a.Category != b.Category {
+				return a.Category < b.Category
+			}
+			return a.Points > b.Points
+		},
+		achievementSortPoints: func(a, b *achievements.Achievement) bool { return a.Points > b.Points },
+		achievementSortProgress: func(a, b *achievements.Achievement) bool {
+			if a.Unlocked != b.Unlocked {
+				return b.Unlocked
+			}
+			return a.Progress*b.Target > b.Progress*a.Target
+		},
+		achievementSortRecent: func(a, b *achievements.Achievement) bool {
+			if a.UnlockedAt == nil || b.UnlockedAt == nil {
+				return a.UnlockedAt != nil
+			}
+			return a.UnlockedAt.After(*b.UnlockedAt)
+		},
+	}[aui.sortMode]
+	sort.SliceStable(listed, func(i, j int) bool { return less(listed[i], listed[j]) })
+	return listed
+}
+
+// handleFilterClick handles the category bars and the sort and show buttons
+func (aui *AchievementsUI) handleFilterClick(x, y int) bool {
+	for i, category := range achievements.Categories {
+		if !inRect(x, y, achievementBarsX+i*achievementBarStep, achievementBarsY-2, achievementBarWidth, 24) {
+			continue
+		}
+		if aui.categoryFilter != nil && *aui.categoryFilter == category {
+			aui.categoryFilter = nil // Clicking the filtered category shows them all again
+		} else {
+			aui.categoryFilter = &category
+		}
+		aui.panelScroll = 0
+		return true
+	}
+
+	switch {
+	case inRect(x, y, achievementBarsX, achievementToolbarY, achievementSortWidth, toolbarHeight):
+		aui.sortMode = (aui.sortMode + 1) % achievementSortCount
+	case inRect(x, y, achievementBarsX+achievementSortWidth+6, achievementToolbarY, achievementShowWidth, toolbarHeight):
+		aui.showMode = (aui.showMode + 1) % achievementShowCount
+	default:
+		return false
+	}
+	aui.panelScroll = 0
+	return true
+}
+
+// drawCategoryBars draws each category's share of unlocked achievements
+func (aui *AchievementsUI) drawCategoryBars(screen *ebiten.Image) {
+	for i, category := range achievements.Categories {
+		x := achievementBarsX + i*achievementBarStep
+		unlocked, total := aui.achievementSystem.GetCategoryProgress(category)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s %d/%d", category, unlocked, total), x, achievementBarsY)
+
+		barY := float32(achievementBarsY + 16)
+		vector.DrawFilledRect(screen, float32(x), barY, achievementBarWidth, 6, color.RGBA{100, 100, 100, 255}, false)
+		if total > 0 {
+			vector.DrawFilledRect(screen, float32(x), barY, float32(achievementBarWidth*unlocked/total), 6, categoryColor(category), false)
+		}
+
+		if aui.categoryFilter != nil && *aui.categoryFilter == category {
+			vector.StrokeRect(screen, float32(x-3), achievementBarsY-3, achievementBarWidth+6, 28, 2, color.RGBA{255, 215, 0, 255}, false)
+		}
+	}
+}
+
+func (aui *AchievementsUI) drawFilterButtons(screen *ebiten.Image) {
+	buttons := []struct {
+		x, width int
+		text     string
+	}{
+		{achievementBarsX, achievementSortWidth, "Sort: " + achievementSortNames[aui.sortMode]},
+		{achievementBarsX + achievementSortWidth + 6, achievementShowWidth, "Show: " + achievementShowNames[aui.showMode]},
+	}
+	for _, button := range buttons {
+		btnColor := color.Color(color.RGBA{210, 210, 230, 255})
+		if inRect(aui.hoverX, aui.hoverY, button.x, achievementToolbarY, button.width, toolbarHeight) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(button.x), achievementToolbarY, float32(button.width), toolbarHeight, btnColor, false)
+		vector.StrokeRect(screen, float32(button.x), achievementToolbarY, float32(button.width), toolbarHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, button.text, button.x+6, achievementToolbarY+4)
+	}
+}
+
+// categoryColor fills a category's progress bar
+func categoryColor(category achievements.Category) color.Color {
+	switch category {
+	case achievements.CategorySkill:
+		return color.RGBA{230, 120, 40, 255}
+	case achievements.CategoryCreativity:
+		return color.RGBA{170, 90, 220, 255}
+	case achievements.CategoryDedication:
+		return color.RGBA{220, 60, 90, 255}
+	default:
+		return color.RGBA{0, 200, 0, 255}
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/achievements"
+)
+
+// Unlocked achievements that grant a title have a button at the bottom left of
+// their list item to wear it beside the player's name
+const (
+	titleButtonX      = achievementBarsX
+	titleButtonOffset = 38
+	titleButtonWidth  = 200
+	titleButtonHeight = 16
+)
+
+// SetTitle marks the title the player wears; "" is none
+func (aui *AchievementsUI) SetTitle(title string) {
+	aui.title = title
+}
+
+// achievementItemVisible reports whether a list item at y fits in the list area
+func achievementItemVisible(itemY float64) bool {
+	return itemY >= achievementListY && itemY+60 <= achievementListY+achievementListHeight
+}
+
+// handleTitleClick wears the clicked title, or takes it off when it is
+// already worn
+func (aui *AchievementsUI) handleTitleClick(x, y int) bool {
+	for i, achievement := range aui.listedAchievements() {
+		itemY := achievementListY - aui.panelScroll + float64(i*achievementItemSpacing)
+		if !achievement.Unlocked || achievement.Title == "" || !achievementItemVisible(itemY) {
+			continue
+		}
+		if !inRect(x, y, titleButtonX, int(itemY)+titleButtonOffset, titleButtonWidth, titleButtonHeight) {
+			continue
+		}
+		title := achievement.Title
+		if title == aui.title {
+			title = ""
+		}
+		aui.title = title
+		if aui.OnTitle != nil {
+			aui.OnTitle(title)
+		}
+		return true
+	}
+	return false
+}
+
+func (aui *AchievementsUI) drawTitleButton(screen *ebiten.Image, achievement *achievements.Achievement, itemY float64) {
+	y := int(itemY) + titleButtonOffset
+	text := "Wear title: " + achievement.Title
+	bg := color.Color(color.RGBA{210, 210, 230, 255})
+	if achievement.Title == aui.title {
+		text = "Wearing: " + achievement.Title
+		bg = color.RGBA{255, 215, 0, 255}
+	}
+	if inRect(aui.hoverX, aui.hoverY, titleButtonX, y, titleButtonWidth, titleButtonHeight) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, titleButtonX, float32(y), titleButtonWidth, titleButtonHeight, bg, false)
+	vector.StrokeRect(screen, titleButtonX, float32(y), titleButtonWidth, titleButtonHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, truncateText(text, titleButtonWidth/6-2), titleButtonX+6, y)
+}
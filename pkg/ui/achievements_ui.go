@@ -10,26 +10,56 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/ponyo877/island-merge/pkg/achievements"
+	"github.com/ponyo877/island-merge/pkg/clock"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// Notifications slide down from off-screen, hold, then slide back up. Up to
+// maxShownNotifications stack below each other; later unlocks wait their turn.
+const (
+	notificationHiddenY   = -100.0
+	notificationShownY    = 20.0
+	notificationSpacing   = 70.0
+	notificationSlide     = 800 * time.Millisecond
+	notificationHold      = 2400 * time.Millisecond
+	maxShownNotifications = 3
 )
 
 type AchievementNotification struct {
 	Achievement *achievements.Achievement
-	StartTime   time.Time
-	Duration    time.Duration
 	Y           float64
+	slot        int // Position in the stack, 0 at the top
+	slideIn     *systems.Animation
+	slideOut    *systems.Animation
 }
 
 type AchievementsUI struct {
 	achievementSystem *achievements.AchievementSystem
 	notifications     []*AchievementNotification
+	pending           []*achievements.Achievement // Unlocked but not shown yet, oldest first
+	deferred          bool                        // Do not disturb holds notifications back
+	animations        *systems.AnimationSystem
 	showPanel         bool
 	panelScroll       float64
+	hoverX, hoverY    int
+	sortMode          achievementSort
+	showMode          achievementShow
+	categoryFilter    *achievements.Category // Only this category is listed; nil lists all
+	showStats         bool                   // The panel shows the activity calendar instead of the list
+	title             string                 // Title the player wears, from an unlocked achievement
+	ratings           []storage.RatingEntry  // Race rating after each rated race, charted in the stats view
+	
+	OnShown func(*achievements.Achievement) // Called as each notification appears, e.g. to play a sound
+	OnTitle func(title string)              // Called when a title is worn or taken off; "" is none
 }
 
-func NewAchievementsUI(system *achievements.AchievementSystem) *AchievementsUI {
+// NewAchievementsUI creates the achievement panel; notifications slide on clk
+func NewAchievementsUI(system *achievements.AchievementSystem, clk clock.Clock) *AchievementsUI {
 	ui := &AchievementsUI{
 		achievementSystem: system,
 		notifications:     make([]*AchievementNotification, 0),
+		animations:        systems.NewAnimationSystem(clk),
 		showPanel:         false,
 	}
 	
@@ -40,46 +70,79 @@ func NewAchievementsUI(system *achievements.AchievementSystem) *AchievementsUI {
 }
 
 func (aui *AchievementsUI) onAchievementUnlocked(achievement *achievements.Achievement) {
+	aui.pending = append(aui.pending, achievement)
+	aui.showPending()
+}
+
+// SetDeferred holds notifications back while on, for do not disturb; turning
+// it off shows the ones that waited
+func (aui *AchievementsUI) SetDeferred(deferred bool) {
+	aui.deferred = deferred
+	aui.showPending()
+}
+
+// showPending shows waiting notifications while there is room in the stack
+func (aui *AchievementsUI) showPending() {
+	for !aui.deferred && len(aui.pending) > 0 && len(aui.notifications) < maxShownNotifications {
+		achievement := aui.pending[0]
+		aui.pending = aui.pending[1:]
+		aui.show(achievement)
+	}
+}
+
+// freeSlot is the highest place in the stack no notification holds
+func (aui *AchievementsUI) freeSlot() int {
+	for slot := 0; ; slot++ {
+		taken := false
+		for _, n := range aui.notifications {
+			taken = taken || n.slot == slot
+		}
+		if !taken {
+			return slot
+		}
+	}
+}
+
+func (aui *AchievementsUI) show(achievement *achievements.Achievement) {
 	notification := &AchievementNotification{
 		Achievement: achievement,
-		StartTime:   time.Now(),
-		Duration:    time.Second * 4,
-		Y:           -100, // Start off-screen
+		Y:           notificationHiddenY,
+		slot:        aui.freeSlot(),
 	}
+	notification.slideIn = systems.NewAnimation(systems.AnimationTween, 0, 0, notificationSlide).
+		WithEasing(systems.EaseOutCubic)
+	notification.slideOut = systems.NewAnimation(systems.AnimationTween, 0, 0, notificationSlide).
+		WithEasing(systems.EaseInCubic).
+		OnComplete(func() { aui.removeNotification(notification) })
+	notification.slideIn.
+		Then(systems.NewAnimation(systems.AnimationTween, 0, 0, notificationHold)).
+		Then(notification.slideOut)
 	
 	aui.notifications = append(aui.notifications, notification)
+	aui.animations.Play(notification.slideIn)
+	if aui.OnShown != nil {
+		aui.OnShown(achievement)
+	}
+}
+
+func (aui *AchievementsUI) removeNotification(notification *AchievementNotification) {
+	for i, n := range aui.notifications {
+		if n == notification {
+			aui.notifications = append(aui.notifications[:i], aui.notifications[i+1:]...)
+			break
+		}
+	}
+	aui.showPending()
 }
 
 func (aui *AchievementsUI) Update() {
-	now := time.Now()
+	aui.animations.Update()
 	
-	// Update notifications
-	activeNotifications := make([]*AchievementNotification, 0)
 	for _, notification := range aui.notifications {
-		elapsed := now.Sub(notification.StartTime)
-		
-		if elapsed < notification.Duration {
-			// Animate notification sliding in and out
-			progress := float64(elapsed) / float64(notification.Duration)
-			
-			if progress < 0.2 {
-				// Slide in
-				slideProgress := progress / 0.2
-				notification.Y = -100 + slideProgress*120 // Slide to Y=20
-			} else if progress < 0.8 {
-				// Stay visible
-				notification.Y = 20
-			} else {
-				// Slide out
-				slideProgress := (progress - 0.8) / 0.2
-				notification.Y = 20 - slideProgress*120 // Slide to Y=-100
-			}
-			
-			activeNotifications = append(activeNotifications, notification)
-		}
+		slide := notification.slideIn.Value() - notification.slideOut.Value()
+		shownY := notificationShownY + float64(notification.slot)*notificationSpacing
+		notification.Y = notificationHiddenY + slide*(shownY-notificationHiddenY)
 	}
-	
-	aui.notifications = activeNotifications
 }
 
 func (aui *AchievementsUI) TogglePanel() {
@@ -87,13 +150,31 @@ func (aui *AchievementsUI) TogglePanel() {
 	aui.panelScroll = 0
 }
 
+func (aui *AchievementsUI) IsOpen() bool {
+	return aui.showPanel
+}
+
+// UpdateHover records the pointer position for hover highlights
+func (aui *AchievementsUI) UpdateHover(x, y int) {
+	aui.hoverX, aui.hoverY = x, y
+}
+
 func (aui *AchievementsUI) HandleScroll(deltaY float64) {
-	if aui.showPanel {
-		aui.panelScroll += deltaY * 20
-		aui.panelScroll = math.Max(0, aui.panelScroll)
+	if aui.showPanel && !aui.showStats {
+		aui.panelScroll += deltaY * scrollStep
+		aui.panelScroll = clampScroll(aui.panelScroll, aui.listContentHeight(), achievementListHeight)
 	}
 }
 
+const (
+	achievementItemSpacing = 70
+	achievementListHeight  = 250 // Visible list area inside the panel
+)
+
+func (aui *AchievementsUI) listContentHeight() float64 {
+	return float64(len(aui.listedAchievements()) * achievementItemSpacing)
+}
+
 func (aui *AchievementsUI) HandleClick(x, y int) bool {
 	if !aui.showPanel {
 		return false
@@ -104,6 +185,9 @@ func (aui *AchievementsUI) HandleClick(x, y int) bool {
 		aui.showPanel = false
 		return true
 	}
+	if !aui.handleStatsToggle(x, y) && !aui.showStats && !aui.handleTitleClick(x, y) {
+		aui.handleFilterClick(x, y)
+	}
 	
 	return true // Consume click when panel is open
 }
@@ -122,6 +206,17 @@ func (aui *AchievementsUI) drawNotifications(screen *ebiten.Image) {
 	for _, notification := range aui.notifications {
 		aui.drawNotification(screen, notification)
 	}
+	
+	// The lowest notification counts the ones still waiting
+	if len(aui.pending) > 0 && len(aui.notifications) > 0 {
+		lowest := aui.notifications[0]
+		for _, notification := range aui.notifications {
+			if notification.slot > lowest.slot {
+				lowest = notification
+			}
+		}
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("+%d more", len(aui.pending)), 284, int(lowest.Y+10))
+	}
 }
 
 func (aui *AchievementsUI) drawNotification(screen *ebiten.Image, notification *AchievementNotification) {
@@ -207,27 +302,50 @@ func (aui *AchievementsUI) drawAchievementsPanel(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Achievements", int(panelX+20), int(panelY+20))
 	
 	// Close button
-	vector.DrawFilledRect(screen, 580, 20, 40, 40, color.RGBA{200, 100, 100, 255}, false)
+	var closeColor color.Color = color.RGBA{200, 100, 100, 255}
+	if aui.hoverX >= 580 && aui.hoverX <= 620 && aui.hoverY >= 20 && aui.hoverY <= 60 {
+		closeColor = brighten(closeColor)
+	}
+	vector.DrawFilledRect(screen, 580, 20, 40, 40, closeColor, false)
 	ebitenutil.DebugPrintAt(screen, "X", 595, 35)
 	
 	// Progress summary
 	summary := aui.achievementSystem.GetProgressSummary()
 	ebitenutil.DebugPrintAt(screen, summary, int(panelX+20), int(panelY+40))
 	
+	// Score, gamerscore style
+	earned, possible := aui.achievementSystem.GetScore()
+	scoreText := fmt.Sprintf("Score: %d / %d", earned, possible)
+	ebitenutil.DebugPrintAt(screen, scoreText, int(panelX+panelWidth-20)-len(scoreText)*6, int(panelY+40))
+	
+	aui.drawStatsToggle(screen)
+	if aui.showStats {
+		aui.drawStats(screen)
+		return
+	}
+	aui.drawCategoryBars(screen)
+	aui.drawFilterButtons(screen)
+	
 	// Achievement list
-	achievements := aui.achievementSystem.GetAchievements()
-	startY := panelY + 70 - aui.panelScroll
+	listed := aui.listedAchievements()
+	startY := achievementListY - aui.panelScroll
 	
-	for i, achievement := range achievements {
-		itemY := startY + float64(i*70)
+	for i, achievement := range listed {
+		itemY := startY + float64(i*achievementItemSpacing)
 		
 		// Skip if outside visible area
-		if itemY < panelY+60 || itemY > panelY+panelHeight-10 {
+		if !achievementItemVisible(itemY) {
 			continue
 		}
 		
-		aui.drawAchievementItem(screen, achievement, panelX+10, itemY, panelWidth-20)
+		aui.drawAchievementItem(screen, achievement, panelX+10, itemY, panelWidth-30)
+	}
+	if len(listed) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No achievements match", int(panelX+20), achievementListY+10)
 	}
+	
+	drawScrollbar(screen, panelX+panelWidth-14, achievementListY, achievementListHeight,
+		aui.listContentHeight(), achievementListHeight, aui.panelScroll)
 }
 
 func (aui *AchievementsUI) drawAchievementItem(screen *ebiten.Image, achievement *achievements.Achievement, x, y, width float64) {
@@ -266,6 +384,10 @@ func (aui *AchievementsUI) drawAchievementItem(screen *ebiten.Image, achievement
 	nameText := fmt.Sprintf("%s %s", achievement.Icon, achievement.Name)
 	ebitenutil.DebugPrintAt(screen, nameText, int(x+10), int(y+10))
 	
+	// Category and points
+	worth := fmt.Sprintf("%s  %d pts", achievement.Category, achievement.Points)
+	ebitenutil.DebugPrintAt(screen, worth, int(x+width-10)-len(worth)*6, int(y+10))
+	
 	// Description
 	ebitenutil.DebugPrintAt(screen, achievement.Description, int(x+10), int(y+25))
 	
@@ -302,6 +424,9 @@ func (aui *AchievementsUI) drawAchievementItem(screen *ebiten.Image, achievement
 		)
 	} else if achievement.Unlocked {
 		ebitenutil.DebugPrintAt(screen, "UNLOCKED", int(x+width-80), int(y+40))
+		if achievement.Title != "" {
+			aui.drawTitleButton(screen, achievement, y)
+		}
 	}
 }
 
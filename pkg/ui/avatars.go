@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Avatars follow the order of ai.Personality
+const (
+	avatarSteady = iota
+	avatarPlanner
+	avatarRusher
+	avatarChaotic
+)
+
+// avatarColors fill each personality's face
+var avatarColors = []color.RGBA{
+	avatarSteady:  {220, 80, 80, 255},
+	avatarPlanner: {90, 140, 220, 255},
+	avatarRusher:  {240, 150, 50, 255},
+	avatarChaotic: {170, 90, 200, 255},
+}
+
+// avatarIndex maps a personality without an avatar to Steady's
+func avatarIndex(personality int) int {
+	if personality < 0 || personality >= len(avatarColors) {
+		return avatarSteady
+	}
+	return personality
+}
+
+// drawAvatar draws the face of an AI personality centred on (x, y)
+func drawAvatar(screen *ebiten.Image, personality int, x, y, r float32) {
+	personality = avatarIndex(personality)
+	ink := color.RGBA{40, 40, 40, 255}
+	line := r / 6
+
+	if personality == avatarRusher {
+		// Speed lines trail behind the Rusher
+		for i := float32(-1); i <= 1; i++ {
+			vector.StrokeLine(screen, x-r*2, y+i*r/2, x-r*1.2, y+i*r/2, line, avatarColors[avatarRusher], true)
+		}
+	}
+	vector.DrawFilledCircle(screen, x, y, r, avatarColors[personality], true)
+	vector.StrokeCircle(screen, x, y, r, line, ink, true)
+
+	eyeY, eyeX := y-r/4, r*0.4
+	switch personality {
+	case avatarPlanner:
+		// Round glasses and a small smile
+		vector.StrokeCircle(screen, x-eyeX, eyeY, r/3, line, ink, true)
+		vector.StrokeCircle(screen, x+eyeX, eyeY, r/3, line, ink, true)
+		vector.StrokeLine(screen, x-eyeX+r/3, eyeY, x+eyeX-r/3, eyeY, line, ink, true)
+		vector.StrokeLine(screen, x-r/4, y+r/2, x+r/4, y+r/2, line, ink, true)
+	case avatarRusher:
+		// Angry brows and a wide grin
+		vector.StrokeLine(screen, x-eyeX-r/4, eyeY-r/4, x-eyeX+r/4, eyeY, line, ink, true)
+		vector.StrokeLine(screen, x+eyeX+r/4, eyeY-r/4, x+eyeX-r/4, eyeY, line, ink, true)
+		vector.DrawFilledCircle(screen, x-eyeX, eyeY+r/8, r/8, ink, true)
+		vector.DrawFilledCircle(screen, x+eyeX, eyeY+r/8, r/8, ink, true)
+		vector.DrawFilledRect(screen, x-r/2, y+r/3, r, r/4, color.RGBA{255, 255, 255, 255}, true)
+		vector.StrokeRect(screen, x-r/2, y+r/3, r, r/4, line/2, ink, true)
+	case avatarChaotic:
+		// Mismatched eyes and a zigzag mouth
+		vector.DrawFilledCircle(screen, x-eyeX, eyeY, r/4, color.RGBA{255, 255, 255, 255}, true)
+		vector.DrawFilledCircle(screen, x-eyeX, eyeY, r/10, ink, true)
+		vector.DrawFilledCircle(screen, x+eyeX, eyeY, r/10, ink, true)
+		for i := float32(0); i < 4; i++ {
+			dy := r / 8
+			if int(i)%2 == 1 {
+				dy = -dy
+			}
+			vector.StrokeLine(screen, x-r/2+i*r/4, y+r/2-dy, x-r/4+i*r/4, y+r/2+dy, line, ink, true)
+		}
+	default:
+		// Dot eyes and a straight mouth
+		vector.DrawFilledCircle(screen, x-eyeX, eyeY, r/8, ink, true)
+		vector.DrawFilledCircle(screen, x+eyeX, eyeY, r/8, ink, true)
+		vector.StrokeLine(screen, x-r/3, y+r/2, x+r/3, y+r/2, line, ink, true)
+	}
+}
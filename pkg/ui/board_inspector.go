@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+const (
+	inspectorX, inspectorY          = 440, 50
+	inspectorWidth, inspectorHeight = 190, 128
+
+	// inspectorLabelTileSize is the smallest tile that fits index and root labels;
+	// smaller boards only describe the tile under the pointer
+	inspectorLabelTileSize = 36
+)
+
+// BoardInspector is a debug overlay showing tile indices, union-find roots and
+// component counts, for diagnosing connectivity bugs
+type BoardInspector struct {
+	visible bool
+}
+
+func NewBoardInspector() *BoardInspector {
+	return &BoardInspector{}
+}
+
+func (bi *BoardInspector) Toggle() {
+	bi.visible = !bi.visible
+}
+
+func (bi *BoardInspector) IsVisible() bool {
+	return bi.visible
+}
+
+// Draw labels the board's tiles and shows its statistics; pointerX and pointerY
+// pick the tile described in detail
+func (bi *BoardInspector) Draw(screen *ebiten.Image, board *island.Board, render *systems.RenderSystem, pointerX, pointerY int) {
+	if !bi.visible || board == nil {
+		return
+	}
+
+	tileSize := render.TileSize()
+	if tileSize >= inspectorLabelTileSize {
+		for y := 0; y < board.Height; y++ {
+			for x := 0; x < board.Width; x++ {
+				bi.drawTileLabels(screen, board, render, x, y)
+			}
+		}
+	}
+
+	stats := bi.stats(board)
+	lines := []string{
+		fmt.Sprintf("FPS %.1f  TPS %.1f", ebiten.ActualFPS(), ebiten.ActualTPS()),
+		fmt.Sprintf("Board %dx%d, tile %dpx", board.Width, board.Height, tileSize),
+		fmt.Sprintf("Island groups: %d", board.IslandGroupCount()),
+		fmt.Sprintf("Networks: %d (%d bridges)", stats.networks, stats.bridges),
+		fmt.Sprintf("UF components: %d", board.UnionFind.ComponentCount()),
+		fmt.Sprintf("Land tiles: %d", len(board.Islands)),
+	}
+
+	gridX, gridY := render.ScreenToGrid(pointerX, pointerY)
+	if tile := board.GetTile(gridX, gridY); tile != nil {
+		idx := gridY*board.Width + gridX
+		lines = append(lines, fmt.Sprintf("(%d,%d) #%d root %d", gridX, gridY, idx, board.UnionFind.Find(idx)))
+	}
+
+	vector.DrawFilledRect(screen, inspectorX, inspectorY, inspectorWidth, inspectorHeight, color.RGBA{0, 0, 0, 180}, false)
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, inspectorX+6, inspectorY+4+i*16)
+	}
+}
+
+// drawTileLabels prints a tile's index and, for land and bridges, its union-find root
+func (bi *BoardInspector) drawTileLabels(screen *ebiten.Image, board *island.Board, render *systems.RenderSystem, x, y int) {
+	tile := board.GetTile(x, y)
+	if tile == nil {
+		return
+	}
+
+	idx := y*board.Width + x
+	cx, cy := render.TileCenter(x, y)
+	half := render.TileSize() / 2
+	left, top := int(cx)-half+2, int(cy)-half+1
+	ebitenutil.DebugPrintAt(screen, fmt.Sprint(idx), left, top)
+	if tile.Type == island.TileLand || tile.Type == island.TileBridge {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("r%d", board.UnionFind.Find(idx)), left, top+14)
+	}
+}
+
+type inspectorStats struct {
+	networks int // Distinct groups of land and bridge tiles
+	bridges  int
+}
+
+func (bi *BoardInspector) stats(board *island.Board) inspectorStats {
+	var stats inspectorStats
+	roots := make(map[int]bool)
+	for idx, tile := range board.Tiles {
+		if tile.Type != island.TileLand && tile.Type != island.TileBridge {
+			continue
+		}
+		if tile.Type == island.TileBridge {
+			stats.bridges++
+		}
+		roots[board.UnionFind.Find(idx)] = true
+	}
+	stats.networks = len(roots)
+	return stats
+}
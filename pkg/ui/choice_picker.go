@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Choice is one row of a ChoicePicker
+type Choice struct {
+	Label    string
+	Detail   string // Drawn right-aligned in the row
+	Disabled bool
+}
+
+const (
+	choiceX, choiceWidth = 140, 360
+	choiceRowX           = choiceX + 20
+	choiceRowWidth       = choiceWidth - 40
+	choiceRowHeight      = 30
+	choiceRowSpacing     = 38
+	choiceTitleHeight    = 45
+	choiceFooterHeight   = dialogButtonHeight + 30
+)
+
+// ChoicePicker is a modal list of choices with a Cancel button. It takes every
+// click while open; OnChoose runs after it closes with the index of the choice.
+type ChoicePicker struct {
+	Title    string
+	Choices  []Choice
+	OnChoose func(index int)
+
+	open           bool
+	hoverX, hoverY int
+}
+
+func NewChoicePicker() *ChoicePicker {
+	return &ChoicePicker{}
+}
+
+func (cp *ChoicePicker) Show() {
+	cp.open = true
+}
+
+func (cp *ChoicePicker) IsOpen() bool {
+	return cp.open
+}
+
+// UpdateHover records the pointer position so rows and buttons can highlight under it
+func (cp *ChoicePicker) UpdateHover(x, y int) {
+	cp.hoverX, cp.hoverY = x, y
+}
+
+// bounds centres the panel vertically around its rows
+func (cp *ChoicePicker) bounds() (y, height int) {
+	height = choiceTitleHeight + len(cp.Choices)*choiceRowSpacing + choiceFooterHeight
+	return (480 - height) / 2, height
+}
+
+func (cp *ChoicePicker) rowY(index int) int {
+	y, _ := cp.bounds()
+	return y + choiceTitleHeight + index*choiceRowSpacing
+}
+
+func (cp *ChoicePicker) cancelY() int {
+	y, height := cp.bounds()
+	return y + height - dialogButtonHeight - 15
+}
+
+func (cp *ChoicePicker) cancelX() int {
+	return choiceX + (choiceWidth-dialogButtonWidth)/2
+}
+
+func (cp *ChoicePicker) HandleClick(x, y int) bool {
+	if !cp.open {
+		return false
+	}
+
+	for i, choice := range cp.Choices {
+		if !choice.Disabled && inRect(x, y, choiceRowX, cp.rowY(i), choiceRowWidth, choiceRowHeight) {
+			cp.open = false
+			if cp.OnChoose != nil {
+				cp.OnChoose(i)
+			}
+			return true
+		}
+	}
+	if inRect(x, y, cp.cancelX(), cp.cancelY(), dialogButtonWidth, dialogButtonHeight) {
+		cp.open = false
+	}
+	return true
+}
+
+func (cp *ChoicePicker) Draw(screen *ebiten.Image) {
+	if !cp.open {
+		return
+	}
+
+	y, height := cp.bounds()
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	vector.DrawFilledRect(screen, choiceX, float32(y), choiceWidth, float32(height), color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, choiceX, float32(y), choiceWidth, float32(height), 3, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, cp.Title, choiceX+20, y+15)
+
+	for i, choice := range cp.Choices {
+		rowY := cp.rowY(i)
+		bg := color.Color(color.RGBA{225, 225, 225, 255})
+		if choice.Disabled {
+			bg = color.RGBA{200, 200, 200, 255}
+		} else if inRect(cp.hoverX, cp.hoverY, choiceRowX, rowY, choiceRowWidth, choiceRowHeight) {
+			bg = brighten(bg)
+		}
+		vector.DrawFilledRect(screen, choiceRowX, float32(rowY), choiceRowWidth, choiceRowHeight, bg, false)
+		vector.StrokeRect(screen, choiceRowX, float32(rowY), choiceRowWidth, choiceRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, choice.Label, choiceRowX+10, rowY+choiceRowHeight/2-8)
+		ebitenutil.DebugPrintAt(screen, choice.Detail, choiceRowX+choiceRowWidth-10-len(choice.Detail)*6, rowY+choiceRowHeight/2-8)
+	}
+
+	x, buttonY := cp.cancelX(), cp.cancelY()
+	bg := color.Color(color.RGBA{200, 200, 200, 255})
+	if inRect(cp.hoverX, cp.hoverY, x, buttonY, dialogButtonWidth, dialogButtonHeight) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(buttonY), dialogButtonWidth, dialogButtonHeight, bg, false)
+	vector.StrokeRect(screen, float32(x), float32(buttonY), dialogButtonWidth, dialogButtonHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Cancel", x+(dialogButtonWidth-6*6)/2, buttonY+dialogButtonHeight/2-4)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	dialogX, dialogY          = 140, 150
+	dialogWidth, dialogHeight = 360, 160
+	dialogButtonWidth         = 120
+	dialogButtonHeight        = 30
+	dialogButtonY             = dialogY + dialogHeight - dialogButtonHeight - 15
+)
+
+// ConfirmDialog is a modal question with one or two buttons. It takes every
+// click while open; OnConfirm or OnCancel runs after it closes.
+type ConfirmDialog struct {
+	Title        string
+	Lines        []string
+	ConfirmLabel string
+	CancelLabel  string // Empty shows only the confirm button
+	OnConfirm    func()
+	OnCancel     func()
+	Destructive  bool // The confirm button is red, as it deletes something
+
+	open           bool
+	hoverX, hoverY int
+}
+
+// NewConfirmDialog creates a closed dialog
+func NewConfirmDialog() *ConfirmDialog {
+	return &ConfirmDialog{}
+}
+
+func (cd *ConfirmDialog) Show() {
+	cd.open = true
+}
+
+// Confirm asks before a destructive action, warning that it cannot be undone.
+// Notes add lines under the warning; onConfirm runs only if it is confirmed.
+func (cd *ConfirmDialog) Confirm(title, confirmLabel string, onConfirm func(), notes ...string) {
+	cd.Title = title
+	cd.Lines = append([]string{"Are you sure? This cannot be undone."}, notes...)
+	cd.ConfirmLabel = confirmLabel
+	cd.CancelLabel = "Cancel"
+	cd.OnConfirm = onConfirm
+	cd.OnCancel = nil
+	cd.Destructive = true
+	cd.Show()
+}
+
+func (cd *ConfirmDialog) IsOpen() bool {
+	return cd.open
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (cd *ConfirmDialog) UpdateHover(x, y int) {
+	cd.hoverX, cd.hoverY = x, y
+}
+
+func (cd *ConfirmDialog) confirmX() int {
+	if cd.CancelLabel == "" {
+		return dialogX + (dialogWidth-dialogButtonWidth)/2
+	}
+	return dialogX + dialogWidth/2 - dialogButtonWidth - 10
+}
+
+func (cd *ConfirmDialog) cancelX() int {
+	return dialogX + dialogWidth/2 + 10
+}
+
+func (cd *ConfirmDialog) HandleClick(x, y int) bool {
+	if !cd.open {
+		return false
+	}
+
+	if inRect(x, y, cd.confirmX(), dialogButtonY, dialogButtonWidth, dialogButtonHeight) {
+		cd.open = false
+		if cd.OnConfirm != nil {
+			cd.OnConfirm()
+		}
+	} else if cd.CancelLabel != "" && inRect(x, y, cd.cancelX(), dialogButtonY, dialogButtonWidth, dialogButtonHeight) {
+		cd.open = false
+		if cd.OnCancel != nil {
+			cd.OnCancel()
+		}
+	}
+	return true
+}
+
+func (cd *ConfirmDialog) Draw(screen *ebiten.Image) {
+	if !cd.open {
+		return
+	}
+
+	// Dark overlay
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+
+	vector.DrawFilledRect(screen, dialogX, dialogY, dialogWidth, dialogHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, dialogX, dialogY, dialogWidth, dialogHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+
+	ebitenutil.DebugPrintAt(screen, cd.Title, dialogX+20, dialogY+15)
+	for i, line := range cd.Lines {
+		ebitenutil.DebugPrintAt(screen, line, dialogX+20, dialogY+40+i*16)
+	}
+
+	confirmColor := color.RGBA{100, 200, 100, 255}
+	if cd.Destructive {
+		confirmColor = color.RGBA{220, 90, 90, 255}
+	}
+	cd.drawButton(screen, cd.confirmX(), cd.ConfirmLabel, confirmColor)
+	if cd.CancelLabel != "" {
+		cd.drawButton(screen, cd.cancelX(), cd.CancelLabel, color.RGBA{200, 200, 200, 255})
+	}
+}
+
+func (cd *ConfirmDialog) drawButton(screen *ebiten.Image, x int, label string, bgColor color.Color) {
+	if inRect(cd.hoverX, cd.hoverY, x, dialogButtonY, dialogButtonWidth, dialogButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), dialogButtonY, dialogButtonWidth, dialogButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, float32(x), dialogButtonY, dialogButtonWidth, dialogButtonHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, x+(dialogButtonWidth-len(label)*6)/2, dialogButtonY+dialogButtonHeight/2-4)
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+const (
+	consoleHeight     = 200
+	consoleLineHeight = 14
+	consoleMaxLines   = 12
+	consoleMaxInput   = 100
+	consoleToggleChar = '`'
+)
+
+// Console is the developer console: a line of typed commands over a scrolling log.
+// OnCommand runs each entered line and returns the text to log.
+type Console struct {
+	open    bool
+	input   []rune
+	log     []string
+	history []string
+	recall  int // Index into history while browsing with Up/Down
+
+	OnCommand func(line string) string
+}
+
+func NewConsole() *Console {
+	return &Console{}
+}
+
+func (c *Console) Toggle() {
+	c.open = !c.open
+}
+
+func (c *Console) IsOpen() bool {
+	return c.open
+}
+
+// Print adds a line to the log
+func (c *Console) Print(line string) {
+	c.log = append(c.log, strings.Split(line, "\n")...)
+	if len(c.log) > consoleMaxLines {
+		c.log = c.log[len(c.log)-consoleMaxLines:]
+	}
+}
+
+// HandleText feeds typing into the command line; Enter runs it
+func (c *Console) HandleText(text systems.TextInput) {
+	if !c.open {
+		return
+	}
+
+	for _, char := range text.Chars {
+		// The toggle key types a backquote too; the debug font only covers printable ASCII
+		if char != consoleToggleChar && char >= ' ' && char <= '~' && len(c.input) < consoleMaxInput {
+			c.input = append(c.input, char)
+		}
+	}
+	if text.Backspace && len(c.input) > 0 {
+		c.input = c.input[:len(c.input)-1]
+	}
+	if text.Enter {
+		c.submit()
+	}
+}
+
+// HandleHistory steps through previously entered commands, -1 for older and 1 for newer
+func (c *Console) HandleHistory(step int) {
+	if !c.open || len(c.history) == 0 {
+		return
+	}
+
+	c.recall += step
+	if c.recall < 0 {
+		c.recall = 0
+	}
+	if c.recall >= len(c.history) {
+		c.recall = len(c.history)
+		c.input = nil
+		return
+	}
+	c.input = []rune(c.history[c.recall])
+}
+
+func (c *Console) submit() {
+	line := strings.TrimSpace(string(c.input))
+	c.input = nil
+	if line == "" {
+		return
+	}
+
+	c.history = append(c.history, line)
+	c.recall = len(c.history)
+	c.Print("> " + line)
+	if c.OnCommand != nil {
+		if output := c.OnCommand(line); output != "" {
+			c.Print(output)
+		}
+	}
+}
+
+func (c *Console) Draw(screen *ebiten.Image) {
+	if !c.open {
+		return
+	}
+
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), consoleHeight, color.RGBA{0, 0, 0, 200}, false)
+	vector.StrokeLine(screen, 0, consoleHeight, float32(bounds.Dx()), consoleHeight, 1, color.RGBA{100, 200, 100, 255}, false)
+
+	for i, line := range c.log {
+		ebitenutil.DebugPrintAt(screen, line, 8, 6+i*consoleLineHeight)
+	}
+	ebitenutil.DebugPrintAt(screen, "] "+string(c.input)+"_", 8, consoleHeight-consoleLineHeight-4)
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// Controls tab layout, relative to the settings panel
+const (
+	controlRowTop     = 115
+	controlRowHeight  = 19
+	controlBindingX   = 200
+	controlBindingW   = 150
+	controlResetX     = 280
+	controlResetY     = 86
+	controlResetWidth = 100
+)
+
+// IsCapturingBinding reports whether a control is waiting for its new key or button
+func (slui *SaveLoadUI) IsCapturingBinding() bool {
+	return slui.showPanel && slui.capturing != ""
+}
+
+// CaptureBinding assigns input to the control being rebound. Escape cancels, and
+// inputs already used by another control or a fixed shortcut are refused.
+func (slui *SaveLoadUI) CaptureBinding(input string) {
+	control := slui.capturing
+	if control == "" {
+		return
+	}
+	slui.capturing = ""
+
+	if input == ebiten.KeyEscape.String() {
+		slui.showStatus("Rebinding cancelled")
+		return
+	}
+	if owner := slui.bindings.Conflict(control, input); owner != "" {
+		slui.showStatus(input + " is already used by " + owner)
+		return
+	}
+
+	slui.bindings[control] = input
+	slui.settings.KeyBindings = slui.bindings.Settings()
+	slui.saveSettings()
+	slui.showStatus(control.Label() + ": " + input)
+}
+
+func (slui *SaveLoadUI) handleControlsClick(x, y, panelX, panelY int) bool {
+	if inRect(x, y, panelX+controlResetX, panelY+controlResetY, controlResetWidth, 18) {
+		slui.bindings = systems.DefaultBindings()
+		slui.settings.KeyBindings = nil
+		slui.saveSettings()
+		slui.showStatus("Controls reset to defaults")
+		return true
+	}
+
+	for i, control := range systems.Controls {
+		rowY := panelY + controlRowTop + i*controlRowHeight
+		if inRect(x, y, panelX+controlBindingX, rowY, controlBindingW, controlRowHeight-3) {
+			slui.capturing = control
+			slui.showStatus("Press a key or mouse button (Esc cancels)")
+			return true
+		}
+	}
+	return true
+}
+
+func (slui *SaveLoadUI) drawControlsTab(screen *ebiten.Image, panelX, panelY int) {
+	ebitenutil.DebugPrintAt(screen, "Controls", panelX+20, panelY+90)
+	slui.drawButton(screen, panelX+controlResetX, panelY+controlResetY, controlResetWidth, 18, "Reset Defaults", color.RGBA{200, 200, 200, 255})
+
+	for i, control := range systems.Controls {
+		rowY := panelY + controlRowTop + i*controlRowHeight
+		ebitenutil.DebugPrintAt(screen, control.Label(), panelX+30, rowY+3)
+
+		label := slui.bindings[control]
+		bgColor := color.RGBA{220, 220, 230, 255}
+		if control == slui.capturing {
+			label = "Press a key..."
+			bgColor = color.RGBA{255, 215, 0, 255}
+		}
+		slui.drawButton(screen, panelX+controlBindingX, rowY, controlBindingW, controlRowHeight-3, label, bgColor)
+	}
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"image/color"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// customSort orders the custom level list
+type customSort int
+
+const (
+	customSortManual customSort = iota // Collection order, the only order that can be rearranged
+	customSortRating
+	customSortSize
+	customSortDifficulty
+	customSortCount
+)
+
+var customSortNames = []string{"Manual", "Rating", "Size", "Difficulty"}
+
+// difficultyFilters cycles through the difficulty filter values; "" shows all
+var difficultyFilters = []string{"", "beginner", "intermediate", "expert", "master"}
+
+var difficultyRank = map[string]int{"beginner": 0, "intermediate": 1, "expert": 2, "master": 3}
+
+const (
+	toolbarHeight = 22
+	tagMenuWidth  = 100
+	tagMenuRow    = 16
+)
+
+// visibleIDs returns the selected collection's levels after filtering and sorting
+func (clui *CustomLevelsUI) visibleIDs() []string {
+	collection := clui.currentCollection()
+	if collection == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(collection.LevelIDs))
+	for _, id := range collection.LevelIDs {
+		level, ok := clui.levels[id]
+		if !ok {
+			continue
+		}
+		if clui.difficultyFilter != "" && level.DifficultyLabel() != clui.difficultyFilter {
+			continue
+		}
+		if !clui.filter.match(levelFacts{
+			Name:      level.Name,
+			Completed: level.Completions > 0,
+			Width:     level.Width,
+			Height:    level.Height,
+		}) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	less := map[customSort]func(a, b storage.CustomLevel) bool{
+		customSortRating: func(a, b storage.CustomLevel) bool { return a.Rating > b.Rating },
+		customSortSize:   func(a, b storage.CustomLevel) bool { return a.Width*a.Height < b.Width*b.Height },
+		customSortDifficulty: func(a, b storage.CustomLevel) bool {
+			return difficultyRank[a.DifficultyLabel()] < difficultyRank[b.DifficultyLabel()]
+		},
+	}[clui.sortMode]
+	if less != nil {
+		sort.SliceStable(ids, func(i, j int) bool {
+			return less(clui.levels[ids[i]], clui.levels[ids[j]])
+		})
+	}
+	return ids
+}
+
+// isManualOrder reports whether the list shows the collection exactly as stored
+func (clui *CustomLevelsUI) isManualOrder() bool {
+	return clui.sortMode == customSortManual && clui.difficultyFilter == "" && !clui.filter.active()
+}
+
+func (clui *CustomLevelsUI) toolbarButtons() []struct {
+	x, width int
+	text     string
+} {
+	difficulty := "All"
+	if clui.difficultyFilter != "" {
+		difficulty = clui.difficultyFilter
+	}
+	return []struct {
+		x, width int
+		text     string
+	}{
+		{customListX, 110, "Sort: " + customSortNames[clui.sortMode]},
+		{customListX + 116, 110, "Diff: " + difficulty},
+		{customListX + 232, 98, clui.filter.progressLabel()},
+	}
+}
+
+func (clui *CustomLevelsUI) handleToolbarClick(x, y int) bool {
+	for i, button := range clui.toolbarButtons() {
+		if !inRect(x, y, button.x, customToolbarY, button.width, toolbarHeight) {
+			continue
+		}
+
+		switch i {
+		case 0:
+			clui.sortMode = (clui.sortMode + 1) % customSortCount
+		case 1:
+			for j, filter := range difficultyFilters {
+				if filter == clui.difficultyFilter {
+					clui.difficultyFilter = difficultyFilters[(j+1)%len(difficultyFilters)]
+					break
+				}
+			}
+		case 2:
+			clui.filter.cycleProgress()
+		}
+		clui.filter.resetCursor()
+		clui.scrollOffset = 0
+		return true
+	}
+	return false
+}
+
+func (clui *CustomLevelsUI) drawToolbar(screen *ebiten.Image) {
+	clui.filter.drawSearchBox(screen, customListX, customFilterY)
+	drawFilterButtons(screen, clui.filterButtons(), customFilterY, clui.hoverX, clui.hoverY)
+
+	for _, button := range clui.toolbarButtons() {
+		btnColor := color.Color(color.RGBA{210, 210, 230, 255})
+		if inRect(clui.hoverX, clui.hoverY, button.x, customToolbarY, button.width, toolbarHeight) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(button.x), customToolbarY, float32(button.width), toolbarHeight, btnColor, false)
+		vector.StrokeRect(screen, float32(button.x), customToolbarY, float32(button.width), toolbarHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, button.text, button.x+6, customToolbarY+4)
+	}
+}
+
+// filterButtons lays out the row under the toolbar, right of the search box
+func (clui *CustomLevelsUI) filterButtons() []filterButton {
+	return []filterButton{
+		{customListX + searchBoxWidth + 6, 84, clui.filter.sizeLabel(), clui.filter.cycleSize},
+	}
+}
+
+// handleRowControlClick handles the rating stars and history and tag buttons of a row
+func (clui *CustomLevelsUI) handleRowControlClick(levelID string, x, y int) bool {
+	for i, id := range clui.visibleIDs() {
+		if id != levelID {
+			continue
+		}
+		rowY := clui.rowY(i)
+
+		starsX := customListX + customRowWidth - 5*starSize - 42
+		if inRect(x, y, starsX, rowY+2, 5*starSize, starSize+2) {
+			rating := (x-starsX)/starSize + 1
+			if rating == clui.levels[levelID].Rating {
+				rating = 0 // Clicking the current rating clears it
+			}
+			if err := clui.saveSystem.RateCustomLevel(levelID, rating); err != nil {
+				clui.statusMessage = "Rating failed: " + err.Error()
+			}
+			clui.reload()
+			return true
+		}
+
+		if inRect(x, y, customListX+customRowWidth-36, rowY+3, 14, 12) {
+			clui.openHistory(levelID)
+			return true
+		}
+		if inRect(x, y, customListX+customRowWidth-18, rowY+3, 14, 12) {
+			clui.tagMenuLevel = levelID
+			clui.tagMenuX = customListX + customRowWidth - tagMenuWidth
+			clui.tagMenuY = rowY + 16
+			if bottom := clui.tagMenuY + len(storage.CustomTagPresets)*tagMenuRow; bottom > customPanelY+customPanelHeight {
+				clui.tagMenuY = rowY - len(storage.CustomTagPresets)*tagMenuRow
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// handleTagMenuClick toggles the clicked tag; clicking elsewhere closes the menu
+func (clui *CustomLevelsUI) handleTagMenuClick(x, y int) {
+	for i, tag := range storage.CustomTagPresets {
+		if inRect(x, y, clui.tagMenuX, clui.tagMenuY+i*tagMenuRow, tagMenuWidth, tagMenuRow) {
+			if err := clui.saveSystem.ToggleCustomLevelTag(clui.tagMenuLevel, tag); err != nil {
+				clui.statusMessage = "Tagging failed: " + err.Error()
+			}
+			clui.reload()
+			return
+		}
+	}
+	clui.tagMenuLevel = ""
+}
+
+func (clui *CustomLevelsUI) drawTagMenu(screen *ebiten.Image) {
+	level, ok := clui.levels[clui.tagMenuLevel]
+	if clui.tagMenuLevel == "" || !ok {
+		return
+	}
+
+	height := len(storage.CustomTagPresets) * tagMenuRow
+	vector.DrawFilledRect(screen, float32(clui.tagMenuX), float32(clui.tagMenuY), tagMenuWidth, float32(height), color.RGBA{255, 255, 255, 250}, false)
+	vector.StrokeRect(screen, float32(clui.tagMenuX), float32(clui.tagMenuY), tagMenuWidth, float32(height), 1, color.RGBA{100, 100, 100, 255}, false)
+
+	for i, tag := range storage.CustomTagPresets {
+		mark := "[ ] "
+		for _, existing := range level.Tags {
+			if existing == tag {
+				mark = "[x] "
+			}
+		}
+		ebitenutil.DebugPrintAt(screen, mark+tag, clui.tagMenuX+6, clui.tagMenuY+i*tagMenuRow+1)
+	}
+}
@@ -0,0 +1,564 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+const (
+	customPanelX, customPanelY          = 50, 30
+	customPanelWidth, customPanelHeight = 540, 420
+
+	folderListX     = customPanelX + 20
+	folderListY     = customPanelY + 50
+	folderRowWidth  = 140
+	folderRowHeight = 30
+
+	customToolbarY   = customPanelY + 50
+	customFilterY    = customToolbarY + toolbarHeight + 4
+	customListX      = customPanelX + 180
+	customListY      = customPanelY + 110
+	customRowWidth   = 330
+	customRowHeight  = 36
+	customListHeight = 294
+
+	starSize = 11 // Width of one rating star in a level row
+
+	dragThreshold = 5 // Pixels the pointer must move before a press becomes a drag
+)
+
+// CustomLevelsUI browses custom levels by collection. Levels can be dragged to
+// reorder them within a collection or dropped on another collection to move them.
+type CustomLevelsUI struct {
+	saveSystem     *storage.SaveSystem
+	collections    []storage.LevelCollection
+	levels         map[string]storage.CustomLevel
+	published      map[string]bool // IDs of levels the profile has exported in a pack
+	selected       int
+	scrollOffset   float64
+	showPanel      bool
+	hoverX, hoverY int
+	statusMessage  string
+
+	// Drag state: pressedLevel is set on press, dragging once the pointer moves far enough
+	pressedLevel   string
+	pressX, pressY int
+	dragging       bool
+
+	// Sorting and filtering of the level list
+	sortMode         customSort
+	difficultyFilter string // Empty shows every difficulty
+	filter           levelFilter
+
+	// Tag menu opened from a level row
+	tagMenuLevel       string
+	tagMenuX, tagMenuY int
+
+	// History panel opened from a level row
+	historyLevel string
+	history      []storage.LevelRevision // Newest first
+	historyPick  int
+
+	confirm *ConfirmDialog
+
+	OnLevelSelected func(*storage.CustomLevel)
+	OnBack          func()
+	OnExported      func(collectionID string) // Called after a collection is exported as a level pack
+}
+
+func NewCustomLevelsUI(saveSystem *storage.SaveSystem) *CustomLevelsUI {
+	return &CustomLevelsUI{
+		saveSystem: saveSystem,
+		levels:     make(map[string]storage.CustomLevel),
+		confirm:    NewConfirmDialog(),
+		filter:     newLevelFilter(),
+	}
+}
+
+func (clui *CustomLevelsUI) Show() {
+	clui.showPanel = true
+	clui.scrollOffset = 0
+	clui.statusMessage = ""
+	clui.filter.resetCursor()
+	clui.reload()
+}
+
+func (clui *CustomLevelsUI) Hide() {
+	clui.showPanel = false
+	clui.pressedLevel = ""
+	clui.dragging = false
+	clui.tagMenuLevel = ""
+	clui.historyLevel = ""
+}
+
+func (clui *CustomLevelsUI) IsShown() bool {
+	return clui.showPanel
+}
+
+// reload refreshes collections and levels from storage
+func (clui *CustomLevelsUI) reload() {
+	collections, err := clui.saveSystem.LoadCollections()
+	if err != nil {
+		clui.statusMessage = "Failed to load collections"
+		return
+	}
+	clui.collections = collections
+
+	levels, _ := clui.saveSystem.LoadCustomLevels()
+	clui.levels = make(map[string]storage.CustomLevel, len(levels))
+	for _, level := range levels {
+		clui.levels[level.ID] = level
+	}
+
+	published, _ := clui.saveSystem.LoadPublishedLevels()
+	clui.published = make(map[string]bool, len(published))
+	for _, level := range published {
+		clui.published[level.LevelID] = true
+	}
+
+	if clui.selected >= len(clui.collections) {
+		clui.selected = 0
+	}
+}
+
+func (clui *CustomLevelsUI) currentCollection() *storage.LevelCollection {
+	if clui.selected < 0 || clui.selected >= len(clui.collections) {
+		return nil
+	}
+	return &clui.collections[clui.selected]
+}
+
+// UpdateHover records the pointer position; while dragging it also tracks the drag
+func (clui *CustomLevelsUI) UpdateHover(x, y int) {
+	clui.hoverX, clui.hoverY = x, y
+	clui.confirm.UpdateHover(x, y)
+
+	if clui.pressedLevel != "" && !clui.dragging {
+		dx, dy := x-clui.pressX, y-clui.pressY
+		if dx*dx+dy*dy >= dragThreshold*dragThreshold {
+			clui.dragging = true
+		}
+	}
+}
+
+func (clui *CustomLevelsUI) HandleScroll(deltaY float64) {
+	if !clui.showPanel {
+		return
+	}
+	clui.scrollOffset += deltaY * scrollStep
+	clui.scrollOffset = clampScroll(clui.scrollOffset, clui.contentHeight(), customListHeight)
+}
+
+func (clui *CustomLevelsUI) contentHeight() float64 {
+	return float64(len(clui.visibleIDs()) * customRowHeight)
+}
+
+// HandleClick handles a press; level rows start a potential drag that resolves on release
+func (clui *CustomLevelsUI) HandleClick(x, y int) bool {
+	if !clui.showPanel {
+		return false
+	}
+	if clui.confirm.HandleClick(x, y) {
+		return true
+	}
+
+	// Clicking outside or on the back button closes the browser
+	if x < customPanelX || x > customPanelX+customPanelWidth || y < customPanelY || y > customPanelY+customPanelHeight ||
+		(x >= customPanelX+customPanelWidth-40 && x <= customPanelX+customPanelWidth-10 && y >= customPanelY+10 && y <= customPanelY+40) {
+		clui.Hide()
+		if clui.OnBack != nil {
+			clui.OnBack()
+		}
+		return true
+	}
+
+	if clui.tagMenuLevel != "" {
+		clui.handleTagMenuClick(x, y)
+		return true
+	}
+	if clui.historyLevel != "" {
+		clui.handleHistoryClick(x, y)
+		return true
+	}
+
+	if clui.handleToolbarClick(x, y) {
+		return true
+	}
+	if clickFilterButtons(clui.filterButtons(), customFilterY, x, y) {
+		clui.scrollOffset = 0
+		return true
+	}
+
+	if i := clui.folderAt(x, y); i >= 0 {
+		clui.selected = i
+		clui.scrollOffset = 0
+		clui.filter.resetCursor()
+		return true
+	}
+
+	buttonY := clui.folderButtonsY()
+	if inRect(x, y, folderListX, buttonY, folderRowWidth, 24) {
+		clui.createFolder()
+		return true
+	}
+	if inRect(x, y, folderListX, buttonY+30, folderRowWidth, 24) {
+		clui.exportFolder()
+		return true
+	}
+	if inRect(x, y, folderListX, buttonY+60, folderRowWidth, 24) {
+		clui.confirmDeleteFolder()
+		return true
+	}
+
+	if id, ok := clui.levelAt(x, y); ok {
+		if clui.handleRowControlClick(id, x, y) {
+			return true
+		}
+		clui.pressedLevel = id
+		clui.pressX, clui.pressY = x, y
+		clui.dragging = false
+	}
+	return true
+}
+
+// HandleText types into the search box; the arrow keys pick a level and Enter plays it
+func (clui *CustomLevelsUI) HandleText(text systems.TextInput) {
+	if !clui.showPanel || clui.confirm.IsOpen() || clui.historyLevel != "" {
+		return
+	}
+	if clui.filter.typeText(text) {
+		clui.scrollOffset = 0
+	}
+
+	visible := clui.visibleIDs()
+	if i, ok := clui.filter.navigate(text, len(visible), 1); ok {
+		if level, ok := clui.levels[visible[i]]; ok && clui.OnLevelSelected != nil {
+			clui.Hide()
+			clui.OnLevelSelected(&level)
+		}
+		return
+	}
+
+	// Scroll the picked level into view
+	if cursor := clui.filter.cursor; cursor >= 0 {
+		top := float64(cursor * customRowHeight)
+		if top < clui.scrollOffset {
+			clui.scrollOffset = top
+		} else if bottom := top + customRowHeight; bottom > clui.scrollOffset+customListHeight {
+			clui.scrollOffset = bottom - customListHeight
+		}
+	}
+}
+
+// HandleRelease finishes a press: a drag drops the level, a plain click plays it
+func (clui *CustomLevelsUI) HandleRelease(x, y int) {
+	if clui.pressedLevel == "" {
+		return
+	}
+	levelID := clui.pressedLevel
+	wasDragging := clui.dragging
+	clui.pressedLevel = ""
+	clui.dragging = false
+
+	if !wasDragging {
+		level, ok := clui.levels[levelID]
+		if ok && clui.OnLevelSelected != nil {
+			clui.Hide()
+			clui.OnLevelSelected(&level)
+		}
+		return
+	}
+
+	if i := clui.folderAt(x, y); i >= 0 {
+		clui.moveLevel(levelID, clui.collections[i].ID, len(clui.collections[i].LevelIDs))
+		return
+	}
+
+	if collection := clui.currentCollection(); collection != nil && inRect(x, y, customListX, customListY, customRowWidth, customListHeight) {
+		if !clui.isManualOrder() {
+			clui.statusMessage = "Reordering needs manual sort and no filters"
+			return
+		}
+		clui.moveLevel(levelID, collection.ID, clui.dropIndex(levelID, y))
+	}
+}
+
+// dropIndex returns the insertion index for a drop at y, ignoring the dragged row itself
+func (clui *CustomLevelsUI) dropIndex(levelID string, y int) int {
+	collection := clui.currentCollection()
+	index := int((float64(y-customListY)+clui.scrollOffset)/customRowHeight + 0.5)
+	for i, id := range collection.LevelIDs {
+		if id == levelID && i < index {
+			index--
+			break
+		}
+	}
+	return index
+}
+
+func (clui *CustomLevelsUI) moveLevel(levelID, collectionID string, index int) {
+	if err := clui.saveSystem.MoveCustomLevel(levelID, collectionID, index); err != nil {
+		clui.statusMessage = "Move failed: " + err.Error()
+		return
+	}
+	clui.reload()
+}
+
+func (clui *CustomLevelsUI) createFolder() {
+	name := fmt.Sprintf("Folder %d", len(clui.collections))
+	if _, err := clui.saveSystem.CreateCollection(name); err != nil {
+		clui.statusMessage = "Create failed: " + err.Error()
+		return
+	}
+	clui.reload()
+	clui.selected = len(clui.collections) - 1
+	clui.scrollOffset = 0
+}
+
+func (clui *CustomLevelsUI) exportFolder() {
+	collection := clui.currentCollection()
+	if collection == nil {
+		return
+	}
+	fileName, err := clui.saveSystem.ExportCollectionFile(collection.ID)
+	if err != nil {
+		clui.statusMessage = "Export failed: " + err.Error()
+		return
+	}
+	clui.statusMessage = "Exported " + fileName
+	clui.reload() // Levels made here are now published
+	if clui.OnExported != nil {
+		clui.OnExported(collection.ID)
+	}
+}
+
+// confirmDeleteFolder asks before deleting the selected collection
+func (clui *CustomLevelsUI) confirmDeleteFolder() {
+	collection := clui.currentCollection()
+	if collection == nil || collection.ID == storage.DefaultCollectionID {
+		clui.statusMessage = "Unsorted cannot be deleted"
+		return
+	}
+	id := collection.ID
+	clui.confirm.Confirm("Delete folder "+collection.Name+"?", "Delete", func() {
+		clui.deleteFolder(id)
+	}, "Its levels move to Unsorted.")
+}
+
+func (clui *CustomLevelsUI) deleteFolder(id string) {
+	if err := clui.saveSystem.DeleteCollection(id); err != nil {
+		clui.statusMessage = "Delete failed: " + err.Error()
+		return
+	}
+	clui.selected = 0
+	clui.scrollOffset = 0
+	clui.reload()
+}
+
+func (clui *CustomLevelsUI) folderButtonsY() int {
+	return folderListY + len(clui.collections)*folderRowHeight + 10
+}
+
+// folderAt returns the index of the collection row under (x, y), or -1
+func (clui *CustomLevelsUI) folderAt(x, y int) int {
+	for i := range clui.collections {
+		if inRect(x, y, folderListX, folderListY+i*folderRowHeight, folderRowWidth, folderRowHeight-4) {
+			return i
+		}
+	}
+	return -1
+}
+
+// levelAt returns the ID of the level row under (x, y) in the selected collection
+func (clui *CustomLevelsUI) levelAt(x, y int) (string, bool) {
+	if !inRect(x, y, customListX, customListY, customRowWidth, customListHeight) {
+		return "", false
+	}
+	for i, id := range clui.visibleIDs() {
+		rowY := clui.rowY(i)
+		if inRect(x, y, customListX, rowY, customRowWidth, customRowHeight-4) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func (clui *CustomLevelsUI) rowY(i int) int {
+	return int(float64(customListY+i*customRowHeight) - clui.scrollOffset)
+}
+
+func inRect(x, y, rx, ry, width, height int) bool {
+	slop := touchSlop()
+	return x >= rx-slop && x <= rx+width+slop && y >= ry-slop && y <= ry+height+slop
+}
+
+func (clui *CustomLevelsUI) Draw(screen *ebiten.Image) {
+	if !clui.showPanel {
+		return
+	}
+
+	// Panel background
+	vector.DrawFilledRect(screen, customPanelX, customPanelY, customPanelWidth, customPanelHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, customPanelX, customPanelY, customPanelWidth, customPanelHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+
+	// Title
+	ebitenutil.DebugPrintAt(screen, "Custom Levels", customPanelX+20, customPanelY+15)
+
+	// Back button
+	vector.DrawFilledRect(screen, customPanelX+customPanelWidth-40, customPanelY+10, 30, 30, color.RGBA{200, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "←", customPanelX+customPanelWidth-30, customPanelY+20)
+
+	clui.drawFolders(screen)
+	clui.drawLevels(screen)
+	clui.drawTagMenu(screen)
+	clui.drawHistory(screen)
+
+	if clui.statusMessage != "" {
+		ebitenutil.DebugPrintAt(screen, clui.statusMessage, customPanelX+20, customPanelY+customPanelHeight-20)
+	}
+
+	clui.confirm.Draw(screen)
+}
+
+func (clui *CustomLevelsUI) drawFolders(screen *ebiten.Image) {
+	for i, collection := range clui.collections {
+		y := folderListY + i*folderRowHeight
+
+		bgColor := color.RGBA{200, 200, 200, 255}
+		if i == clui.selected {
+			bgColor = color.RGBA{150, 150, 250, 255}
+		}
+		// Highlight the folder a dragged level would drop into
+		if clui.dragging && clui.folderAt(clui.hoverX, clui.hoverY) == i {
+			bgColor = color.RGBA{139, 195, 74, 255}
+		}
+
+		vector.DrawFilledRect(screen, float32(folderListX), float32(y), folderRowWidth, folderRowHeight-4, bgColor, false)
+		vector.StrokeRect(screen, float32(folderListX), float32(y), folderRowWidth, folderRowHeight-4, 1, color.RGBA{100, 100, 100, 255}, false)
+
+		label := fmt.Sprintf("%s (%d)", collection.Name, len(collection.LevelIDs))
+		ebitenutil.DebugPrintAt(screen, label, folderListX+8, y+8)
+	}
+
+	buttonY := clui.folderButtonsY()
+	buttons := []struct {
+		text  string
+		color color.RGBA
+	}{
+		{"+ New Folder", color.RGBA{100, 200, 100, 255}},
+		{"Export Pack", color.RGBA{100, 200, 200, 255}},
+		{"Delete Folder", color.RGBA{200, 100, 100, 255}},
+	}
+	for i, button := range buttons {
+		y := buttonY + i*30
+		btnColor := color.Color(button.color)
+		if inRect(clui.hoverX, clui.hoverY, folderListX, y, folderRowWidth, 24) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(folderListX), float32(y), folderRowWidth, 24, btnColor, false)
+		ebitenutil.DebugPrintAt(screen, button.text, folderListX+(folderRowWidth-len(button.text)*6)/2, y+6)
+	}
+}
+
+func (clui *CustomLevelsUI) drawLevels(screen *ebiten.Image) {
+	collection := clui.currentCollection()
+	if collection == nil {
+		return
+	}
+
+	clui.drawToolbar(screen)
+
+	visible := clui.visibleIDs()
+	if len(collection.LevelIDs) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No levels here yet.", customListX+10, customListY+10)
+		ebitenutil.DebugPrintAt(screen, "Export from the editor or drag levels in.", customListX+10, customListY+26)
+		return
+	}
+	if len(visible) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No levels match the filters.", customListX+10, customListY+10)
+		return
+	}
+
+	for i, id := range visible {
+		y := clui.rowY(i)
+		if y < customListY || y+customRowHeight > customListY+customListHeight {
+			continue
+		}
+
+		bgColor := color.RGBA{255, 255, 255, 255}
+		if id == clui.pressedLevel && clui.dragging {
+			bgColor = color.RGBA{220, 220, 220, 255}
+		} else if !clui.dragging && (i == clui.filter.cursor || inRect(clui.hoverX, clui.hoverY, customListX, y, customRowWidth, customRowHeight-4)) {
+			bgColor = color.RGBA{235, 235, 255, 255}
+		}
+		clui.drawLevelRow(screen, id, customListX, y, bgColor)
+	}
+
+	drawScrollbar(screen, float64(customListX+customRowWidth+4), customListY, customListHeight,
+		clui.contentHeight(), customListHeight, clui.scrollOffset)
+
+	if !clui.dragging {
+		return
+	}
+
+	// Insertion marker when dropping inside the list
+	if clui.isManualOrder() && inRect(clui.hoverX, clui.hoverY, customListX, customListY, customRowWidth, customListHeight) {
+		index := int((float64(clui.hoverY-customListY)+clui.scrollOffset)/customRowHeight + 0.5)
+		markerY := float32(clui.rowY(index) - 2)
+		vector.StrokeLine(screen, float32(customListX), markerY, float32(customListX+customRowWidth), markerY, 2, color.RGBA{50, 100, 200, 255}, false)
+	}
+
+	// The dragged row follows the pointer
+	clui.drawLevelRow(screen, clui.pressedLevel, clui.hoverX-customRowWidth/2, clui.hoverY-customRowHeight/2, color.RGBA{255, 248, 220, 230})
+}
+
+func (clui *CustomLevelsUI) drawLevelRow(screen *ebiten.Image, id string, x, y int, bgColor color.Color) {
+	level, ok := clui.levels[id]
+	if !ok {
+		return
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), customRowWidth, customRowHeight-4, bgColor, false)
+	vector.StrokeRect(screen, float32(x), float32(y), customRowWidth, customRowHeight-4, 1, color.RGBA{150, 150, 150, 255}, false)
+
+	ebitenutil.DebugPrintAt(screen, truncateText(level.Name, 35), x+8, y+4)
+	info := fmt.Sprintf("%dx%d %s  plays %d wins %d", level.Width, level.Height, level.DifficultyLabel(), level.PlayCount, level.Completions)
+	if level.Author != "" {
+		info += "  by " + level.Author
+	} else if clui.published[id] {
+		info += "  published"
+	}
+	if len(level.Tags) > 0 {
+		info += "  #" + strings.Join(level.Tags, " #")
+	}
+	ebitenutil.DebugPrintAt(screen, truncateText(info, (customRowWidth-16)/6), x+8, y+17)
+
+	// Rating stars and the history and tag buttons sit at the right end of the name line
+	starsX := x + customRowWidth - 5*starSize - 42
+	for i := 0; i < 5; i++ {
+		starColor := color.RGBA{200, 200, 200, 255}
+		if i < level.Rating {
+			starColor = color.RGBA{255, 215, 0, 255}
+		}
+		vector.DrawFilledRect(screen, float32(starsX+i*starSize), float32(y+5), starSize-3, starSize-3, starColor, false)
+	}
+	vector.StrokeRect(screen, float32(x+customRowWidth-36), float32(y+3), 14, 12, 1, color.RGBA{120, 120, 120, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "H", x+customRowWidth-32, y+2)
+	vector.StrokeRect(screen, float32(x+customRowWidth-18), float32(y+3), 14, 12, 1, color.RGBA{120, 120, 120, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "#", x+customRowWidth-14, y+2)
+}
+
+// truncateText shortens text to at most maxChars characters of the debug font
+func truncateText(text string, maxChars int) string {
+	if len(text) <= maxChars {
+		return text
+	}
+	return text[:maxChars-2] + ".."
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	defeatX, defeatY          = 110, 95
+	defeatWidth, defeatHeight = 420, 290
+	defeatBannerHeight        = 70
+	defeatStatsY              = defeatY + defeatBannerHeight + 40
+	defeatButtonY             = defeatY + defeatHeight - dialogButtonHeight - 20
+	defeatButtonSpacing       = 15
+	defeatTitleSize           = 3
+)
+
+// DefeatScreen covers a lost game with why it was lost, how far the attempt
+// got and where to go next. It takes every click while open; the chosen
+// button's callback runs after it closes.
+type DefeatScreen struct {
+	Title         string   // Drawn large across the banner, e.g. "Time's up!"
+	Reason        string   // One line under the banner explaining the loss
+	Stats         []string // The attempt's statistics
+	OnRetry       func()
+	OnLevelSelect func()
+	OnMenu        func()
+
+	open           bool
+	hoverX, hoverY int
+}
+
+// NewDefeatScreen creates a closed defeat screen
+func NewDefeatScreen() *DefeatScreen {
+	return &DefeatScreen{}
+}
+
+func (ds *DefeatScreen) Show() {
+	ds.open = true
+}
+
+func (ds *DefeatScreen) Hide() {
+	ds.open = false
+}
+
+func (ds *DefeatScreen) IsOpen() bool {
+	return ds.open
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (ds *DefeatScreen) UpdateHover(x, y int) {
+	ds.hoverX, ds.hoverY = x, y
+}
+
+// defeatButton is one of the buttons along the bottom of the defeat screen
+type defeatButton struct {
+	label   string
+	onClick func()
+	bgColor color.Color
+}
+
+// buttons lists the screen's buttons from left to right
+func (ds *DefeatScreen) buttons() []defeatButton {
+	return []defeatButton{
+		{"Retry", ds.OnRetry, color.RGBA{100, 200, 100, 255}},
+		{"Level Select", ds.OnLevelSelect, color.RGBA{100, 150, 220, 255}},
+		{"Menu", ds.OnMenu, color.RGBA{200, 200, 200, 255}},
+	}
+}
+
+func (ds *DefeatScreen) buttonX(index int) int {
+	total := 3*dialogButtonWidth + 2*defeatButtonSpacing
+	return defeatX + (defeatWidth-total)/2 + index*(dialogButtonWidth+defeatButtonSpacing)
+}
+
+func (ds *DefeatScreen) HandleClick(x, y int) bool {
+	if !ds.open {
+		return false
+	}
+
+	for i, button := range ds.buttons() {
+		if inRect(x, y, ds.buttonX(i), defeatButtonY, dialogButtonWidth, dialogButtonHeight) {
+			ds.open = false
+			if button.onClick != nil {
+				button.onClick()
+			}
+			break
+		}
+	}
+	return true
+}
+
+func (ds *DefeatScreen) Draw(screen *ebiten.Image) {
+	if !ds.open {
+		return
+	}
+
+	// A red wash sets defeat apart from the grey dialogs
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{60, 0, 0, 150}, false)
+	vector.DrawFilledRect(screen, defeatX, defeatY, defeatWidth, defeatHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.DrawFilledRect(screen, defeatX, defeatY, defeatWidth, defeatBannerHeight, color.RGBA{170, 40, 40, 255}, false)
+	vector.StrokeRect(screen, defeatX, defeatY, defeatWidth, defeatHeight, 3, color.RGBA{100, 30, 30, 255}, false)
+
+	titleWidth := len(ds.Title) * scaled(hudCharWidth) * defeatTitleSize
+	titleHeight := scaled(hudLineHeight) * defeatTitleSize
+	printLarge(screen, ds.Title, defeatX+(defeatWidth-titleWidth)/2, defeatY+(defeatBannerHeight-titleHeight)/2, defeatTitleSize, color.White)
+
+	ebitenutil.DebugPrintAt(screen, ds.Reason, defeatX+(defeatWidth-len(ds.Reason)*hudCharWidth)/2, defeatY+defeatBannerHeight+12)
+	for i, line := range ds.Stats {
+		ebitenutil.DebugPrintAt(screen, line, defeatX+60, defeatStatsY+i*hudLineHeight)
+	}
+
+	for i, button := range ds.buttons() {
+		ds.drawButton(screen, ds.buttonX(i), button.label, button.bgColor)
+	}
+}
+
+func (ds *DefeatScreen) drawButton(screen *ebiten.Image, x int, label string, bgColor color.Color) {
+	if inRect(ds.hoverX, ds.hoverY, x, defeatButtonY, dialogButtonWidth, dialogButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), defeatButtonY, dialogButtonWidth, dialogButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, float32(x), defeatButtonY, dialogButtonWidth, dialogButtonHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, x+(dialogButtonWidth-len(label)*6)/2, defeatButtonY+dialogButtonHeight/2-4)
+}
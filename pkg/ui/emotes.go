@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Emote is a quick message sent to the other racer
+type Emote int
+
+const (
+	EmoteGG Emote = iota
+	EmoteNice
+	EmoteShocked // 😱
+	EmoteHurry   // ⏱️
+	emoteCount
+)
+
+var emoteNames = []string{"GG", "Nice!", "Shocked", "Hurry"}
+
+func (e Emote) String() string {
+	if e < 0 || e >= emoteCount {
+		return "Unknown"
+	}
+	return emoteNames[e]
+}
+
+// EmoteBubble is an emote floating up from a racer's progress bar
+type EmoteBubble struct {
+	Emote    Emote
+	Opponent bool    // Sent by the other racer rather than the player
+	Age      float64 // 0 when sent, 1 when it disappears
+}
+
+// The Emote button sits above the plan buttons; its wheel opens to the left,
+// with the four emotes around a mute toggle
+const (
+	emoteButtonTop    = planButtonTop - planButtonHeight - planButtonGap
+	emoteWheelX       = 500
+	emoteWheelY       = 330
+	emoteWheelRadius  = 64
+	emoteOptionSpread = 40
+	emoteOptionWidth  = 40
+	emoteOptionHeight = 24
+	emoteMuteWidth    = 44
+	emoteBubbleWidth  = 40
+	emoteBubbleHeight = 20
+	emoteBubbleRise   = 24
+)
+
+// emoteOffsets place the emotes at the top, right, bottom and left of the wheel
+var emoteOffsets = [emoteCount][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// EmoteWheel is the in-game button that opens a wheel of emotes while racing
+type EmoteWheel struct {
+	OnEmote func(Emote)
+	OnMute  func()
+
+	open           bool
+	hoverX, hoverY int
+}
+
+func NewEmoteWheel() *EmoteWheel {
+	return &EmoteWheel{}
+}
+
+// IsOpen reports whether the wheel is showing its emotes
+func (ew *EmoteWheel) IsOpen() bool {
+	return ew.open
+}
+
+// Close folds the wheel back into its button
+func (ew *EmoteWheel) Close() {
+	ew.open = false
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (ew *EmoteWheel) UpdateHover(x, y int) {
+	ew.hoverX, ew.hoverY = x, y
+}
+
+func emoteOptionRect(emote Emote) (x, y int) {
+	offset := emoteOffsets[emote]
+	x = emoteWheelX + offset[0]*emoteOptionSpread - emoteOptionWidth/2
+	y = emoteWheelY + offset[1]*emoteOptionSpread - emoteOptionHeight/2
+	return x, y
+}
+
+func emoteMuteRect() (x, y int) {
+	return emoteWheelX - emoteMuteWidth/2, emoteWheelY - emoteOptionHeight/2
+}
+
+// HandleClick opens or closes the wheel and sends the clicked emote. While the
+// wheel is open it takes every click, so a click beside it only closes it.
+func (ew *EmoteWheel) HandleClick(x, y int) bool {
+	if inRect(x, y, planBarX, emoteButtonTop, planButtonWidth, planButtonHeight) {
+		ew.open = !ew.open
+		return true
+	}
+	if !ew.open {
+		return false
+	}
+	ew.open = false
+
+	for emote := Emote(0); emote < emoteCount; emote++ {
+		ox, oy := emoteOptionRect(emote)
+		if inRect(x, y, ox, oy, emoteOptionWidth, emoteOptionHeight) {
+			if ew.OnEmote != nil {
+				ew.OnEmote(emote)
+			}
+			return true
+		}
+	}
+	mx, my := emoteMuteRect()
+	if inRect(x, y, mx, my, emoteMuteWidth, emoteOptionHeight) && ew.OnMute != nil {
+		ew.OnMute()
+	}
+	return true
+}
+
+// Draw shows the button and, when open, the wheel; muted lights the mute toggle
+func (ew *EmoteWheel) Draw(screen *ebiten.Image, muted bool) {
+	buttonColor := color.Color(color.RGBA{220, 220, 220, 255})
+	if ew.open {
+		buttonColor = color.RGBA{250, 210, 120, 255}
+	}
+	ew.drawButton(screen, planBarX, emoteButtonTop, planButtonWidth, planButtonHeight, buttonColor)
+	ebitenutil.DebugPrintAt(screen, "Emote", planBarX+(planButtonWidth-5*hudCharWidth)/2, emoteButtonTop+planButtonHeight/2-8)
+
+	if !ew.open {
+		return
+	}
+	vector.DrawFilledCircle(screen, emoteWheelX, emoteWheelY, emoteWheelRadius, color.RGBA{0, 0, 0, 140}, true)
+	for emote := Emote(0); emote < emoteCount; emote++ {
+		x, y := emoteOptionRect(emote)
+		ew.drawButton(screen, x, y, emoteOptionWidth, emoteOptionHeight, color.RGBA{240, 240, 240, 255})
+		drawEmote(screen, emote, x+emoteOptionWidth/2, y+emoteOptionHeight/2)
+	}
+
+	label, muteColor := "Mute", color.RGBA{200, 200, 200, 255}
+	if muted {
+		label, muteColor = "Unmute", color.RGBA{230, 120, 120, 255}
+	}
+	x, y := emoteMuteRect()
+	ew.drawButton(screen, x, y, emoteMuteWidth, emoteOptionHeight, muteColor)
+	ebitenutil.DebugPrintAt(screen, label, x+(emoteMuteWidth-len(label)*hudCharWidth)/2, y+emoteOptionHeight/2-8)
+}
+
+func (ew *EmoteWheel) drawButton(screen *ebiten.Image, x, y, width, height int, bgColor color.Color) {
+	if inRect(ew.hoverX, ew.hoverY, x, y, width, height) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), bgColor, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(width), float32(height), 1, color.RGBA{100, 100, 100, 255}, false)
+}
+
+// drawEmote draws an emote centred on (cx, cy). The debug font has no emoji,
+// so the face and the stopwatch are drawn by hand.
+func drawEmote(screen *ebiten.Image, emote Emote, cx, cy int) {
+	x, y := float32(cx), float32(cy)
+	outline := color.RGBA{60, 60, 60, 255}
+	switch emote {
+	case EmoteShocked:
+		vector.DrawFilledCircle(screen, x, y, 8, color.RGBA{255, 210, 60, 255}, true)
+		vector.StrokeCircle(screen, x, y, 8, 1, outline, true)
+		vector.DrawFilledCircle(screen, x-3, y-2, 1.5, outline, true)
+		vector.DrawFilledCircle(screen, x+3, y-2, 1.5, outline, true)
+		vector.DrawFilledCircle(screen, x, y+3.5, 2.5, outline, true)
+	case EmoteHurry:
+		vector.DrawFilledRect(screen, x-2, y-11, 4, 3, outline, false)
+		vector.DrawFilledCircle(screen, x, y+1, 8, color.RGBA{255, 255, 255, 255}, true)
+		vector.StrokeCircle(screen, x, y+1, 8, 1.5, outline, true)
+		angle := -math.Pi / 4
+		vector.StrokeLine(screen, x, y+1, x+6*float32(math.Cos(angle)), y+1+6*float32(math.Sin(angle)), 1.5, color.RGBA{220, 60, 60, 255}, true)
+	default:
+		text := emote.String()
+		ebitenutil.DebugPrintAt(screen, text, cx-len(text)*hudCharWidth/2, cy-8)
+	}
+}
+
+// drawEmoteBubble draws a bubble rising from (x, y) as it ages, edged in the
+// colour of its racer's bar
+func drawEmoteBubble(screen *ebiten.Image, bubble EmoteBubble, x, y int, edge color.Color) {
+	if bubble.Age < 0 || bubble.Age >= 1 {
+		return
+	}
+	top := y - emoteBubbleHeight - 4 - int(bubble.Age*emoteBubbleRise)
+	left := x - emoteBubbleWidth/2
+	vector.DrawFilledRect(screen, float32(x-2), float32(top+emoteBubbleHeight), 4, 4, edge, false)
+	vector.DrawFilledRect(screen, float32(left), float32(top), emoteBubbleWidth, emoteBubbleHeight, color.RGBA{255, 255, 255, 235}, false)
+	vector.StrokeRect(screen, float32(left), float32(top), emoteBubbleWidth, emoteBubbleHeight, 1.5, edge, false)
+	drawEmote(screen, bubble.Emote, x, top+emoteBubbleHeight/2)
+}
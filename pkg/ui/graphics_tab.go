@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// tpsOptions are the selectable update rates
+var tpsOptions = []int{30, storage.DefaultTPS}
+
+// Graphics tab layout, relative to the settings panel
+const (
+	graphicsTPSY        = 140
+	graphicsAmbientY    = 180
+	graphicsReducedY    = 210
+	graphicsPowerSaveY  = 240
+	graphicsVSyncX      = 190
+	graphicsCheckboxX   = 30
+	graphicsTPSButtonW  = 60
+	graphicsTPSSpacing  = 70
+	graphicsCheckboxLen = 20
+)
+
+func (slui *SaveLoadUI) handleGraphicsClick(x, y, panelX, panelY int) bool {
+	for i, tps := range tpsOptions {
+		if inRect(x, y, panelX+graphicsCheckboxX+i*graphicsTPSSpacing, panelY+graphicsTPSY, graphicsTPSButtonW, 20) {
+			slui.settings.TargetTPS = tps
+			slui.saveSettings()
+			slui.showStatus(fmt.Sprintf("Frame rate: %d", tps))
+			return true
+		}
+	}
+
+	toggles := []struct {
+		x, y    int
+		setting *bool
+	}{
+		{graphicsVSyncX, graphicsTPSY, &slui.settings.DisableVSync},
+		{graphicsCheckboxX, graphicsAmbientY, &slui.settings.DisableAmbient},
+		{graphicsCheckboxX, graphicsReducedY, &slui.settings.ReducedEffects},
+		{graphicsCheckboxX, graphicsPowerSaveY, &slui.settings.PowerSaving},
+		{graphicsVSyncX, graphicsAmbientY, &slui.settings.DoNotDisturb},
+		{graphicsVSyncX, graphicsReducedY, &slui.settings.AutoAdvance},
+	}
+	for _, toggle := range toggles {
+		if inRect(x, y, panelX+toggle.x, panelY+toggle.y, graphicsCheckboxLen, graphicsCheckboxLen) {
+			*toggle.setting = !*toggle.setting
+			slui.saveSettings()
+			slui.showStatus("Settings saved!")
+			return true
+		}
+	}
+	return true
+}
+
+func (slui *SaveLoadUI) drawGraphicsTab(screen *ebiten.Image, panelX, panelY int) {
+	ebitenutil.DebugPrintAt(screen, "Graphics", panelX+20, panelY+90)
+
+	// Options overridden by power saving are shown with their effective value
+	quality := slui.settings.Graphics()
+
+	ebitenutil.DebugPrintAt(screen, "Frame rate:", panelX+graphicsCheckboxX, panelY+graphicsTPSY-18)
+	for i, tps := range tpsOptions {
+		bgColor := color.RGBA{150, 150, 150, 255}
+		if quality.TPS == tps {
+			bgColor = color.RGBA{100, 200, 100, 255}
+		}
+		slui.drawButton(screen, panelX+graphicsCheckboxX+i*graphicsTPSSpacing, panelY+graphicsTPSY, graphicsTPSButtonW, 20, fmt.Sprintf("%d", tps), bgColor)
+	}
+	slui.drawCheckbox(screen, panelX+graphicsVSyncX, panelY+graphicsTPSY, quality.VSync, "VSync")
+
+	slui.drawCheckbox(screen, panelX+graphicsCheckboxX, panelY+graphicsAmbientY, quality.AmbientAnimations, "Ambient animations")
+	slui.drawCheckbox(screen, panelX+graphicsCheckboxX, panelY+graphicsReducedY, quality.ReducedEffects, "Reduced effects")
+	slui.drawCheckbox(screen, panelX+graphicsCheckboxX, panelY+graphicsPowerSaveY, slui.settings.PowerSaving, "Power saving")
+	slui.drawCheckbox(screen, panelX+graphicsVSyncX, panelY+graphicsAmbientY, slui.settings.DoNotDisturb, "Do not disturb")
+	slui.drawCheckbox(screen, panelX+graphicsVSyncX, panelY+graphicsReducedY, slui.settings.AutoAdvance, "Auto-play next level")
+	ebitenutil.DebugPrintAt(screen, "Power saving caps the frame rate at 30, turns\noff ambient animations and effects, and pauses\nthe game while its window or tab is hidden.", panelX+graphicsCheckboxX, panelY+graphicsPowerSaveY+30)
+}
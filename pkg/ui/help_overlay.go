@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// HelpAnnotation labels one HUD element in the help overlay
+type HelpAnnotation struct {
+	X, Y          int
+	Width, Height int
+	Label         string
+	LabelX        int
+	LabelY        int
+}
+
+// HelpOverlay dims the screen and explains what each HUD element does
+type HelpOverlay struct {
+	visible bool
+}
+
+func NewHelpOverlay() *HelpOverlay {
+	return &HelpOverlay{}
+}
+
+func (ho *HelpOverlay) Toggle() {
+	ho.visible = !ho.visible
+}
+
+func (ho *HelpOverlay) Hide() {
+	ho.visible = false
+}
+
+func (ho *HelpOverlay) IsVisible() bool {
+	return ho.visible
+}
+
+func (ho *HelpOverlay) Draw(screen *ebiten.Image, annotations []HelpAnnotation) {
+	if !ho.visible {
+		return
+	}
+
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), color.RGBA{0, 0, 0, 150}, false)
+
+	highlight := color.RGBA{255, 215, 0, 255}
+	for _, annotation := range annotations {
+		vector.StrokeRect(
+			screen,
+			float32(annotation.X), float32(annotation.Y),
+			float32(annotation.Width), float32(annotation.Height),
+			2,
+			highlight,
+			false,
+		)
+
+		// Connect the label to the element it describes
+		vector.StrokeLine(
+			screen,
+			float32(annotation.LabelX), float32(annotation.LabelY+6),
+			float32(annotation.X+annotation.Width/2), float32(annotation.Y+annotation.Height/2),
+			1,
+			highlight,
+			false,
+		)
+		DrawTooltipBox(screen, annotation.Label, annotation.LabelX, annotation.LabelY)
+	}
+
+	footer := "Press H or ? to close help"
+	ebitenutil.DebugPrintAt(screen, footer, bounds.Dx()/2-len(footer)*3, bounds.Dy()-20)
+}
@@ -0,0 +1,519 @@
+package ui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// HUDAnchor names a screen region the HUD lays text out in
+type HUDAnchor int
+
+const (
+	HUDTopLeft HUDAnchor = iota
+	HUDTopRight
+	HUDBottom
+	HUDRace
+	HUDResults
+	HUDMoveTimes
+	HUDRecord
+	HUDSplits
+	HUDCountdown
+	HUDObjectives
+	HUDPlayer
+)
+
+const (
+	hudMargin      = 10
+	hudButtonBar   = 45 // Settings and achievement buttons occupy the top strip
+	hudLineHeight  = 16
+	hudCharWidth   = 6
+	hudRaceRow     = 18
+	hudResultsTop  = 320 // Just under the victory stars
+	hudChartHeight = 40
+	hudRecordTop   = 214 // Just above the victory message
+	hudPlayerTop   = 180 // Above the record banner
+	hudCountdown   = 2   // Countdown text size relative to the HUD's
+)
+
+// The countdown turns amber, then red and beating, as time runs out
+const (
+	countdownCaution = 30 * time.Second
+	countdownDanger  = 10 * time.Second
+)
+
+// RaceStatus is the progress shown when racing the AI
+type RaceStatus struct {
+	PlayerProgress float64
+	AIProgress     float64
+	AIName         string
+	AIMoves        int
+	AIPersonality  int           // ai.Personality, which picks the avatar at the tip of the AI's bar
+	Online         bool          // The opponent is a player online: named on its own, without an avatar
+	Taunt          string        // What the AI is saying; empty when it is quiet
+	Bubbles        []EmoteBubble // Emotes floating from the bars; the opponent's rise from theirs
+}
+
+// Countdown is the clock of a timed game
+type Countdown struct {
+	Left     time.Duration // Negative once the limit has passed in overtime
+	Overtime bool          // The game goes on past the limit
+}
+
+// SplitRow is one level of a speedrun in the split timer
+type SplitRow struct {
+	Name    string
+	Time    time.Duration // Run time at the end of the level, so far on the current one, or the best's split ahead of it
+	Delta   time.Duration // Against the personal best's split
+	HasBest bool          // A personal best exists, so Delta is shown
+	Gold    bool          // The level was finished faster than ever before in a run
+	Done    bool
+	Current bool
+}
+
+// ObjectiveLine is one objective on the checklist
+type ObjectiveLine struct {
+	Text   string
+	Met    bool
+	Failed bool
+}
+
+// HUDData is everything the in-game HUD displays for one frame
+type HUDData struct {
+	ModeName   string
+	Moves      int
+	Time       time.Duration
+	Points     int
+	Combo      int // Current combo multiplier; shown once above 1
+	BestMoves  int // Personal best on this level; zero hides the comparison
+	BestTime   time.Duration
+	Connected  int      // Islands in the largest connected group
+	Islands    int      // Islands on the board; touching land counts as one
+	Traffic    int      // Travelers delivered in the last minute; zero hides the line
+	HideTime   bool     // Relaxed mode shows no clock
+	Extras     []string // Mode-specific lines shown under the mode name
+	Hints      []string
+	Race       *RaceStatus
+	Countdown  *Countdown      // Time left in timed games; nil hides it
+	Splits     []SplitRow      // Speedrun split timer; empty outside speedruns
+	Objectives []ObjectiveLine // Level objectives as a checklist; empty for mode games
+	Results    []string        // Score breakdown shown on the victory screen
+
+	MoveTimes    []time.Duration // Thinking time per move, charted under the results
+	SlowestMove  int             // Index of the bar to highlight
+	RecordBanner string          // New records set by this win
+	Player       string          // Player's name and worn title on the victory screen
+}
+
+// HUD draws in-game stats in regions anchored to the screen edges and the board,
+// so text never collides with the top buttons or runs over the board.
+type HUD struct {
+	regions map[HUDAnchor]image.Rectangle
+}
+
+func NewHUD() *HUD {
+	return &HUD{
+		regions: make(map[HUDAnchor]image.Rectangle),
+	}
+}
+
+// Region returns where an anchored block was placed during the last Layout
+func (h *HUD) Region(anchor HUDAnchor) (image.Rectangle, bool) {
+	rect, ok := h.regions[anchor]
+	return rect, ok && !rect.Empty()
+}
+
+// Layout positions every anchored block for the given screen and board bounds
+func (h *HUD) Layout(screenWidth, screenHeight int, board image.Rectangle, data HUDData) {
+	h.regions = make(map[HUDAnchor]image.Rectangle)
+
+	// Top-left: player stats below the button bar
+	stats := h.statsLines(data)
+	h.regions[HUDTopLeft] = textBlock(hudMargin, hudButtonBar, stats)
+
+	// Top-right: mode and timer, right-aligned below the button bar
+	modeLines := append([]string{data.ModeName}, data.Extras...)
+	modeRect := textBlock(0, hudButtonBar, modeLines)
+	h.regions[HUDTopRight] = modeRect.Add(image.Pt(screenWidth-hudMargin-modeRect.Dx(), 0))
+
+	// Countdown: large and centred in the gap between the top buttons
+	if data.Countdown != nil {
+		size := scaled(hudCharWidth) * hudCountdown
+		text := countdownText(*data.Countdown)
+		width := len(text) * size
+		h.regions[HUDCountdown] = image.Rect((screenWidth-width)/2, hudMargin, (screenWidth+width)/2, hudMargin+scaled(hudLineHeight)*hudCountdown)
+	}
+
+	// Objectives: right-aligned under the mode, with room for a marker before each line
+	rightTop := h.regions[HUDTopRight].Max.Y
+	if len(data.Objectives) > 0 {
+		objectives := textBlock(0, rightTop+scaled(8), objectiveTexts(data.Objectives))
+		h.regions[HUDObjectives] = objectives.Add(image.Pt(screenWidth-hudMargin-objectives.Dx(), 0))
+		rightTop = h.regions[HUDObjectives].Max.Y
+	}
+
+	// Splits: right-aligned under the mode and objectives, with room for a marker before each row
+	if len(data.Splits) > 0 {
+		splits := textBlock(0, rightTop+scaled(8), splitLines(data.Splits))
+		h.regions[HUDSplits] = splits.Add(image.Pt(screenWidth-hudMargin-splits.Dx(), 0))
+	}
+
+	// Record banner: centred above the victory message
+	if data.RecordBanner != "" {
+		banner := textBlock(0, hudRecordTop, []string{data.RecordBanner})
+		h.regions[HUDRecord] = banner.Add(image.Pt((screenWidth-banner.Dx())/2, 0))
+	}
+
+	// Player: centred above the record banner
+	if data.Player != "" {
+		player := textBlock(0, hudPlayerTop, []string{data.Player})
+		h.regions[HUDPlayer] = player.Add(image.Pt((screenWidth-player.Dx())/2, 0))
+	}
+
+	// Results: centred under the victory stars
+	if len(data.Results) > 0 {
+		results := textBlock(0, 0, data.Results)
+		h.regions[HUDResults] = results.Add(image.Pt((screenWidth-results.Dx())/2, hudResultsTop))
+
+		if len(data.MoveTimes) > 0 {
+			top := h.regions[HUDResults].Max.Y + scaled(12)
+			h.regions[HUDMoveTimes] = image.Rect(h.regions[HUDResults].Min.X, top, h.regions[HUDResults].Max.X, top+scaled(hudChartHeight))
+		}
+	}
+
+	// Bottom: race bars hug the bottom edge under a row for the AI's taunts,
+	// hints sit between them and the board
+	bottom := screenHeight - hudMargin
+	if data.Race != nil {
+		race := image.Rect(hudMargin, bottom-scaled(hudRaceRow)*3, screenWidth-hudMargin, bottom)
+		h.regions[HUDRace] = race
+		bottom = race.Min.Y - 4
+	}
+
+	if len(data.Hints) > 0 {
+		hints := textBlock(0, 0, data.Hints)
+		x := (screenWidth - hints.Dx()) / 2
+		if board.Max.Y+hints.Dy() <= bottom {
+			h.regions[HUDBottom] = hints.Add(image.Pt(x, bottom-hints.Dy()))
+		} else {
+			// No room below the board: use the gap between the top buttons instead
+			h.regions[HUDBottom] = hints.Add(image.Pt(x, hudMargin+2))
+		}
+	}
+}
+
+func (h *HUD) statsLines(data HUDData) []string {
+	lines := []string{fmt.Sprintf("Moves: %d", data.Moves)}
+	if data.Islands > 0 {
+		lines = append(lines, fmt.Sprintf("%d of %d islands connected", data.Connected, data.Islands))
+	}
+	if data.Traffic > 0 {
+		lines = append(lines, fmt.Sprintf("Traffic: %d/min", data.Traffic))
+	}
+	if !data.HideTime {
+		lines = append(lines, fmt.Sprintf("Time: %02d:%02d", int(data.Time.Minutes()), int(data.Time.Seconds())%60))
+	}
+	if data.BestMoves > 0 {
+		lines = append(lines, fmt.Sprintf("%+d moves vs best", data.Moves-data.BestMoves))
+	}
+	if data.BestTime > 0 && !data.HideTime {
+		lines = append(lines, formatDelta(data.Time-data.BestTime)+" vs best")
+	}
+	lines = append(lines, fmt.Sprintf("Score: %d", data.Points))
+	if data.Combo > 1 {
+		lines = append(lines, fmt.Sprintf("Combo x%d", data.Combo))
+	}
+	return lines
+}
+
+// Draw lays out and renders the HUD
+func (h *HUD) Draw(screen *ebiten.Image, board image.Rectangle, data HUDData) {
+	bounds := screen.Bounds()
+	h.Layout(bounds.Dx(), bounds.Dy(), board, data)
+
+	drawLines(screen, h.regions[HUDTopLeft], h.statsLines(data))
+	drawLines(screen, h.regions[HUDTopRight], append([]string{data.ModeName}, data.Extras...))
+
+	if rect, ok := h.Region(HUDBottom); ok {
+		drawLines(screen, rect, data.Hints)
+	}
+
+	if rect, ok := h.Region(HUDRace); ok && data.Race != nil {
+		h.drawRace(screen, rect, data.Race)
+	}
+
+	if rect, ok := h.Region(HUDResults); ok {
+		panel := rect.Inset(-scaled(8))
+		vector.DrawFilledRect(screen, float32(panel.Min.X), float32(panel.Min.Y), float32(panel.Dx()), float32(panel.Dy()), color.RGBA{0, 0, 0, 160}, false)
+		drawLines(screen, rect, data.Results)
+	}
+
+	if rect, ok := h.Region(HUDRecord); ok {
+		panel := rect.Inset(-scaled(6))
+		vector.DrawFilledRect(screen, float32(panel.Min.X), float32(panel.Min.Y), float32(panel.Dx()), float32(panel.Dy()), color.RGBA{200, 130, 0, 230}, false)
+		drawLines(screen, rect, []string{data.RecordBanner})
+	}
+
+	if rect, ok := h.Region(HUDPlayer); ok {
+		drawLines(screen, rect, []string{data.Player})
+	}
+
+	if rect, ok := h.Region(HUDMoveTimes); ok {
+		drawMoveTimes(screen, rect, data.MoveTimes, data.SlowestMove)
+	}
+
+	if rect, ok := h.Region(HUDObjectives); ok {
+		drawObjectives(screen, rect, data.Objectives)
+	}
+
+	if rect, ok := h.Region(HUDSplits); ok {
+		drawSplits(screen, rect, data.Splits)
+	}
+
+	if rect, ok := h.Region(HUDCountdown); ok && data.Countdown != nil {
+		drawCountdown(screen, rect, *data.Countdown)
+	}
+}
+
+// countdownText shows the time left as m:ss, counting up past the limit in overtime
+func countdownText(c Countdown) string {
+	if c.Left < 0 {
+		over := -c.Left
+		return fmt.Sprintf("OT +%d:%02d", int(over.Minutes()), int(over.Seconds())%60)
+	}
+	// Round up so the clock reads 0:00 only once time is up
+	left := (c.Left + time.Second - 1).Truncate(time.Second)
+	return fmt.Sprintf("%d:%02d", int(left.Minutes()), int(left.Seconds())%60)
+}
+
+// drawCountdown draws the clock white with plenty of time, amber when it runs
+// low and red in the last seconds, when it also swells with every second
+func drawCountdown(screen *ebiten.Image, rect image.Rectangle, c Countdown) {
+	col := color.Color(color.White)
+	size := float64(hudCountdown)
+	switch {
+	case c.Left < 0:
+		col = color.RGBA{200, 120, 255, 255}
+	case c.Left <= countdownDanger:
+		col = color.RGBA{255, 70, 60, 255}
+		// Each second starts with a beat that fades over its first quarter
+		beat := math.Max(0, 1-4*(1-float64(c.Left%time.Second)/float64(time.Second)))
+		size *= 1 + 0.15*beat
+	case c.Left <= countdownCaution:
+		col = color.RGBA{255, 190, 40, 255}
+	}
+
+	text := countdownText(c)
+	width := float64(len(text)*scaled(hudCharWidth)) * size
+	height := float64(scaled(hudLineHeight)) * size
+	panel := rect.Inset(-scaled(4))
+	vector.DrawFilledRect(screen, float32(panel.Min.X), float32(panel.Min.Y), float32(panel.Dx()), float32(panel.Dy()), color.RGBA{0, 0, 0, 160}, false)
+	centreX := float64(rect.Min.X+rect.Max.X) / 2
+	centreY := float64(rect.Min.Y+rect.Max.Y) / 2
+	printLarge(screen, text, int(centreX-width/2), int(centreY-height/2), size, col)
+}
+
+// splitLines formats each split as the level name, its time and the difference to the best
+func splitLines(rows []SplitRow) []string {
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		name := row.Name
+		if len(name) > 14 {
+			name = name[:13] + "."
+		}
+		line := fmt.Sprintf("%-14s %8s", name, "-")
+		if row.Time > 0 {
+			line = fmt.Sprintf("%-14s %8s", name, FormatRunTime(row.Time))
+		}
+		delta := ""
+		if row.HasBest && (row.Done || row.Current) {
+			delta = FormatRunDelta(row.Delta)
+		}
+		lines[i] = fmt.Sprintf("%s %9s", line, delta)
+	}
+	return lines
+}
+
+// drawSplits draws the split timer with a marker before each row: gold for the
+// fastest level ever, green ahead of the best, red behind it, blue for the level
+// being played
+func drawSplits(screen *ebiten.Image, rect image.Rectangle, rows []SplitRow) {
+	panel := rect.Inset(-scaled(4))
+	panel.Min.X -= scaled(10)
+	vector.DrawFilledRect(screen, float32(panel.Min.X), float32(panel.Min.Y), float32(panel.Dx()), float32(panel.Dy()), color.RGBA{0, 0, 0, 160}, false)
+
+	for i, row := range rows {
+		y := rect.Min.Y + i*scaled(hudLineHeight)
+		var marker color.Color
+		switch {
+		case row.Current:
+			marker = color.RGBA{100, 160, 255, 255}
+		case row.Done && row.Gold:
+			marker = color.RGBA{255, 200, 0, 255}
+		case row.Done && row.HasBest && row.Delta <= 0:
+			marker = color.RGBA{80, 200, 80, 255}
+		case row.Done && row.HasBest:
+			marker = color.RGBA{220, 80, 80, 255}
+		}
+		if marker != nil {
+			size := float32(scaled(6))
+			vector.DrawFilledRect(screen, float32(rect.Min.X-scaled(10)), float32(y+scaled(5)), size, size, marker, false)
+		}
+	}
+	drawLines(screen, rect, splitLines(rows))
+}
+
+func objectiveTexts(objectives []ObjectiveLine) []string {
+	lines := make([]string, len(objectives))
+	for i, objective := range objectives {
+		lines[i] = objective.Text
+	}
+	return lines
+}
+
+// drawObjectives draws the checklist with a marker before each line, green and
+// filled once an objective is met, red once it can no longer be, hollow until then
+func drawObjectives(screen *ebiten.Image, rect image.Rectangle, objectives []ObjectiveLine) {
+	panel := rect.Inset(-scaled(4))
+	panel.Min.X -= scaled(10)
+	vector.DrawFilledRect(screen, float32(panel.Min.X), float32(panel.Min.Y), float32(panel.Dx()), float32(panel.Dy()), color.RGBA{0, 0, 0, 160}, false)
+
+	for i, objective := range objectives {
+		y := rect.Min.Y + i*scaled(hudLineHeight)
+		size := float32(scaled(6))
+		markerX, markerY := float32(rect.Min.X-scaled(10)), float32(y+scaled(5))
+		col := color.Color(color.White)
+		switch {
+		case objective.Failed:
+			col = color.RGBA{255, 90, 80, 255}
+			vector.DrawFilledRect(screen, markerX, markerY, size, size, col, false)
+		case objective.Met:
+			col = color.RGBA{120, 220, 100, 255}
+			vector.DrawFilledRect(screen, markerX, markerY, size, size, col, false)
+		default:
+			vector.StrokeRect(screen, markerX, markerY, size, size, 1, col, false)
+		}
+		printLarge(screen, objective.Text, rect.Min.X, y, 1, col)
+	}
+}
+
+// FormatRunTime shows a speedrun time as m:ss.t
+func FormatRunTime(d time.Duration) string {
+	tenths := int(d / (100 * time.Millisecond))
+	return fmt.Sprintf("%d:%02d.%d", tenths/600, tenths/10%60, tenths%10)
+}
+
+// FormatRunDelta shows a split difference as a signed m:ss.t
+func FormatRunDelta(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatRunTime(-d)
+	}
+	return "+" + FormatRunTime(d)
+}
+
+// formatDelta shows a time difference as a signed m:ss
+func formatDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// drawMoveTimes charts the thinking time of each move as a bar, the slowest in red
+func drawMoveTimes(screen *ebiten.Image, rect image.Rectangle, times []time.Duration, slowest int) {
+	vector.DrawFilledRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), color.RGBA{0, 0, 0, 160}, false)
+
+	longest := time.Duration(1)
+	for _, t := range times {
+		longest = max(longest, t)
+	}
+	slot := float32(rect.Dx()) / float32(len(times))
+	gap := min(slot/4, 2)
+	for i, t := range times {
+		col := color.RGBA{139, 195, 74, 255}
+		if i == slowest {
+			col = color.RGBA{220, 80, 80, 255}
+		}
+		height := float32(rect.Dy()) * float32(t) / float32(longest)
+		x := float32(rect.Min.X) + float32(i)*slot
+		vector.DrawFilledRect(screen, x+gap/2, float32(rect.Max.Y)-height, slot-gap, height, col, false)
+	}
+}
+
+func (h *HUD) drawRace(screen *ebiten.Image, rect image.Rectangle, race *RaceStatus) {
+	labelWidth := scaled(150)
+	barX := float32(rect.Min.X + labelWidth)
+	barWidth := float32(rect.Dx() - labelWidth)
+	barHeight := float32(scaled(8))
+
+	bars := []struct {
+		label    string
+		progress float64
+		fill     color.RGBA
+	}{
+		{"You", race.PlayerProgress, color.RGBA{139, 195, 74, 255}},
+		{fmt.Sprintf("AI (%s) %d", race.AIName, race.AIMoves), race.AIProgress, color.RGBA{220, 80, 80, 255}},
+	}
+	if race.Online {
+		bars[1].label = fmt.Sprintf("%s %d", race.AIName, race.AIMoves)
+	}
+
+	top := rect.Min.Y + scaled(hudRaceRow) // The first row holds the AI's taunts
+	for i, bar := range bars {
+		y := top + i*scaled(hudRaceRow)
+		printAt(screen, bar.label, rect.Min.X, y)
+
+		barY := float32(y + scaled(5))
+		vector.DrawFilledRect(screen, barX, barY, barWidth, barHeight, color.RGBA{100, 100, 100, 255}, false)
+		vector.DrawFilledRect(screen, barX, barY, barWidth*float32(math.Min(1.0, bar.progress)), barHeight, bar.fill, false)
+	}
+
+	// The AI's avatar rides the tip of its bar and says its taunts from there
+	avatarX := barX + barWidth*float32(math.Min(1.0, race.AIProgress))
+	avatarY := float32(top+scaled(hudRaceRow)+scaled(5)) + barHeight/2
+	if !race.Online {
+		drawAvatar(screen, race.AIPersonality, avatarX, avatarY, float32(scaled(7)))
+	}
+	if race.Taunt != "" {
+		width := len(race.Taunt)*scaled(hudCharWidth) + scaled(8)
+		x := min(max(int(avatarX)-width/2, rect.Min.X), rect.Max.X-width)
+		y := rect.Min.Y
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(scaled(hudRaceRow)-2), color.RGBA{255, 255, 255, 235}, false)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(width), float32(scaled(hudRaceRow)-2), 1.5, avatarColors[avatarIndex(race.AIPersonality)], false)
+		printAt(screen, race.Taunt, x+scaled(4), y)
+	}
+
+	// Bubbles start at the tip of their racer's bar, kept on screen at either end
+	for _, bubble := range race.Bubbles {
+		row := bars[0]
+		y := top + scaled(5)
+		if bubble.Opponent {
+			row = bars[1]
+			y += scaled(hudRaceRow)
+		}
+		x := int(barX + barWidth*float32(math.Min(1.0, row.progress)))
+		x = min(max(x, int(barX)+emoteBubbleWidth/2), rect.Max.X-emoteBubbleWidth/2)
+		drawEmoteBubble(screen, bubble, x, y, row.fill)
+	}
+}
+
+// textBlock returns the bounds of lines of debug-font text starting at (x, y)
+func textBlock(x, y int, lines []string) image.Rectangle {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len(line)*scaled(hudCharWidth))
+	}
+	return image.Rect(x, y, x+width, y+len(lines)*scaled(hudLineHeight))
+}
+
+func drawLines(screen *ebiten.Image, rect image.Rectangle, lines []string) {
+	for i, line := range lines {
+		printAt(screen, line, rect.Min.X, rect.Min.Y+i*scaled(hudLineHeight))
+	}
+}
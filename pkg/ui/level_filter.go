@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// levelProgress filters levels by whether they have been completed
+type levelProgress int
+
+const (
+	progressAny levelProgress = iota
+	progressUnfinished
+	progressCompleted
+	progressCount
+)
+
+var progressNames = []string{"All levels", "Unfinished", "Completed"}
+
+// sizeRange filters levels by their longer side
+type sizeRange struct {
+	name             string
+	minSide, maxSide int // 0 for no bound
+}
+
+var sizeRanges = []sizeRange{
+	{name: "Any"},
+	{name: "Small", maxSide: 6},
+	{name: "Medium", minSide: 7, maxSide: 10},
+	{name: "Large", minSide: 11},
+}
+
+const (
+	searchMaxLen    = 20 // Fits the box with the caret
+	searchBoxWidth  = 150
+	filterRowHeight = 20
+)
+
+// levelFacts is what a level list knows about one level, for filtering
+type levelFacts struct {
+	Name          string
+	Completed     bool
+	Stars         int
+	Timed         bool
+	Width, Height int
+}
+
+// levelFilter narrows a level list by a typed search and by the player's
+// results, and keeps a keyboard cursor on the results
+type levelFilter struct {
+	query      []rune
+	progress   levelProgress
+	threeStars bool
+	timed      bool
+	size       int // Index into sizeRanges
+	cursor     int // Result picked with the arrow keys; -1 before any is
+}
+
+func newLevelFilter() levelFilter {
+	return levelFilter{cursor: -1}
+}
+
+// active reports whether anything is filtered out
+func (f *levelFilter) active() bool {
+	return len(f.query) > 0 || f.progress != progressAny || f.threeStars || f.timed || f.size != 0
+}
+
+// match reports whether a level passes the search and every filter
+func (f *levelFilter) match(level levelFacts) bool {
+	if len(f.query) > 0 && !strings.Contains(strings.ToLower(level.Name), strings.ToLower(string(f.query))) {
+		return false
+	}
+	switch f.progress {
+	case progressUnfinished:
+		if level.Completed {
+			return false
+		}
+	case progressCompleted:
+		if !level.Completed {
+			return false
+		}
+	}
+	if f.threeStars && level.Stars < 3 {
+		return false
+	}
+	if f.timed && !level.Timed {
+		return false
+	}
+	side := max(level.Width, level.Height)
+	sizes := sizeRanges[f.size]
+	return (sizes.minSide == 0 || side >= sizes.minSide) && (sizes.maxSide == 0 || side <= sizes.maxSide)
+}
+
+// typeText feeds typing into the search box and reports whether the query changed
+func (f *levelFilter) typeText(text systems.TextInput) bool {
+	changed := false
+	for _, char := range text.Chars {
+		// The debug font only covers printable ASCII
+		if char >= ' ' && char <= '~' && len(f.query) < searchMaxLen {
+			f.query = append(f.query, char)
+			changed = true
+		}
+	}
+	if text.Backspace && len(f.query) > 0 {
+		f.query = f.query[:len(f.query)-1]
+		changed = true
+	}
+	if changed {
+		f.cursor = 0
+	}
+	return changed
+}
+
+// navigate moves the cursor with the arrow keys over count results laid out in
+// rows of columns, and returns the result Enter picked, if any
+func (f *levelFilter) navigate(text systems.TextInput, count, columns int) (int, bool) {
+	step := 0
+	switch {
+	case text.Left:
+		step = -1
+	case text.Right:
+		step = 1
+	case text.Up:
+		step = -columns
+	case text.Down:
+		step = columns
+	}
+	if step != 0 {
+		if f.cursor < 0 {
+			f.cursor = 0
+		} else if next := f.cursor + step; next >= 0 && next < count {
+			f.cursor = next
+		}
+	}
+	f.cursor = min(f.cursor, count-1)
+
+	if text.Enter && count > 0 {
+		return max(f.cursor, 0), true
+	}
+	return 0, false
+}
+
+// resetCursor forgets the picked result once the results change under it
+func (f *levelFilter) resetCursor() {
+	f.cursor = -1
+}
+
+func (f *levelFilter) cycleProgress() {
+	f.progress = (f.progress + 1) % progressCount
+	f.resetCursor()
+}
+
+func (f *levelFilter) cycleSize() {
+	f.size = (f.size + 1) % len(sizeRanges)
+	f.resetCursor()
+}
+
+func (f *levelFilter) toggleThreeStars() {
+	f.threeStars = !f.threeStars
+	f.resetCursor()
+}
+
+func (f *levelFilter) toggleTimed() {
+	f.timed = !f.timed
+	f.resetCursor()
+}
+
+func (f *levelFilter) progressLabel() string {
+	return progressNames[f.progress]
+}
+
+func (f *levelFilter) sizeLabel() string {
+	return "Size: " + sizeRanges[f.size].name
+}
+
+func (f *levelFilter) threeStarsLabel() string {
+	return checkboxLabel(f.threeStars, "3 stars")
+}
+
+func (f *levelFilter) timedLabel() string {
+	return checkboxLabel(f.timed, "Timed")
+}
+
+func checkboxLabel(checked bool, text string) string {
+	if checked {
+		return "[x] " + text
+	}
+	return "[ ] " + text
+}
+
+// drawSearchBox draws the query with a caret, or a prompt while it is empty
+func (f *levelFilter) drawSearchBox(screen *ebiten.Image, x, y int) {
+	vector.DrawFilledRect(screen, float32(x), float32(y), searchBoxWidth, filterRowHeight, color.RGBA{255, 255, 255, 255}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), searchBoxWidth, filterRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+	text := "Type to search"
+	if len(f.query) > 0 {
+		text = string(f.query) + "_"
+	}
+	ebitenutil.DebugPrintAt(screen, truncateText(text, (searchBoxWidth-8)/6), x+4, y+3)
+}
+
+// filterButton is one toggle or cycle in a filter row
+type filterButton struct {
+	x, width int
+	text     string
+	onClick  func()
+}
+
+// drawFilterButtons draws a row of filter buttons, brightening the hovered one
+func drawFilterButtons(screen *ebiten.Image, buttons []filterButton, y, hoverX, hoverY int) {
+	for _, button := range buttons {
+		btnColor := color.Color(color.RGBA{210, 210, 230, 255})
+		if inRect(hoverX, hoverY, button.x, y, button.width, filterRowHeight) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(button.x), float32(y), float32(button.width), filterRowHeight, btnColor, false)
+		vector.StrokeRect(screen, float32(button.x), float32(y), float32(button.width), filterRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, button.text, button.x+6, y+3)
+	}
+}
+
+// clickFilterButtons runs the clicked button's action and reports whether one was hit
+func clickFilterButtons(buttons []filterButton, rowY, x, y int) bool {
+	for _, button := range buttons {
+		if inRect(x, y, button.x, rowY, button.width, filterRowHeight) {
+			button.onClick()
+			return true
+		}
+	}
+	return false
+}
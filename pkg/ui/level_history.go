@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// History panel layout, over the custom level browser
+const (
+	historyX, historyY          = customPanelX + 40, customPanelY + 40
+	historyWidth, historyHeight = 460, 340
+
+	historyRowY      = historyY + 35
+	historyRowWidth  = 170
+	historyRowHeight = 24
+
+	historyPreviewX      = historyX + 195
+	historyPreviewY      = historyRowY
+	historyPreviewWidth  = 255
+	historyPreviewHeight = 220
+
+	historyButtonY = historyY + historyHeight - 45
+)
+
+var changedTileColor = color.RGBA{255, 140, 0, 255}
+
+// openHistory shows a level's earlier versions
+func (clui *CustomLevelsUI) openHistory(levelID string) {
+	history, err := clui.saveSystem.LoadLevelHistory(levelID)
+	if err != nil {
+		clui.statusMessage = "History failed: " + err.Error()
+		return
+	}
+	clui.historyLevel = levelID
+	clui.history = history
+	clui.historyPick = 0
+}
+
+// handleHistoryClick picks a version or rolls back to it; clicking outside the
+// panel closes it
+func (clui *CustomLevelsUI) handleHistoryClick(x, y int) {
+	for i := range clui.history {
+		if inRect(x, y, historyX+10, historyRowY+i*historyRowHeight, historyRowWidth, historyRowHeight-4) {
+			clui.historyPick = i
+			return
+		}
+	}
+	switch {
+	case len(clui.history) > 0 && inRect(x, y, historyPreviewX, historyButtonY, 120, 28):
+		clui.rollback()
+	case inRect(x, y, historyX+historyWidth-120, historyButtonY, 100, 28),
+		!inRect(x, y, historyX, historyY, historyWidth, historyHeight):
+		clui.historyLevel = ""
+	}
+}
+
+// rollback restores the picked version. The replaced layout joins the history,
+// so the rollback can be undone the same way.
+func (clui *CustomLevelsUI) rollback() {
+	revision := clui.history[clui.historyPick]
+	if err := clui.saveSystem.RollbackCustomLevel(clui.historyLevel, clui.historyPick); err != nil {
+		clui.statusMessage = "Rollback failed: " + err.Error()
+		return
+	}
+	clui.statusMessage = "Rolled back to the version from " + revision.SavedAt.Format("Jan 2 15:04")
+	clui.historyLevel = ""
+	clui.reload()
+}
+
+func (clui *CustomLevelsUI) drawHistory(screen *ebiten.Image) {
+	level, ok := clui.levels[clui.historyLevel]
+	if clui.historyLevel == "" || !ok {
+		return
+	}
+
+	vector.DrawFilledRect(screen, historyX, historyY, historyWidth, historyHeight, color.RGBA{250, 250, 250, 255}, false)
+	vector.StrokeRect(screen, historyX, historyY, historyWidth, historyHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, truncateText("History of "+level.Name, (historyWidth-30)/6), historyX+15, historyY+12)
+	drawHistoryButton(screen, clui.hoverX, clui.hoverY, historyX+historyWidth-120, 100, "Close", color.RGBA{180, 180, 180, 255})
+
+	if len(clui.history) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No earlier versions yet.", historyX+15, historyRowY+5)
+		ebitenutil.DebugPrintAt(screen, "Export the level from the editor again", historyX+15, historyRowY+25)
+		ebitenutil.DebugPrintAt(screen, "and the version it replaces is kept here.", historyX+15, historyRowY+41)
+		return
+	}
+
+	for i, revision := range clui.history {
+		y := historyRowY + i*historyRowHeight
+		rowColor := color.Color(color.RGBA{225, 225, 225, 255})
+		if i == clui.historyPick {
+			rowColor = color.RGBA{150, 150, 250, 255}
+		} else if inRect(clui.hoverX, clui.hoverY, historyX+10, y, historyRowWidth, historyRowHeight-4) {
+			rowColor = brighten(rowColor)
+		}
+		vector.DrawFilledRect(screen, historyX+10, float32(y), historyRowWidth, historyRowHeight-4, rowColor, false)
+		label := fmt.Sprintf("%s  %dx%d", revision.SavedAt.Format("Jan 2 15:04"), revision.Width, revision.Height)
+		ebitenutil.DebugPrintAt(screen, label, historyX+16, y+3)
+	}
+
+	revision := clui.history[clui.historyPick]
+	changed := storage.DiffTiles(revision.Tiles, level.Tiles)
+	drawRevision(screen, revision, changed)
+	summary := fmt.Sprintf("%d tiles differ from the current version", len(changed))
+	if len(changed) == 0 {
+		summary = "Same tiles as the current version"
+	}
+	ebitenutil.DebugPrintAt(screen, summary, historyPreviewX, historyPreviewY+historyPreviewHeight+6)
+	drawHistoryButton(screen, clui.hoverX, clui.hoverY, historyPreviewX, 120, "Roll back", color.RGBA{255, 200, 100, 255})
+}
+
+// drawRevision draws a version's tiles in the preview, marking the tiles that
+// differ from the current version
+func drawRevision(screen *ebiten.Image, revision storage.LevelRevision, changed map[island.Point]bool) {
+	if revision.Width == 0 || revision.Height == 0 {
+		return
+	}
+	size := max(1, min(historyPreviewWidth/revision.Width, historyPreviewHeight/revision.Height))
+	for y, row := range revision.Tiles {
+		for x, tile := range row {
+			tileColor := color.RGBA{200, 200, 200, 255}
+			switch island.TileType(tile) {
+			case island.TileLand:
+				tileColor = color.RGBA{139, 195, 74, 255}
+			case island.TileSea:
+				tileColor = color.RGBA{64, 164, 223, 255}
+			}
+			tileX, tileY := float32(historyPreviewX+x*size), float32(historyPreviewY+y*size)
+			vector.DrawFilledRect(screen, tileX, tileY, float32(size), float32(size), tileColor, false)
+			if changed[island.Point{X: x, Y: y}] {
+				vector.StrokeRect(screen, tileX+1, tileY+1, float32(size-2), float32(size-2), 2, changedTileColor, false)
+			}
+		}
+	}
+	vector.StrokeRect(screen, historyPreviewX, historyPreviewY, float32(revision.Width*size), float32(revision.Height*size), 1, color.RGBA{100, 100, 100, 255}, false)
+}
+
+func drawHistoryButton(screen *ebiten.Image, hoverX, hoverY, x, width int, text string, bgColor color.Color) {
+	if inRect(hoverX, hoverY, x, historyButtonY, width, 28) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), historyButtonY, float32(width), 28, bgColor, false)
+	vector.StrokeRect(screen, float32(x), historyButtonY, float32(width), 28, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, text, x+(width-len(text)*6)/2, historyButtonY+8)
+}
@@ -3,34 +3,45 @@ package ui
 import (
 	"fmt"
 	"image/color"
-	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/ponyo877/island-merge/pkg/levels"
+	"github.com/ponyo877/island-merge/pkg/systems"
 )
 
 type LevelSelectUI struct {
 	levelManager     *levels.LevelManager
-	selectedDifficulty levels.Difficulty
+	selectedTab      int // Index into tabs()
 	scrollOffset     float64
 	showPanel        bool
+	hoverX, hoverY   int
+	statusMessage    string
+	filter           levelFilter // Search and filters; the campaign shows a list while one is set
+	recommendation   *levels.Recommendation // Level suggested from recent wins; nil for none
 	OnLevelSelected  func(*levels.LevelData)
 	OnBack          func()
+	OnImportPack    func()
+	Thumbnails      *Thumbnails // Draws each level's layout on its button; nil for none
 }
 
+// levelThumbSize is the side of the layout miniature on level buttons
+const levelThumbSize = 28
+
 func NewLevelSelectUI(levelManager *levels.LevelManager) *LevelSelectUI {
 	return &LevelSelectUI{
-		levelManager:       levelManager,
-		selectedDifficulty: levels.DifficultyBeginner,
-		scrollOffset:       0,
-		showPanel:          false,
+		levelManager: levelManager,
+		selectedTab:  0,
+		scrollOffset: 0,
+		showPanel:    false,
+		filter:       newLevelFilter(),
 	}
 }
 
 func (lsui *LevelSelectUI) Show() {
 	lsui.showPanel = true
+	lsui.filter.resetCursor()
 	lsui.scrollOffset = 0
 }
 
@@ -42,6 +53,84 @@ func (lsui *LevelSelectUI) IsShown() bool {
 	return lsui.showPanel
 }
 
+// SetStatus shows a short message next to the title, e.g. the result of a pack import
+func (lsui *LevelSelectUI) SetStatus(message string) {
+	lsui.statusMessage = message
+}
+
+// SetRecommendation shows the level suggested from recent wins under the levels; nil hides it
+func (lsui *LevelSelectUI) SetRecommendation(recommendation *levels.Recommendation) {
+	lsui.recommendation = recommendation
+}
+
+// SelectPack switches to the tab of the pack with the given ID
+func (lsui *LevelSelectUI) SelectPack(packID string) {
+	for i, levelSet := range lsui.tabs() {
+		if levelSet != nil && levelSet.PackID == packID {
+			lsui.selectedTab = i
+			lsui.scrollOffset = 0
+		}
+	}
+}
+
+// tabs lists the campaign map, as nil, followed by each level pack. The
+// built-in sets share the campaign tab as regions of the map.
+func (lsui *LevelSelectUI) tabs() []*levels.LevelSet {
+	var tabs []*levels.LevelSet
+	if len(lsui.builtinSets()) > 0 {
+		tabs = append(tabs, nil)
+	}
+	for _, levelSet := range lsui.levelManager.LevelSets {
+		if levelSet.PackID != "" {
+			tabs = append(tabs, levelSet)
+		}
+	}
+	return tabs
+}
+
+// onCampaign reports whether the campaign map tab is selected
+func (lsui *LevelSelectUI) onCampaign() bool {
+	tabs := lsui.tabs()
+	return lsui.selectedTab >= 0 && lsui.selectedTab < len(tabs) && tabs[lsui.selectedTab] == nil
+}
+
+// tabWidth narrows the set tabs as packs are added so they all fit in the panel
+func (lsui *LevelSelectUI) tabWidth() int {
+	count := len(lsui.tabs())
+	if count <= 4 {
+		return 120
+	}
+	return 500 / count
+}
+
+// tabLabel names the campaign tab and pack sets by their own name
+func (lsui *LevelSelectUI) tabLabel(levelSet *levels.LevelSet, width int) string {
+	label := "Campaign"
+	if levelSet != nil {
+		label = levelSet.Name
+	}
+	if maxChars := (width - 10) / 6; len(label) > maxChars {
+		label = label[:maxChars]
+	}
+	return label
+}
+
+var difficultyNames = map[levels.Difficulty]string{
+	levels.DifficultyBeginner:     "Beginner",
+	levels.DifficultyIntermediate: "Intermediate",
+	levels.DifficultyExpert:       "Expert",
+	levels.DifficultyMaster:       "Master",
+}
+
+func (lsui *LevelSelectUI) isImportClicked(x, y, panelX, panelY, panelWidth int) bool {
+	return x >= panelX+panelWidth-150 && x <= panelX+panelWidth-50 && y >= panelY+10 && y <= panelY+40
+}
+
+// UpdateHover records the pointer position so level buttons can highlight under it
+func (lsui *LevelSelectUI) UpdateHover(x, y int) {
+	lsui.hoverX, lsui.hoverY = x, y
+}
+
 func (lsui *LevelSelectUI) HandleClick(x, y int) bool {
 	if !lsui.showPanel {
 		return false
@@ -68,74 +157,263 @@ func (lsui *LevelSelectUI) HandleClick(x, y int) bool {
 		return true
 	}
 	
-	// Difficulty tabs
-	tabWidth := 120
+	// Import pack button
+	if lsui.isImportClicked(x, y, panelX, panelY, panelWidth) {
+		if lsui.OnImportPack != nil {
+			lsui.OnImportPack()
+		}
+		return true
+	}
+	
+	// Level set tabs
+	tabWidth := lsui.tabWidth()
 	tabY := panelY + 50
-	for i := 0; i < 4; i++ {
+	for i := range lsui.tabs() {
 		tabX := panelX + 20 + i*tabWidth
 		if x >= tabX && x <= tabX+tabWidth-10 && y >= tabY && y <= tabY+30 {
-			lsui.selectedDifficulty = levels.Difficulty(i)
+			lsui.selectedTab = i
 			lsui.scrollOffset = 0
+			lsui.filter.resetCursor()
 			return true
 		}
 	}
 	
+	// Recommended level
+	if lsui.recommendation != nil && inRect(x, y, recommendX, recommendY, recommendWidth, recommendHeight) {
+		lsui.playLevel(lsui.recommendation.Level)
+		return true
+	}
+	
+	// Filters
+	if clickFilterButtons(lsui.filterButtons(panelX), panelY+levelFilterTop, x, y) {
+		lsui.scrollOffset = 0
+		return true
+	}
+	
 	// Level selection
-	lsui.handleLevelClick(x, y, panelX, panelY)
+	if lsui.showsMap() {
+		lsui.handleMapClick(x, y)
+	} else {
+		lsui.handleLevelClick(x, y, panelX, panelY)
+	}
 	
 	return true
 }
 
 func (lsui *LevelSelectUI) handleLevelClick(x, y, panelX, panelY int) {
-	levelSet := lsui.getCurrentLevelSet()
-	if levelSet == nil {
-		return
-	}
-	
-	levelsStartY := panelY + 120
-	levelWidth := 100
-	levelHeight := 80
-	levelsPerRow := 5
-	spacing := 10
-	
-	for i, level := range levelSet.Levels {
-		row := i / levelsPerRow
-		col := i % levelsPerRow
-		
-		levelX := panelX + 20 + col*(levelWidth+spacing)
-		levelY := int(float64(levelsStartY + row*(levelHeight+spacing)) - lsui.scrollOffset)
+	for i, level := range lsui.filteredLevels() {
+		levelX, levelY := lsui.levelButtonPos(i, panelX, panelY)
 		
 		// Skip if not visible
-		if levelY < levelsStartY-levelHeight || levelY > panelY+400 {
+		if !lsui.isLevelVisible(levelY, panelY) {
 			continue
 		}
 		
-		if x >= levelX && x <= levelX+levelWidth && y >= levelY && y <= levelY+levelHeight {
-			if level.Unlocked && lsui.OnLevelSelected != nil {
-				lsui.OnLevelSelected(level)
-				lsui.Hide()
-			}
+		if x >= levelX && x <= levelX+levelButtonWidth && y >= levelY && y <= levelY+levelButtonHeight {
+			lsui.playLevel(level)
 			return
 		}
 	}
 }
 
+// playLevel starts an unlocked level and closes the panel
+func (lsui *LevelSelectUI) playLevel(level *levels.LevelData) {
+	if level.Unlocked && lsui.OnLevelSelected != nil {
+		lsui.OnLevelSelected(level)
+		lsui.Hide()
+	}
+}
+
+// HandleText types into the search box; the arrow keys pick a level and Enter plays it
+func (lsui *LevelSelectUI) HandleText(text systems.TextInput) {
+	if !lsui.showPanel {
+		return
+	}
+	if lsui.filter.typeText(text) {
+		lsui.scrollOffset = 0
+	}
+	
+	results := lsui.filteredLevels()
+	if i, ok := lsui.filter.navigate(text, len(results), levelsPerRow); ok {
+		lsui.playLevel(results[i])
+		return
+	}
+	
+	// Scroll the picked level into view
+	if cursor := lsui.filter.cursor; cursor >= 0 {
+		top := float64(cursor / levelsPerRow * levelRowHeight)
+		if top < lsui.scrollOffset {
+			lsui.scrollOffset = top
+		} else if bottom := top + levelButtonHeight; bottom > lsui.scrollOffset+levelListHeight {
+			lsui.scrollOffset = bottom - levelListHeight
+		}
+	}
+}
+
+// Level list layout below the filter row
+const (
+	levelFilterTop    = 88 // Below the panel top
+	levelButtonWidth  = 100
+	levelButtonHeight = 80
+	levelsPerRow      = 5
+	levelSpacing      = 10
+)
+
+// levelButtonPos returns where the i-th listed level's button is drawn
+func (lsui *LevelSelectUI) levelButtonPos(i, panelX, panelY int) (int, int) {
+	row := i / levelsPerRow
+	col := i % levelsPerRow
+	levelX := panelX + 20 + col*(levelButtonWidth+levelSpacing)
+	levelY := int(float64(panelY+120+row*(levelButtonHeight+levelSpacing)) - lsui.scrollOffset)
+	return levelX, levelY
+}
+
+// showsMap reports whether the campaign map is drawn instead of a level list
+func (lsui *LevelSelectUI) showsMap() bool {
+	return lsui.onCampaign() && !lsui.filter.active()
+}
+
+// filteredLevels lists the levels the search and filters let through: the
+// selected pack's levels, or every campaign level once a filter is set
+func (lsui *LevelSelectUI) filteredLevels() []*levels.LevelData {
+	var candidates []*levels.LevelData
+	if lsui.onCampaign() {
+		if !lsui.filter.active() {
+			return nil
+		}
+		for _, set := range lsui.builtinSets() {
+			candidates = append(candidates, set.Levels...)
+		}
+	} else if levelSet := lsui.getCurrentLevelSet(); levelSet != nil {
+		candidates = levelSet.Levels
+	}
+	
+	results := make([]*levels.LevelData, 0, len(candidates))
+	for _, level := range candidates {
+		stars := 0
+		if level.BestScore != nil {
+			stars = level.BestScore.Stars
+		}
+		if lsui.filter.match(levelFacts{
+			Name:      level.Name,
+			Completed: level.Completed,
+			Stars:     stars,
+			Timed:     level.TimeLimit > 0,
+			Width:     level.Width,
+			Height:    level.Height,
+		}) {
+			results = append(results, level)
+		}
+	}
+	return results
+}
+
+// filterButtons lays out the filter row right of the search box
+func (lsui *LevelSelectUI) filterButtons(panelX int) []filterButton {
+	x := panelX + 20 + searchBoxWidth + 6
+	return []filterButton{
+		{x, 84, lsui.filter.progressLabel(), lsui.filter.cycleProgress},
+		{x + 90, 78, lsui.filter.threeStarsLabel(), lsui.filter.toggleThreeStars},
+		{x + 174, 66, lsui.filter.timedLabel(), lsui.filter.toggleTimed},
+		{x + 246, 84, lsui.filter.sizeLabel(), lsui.filter.cycleSize},
+	}
+}
+
+// TooltipRegions describes the tabs and visible levels for hover tooltips
+func (lsui *LevelSelectUI) TooltipRegions() []TooltipRegion {
+	if !lsui.showPanel {
+		return nil
+	}
+	
+	panelX, panelY := 50, 30
+	regions := make([]TooltipRegion, 0)
+	
+	tabWidth := lsui.tabWidth()
+	for i, levelSet := range lsui.tabs() {
+		var text string
+		if levelSet == nil {
+			earned, possible := lsui.campaignStars()
+			text = fmt.Sprintf("Campaign\nStars: %d/%d", earned, possible)
+		} else {
+			text = fmt.Sprintf("%s\n%s", levelSet.Name, levelSet.Description)
+		}
+		regions = append(regions, TooltipRegion{
+			X: panelX + 20 + i*tabWidth, Y: panelY + 50, Width: tabWidth - 10, Height: 30, Text: text,
+		})
+	}
+	
+	if lsui.showsMap() {
+		return append(regions, lsui.mapTooltipRegions()...)
+	}
+	
+	for i, level := range lsui.filteredLevels() {
+		levelX, levelY := lsui.levelButtonPos(i, panelX, panelY)
+		if !lsui.isLevelVisible(levelY, panelY) {
+			continue
+		}
+		
+		regions = append(regions, TooltipRegion{
+			X: levelX, Y: levelY, Width: levelButtonWidth, Height: levelButtonHeight, Text: levelTooltip(level),
+		})
+	}
+	
+	return regions
+}
+
+func levelTooltip(level *levels.LevelData) string {
+	if !level.Unlocked {
+		return level.Name + "\nLocked - complete the previous level"
+	}
+	
+	text := fmt.Sprintf("%s\n%s\nPar: %d moves", level.Name, level.Description, level.OptimalMoves)
+	if level.Author != "" {
+		text += "\nBy " + level.Author
+	}
+	if level.Tampered {
+		text += "\nWarning: changed since its author shared it"
+	}
+	if level.TimeLimit > 0 {
+		text += fmt.Sprintf("\nTime limit: %d:%02d", int(level.TimeLimit.Minutes()), int(level.TimeLimit.Seconds())%60)
+	}
+	if level.BestScore != nil {
+		text += fmt.Sprintf("\nBest: %d moves", level.BestScore.Moves)
+	}
+	return text
+}
+
 func (lsui *LevelSelectUI) HandleScroll(deltaY float64) {
 	if !lsui.showPanel {
 		return
 	}
 	
-	lsui.scrollOffset += deltaY * 20
-	lsui.scrollOffset = math.Max(0, lsui.scrollOffset)
+	lsui.scrollOffset += deltaY * scrollStep
+	lsui.scrollOffset = clampScroll(lsui.scrollOffset, lsui.contentHeight(), levelListHeight)
+}
+
+const (
+	levelRowHeight  = 90  // Button height plus spacing
+	levelListHeight = 270 // Visible list area inside the panel, above the recommendation
+)
+
+// contentHeight returns the height of all listed level rows; the campaign map
+// never scrolls
+func (lsui *LevelSelectUI) contentHeight() float64 {
+	rows := (len(lsui.filteredLevels()) + levelsPerRow - 1) / levelsPerRow
+	return float64(rows * levelRowHeight)
+}
+
+// isLevelVisible reports whether a level button at levelY lies fully inside the list area
+func (lsui *LevelSelectUI) isLevelVisible(levelY, panelY int) bool {
+	listTop := panelY + 120
+	return levelY >= listTop && levelY+80 <= listTop+levelListHeight
 }
 
 func (lsui *LevelSelectUI) getCurrentLevelSet() *levels.LevelSet {
-	for _, levelSet := range lsui.levelManager.LevelSets {
-		if levelSet.Difficulty == lsui.selectedDifficulty {
-			return levelSet
-		}
+	tabs := lsui.tabs()
+	if lsui.selectedTab < 0 || lsui.selectedTab >= len(tabs) {
+		return nil
 	}
-	return nil
+	return tabs[lsui.selectedTab]
 }
 
 func (lsui *LevelSelectUI) Draw(screen *ebiten.Image) {
@@ -177,43 +455,74 @@ func (lsui *LevelSelectUI) Draw(screen *ebiten.Image) {
 	vector.DrawFilledRect(screen, float32(panelX+panelWidth-40), float32(panelY+10), 30, 30, color.RGBA{200, 100, 100, 255}, false)
 	ebitenutil.DebugPrintAt(screen, "←", panelX+panelWidth-30, panelY+20)
 	
-	// Draw difficulty tabs
-	lsui.drawDifficultyTabs(screen, panelX, panelY)
+	// Import pack button
+	importColor := color.Color(color.RGBA{100, 200, 200, 255})
+	if lsui.isImportClicked(lsui.hoverX, lsui.hoverY, panelX, panelY, panelWidth) {
+		importColor = brighten(importColor)
+	}
+	vector.DrawFilledRect(screen, float32(panelX+panelWidth-150), float32(panelY+10), 100, 30, importColor, false)
+	ebitenutil.DebugPrintAt(screen, "Import Pack", panelX+panelWidth-133, panelY+18)
 	
-	// Draw current level set
-	levelSet := lsui.getCurrentLevelSet()
-	if levelSet != nil {
-		lsui.drawLevelSet(screen, levelSet, panelX, panelY)
+	if lsui.statusMessage != "" {
+		ebitenutil.DebugPrintAt(screen, lsui.statusMessage, panelX+20, panelY+32)
 	}
+	
+	// Draw campaign and pack tabs
+	lsui.drawSetTabs(screen, panelX, panelY)
+	
+	// Search and filters
+	lsui.filter.drawSearchBox(screen, panelX+20, panelY+levelFilterTop)
+	drawFilterButtons(screen, lsui.filterButtons(panelX), panelY+levelFilterTop, lsui.hoverX, lsui.hoverY)
+	
+	// Draw the campaign map or the listed levels
+	if lsui.showsMap() {
+		lsui.drawCampaignMap(screen)
+	} else {
+		lsui.drawLevelList(screen, panelX, panelY)
+	}
+	lsui.drawRecommendation(screen)
 }
 
-func (lsui *LevelSelectUI) drawDifficultyTabs(screen *ebiten.Image, panelX, panelY int) {
-	difficulties := []struct {
-		name string
-		diff levels.Difficulty
-	}{
-		{"Beginner", levels.DifficultyBeginner},
-		{"Intermediate", levels.DifficultyIntermediate},
-		{"Expert", levels.DifficultyExpert},
-		{"Master", levels.DifficultyMaster},
+// Recommendation banner along the bottom of the panel, under the map and the list
+const (
+	recommendX, recommendY          = 220, 424
+	recommendWidth, recommendHeight = 360, 20
+)
+
+// drawRecommendation draws the suggested level's banner; clicking it plays the level
+func (lsui *LevelSelectUI) drawRecommendation(screen *ebiten.Image) {
+	if lsui.recommendation == nil {
+		return
 	}
-	
-	tabWidth := 120
+	bg := color.Color(color.RGBA{255, 230, 150, 255})
+	if inRect(lsui.hoverX, lsui.hoverY, recommendX, recommendY, recommendWidth, recommendHeight) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, recommendX, recommendY, recommendWidth, recommendHeight, bg, false)
+	vector.StrokeRect(screen, recommendX, recommendY, recommendWidth, recommendHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+	reason := lsui.recommendation.Reason
+	if maxChars := (recommendWidth - 10) / 6; len(reason) > maxChars {
+		reason = reason[:maxChars]
+	}
+	ebitenutil.DebugPrintAt(screen, reason, recommendX+5, recommendY+3)
+}
+
+func (lsui *LevelSelectUI) drawSetTabs(screen *ebiten.Image, panelX, panelY int) {
+	tabWidth := lsui.tabWidth()
 	tabHeight := 30
 	tabY := panelY + 50
 	
-	for i, difficulty := range difficulties {
+	for i, levelSet := range lsui.tabs() {
 		tabX := panelX + 20 + i*tabWidth
 		
 		// Tab background
 		bgColor := color.RGBA{200, 200, 200, 255}
-		if difficulty.diff == lsui.selectedDifficulty {
+		if i == lsui.selectedTab {
 			bgColor = color.RGBA{150, 150, 250, 255}
 		}
 		
-		// Check if difficulty is unlocked
-		levelSet := lsui.getLevelSetByDifficulty(difficulty.diff)
-		isUnlocked := lsui.isDifficultyUnlocked(levelSet)
+		// The campaign tab is always open; its regions unlock on the map
+		isUnlocked := levelSet == nil || lsui.isDifficultyUnlocked(levelSet)
 		if !isUnlocked {
 			bgColor = color.RGBA{150, 150, 150, 128}
 		}
@@ -237,9 +546,10 @@ func (lsui *LevelSelectUI) drawDifficultyTabs(screen *ebiten.Image, panelX, pane
 		)
 		
 		// Tab text
-		textX := tabX + (tabWidth-len(difficulty.name)*6)/2
+		label := lsui.tabLabel(levelSet, tabWidth)
+		textX := tabX + (tabWidth-10-len(label)*6)/2
 		textY := tabY + tabHeight/2 - 4
-		ebitenutil.DebugPrintAt(screen, difficulty.name, textX, textY)
+		ebitenutil.DebugPrintAt(screen, label, textX, textY)
 		
 		if !isUnlocked {
 			ebitenutil.DebugPrintAt(screen, "🔒", tabX+tabWidth-25, textY)
@@ -247,35 +557,30 @@ func (lsui *LevelSelectUI) drawDifficultyTabs(screen *ebiten.Image, panelX, pane
 	}
 }
 
-func (lsui *LevelSelectUI) drawLevelSet(screen *ebiten.Image, levelSet *levels.LevelSet, panelX, panelY int) {
-	// Level set description
-	descY := panelY + 90
-	ebitenutil.DebugPrintAt(screen, levelSet.Description, panelX+20, descY)
-	
-	// Level grid
-	levelsStartY := panelY + 120
-	levelWidth := 100
-	levelHeight := 80
-	levelsPerRow := 5
-	spacing := 10
+func (lsui *LevelSelectUI) drawLevelList(screen *ebiten.Image, panelX, panelY int) {
+	results := lsui.filteredLevels()
+	if len(results) == 0 {
+		ebitenutil.DebugPrintAt(screen, "No levels match the filters.", panelX+20, panelY+120)
+		return
+	}
 	
-	for i, level := range levelSet.Levels {
-		row := i / levelsPerRow
-		col := i % levelsPerRow
-		
-		levelX := panelX + 20 + col*(levelWidth+spacing)
-		levelY := int(float64(levelsStartY + row*(levelHeight+spacing)) - lsui.scrollOffset)
+	for i, level := range results {
+		levelX, levelY := lsui.levelButtonPos(i, panelX, panelY)
 		
 		// Skip if not visible
-		if levelY < levelsStartY-levelHeight || levelY > panelY+400 {
+		if !lsui.isLevelVisible(levelY, panelY) {
 			continue
 		}
 		
-		lsui.drawLevelButton(screen, level, levelX, levelY, levelWidth, levelHeight)
+		lsui.drawLevelButton(screen, level, levelX, levelY, levelButtonWidth, levelButtonHeight, i == lsui.filter.cursor)
 	}
+	
+	drawScrollbar(screen, float64(panelX+530), float64(panelY+120), levelListHeight,
+		lsui.contentHeight(), levelListHeight, lsui.scrollOffset)
 }
 
-func (lsui *LevelSelectUI) drawLevelButton(screen *ebiten.Image, level *levels.LevelData, x, y, width, height int) {
+// drawLevelButton draws a level's button; picked marks the one chosen with the keyboard
+func (lsui *LevelSelectUI) drawLevelButton(screen *ebiten.Image, level *levels.LevelData, x, y, width, height int, picked bool) {
 	// Background color based on status
 	var bgColor color.Color
 	if !level.Unlocked {
@@ -296,15 +601,21 @@ func (lsui *LevelSelectUI) drawLevelButton(screen *ebiten.Image, level *levels.L
 	
 	// Border
 	borderColor := color.RGBA{100, 100, 100, 255}
+	borderWidth := float32(2)
 	if level.Completed {
 		borderColor = color.RGBA{255, 215, 0, 255} // Gold border for completed
 	}
+	hovered := lsui.hoverX >= x && lsui.hoverX <= x+width && lsui.hoverY >= y && lsui.hoverY <= y+height
+	if (hovered || picked) && level.Unlocked {
+		borderColor = color.RGBA{100, 100, 250, 255}
+		borderWidth = 3
+	}
 	
 	vector.StrokeRect(
 		screen,
 		float32(x), float32(y),
 		float32(width), float32(height),
-		2,
+		borderWidth,
 		borderColor,
 		false,
 	)
@@ -317,10 +628,17 @@ func (lsui *LevelSelectUI) drawLevelButton(screen *ebiten.Image, level *levels.L
 		ebitenutil.DebugPrintAt(screen, line, textX, textY)
 	}
 	
-	// Size indicator
+	// Size indicator, beside the layout once the level is unlocked
 	sizeText := fmt.Sprintf("%dx%d", level.Width, level.Height)
 	sizeX := x + (width-len(sizeText)*6)/2
 	sizeY := y + height - 30
+	if level.Unlocked && lsui.Thumbnails != nil {
+		thumbX := x + (width-levelThumbSize-len(sizeText)*6-6)/2
+		thumbY := y + height - levelThumbSize - 6
+		lsui.Thumbnails.Draw(screen, LevelThumbnailKey(level.ID), level.NewBoard, thumbX, thumbY, levelThumbSize)
+		sizeX = thumbX + levelThumbSize + 6
+		sizeY = thumbY + levelThumbSize/2 - 8
+	}
 	ebitenutil.DebugPrintAt(screen, sizeText, sizeX, sizeY)
 	
 	// Stars (if completed)
@@ -333,6 +651,12 @@ func (lsui *LevelSelectUI) drawLevelButton(screen *ebiten.Image, level *levels.L
 		ebitenutil.DebugPrintAt(screen, "🔒", x+width/2-6, y+height/2-6)
 	}
 	
+	// Levels changed after their author signed them are marked in red
+	if level.Tampered {
+		vector.DrawFilledRect(screen, float32(x+18), float32(y+4), 12, 12, color.RGBA{220, 60, 60, 255}, false)
+		ebitenutil.DebugPrintAt(screen, "!", x+21, y+2)
+	}
+	
 	// Difficulty indicator
 	diffColor := lsui.getDifficultyColor(level.Difficulty)
 	vector.DrawFilledRect(
@@ -397,15 +721,6 @@ func (lsui *LevelSelectUI) getDifficultyColor(difficulty levels.Difficulty) colo
 	}
 }
 
-func (lsui *LevelSelectUI) getLevelSetByDifficulty(difficulty levels.Difficulty) *levels.LevelSet {
-	for _, levelSet := range lsui.levelManager.LevelSets {
-		if levelSet.Difficulty == difficulty {
-			return levelSet
-		}
-	}
-	return nil
-}
-
 func (lsui *LevelSelectUI) isDifficultyUnlocked(levelSet *levels.LevelSet) bool {
 	if levelSet == nil {
 		return false
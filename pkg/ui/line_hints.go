@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// lineHintSize is the side of the chip behind each count
+const lineHintSize = 16
+
+// Chip colors: still short, exactly right, too many bridges
+var (
+	lineHintOpen = color.RGBA{90, 90, 90, 220}
+	lineHintDone = color.RGBA{76, 160, 60, 230}
+	lineHintOver = color.RGBA{200, 60, 60, 230}
+)
+
+// DrawLineHints draws the Bridge Counts clues along the board's edges: each
+// row's count to its left and each column's above it. Counts turn green when
+// the row or column has exactly that many bridges and red when it has more.
+func DrawLineHints(screen *ebiten.Image, board image.Rectangle, tileSize int, hintRows, hintCols, rows, cols []int) {
+	for y, hint := range hintRows {
+		centerY := board.Min.Y + y*tileSize + tileSize/2
+		drawLineHint(screen, board.Min.X-lineHintSize/2-4, centerY, hint, rows[y])
+	}
+	for x, hint := range hintCols {
+		centerX := board.Min.X + x*tileSize + tileSize/2
+		drawLineHint(screen, centerX, board.Min.Y-lineHintSize/2-4, hint, cols[x])
+	}
+}
+
+func drawLineHint(screen *ebiten.Image, centerX, centerY, hint, built int) {
+	chip := lineHintOpen
+	if built == hint {
+		chip = lineHintDone
+	} else if built > hint {
+		chip = lineHintOver
+	}
+	text := strconv.Itoa(hint)
+	width := max(lineHintSize, len(text)*hudCharWidth+4)
+	vector.DrawFilledRect(screen, float32(centerX-width/2), float32(centerY-lineHintSize/2), float32(width), lineHintSize, chip, false)
+	printAt(screen, text, centerX-len(text)*scaled(hudCharWidth)/2, centerY-scaled(8))
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"image/color"
+	"strconv"
 	
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -16,14 +17,39 @@ type MenuItem struct {
 	Height   float64
 	Hovered  bool
 	Selected bool
+	Hidden   bool
+	Detail   string // Extra info drawn to the right of the button
+	Badge    int    // Count in a red dot on the button's corner, e.g. games waiting on the player; 0 hides it
+	page     int    // Page the item is laid out on
 }
 
 type Menu struct {
 	Title      string
+	Status     string // Drawn under the title, e.g. while offline
+	Summary    string // Drawn under the status, e.g. the last session
 	Items      []*MenuItem
 	Background color.Color
+	Backdrop   func(screen *ebiten.Image) // Drawn under a veil instead of the flat background; nil for none
+	Continue   *MenuCard                  // The saved game; nil when there is none
+	Recent     []*MenuCard                // Recently played levels, latest first
+	Thumbnails *Thumbnails                // Draws the card boards; nil for none
+	page       int                        // Page of items shown when they do not fit on one
+	pages      int
+	prev, next menuPageButton
 }
 
+// menuPageButton turns the items' pages by step
+type menuPageButton struct {
+	text          string
+	step          int
+	x, y          float64
+	width, height float64
+	hovered       bool
+}
+
+// menuVeilAlpha is how much of the background colour covers the backdrop, so the items stay readable
+const menuVeilAlpha = 178 // 70%
+
 func NewMainMenu(onModeSelect func(int)) *Menu {
 	menu := &Menu{
 		Title:      "Island Merge",
@@ -40,29 +66,147 @@ func NewMainMenu(onModeSelect func(int)) *Menu {
 		{"Time Attack", func() { onModeSelect(1) }}, // ModeTimeAttack
 		{"Puzzle Mode", func() { onModeSelect(2) }}, // ModePuzzle
 		{"Level Editor", func() { onModeSelect(3) }}, // Level Editor
+		{"Custom Levels", func() { onModeSelect(4) }}, // Custom level browser
+		{"Level of the Week", func() { onModeSelect(5) }}, // Downloaded weekly level
+		{"Bridge Counts", func() { onModeSelect(6) }}, // Deduction puzzle on a generated board
+		{"Classic", func() { onModeSelect(7) }}, // Generated board of a chosen size
+		{"Storm", func() { onModeSelect(8) }}, // Bridges wear down and need repairs
+		{"Weekly Challenge", func() { onModeSelect(9) }}, // Three generated levels with mutators, new every week
+		{"Speedrun", func() { onModeSelect(10) }}, // Every level of a difficulty back to back
+		{"Zen", func() { onModeSelect(11) }}, // Endless board that grows each time it is connected
+		{"Quests", func() { onModeSelect(12) }}, // Daily and weekly goals and the bridge paints they unlock
+		{"Online", func() { onModeSelect(13) }}, // Online Race and Correspondence against another player
 	}
 	
-	startY := 200.0
-	for i, item := range items {
+	for _, item := range items {
 		menuItem := &MenuItem{
 			Text:   item.text,
 			Action: item.action,
-			X:      320 - 100, // Center
-			Y:      startY + float64(i*60),
-			Width:  200,
-			Height: 40,
 		}
 		menu.Items = append(menu.Items, menuItem)
 	}
+	menu.layout()
 	
 	return menu
 }
 
+// Menu layout; items grow with the UI scale as long as they fit under the title
+const (
+	menuTop         = 160
+	menuBottom      = 470
+	menuItemWidth   = 200
+	menuItemHeight  = 40
+	menuItemGap     = 10
+	menuItemPadding = 4  // Above and below the text of an item squeezed to fit
+	menuPagerWidth  = 60 // Page buttons, under the items when they take several pages
+	menuPagerHeight = 24
+)
+
+// layout centers the visible items and stacks them below the title. Items
+// shrink to fit, but never below their text; those that still do not fit go
+// on further pages.
+func (m *Menu) layout() {
+	visible := 0
+	for _, item := range m.Items {
+		if !item.Hidden {
+			visible++
+		}
+	}
+	room := float64(menuBottom - menuTop)
+	minSpacing := float64(scaled(hudLineHeight) + 2*scaled(menuItemPadding) + menuItemGap)
+	perPage := max(visible, 1)
+	if float64(visible)*minSpacing > room {
+		room -= float64(scaled(menuPagerHeight) + menuItemGap)
+		perPage = max(int(room/minSpacing), 1)
+	}
+	spacing := min(float64(scaled(menuItemHeight+menuItemGap)), room/float64(perPage))
+	m.pages = (visible + perPage - 1) / perPage
+	m.page = min(m.page, max(m.pages-1, 0))
+	
+	shown := 0
+	for _, item := range m.Items {
+		if item.Hidden {
+			continue
+		}
+		item.page = shown / perPage
+		item.Width = float64(scaled(menuItemWidth))
+		item.Height = spacing - menuItemGap
+		item.X = 320 - item.Width/2
+		item.Y = menuTop + float64(shown%perPage)*spacing
+		shown++
+	}
+	
+	pagerWidth, pagerHeight := float64(scaled(menuPagerWidth)), float64(scaled(menuPagerHeight))
+	pagerY := float64(menuBottom - scaled(menuPagerHeight))
+	m.prev = menuPageButton{text: "< Prev", step: -1, x: 320 - float64(scaled(menuItemWidth))/2, y: pagerY, width: pagerWidth, height: pagerHeight}
+	m.next = menuPageButton{text: "Next >", step: 1, x: 320 + float64(scaled(menuItemWidth))/2 - pagerWidth, y: pagerY, width: pagerWidth, height: pagerHeight}
+}
+
+// pageButtons returns the page buttons that lead somewhere: none when the
+// items fit on one page
+func (m *Menu) pageButtons() []*menuPageButton {
+	var buttons []*menuPageButton
+	if m.page > 0 {
+		buttons = append(buttons, &m.prev)
+	}
+	if m.page < m.pages-1 {
+		buttons = append(buttons, &m.next)
+	}
+	return buttons
+}
+
+// onPage reports whether an item is on screen: visible and on the page shown
+func (m *Menu) onPage(item *MenuItem) bool {
+	return !item.Hidden && item.page == m.page
+}
+
+// SetItemVisible shows or hides an item and closes the gap it leaves
+func (m *Menu) SetItemVisible(index int, visible bool) {
+	if index < 0 || index >= len(m.Items) {
+		return
+	}
+	m.Items[index].Hidden = !visible
+	m.layout()
+}
+
+// SetItemDetail sets the text drawn beside an item
+func (m *Menu) SetItemDetail(index int, detail string) {
+	if index < 0 || index >= len(m.Items) {
+		return
+	}
+	m.Items[index].Detail = detail
+}
+
+// SetItemBadge sets the count drawn on an item's corner; 0 hides it
+func (m *Menu) SetItemBadge(index int, count int) {
+	if index < 0 || index >= len(m.Items) {
+		return
+	}
+	m.Items[index].Badge = count
+}
+
 func (m *Menu) Update(mouseX, mouseY int, clicked bool) {
+	if m.updateCards(mouseX, mouseY, clicked) {
+		clicked = false // Items widened for touch can reach under the cards
+	}
+	
+	m.prev.hovered, m.next.hovered = false, false
+	for _, button := range m.pageButtons() {
+		button.hovered = inRect(mouseX, mouseY, int(button.x), int(button.y), int(button.width), int(button.height))
+		if button.hovered && clicked {
+			m.page += button.step
+			clicked = false // The items of the new page are not under the click
+		}
+	}
+	
 	for _, item := range m.Items {
+		if !m.onPage(item) {
+			item.Hovered = false
+			continue
+		}
+		
 		// Check hover
-		item.Hovered = float64(mouseX) >= item.X && float64(mouseX) <= item.X+item.Width &&
-			float64(mouseY) >= item.Y && float64(mouseY) <= item.Y+item.Height
+		item.Hovered = inRect(mouseX, mouseY, int(item.X), int(item.Y), int(item.Width), int(item.Height))
 		
 		// Check click
 		if item.Hovered && clicked && item.Action != nil {
@@ -72,15 +216,35 @@ func (m *Menu) Update(mouseX, mouseY int, clicked bool) {
 }
 
 func (m *Menu) Draw(screen *ebiten.Image) {
-	// Clear background
-	screen.Fill(m.Background)
+	// Clear background, or veil the backdrop with it
+	if m.Backdrop != nil {
+		m.Backdrop(screen)
+		veil := color.NRGBAModel.Convert(m.Background).(color.NRGBA)
+		veil.A = menuVeilAlpha
+		bounds := screen.Bounds()
+		vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), veil, false)
+	} else {
+		screen.Fill(m.Background)
+	}
 	
 	// Draw title
-	titleX := 320 - len(m.Title)*6 // Rough centering
-	ebitenutil.DebugPrintAt(screen, m.Title, titleX, 100)
+	titleX := 320 - len(m.Title)*scaled(6) // Rough centering
+	printAt(screen, m.Title, titleX, 100)
+	if m.Status != "" {
+		printAt(screen, m.Status, 320-len(m.Status)*scaled(3), 125)
+	}
+	if m.Summary != "" {
+		ebitenutil.DebugPrintAt(screen, m.Summary, 320-len(m.Summary)*3, 142)
+	}
+	
+	m.drawCards(screen)
 	
 	// Draw menu items
 	for _, item := range m.Items {
+		if !m.onPage(item) {
+			continue
+		}
+		
 		// Background
 		bgColor := color.RGBA{200, 200, 200, 255}
 		if item.Hovered {
@@ -106,8 +270,46 @@ func (m *Menu) Draw(screen *ebiten.Image) {
 		)
 		
 		// Text
-		textX := int(item.X + item.Width/2 - float64(len(item.Text)*3))
-		textY := int(item.Y + item.Height/2 - 4)
-		ebitenutil.DebugPrintAt(screen, item.Text, textX, textY)
+		textX := int(item.X + item.Width/2 - float64(len(item.Text)*scaled(3)))
+		textY := int(item.Y + item.Height/2 - float64(scaled(4)))
+		printAt(screen, item.Text, textX, textY)
+		
+		if item.Detail != "" {
+			ebitenutil.DebugPrintAt(screen, item.Detail, int(item.X+item.Width)+10, textY)
+		}
+		if item.Badge > 0 {
+			drawBadge(screen, item.X+item.Width, item.Y, item.Badge)
+		}
+	}
+	
+	if m.pages > 1 {
+		m.drawPager(screen)
+	}
+}
+
+// drawPager draws the page buttons and which page is shown
+func (m *Menu) drawPager(screen *ebiten.Image) {
+	for _, button := range m.pageButtons() {
+		bgColor := color.RGBA{200, 200, 200, 255}
+		if button.hovered {
+			bgColor = color.RGBA{150, 150, 250, 255}
+		}
+		vector.DrawFilledRect(screen, float32(button.x), float32(button.y), float32(button.width), float32(button.height), bgColor, false)
+		vector.StrokeRect(screen, float32(button.x), float32(button.y), float32(button.width), float32(button.height), 1, color.RGBA{100, 100, 100, 255}, false)
+		textX := int(button.x + button.width/2 - float64(len(button.text)*scaled(3)))
+		printAt(screen, button.text, textX, int(button.y+button.height/2)-scaled(4))
+	}
+	
+	label := strconv.Itoa(m.page+1) + "/" + strconv.Itoa(m.pages)
+	printAt(screen, label, 320-len(label)*scaled(3), int(m.prev.y+m.prev.height/2)-scaled(4))
+}
+
+// drawBadge draws a count in a red dot centred on (x, y)
+func drawBadge(screen *ebiten.Image, x, y float64, count int) {
+	text := strconv.Itoa(count)
+	if count > 9 {
+		text = "9+"
 	}
+	vector.DrawFilledCircle(screen, float32(x), float32(y), 9, color.RGBA{220, 50, 50, 255}, true)
+	ebitenutil.DebugPrintAt(screen, text, int(x)-len(text)*3, int(y)-8)
 }
\ No newline at end of file
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// MenuCard is a board thumbnail with a few lines of text that starts a game when clicked
+type MenuCard struct {
+	Title   string
+	Lines   []string // Drawn beside the thumbnail
+	Board   *island.Board
+	Key     string // Names the board's layout for the thumbnail cache
+	Action  func()
+	Hovered bool
+	x, y    int
+	width   int
+	height  int
+	thumb   int // Thumbnail size in pixels
+}
+
+// Card column, left of the menu items
+const (
+	menuCardLeft       = 20
+	menuCardWidth      = 180
+	continueCardTop    = 160
+	continueCardHeight = 120
+	continueThumbSize  = 80
+	recentTitleTop     = 295
+	recentCardTop      = 312
+	recentCardHeight   = 44
+	recentCardGap      = 6
+	recentThumbSize    = 36
+	menuCardPadding    = 6
+)
+
+// SetCards shows the saved game's card, or none when continue is nil, and the recently played levels
+func (m *Menu) SetCards(continueCard *MenuCard, recent []*MenuCard) {
+	m.Continue = continueCard
+	m.Recent = recent
+	if continueCard != nil {
+		continueCard.x, continueCard.y = menuCardLeft, continueCardTop
+		continueCard.width, continueCard.height = menuCardWidth, continueCardHeight
+		continueCard.thumb = continueThumbSize
+	}
+	for i, card := range recent {
+		card.x, card.y = menuCardLeft, recentCardTop+i*(recentCardHeight+recentCardGap)
+		card.width, card.height = menuCardWidth, recentCardHeight
+		card.thumb = recentThumbSize
+	}
+}
+
+// cards lists every card on the menu
+func (m *Menu) cards() []*MenuCard {
+	cards := make([]*MenuCard, 0, len(m.Recent)+1)
+	if m.Continue != nil {
+		cards = append(cards, m.Continue)
+	}
+	return append(cards, m.Recent...)
+}
+
+// updateCards tracks hover and runs the action of a clicked card, reporting whether one was
+func (m *Menu) updateCards(mouseX, mouseY int, clicked bool) bool {
+	for _, card := range m.cards() {
+		card.Hovered = inRect(mouseX, mouseY, card.x, card.y, card.width, card.height)
+		if card.Hovered && clicked && card.Action != nil {
+			card.Action()
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Menu) drawCards(screen *ebiten.Image) {
+	if m.Continue != nil {
+		m.drawCard(screen, m.Continue)
+	}
+	if len(m.Recent) > 0 {
+		ebitenutil.DebugPrintAt(screen, "Recently played", menuCardLeft, recentTitleTop)
+	}
+	for _, card := range m.Recent {
+		m.drawCard(screen, card)
+	}
+}
+
+func (m *Menu) drawCard(screen *ebiten.Image, card *MenuCard) {
+	bgColor := color.RGBA{220, 220, 220, 230}
+	if card.Hovered {
+		bgColor = color.RGBA{150, 150, 250, 230}
+	}
+	vector.DrawFilledRect(screen, float32(card.x), float32(card.y), float32(card.width), float32(card.height), bgColor, false)
+	vector.StrokeRect(screen, float32(card.x), float32(card.y), float32(card.width), float32(card.height), 2, color.RGBA{100, 100, 100, 255}, false)
+
+	thumbY := card.y + (card.height-card.thumb)/2
+	textY := card.y + menuCardPadding
+	if card.Title != "" {
+		ebitenutil.DebugPrintAt(screen, card.Title, card.x+menuCardPadding, textY)
+		thumbY = textY + 18
+	}
+	if m.Thumbnails != nil {
+		m.Thumbnails.Draw(screen, card.Key, func() *island.Board { return card.Board }, card.x+menuCardPadding, thumbY, card.thumb)
+	}
+
+	textX := card.x + 2*menuCardPadding + card.thumb
+	maxChars := (card.x + card.width - menuCardPadding - textX) / 6
+	for i, line := range card.Lines {
+		ebitenutil.DebugPrintAt(screen, truncateText(line, maxChars), textX, thumbY+i*16)
+	}
+}
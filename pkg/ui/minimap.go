@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/assets"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Mini-map box, left of the board under the HUD stats
+const (
+	miniMapX    = 10
+	miniMapY    = 250
+	miniMapSize = 120
+)
+
+// disconnectedColor marks land not yet joined to the largest group
+var disconnectedColor = color.RGBA{220, 50, 50, 255}
+
+// MiniMap shows the whole board with one pixel per tile, scaled up into a box,
+// and the part of it on screen. Clicking it calls OnJump with the board
+// position clicked, in tiles.
+type MiniMap struct {
+	OnJump func(gridX, gridY float64)
+
+	img    *ebiten.Image
+	pixels []byte
+}
+
+func NewMiniMap() *MiniMap {
+	return &MiniMap{}
+}
+
+// miniMapRect is where a board of the given size is drawn: as large as fits the box, keeping its shape
+func miniMapRect(boardWidth, boardHeight int) image.Rectangle {
+	scale := float64(miniMapSize) / float64(max(boardWidth, boardHeight, 1))
+	return image.Rect(0, 0, int(float64(boardWidth)*scale), int(float64(boardHeight)*scale)).Add(image.Pt(miniMapX, miniMapY))
+}
+
+// HandleClick jumps the camera to the clicked spot and reports whether the mini-map was hit
+func (mm *MiniMap) HandleClick(x, y, boardWidth, boardHeight int) bool {
+	rect := miniMapRect(boardWidth, boardHeight)
+	if !image.Pt(x, y).In(rect) {
+		return false
+	}
+	if mm.OnJump != nil {
+		mm.OnJump(
+			float64(x-rect.Min.X)*float64(boardWidth)/float64(rect.Dx()),
+			float64(y-rect.Min.Y)*float64(boardHeight)/float64(rect.Dy()),
+		)
+	}
+	return true
+}
+
+// Draw shows the board, with land outside the largest group in red, and
+// outlines the visible part given in tiles
+func (mm *MiniMap) Draw(screen *ebiten.Image, board *island.Board, theme assets.Theme, minX, minY, maxX, maxY float64) {
+	if mm.img == nil || mm.img.Bounds().Dx() != board.Width || mm.img.Bounds().Dy() != board.Height {
+		if mm.img != nil {
+			mm.img.Deallocate()
+		}
+		mm.img = ebiten.NewImage(board.Width, board.Height)
+		mm.pixels = make([]byte, 4*board.Width*board.Height)
+	}
+
+	main := largestGroup(board)
+	for idx, tile := range board.Tiles {
+		col := color.RGBA(theme.Grid)
+		switch tile.Type {
+		case island.TileSea:
+			col = color.RGBA(theme.Sea)
+		case island.TileBridge:
+			col = color.RGBA(theme.Bridge)
+		case island.TileLand:
+			col = color.RGBA(theme.Land)
+			if board.UnionFind.Find(idx) != main {
+				col = disconnectedColor
+			}
+		}
+		copy(mm.pixels[4*idx:], []byte{col.R, col.G, col.B, col.A})
+	}
+	mm.img.WritePixels(mm.pixels)
+
+	rect := miniMapRect(board.Width, board.Height)
+	scale := float64(rect.Dx()) / float64(board.Width)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	screen.DrawImage(mm.img, op)
+	vector.StrokeRect(screen, float32(rect.Min.X), float32(rect.Min.Y), float32(rect.Dx()), float32(rect.Dy()), 1, color.RGBA{60, 60, 60, 255}, false)
+
+	// The view may reach past the board; only the part over it is outlined
+	left := float32(rect.Min.X) + float32(max(minX, 0)*scale)
+	top := float32(rect.Min.Y) + float32(max(minY, 0)*scale)
+	right := float32(rect.Min.X) + float32(min(maxX, float64(board.Width))*scale)
+	bottom := float32(rect.Min.Y) + float32(min(maxY, float64(board.Height))*scale)
+	if right > left && bottom > top {
+		vector.StrokeRect(screen, left, top, right-left, bottom-top, 2, color.RGBA{255, 255, 255, 255}, false)
+	}
+}
+
+// largestGroup returns the union-find root of the group holding the most land tiles
+func largestGroup(board *island.Board) int {
+	counts := make(map[int]int)
+	best, bestCount := -1, 0
+	for _, idx := range board.Islands {
+		root := board.UnionFind.Find(idx)
+		counts[root]++
+		if counts[root] > bestCount {
+			best, bestCount = root, counts[root]
+		}
+	}
+	return best
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// ModeOption is a game mode listed on the Modes tab
+type ModeOption struct {
+	ID   int
+	Name string
+}
+
+// launchLabels are indexed by storage.GameSettings.LaunchInto
+var launchLabels = []string{"Main menu", "Preferred mode", "Last level"}
+
+// Modes tab layout, relative to the settings panel
+const (
+	modesLabelX     = 30
+	modesChoiceX    = 130
+	modesChoiceW    = 150
+	modesLaunchY    = 115
+	modesPreferredY = 140
+	modesHeaderY    = 175
+	modesRowY       = 192
+	modesRowSpacing = 22
+	modesTimersX    = 150
+	modesFeedbackX  = 240
+	modesCellW      = 70
+	modesCellH      = 18
+)
+
+func (slui *SaveLoadUI) handleModesClick(x, y, panelX, panelY int) bool {
+	if inRect(x, y, panelX+modesChoiceX, panelY+modesLaunchY, modesChoiceW, 20) {
+		slui.settings.LaunchInto = (slui.settings.LaunchInto + 1) % len(launchLabels)
+		slui.saveSettings()
+		slui.showStatus("On launch: " + launchLabels[slui.settings.LaunchInto])
+		return true
+	}
+	if len(slui.Modes) > 0 && inRect(x, y, panelX+modesChoiceX, panelY+modesPreferredY, modesChoiceW, 20) {
+		next := slui.Modes[(slui.preferredModeIndex()+1)%len(slui.Modes)]
+		slui.settings.PreferredMode = next.ID
+		slui.saveSettings()
+		slui.showStatus("Preferred mode: " + next.Name)
+		return true
+	}
+
+	for i, mode := range slui.Modes {
+		rowY := panelY + modesRowY + i*modesRowSpacing
+		defaults := slui.settings.ModeDefaults[mode.ID]
+		switch {
+		case inRect(x, y, panelX+modesTimersX, rowY, modesCellW, modesCellH):
+			defaults.Timers = nextOverride(defaults.Timers)
+			slui.showStatus(mode.Name + " timers: " + overrideLabel(defaults.Timers))
+		case inRect(x, y, panelX+modesFeedbackX, rowY, modesCellW, modesCellH):
+			defaults.MoveFeedback = nextOverride(defaults.MoveFeedback)
+			slui.showStatus(mode.Name + " move feedback: " + overrideLabel(defaults.MoveFeedback))
+		default:
+			continue
+		}
+		if slui.settings.ModeDefaults == nil {
+			slui.settings.ModeDefaults = make(map[int]storage.ModeDefaults)
+		}
+		if defaults.Timers == nil && defaults.MoveFeedback == nil {
+			delete(slui.settings.ModeDefaults, mode.ID)
+		} else {
+			slui.settings.ModeDefaults[mode.ID] = defaults
+		}
+		slui.saveSettings()
+		return true
+	}
+	return true
+}
+
+// preferredModeIndex finds the preferred mode in Modes, falling back to the first
+func (slui *SaveLoadUI) preferredModeIndex() int {
+	for i, mode := range slui.Modes {
+		if mode.ID == slui.settings.PreferredMode {
+			return i
+		}
+	}
+	return 0
+}
+
+// nextOverride cycles a per-mode override through default, on and off
+func nextOverride(value *bool) *bool {
+	switch {
+	case value == nil:
+		on := true
+		return &on
+	case *value:
+		off := false
+		return &off
+	default:
+		return nil
+	}
+}
+
+func overrideLabel(value *bool) string {
+	switch {
+	case value == nil:
+		return "Default"
+	case *value:
+		return "On"
+	default:
+		return "Off"
+	}
+}
+
+func (slui *SaveLoadUI) drawModesTab(screen *ebiten.Image, panelX, panelY int) {
+	ebitenutil.DebugPrintAt(screen, "Modes", panelX+20, panelY+90)
+
+	launch := slui.settings.LaunchInto
+	if launch < 0 || launch >= len(launchLabels) {
+		launch = storage.LaunchMenu
+	}
+	choiceColor := color.RGBA{150, 150, 250, 255}
+	ebitenutil.DebugPrintAt(screen, "On launch:", panelX+modesLabelX, panelY+modesLaunchY+6)
+	slui.drawButton(screen, panelX+modesChoiceX, panelY+modesLaunchY, modesChoiceW, 20, launchLabels[launch], choiceColor)
+	if len(slui.Modes) > 0 {
+		ebitenutil.DebugPrintAt(screen, "Preferred:", panelX+modesLabelX, panelY+modesPreferredY+6)
+		slui.drawButton(screen, panelX+modesChoiceX, panelY+modesPreferredY, modesChoiceW, 20, slui.Modes[slui.preferredModeIndex()].Name, choiceColor)
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Defaults", panelX+modesLabelX, panelY+modesHeaderY)
+	ebitenutil.DebugPrintAt(screen, "Timers", panelX+modesTimersX, panelY+modesHeaderY)
+	ebitenutil.DebugPrintAt(screen, "Feedback", panelX+modesFeedbackX, panelY+modesHeaderY)
+	for i, mode := range slui.Modes {
+		rowY := panelY + modesRowY + i*modesRowSpacing
+		defaults := slui.settings.ModeDefaults[mode.ID]
+		ebitenutil.DebugPrintAt(screen, truncateText(mode.Name, (modesTimersX-modesLabelX-10)/6), panelX+modesLabelX, rowY+4)
+		slui.drawButton(screen, panelX+modesTimersX, rowY, modesCellW, modesCellH, overrideLabel(defaults.Timers), overrideColor(defaults.Timers))
+		slui.drawButton(screen, panelX+modesFeedbackX, rowY, modesCellW, modesCellH, overrideLabel(defaults.MoveFeedback), overrideColor(defaults.MoveFeedback))
+	}
+}
+
+// overrideColor greys out options that follow the global setting
+func overrideColor(value *bool) color.Color {
+	switch {
+	case value == nil:
+		return color.RGBA{200, 200, 200, 255}
+	case *value:
+		return color.RGBA{100, 200, 100, 255}
+	default:
+		return color.RGBA{200, 130, 100, 255}
+	}
+}
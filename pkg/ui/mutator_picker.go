@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// MutatorOption is a mutator offered by the picker
+type MutatorOption struct {
+	ID          string
+	Name        string
+	Description string
+	Multiplier  float64
+}
+
+const (
+	mutatorX, mutatorY          = 120, 90
+	mutatorWidth, mutatorHeight = 400, 300
+	mutatorRowX                 = mutatorX + 20
+	mutatorRowWidth             = mutatorWidth - 40
+	mutatorRowHeight            = 40
+	mutatorRowTop               = mutatorY + 45
+	mutatorRowSpacing           = 46
+	mutatorButtonY              = mutatorY + mutatorHeight - dialogButtonHeight - 15
+)
+
+// MutatorPicker is a modal that picks the mutators a level is played under. It
+// takes every click while open; OnPlay runs after it closes with the chosen IDs,
+// in the order the options are listed.
+type MutatorPicker struct {
+	OnPlay   func(ids []string)
+	OnCancel func()
+
+	options        []MutatorOption
+	selected       map[string]bool
+	title          string
+	open           bool
+	hoverX, hoverY int
+}
+
+func NewMutatorPicker(options []MutatorOption) *MutatorPicker {
+	return &MutatorPicker{options: options, selected: make(map[string]bool)}
+}
+
+// Select ticks exactly the given mutators; unknown IDs are ignored
+func (mp *MutatorPicker) Select(ids []string) {
+	mp.selected = make(map[string]bool)
+	for _, id := range ids {
+		mp.selected[id] = true
+	}
+}
+
+// Selected returns the ticked mutators' IDs
+func (mp *MutatorPicker) Selected() []string {
+	var ids []string
+	for _, option := range mp.options {
+		if mp.selected[option.ID] {
+			ids = append(ids, option.ID)
+		}
+	}
+	return ids
+}
+
+// Multiplier is the score multiplier of the ticked mutators together
+func (mp *MutatorPicker) Multiplier() float64 {
+	multiplier := 1.0
+	for _, option := range mp.options {
+		if mp.selected[option.ID] {
+			multiplier *= option.Multiplier
+		}
+	}
+	return multiplier
+}
+
+// Show opens the picker for the named level
+func (mp *MutatorPicker) Show(title string) {
+	mp.title = title
+	mp.open = true
+}
+
+func (mp *MutatorPicker) IsOpen() bool {
+	return mp.open
+}
+
+// UpdateHover records the pointer position so rows and buttons can highlight under it
+func (mp *MutatorPicker) UpdateHover(x, y int) {
+	mp.hoverX, mp.hoverY = x, y
+}
+
+func mutatorRowY(index int) int {
+	return mutatorRowTop + index*mutatorRowSpacing
+}
+
+func (mp *MutatorPicker) HandleClick(x, y int) bool {
+	if !mp.open {
+		return false
+	}
+
+	for i, option := range mp.options {
+		if inRect(x, y, mutatorRowX, mutatorRowY(i), mutatorRowWidth, mutatorRowHeight) {
+			mp.selected[option.ID] = !mp.selected[option.ID]
+			return true
+		}
+	}
+
+	if inRect(x, y, mp.playX(), mutatorButtonY, dialogButtonWidth, dialogButtonHeight) {
+		mp.open = false
+		if mp.OnPlay != nil {
+			mp.OnPlay(mp.Selected())
+		}
+	} else if inRect(x, y, mp.cancelX(), mutatorButtonY, dialogButtonWidth, dialogButtonHeight) {
+		mp.open = false
+		if mp.OnCancel != nil {
+			mp.OnCancel()
+		}
+	}
+	return true
+}
+
+func (mp *MutatorPicker) playX() int {
+	return mutatorX + mutatorWidth/2 - dialogButtonWidth - 10
+}
+
+func (mp *MutatorPicker) cancelX() int {
+	return mutatorX + mutatorWidth/2 + 10
+}
+
+func (mp *MutatorPicker) Draw(screen *ebiten.Image) {
+	if !mp.open {
+		return
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	vector.DrawFilledRect(screen, mutatorX, mutatorY, mutatorWidth, mutatorHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, mutatorX, mutatorY, mutatorWidth, mutatorHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Mutators - "+mp.title, mutatorX+20, mutatorY+15)
+
+	for i, option := range mp.options {
+		mp.drawRow(screen, i, option)
+	}
+
+	summary := "No mutators: normal rules"
+	if len(mp.Selected()) > 0 {
+		summary = fmt.Sprintf("Score multiplier: x%.2f", mp.Multiplier())
+	}
+	ebitenutil.DebugPrintAt(screen, summary, mutatorRowX, mutatorButtonY-22)
+
+	mp.drawButton(screen, mp.playX(), "Play", color.RGBA{100, 200, 100, 255})
+	mp.drawButton(screen, mp.cancelX(), "Cancel", color.RGBA{200, 200, 200, 255})
+}
+
+func (mp *MutatorPicker) drawRow(screen *ebiten.Image, index int, option MutatorOption) {
+	y := mutatorRowY(index)
+	bg := color.Color(color.RGBA{225, 225, 225, 255})
+	if mp.selected[option.ID] {
+		bg = color.RGBA{150, 200, 240, 255}
+	}
+	if inRect(mp.hoverX, mp.hoverY, mutatorRowX, y, mutatorRowWidth, mutatorRowHeight) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, mutatorRowX, float32(y), mutatorRowWidth, mutatorRowHeight, bg, false)
+	vector.StrokeRect(screen, mutatorRowX, float32(y), mutatorRowWidth, mutatorRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+
+	check := "[ ]"
+	if mp.selected[option.ID] {
+		check = "[x]"
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s %s  x%.2f", check, option.Name, option.Multiplier), mutatorRowX+10, y+4)
+	ebitenutil.DebugPrintAt(screen, option.Description, mutatorRowX+34, y+20)
+}
+
+func (mp *MutatorPicker) drawButton(screen *ebiten.Image, x int, label string, bgColor color.Color) {
+	if inRect(mp.hoverX, mp.hoverY, x, mutatorButtonY, dialogButtonWidth, dialogButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), mutatorButtonY, dialogButtonWidth, dialogButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, float32(x), mutatorButtonY, dialogButtonWidth, dialogButtonHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, x+(dialogButtonWidth-len(label)*6)/2, mutatorButtonY+dialogButtonHeight/2-4)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Next level button layout, right of the victory results
+const (
+	nextLevelX, nextLevelY          = 460, 250
+	nextLevelWidth, nextLevelHeight = 170, 26
+	nextLevelReasonChars            = 27
+)
+
+// NextLevelButton offers the recommended level on the victory screen, with
+// why it was picked underneath. While auto-advance counts down the button
+// shows the seconds left.
+type NextLevelButton struct {
+	OnPlay func()
+
+	reason         []string
+	countdown      int
+	shown          bool
+	hoverX, hoverY int
+}
+
+func NewNextLevelButton() *NextLevelButton {
+	return &NextLevelButton{}
+}
+
+// Show offers a level for the reason given
+func (nb *NextLevelButton) Show(reason string) {
+	nb.reason = wrapWords(reason, nextLevelReasonChars)
+	nb.shown = true
+}
+
+func (nb *NextLevelButton) Hide() {
+	nb.shown = false
+}
+
+// SetCountdown shows the seconds left before the level plays by itself; 0 shows none
+func (nb *NextLevelButton) SetCountdown(seconds int) {
+	nb.countdown = seconds
+}
+
+// UpdateHover records the pointer position so the button can highlight under it
+func (nb *NextLevelButton) UpdateHover(x, y int) {
+	nb.hoverX, nb.hoverY = x, y
+}
+
+func (nb *NextLevelButton) HandleClick(x, y int) bool {
+	if !nb.shown || !inRect(x, y, nextLevelX, nextLevelY, nextLevelWidth, nextLevelHeight) {
+		return false
+	}
+	if nb.OnPlay != nil {
+		nb.OnPlay()
+	}
+	return true
+}
+
+func (nb *NextLevelButton) Draw(screen *ebiten.Image) {
+	if !nb.shown {
+		return
+	}
+
+	// The reason sits on a light card so it reads over the board
+	cardHeight := nextLevelHeight + 10 + len(nb.reason)*14
+	vector.DrawFilledRect(screen, nextLevelX-5, nextLevelY-5, nextLevelWidth+10, float32(cardHeight+5), color.RGBA{240, 240, 240, 220}, false)
+	for i, line := range nb.reason {
+		ebitenutil.DebugPrintAt(screen, line, nextLevelX, nextLevelY+nextLevelHeight+4+i*14)
+	}
+
+	bg := color.Color(color.RGBA{100, 200, 100, 255})
+	if inRect(nb.hoverX, nb.hoverY, nextLevelX, nextLevelY, nextLevelWidth, nextLevelHeight) {
+		bg = brighten(bg)
+	}
+	label := "Play next recommended"
+	if nb.countdown > 0 {
+		label = fmt.Sprintf("%s (%d)", label, nb.countdown)
+	}
+	vector.DrawFilledRect(screen, nextLevelX, nextLevelY, nextLevelWidth, nextLevelHeight, bg, false)
+	vector.StrokeRect(screen, nextLevelX, nextLevelY, nextLevelWidth, nextLevelHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, nextLevelX+(nextLevelWidth-len(label)*6)/2, nextLevelY+nextLevelHeight/2-8)
+}
+
+// wrapWords breaks text into lines of at most width characters, at spaces
+func wrapWords(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Plan buttons sit right of the board, clear of the bottom banners
+const (
+	planBarX         = 565
+	planButtonTop    = 380
+	planButtonWidth  = 66
+	planButtonHeight = 24
+	planButtonGap    = 6
+)
+
+// PlanBar holds the in-game buttons of planning mode: one toggles it, the other
+// builds every planned bridge
+type PlanBar struct {
+	OnToggle func()
+	OnCommit func()
+
+	hoverX, hoverY int
+}
+
+func NewPlanBar() *PlanBar {
+	return &PlanBar{}
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (pb *PlanBar) UpdateHover(x, y int) {
+	pb.hoverX, pb.hoverY = x, y
+}
+
+func (pb *PlanBar) commitY() int {
+	return planButtonTop + planButtonHeight + planButtonGap
+}
+
+// HandleClick runs the clicked button's action and reports whether one was hit
+func (pb *PlanBar) HandleClick(x, y int) bool {
+	if inRect(x, y, planBarX, planButtonTop, planButtonWidth, planButtonHeight) {
+		if pb.OnToggle != nil {
+			pb.OnToggle()
+		}
+		return true
+	}
+	if inRect(x, y, planBarX, pb.commitY(), planButtonWidth, planButtonHeight) {
+		if pb.OnCommit != nil {
+			pb.OnCommit()
+		}
+		return true
+	}
+	return false
+}
+
+// Draw shows the buttons; the toggle is lit while planning and Commit is grey with nothing planned
+func (pb *PlanBar) Draw(screen *ebiten.Image, planning bool, planned int) {
+	toggleColor := color.Color(color.RGBA{220, 220, 220, 255})
+	if planning {
+		toggleColor = color.RGBA{150, 150, 250, 255}
+	}
+	pb.drawButton(screen, planButtonTop, "Plan", toggleColor)
+
+	commitColor := color.Color(color.RGBA{180, 180, 180, 255})
+	if planned > 0 {
+		commitColor = color.RGBA{120, 200, 120, 255}
+	}
+	pb.drawButton(screen, pb.commitY(), fmt.Sprintf("Commit %d", planned), commitColor)
+}
+
+func (pb *PlanBar) drawButton(screen *ebiten.Image, y int, text string, bgColor color.Color) {
+	if inRect(pb.hoverX, pb.hoverY, planBarX, y, planButtonWidth, planButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, planBarX, float32(y), planButtonWidth, planButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, planBarX, float32(y), planButtonWidth, planButtonHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+	text = truncateText(text, (planButtonWidth-8)/hudCharWidth)
+	ebitenutil.DebugPrintAt(screen, text, planBarX+(planButtonWidth-len(text)*hudCharWidth)/2, y+planButtonHeight/2-8)
+}
@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+const (
+	profilePanelX, profilePanelY          = 140, 30
+	profilePanelWidth, profilePanelHeight = 360, 420
+
+	profileListX      = profilePanelX + 30
+	profileListY      = profilePanelY + 60
+	profileRowWidth   = 260
+	profileRowHeight  = 44
+	profileDeleteX    = profileListX + profileRowWidth + 10
+	profileNewY       = profilePanelY + 340
+	profileNameMaxLen = 16
+)
+
+// ProfileSelectUI lets players on a shared device pick, create and delete profiles
+type ProfileSelectUI struct {
+	saveSystem     *storage.SaveSystem
+	profiles       []storage.Profile
+	showPanel      bool
+	hoverX, hoverY int
+	statusMessage  string
+
+	naming  bool   // The new profile name box is open
+	name    []rune // Name typed so far
+	confirm *ConfirmDialog
+
+	OnProfileSelected func(profileID string)
+	OnBack            func()
+}
+
+func NewProfileSelectUI(saveSystem *storage.SaveSystem) *ProfileSelectUI {
+	return &ProfileSelectUI{saveSystem: saveSystem, confirm: NewConfirmDialog()}
+}
+
+func (psui *ProfileSelectUI) Show() {
+	psui.showPanel = true
+	psui.naming = false
+	psui.name = nil
+	psui.statusMessage = ""
+	psui.profiles = psui.saveSystem.Profiles()
+}
+
+func (psui *ProfileSelectUI) Hide() {
+	psui.showPanel = false
+}
+
+func (psui *ProfileSelectUI) IsShown() bool {
+	return psui.showPanel
+}
+
+// IsNaming reports whether typing goes to the new profile name box
+func (psui *ProfileSelectUI) IsNaming() bool {
+	return psui.showPanel && psui.naming
+}
+
+// UpdateHover records the pointer position so rows can highlight under it
+func (psui *ProfileSelectUI) UpdateHover(x, y int) {
+	psui.hoverX, psui.hoverY = x, y
+	psui.confirm.UpdateHover(x, y)
+}
+
+// HandleText feeds typing into the name box; Enter creates the profile
+func (psui *ProfileSelectUI) HandleText(text systems.TextInput) {
+	if !psui.IsNaming() {
+		return
+	}
+
+	for _, char := range text.Chars {
+		// The debug font only covers printable ASCII
+		if char >= ' ' && char <= '~' && len(psui.name) < profileNameMaxLen {
+			psui.name = append(psui.name, char)
+		}
+	}
+	if text.Backspace && len(psui.name) > 0 {
+		psui.name = psui.name[:len(psui.name)-1]
+	}
+	if text.Enter {
+		psui.createProfile()
+	}
+}
+
+func (psui *ProfileSelectUI) HandleClick(x, y int) bool {
+	if !psui.showPanel {
+		return false
+	}
+	if psui.confirm.HandleClick(x, y) {
+		return true
+	}
+
+	// Back button keeps the current profile
+	if inRect(x, y, profilePanelX+profilePanelWidth-40, profilePanelY+10, 30, 30) {
+		psui.Hide()
+		if psui.OnBack != nil {
+			psui.OnBack()
+		}
+		return true
+	}
+
+	for i, profile := range psui.profiles {
+		rowY := profileListY + i*profileRowHeight
+		if inRect(x, y, profileListX, rowY, profileRowWidth, profileRowHeight-8) {
+			psui.Hide()
+			if psui.OnProfileSelected != nil {
+				psui.OnProfileSelected(profile.ID)
+			}
+			return true
+		}
+		if psui.canDelete(profile) && inRect(x, y, profileDeleteX, rowY, 30, profileRowHeight-8) {
+			psui.confirm.Confirm("Delete profile "+profile.Name+"?", "Delete", func() {
+				psui.deleteProfile(profile)
+			}, "Its progress, settings and saves go with it.")
+			return true
+		}
+	}
+
+	if psui.naming {
+		if inRect(x, y, profileListX+profileRowWidth-120, profileNewY+36, 55, 26) {
+			psui.createProfile()
+		} else if inRect(x, y, profileListX+profileRowWidth-55, profileNewY+36, 55, 26) {
+			psui.naming = false
+			psui.name = nil
+		}
+		return true
+	}
+
+	if inRect(x, y, profileListX, profileNewY, profileRowWidth, 30) {
+		psui.naming = true
+		psui.statusMessage = "Type a name and press Enter"
+	}
+	return true
+}
+
+// canDelete reports whether a profile has a delete button; the default and
+// active profiles cannot be removed
+func (psui *ProfileSelectUI) canDelete(profile storage.Profile) bool {
+	return profile.ID != storage.DefaultProfileID && profile.ID != psui.saveSystem.CurrentProfile().ID
+}
+
+func (psui *ProfileSelectUI) deleteProfile(profile storage.Profile) {
+	if err := psui.saveSystem.DeleteProfile(profile.ID); err != nil {
+		psui.statusMessage = "Delete failed: " + err.Error()
+		return
+	}
+	psui.profiles = psui.saveSystem.Profiles()
+	psui.statusMessage = "Deleted " + profile.Name
+}
+
+// createProfile adds the typed profile and switches to it straight away
+func (psui *ProfileSelectUI) createProfile() {
+	profile, err := psui.saveSystem.CreateProfile(string(psui.name))
+	if err != nil {
+		psui.statusMessage = "Cannot create profile: " + err.Error()
+		return
+	}
+
+	psui.naming = false
+	psui.name = nil
+	psui.Hide()
+	if psui.OnProfileSelected != nil {
+		psui.OnProfileSelected(profile.ID)
+	}
+}
+
+func (psui *ProfileSelectUI) Draw(screen *ebiten.Image) {
+	if !psui.showPanel {
+		return
+	}
+
+	// Panel background
+	vector.DrawFilledRect(screen, profilePanelX, profilePanelY, profilePanelWidth, profilePanelHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, profilePanelX, profilePanelY, profilePanelWidth, profilePanelHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+
+	// Title
+	ebitenutil.DebugPrintAt(screen, "Who's playing?", profilePanelX+20, profilePanelY+15)
+
+	// Back button
+	vector.DrawFilledRect(screen, profilePanelX+profilePanelWidth-40, profilePanelY+10, 30, 30, color.RGBA{200, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "←", profilePanelX+profilePanelWidth-30, profilePanelY+20)
+
+	current := psui.saveSystem.CurrentProfile().ID
+	for i, profile := range psui.profiles {
+		rowY := profileListY + i*profileRowHeight
+
+		bgColor := color.Color(color.RGBA{255, 255, 255, 255})
+		if profile.ID == current {
+			bgColor = color.RGBA{200, 220, 255, 255}
+		}
+		if inRect(psui.hoverX, psui.hoverY, profileListX, rowY, profileRowWidth, profileRowHeight-8) {
+			bgColor = brighten(bgColor)
+		}
+		vector.DrawFilledRect(screen, float32(profileListX), float32(rowY), profileRowWidth, profileRowHeight-8, bgColor, false)
+		vector.StrokeRect(screen, float32(profileListX), float32(rowY), profileRowWidth, profileRowHeight-8, 1, color.RGBA{150, 150, 150, 255}, false)
+
+		label := profile.Name
+		if profile.ID == current {
+			label += "  (playing)"
+		}
+		ebitenutil.DebugPrintAt(screen, label, profileListX+10, rowY+12)
+
+		if psui.canDelete(profile) {
+			vector.DrawFilledRect(screen, float32(profileDeleteX), float32(rowY), 30, profileRowHeight-8, color.RGBA{200, 100, 100, 255}, false)
+			ebitenutil.DebugPrintAt(screen, "x", profileDeleteX+12, rowY+12)
+		}
+	}
+
+	psui.drawNewProfile(screen)
+
+	if psui.statusMessage != "" {
+		ebitenutil.DebugPrintAt(screen, psui.statusMessage, profilePanelX+20, profilePanelY+profilePanelHeight-16)
+	}
+	psui.confirm.Draw(screen)
+}
+
+// drawNewProfile draws the New Profile button, or the name box while naming
+func (psui *ProfileSelectUI) drawNewProfile(screen *ebiten.Image) {
+	if !psui.naming {
+		btnColor := color.Color(color.RGBA{100, 200, 100, 255})
+		if inRect(psui.hoverX, psui.hoverY, profileListX, profileNewY, profileRowWidth, 30) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(profileListX), float32(profileNewY), profileRowWidth, 30, btnColor, false)
+		text := "+ New Profile"
+		ebitenutil.DebugPrintAt(screen, text, profileListX+(profileRowWidth-len(text)*6)/2, profileNewY+8)
+		return
+	}
+
+	vector.DrawFilledRect(screen, float32(profileListX), float32(profileNewY), profileRowWidth, 30, color.RGBA{255, 255, 255, 255}, false)
+	vector.StrokeRect(screen, float32(profileListX), float32(profileNewY), profileRowWidth, 30, 2, color.RGBA{50, 100, 200, 255}, false)
+	ebitenutil.DebugPrintAt(screen, string(psui.name)+"_", profileListX+8, profileNewY+8)
+
+	buttons := []struct {
+		text  string
+		x     int
+		color color.RGBA
+	}{
+		{"Create", profileListX + profileRowWidth - 120, color.RGBA{100, 200, 100, 255}},
+		{"Cancel", profileListX + profileRowWidth - 55, color.RGBA{200, 200, 200, 255}},
+	}
+	for _, button := range buttons {
+		btnColor := color.Color(button.color)
+		if inRect(psui.hoverX, psui.hoverY, button.x, profileNewY+36, 55, 26) {
+			btnColor = brighten(btnColor)
+		}
+		vector.DrawFilledRect(screen, float32(button.x), float32(profileNewY+36), 55, 26, btnColor, false)
+		ebitenutil.DebugPrintAt(screen, button.text, button.x+(55-len(button.text)*6)/2, profileNewY+42)
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/profiler"
+)
+
+const (
+	profilerX, profilerY          = 10, 300
+	profilerWidth, profilerHeight = 316, 168
+	profilerChartX                = profilerX + 8
+	profilerChartY                = profilerY + 22
+	profilerChartHeight           = 80
+	profilerBarWidth              = 2 // Pixels per frame
+	profilerLegendY               = profilerChartY + profilerChartHeight + 8
+	profilerLegendColumn          = 152
+
+	// profilerChartBudgets is how many frame budgets fit in the chart's height
+	profilerChartBudgets = 1.5
+)
+
+// profilerColors tint each section's bars, indexed by profiler.Section. Update
+// time uses the colour and draw time a darker shade of it.
+var profilerColors = [profiler.SectionCount]color.RGBA{
+	{33, 150, 243, 255},  // Input
+	{76, 175, 80, 255},   // Board
+	{255, 193, 7, 255},   // Animation
+	{244, 67, 54, 255},   // Render
+	{156, 39, 176, 255},  // UI
+	{158, 158, 158, 255}, // Other
+}
+
+// ProfilerOverlay charts the time each system took in recent frames as stacked
+// bars against the frame budget, for performance work
+type ProfilerOverlay struct{}
+
+func NewProfilerOverlay() *ProfilerOverlay {
+	return &ProfilerOverlay{}
+}
+
+// Draw charts the profiler's frames; nothing is drawn while it is disabled
+func (po *ProfilerOverlay) Draw(screen *ebiten.Image, p *profiler.Profiler) {
+	if !p.Enabled() {
+		return
+	}
+
+	budget := time.Second / time.Duration(ebiten.TPS())
+	scale := profilerChartHeight / (profilerChartBudgets * float32(budget))
+	average := p.Average()
+
+	vector.DrawFilledRect(screen, profilerX, profilerY, profilerWidth, profilerHeight, color.RGBA{0, 0, 0, 180}, false)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Frame %.2f ms of %.1f ms (update/draw)", ms(average.Total()), ms(budget)), profilerX+6, profilerY+4)
+
+	// Stack each frame's update sections, then its draw sections, from the bottom
+	bottom := float32(profilerChartY + profilerChartHeight)
+	for i, frame := range p.Frames() {
+		x := float32(profilerChartX + i*profilerBarWidth)
+		y := bottom
+		for phase := range frame {
+			for section, d := range frame[phase] {
+				height := min(float32(d)*scale, y-profilerChartY)
+				if height <= 0 {
+					continue
+				}
+				y -= height
+				vector.DrawFilledRect(screen, x, y, profilerBarWidth, height, profilerColor(profiler.Section(section), profiler.Phase(phase)), false)
+			}
+		}
+	}
+	budgetY := bottom - float32(budget)*scale
+	vector.StrokeLine(screen, profilerChartX, budgetY, profilerChartX+profiler.HistorySize*profilerBarWidth, budgetY, 1, color.RGBA{255, 255, 255, 200}, false)
+
+	for section, name := range profiler.SectionNames {
+		x := profilerChartX + (section%2)*profilerLegendColumn
+		y := profilerLegendY + (section/2)*16
+		vector.DrawFilledRect(screen, float32(x), float32(y+4), 8, 8, profilerColors[section], false)
+		line := fmt.Sprintf("%-9s %.2f/%.2f", name, ms(average[profiler.Update][section]), ms(average[profiler.Draw][section]))
+		ebitenutil.DebugPrintAt(screen, line, x+12, y)
+	}
+}
+
+// profilerColor is a section's bar colour, darker for draw time
+func profilerColor(section profiler.Section, phase profiler.Phase) color.RGBA {
+	c := profilerColors[section]
+	if phase == profiler.Draw {
+		c.R, c.G, c.B = c.R/5*3, c.G/5*3, c.B/5*3
+	}
+	return c
+}
+
+// ms converts a duration to fractional milliseconds
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
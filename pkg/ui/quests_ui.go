@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/cosmetics"
+	"github.com/ponyo877/island-merge/pkg/quests"
+)
+
+// Quests panel layout
+const (
+	questsX, questsY          = 100, 50
+	questsWidth, questsHeight = 440, 380
+	questRowX                 = questsX + 20
+	questRowWidth             = questsWidth - 40
+	questRowHeight            = 32
+	questRowStep              = 38
+	questsDailyY              = questsY + 40
+	questsWeeklyY             = questsY + 172
+	questsPaintsY             = questsY + 268
+	paintSwatchWidth          = 60
+	paintSwatchHeight         = 34
+	paintSwatchStep           = 67
+)
+
+// QuestsUI is the main menu's panel of daily and weekly quests, with the bridge
+// paints their tokens unlock. It takes every click while open.
+type QuestsUI struct {
+	OnPaint func(id string) // Called when an unlocked paint is picked; "" is the theme's colour
+
+	log            *quests.Log
+	paint          string
+	dailyLeft      string // Time until the daily quests rotate, e.g. "5h 12m"
+	weeklyLeft     string
+	open           bool
+	hoverX, hoverY int
+}
+
+func NewQuestsUI() *QuestsUI {
+	return &QuestsUI{}
+}
+
+// Show opens the panel on a quest log with the chosen paint and the time left
+// until each period's quests rotate
+func (qui *QuestsUI) Show(log *quests.Log, paint, dailyLeft, weeklyLeft string) {
+	qui.log = log
+	qui.paint = paint
+	qui.dailyLeft, qui.weeklyLeft = dailyLeft, weeklyLeft
+	qui.open = true
+}
+
+func (qui *QuestsUI) IsOpen() bool {
+	return qui.open
+}
+
+// UpdateHover records the pointer position for hover highlights
+func (qui *QuestsUI) UpdateHover(x, y int) {
+	qui.hoverX, qui.hoverY = x, y
+}
+
+// questsCloseRect is the Close button at the bottom right of the panel
+func questsCloseRect() (x, y int) {
+	return questsX + questsWidth - dialogButtonWidth/2 - 30, questsY + questsHeight - 34
+}
+
+// paintSwatchX places the theme's own colour first, then each paint
+func paintSwatchX(index int) int {
+	return questRowX + index*paintSwatchStep
+}
+
+func (qui *QuestsUI) HandleClick(x, y int) bool {
+	if !qui.open {
+		return false
+	}
+
+	closeX, closeY := questsCloseRect()
+	if inRect(x, y, closeX, closeY, dialogButtonWidth/2, 26) || !inRect(x, y, questsX, questsY, questsWidth, questsHeight) {
+		qui.open = false
+		return true
+	}
+
+	swatchY := questsPaintsY + 18
+	if inRect(x, y, paintSwatchX(0), swatchY, paintSwatchWidth, paintSwatchHeight) {
+		qui.pick("")
+	}
+	for i, paint := range cosmetics.Paints {
+		if paint.Unlocked(qui.log.Tokens) && inRect(x, y, paintSwatchX(i+1), swatchY, paintSwatchWidth, paintSwatchHeight) {
+			qui.pick(paint.ID)
+		}
+	}
+	return true
+}
+
+func (qui *QuestsUI) pick(id string) {
+	qui.paint = id
+	if qui.OnPaint != nil {
+		qui.OnPaint(id)
+	}
+}
+
+func (qui *QuestsUI) Draw(screen *ebiten.Image) {
+	if !qui.open {
+		return
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	vector.DrawFilledRect(screen, questsX, questsY, questsWidth, questsHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, questsX, questsY, questsWidth, questsHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Quests", questsX+20, questsY+15)
+	tokens := fmt.Sprintf("Tokens: %d", qui.log.Tokens)
+	ebitenutil.DebugPrintAt(screen, tokens, questsX+questsWidth-20-len(tokens)*6, questsY+15)
+
+	qui.drawPeriod(screen, quests.Daily, "Daily, new in "+qui.dailyLeft, questsDailyY)
+	qui.drawPeriod(screen, quests.Weekly, "Weekly, new in "+qui.weeklyLeft, questsWeeklyY)
+	qui.drawPaints(screen)
+
+	closeX, closeY := questsCloseRect()
+	bg := color.Color(color.RGBA{200, 200, 200, 255})
+	if inRect(qui.hoverX, qui.hoverY, closeX, closeY, dialogButtonWidth/2, 26) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, float32(closeX), float32(closeY), dialogButtonWidth/2, 26, bg, false)
+	vector.StrokeRect(screen, float32(closeX), float32(closeY), dialogButtonWidth/2, 26, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Close", closeX+(dialogButtonWidth/2-5*6)/2, closeY+8)
+}
+
+// drawPeriod lists one period's quests under a heading
+func (qui *QuestsUI) drawPeriod(screen *ebiten.Image, period quests.Period, heading string, y int) {
+	ebitenutil.DebugPrintAt(screen, heading, questRowX, y)
+	row := 0
+	for _, quest := range qui.log.Quests {
+		if quest.Period != period {
+			continue
+		}
+		rowY := y + 18 + row*questRowStep
+		row++
+
+		bg := color.RGBA{225, 225, 225, 255}
+		if quest.Done() {
+			bg = color.RGBA{190, 235, 190, 255}
+		}
+		vector.DrawFilledRect(screen, questRowX, float32(rowY), questRowWidth, questRowHeight, bg, false)
+		vector.StrokeRect(screen, questRowX, float32(rowY), questRowWidth, questRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, truncateText(quest.Description, 50), questRowX+8, rowY+3)
+
+		reward := fmt.Sprintf("+%d", quest.Tokens)
+		if quest.Done() {
+			reward = "Done"
+		}
+		ebitenutil.DebugPrintAt(screen, reward, questRowX+questRowWidth-8-len(reward)*6, rowY+3)
+
+		barWidth := float32(questRowWidth - 80)
+		vector.DrawFilledRect(screen, questRowX+8, float32(rowY+22), barWidth, 6, color.RGBA{160, 160, 160, 255}, false)
+		vector.DrawFilledRect(screen, questRowX+8, float32(rowY+22), barWidth*float32(quest.Progress)/float32(quest.Target), 6, color.RGBA{0, 170, 0, 255}, false)
+		count := fmt.Sprintf("%d/%d", quest.Progress, quest.Target)
+		ebitenutil.DebugPrintAt(screen, count, questRowX+questRowWidth-8-len(count)*6, rowY+17)
+	}
+}
+
+// drawPaints draws a swatch for the theme's colour and each paint; locked
+// paints show the tokens they need, and the chosen one is outlined
+func (qui *QuestsUI) drawPaints(screen *ebiten.Image) {
+	ebitenutil.DebugPrintAt(screen, "Bridge paint", questRowX, questsPaintsY)
+	if next, ok := cosmetics.NextUnlock(qui.log.Tokens); ok {
+		hint := fmt.Sprintf("%d more tokens for %s", next.Tokens-qui.log.Tokens, next.Name)
+		ebitenutil.DebugPrintAt(screen, hint, questRowX+questRowWidth-len(hint)*6, questsPaintsY)
+	}
+
+	swatchY := questsPaintsY + 18
+	qui.drawSwatch(screen, 0, swatchY, "Theme", color.RGBA{200, 200, 200, 255}, qui.paint == "", true)
+	for i, paint := range cosmetics.Paints {
+		label := paint.Name
+		unlocked := paint.Unlocked(qui.log.Tokens)
+		if !unlocked {
+			label = fmt.Sprintf("%d tok", paint.Tokens)
+		}
+		qui.drawSwatch(screen, i+1, swatchY, label, paint.Color, qui.paint == paint.ID, unlocked)
+	}
+}
+
+func (qui *QuestsUI) drawSwatch(screen *ebiten.Image, index, y int, label string, fill color.Color, chosen, unlocked bool) {
+	x := paintSwatchX(index)
+	if !unlocked {
+		fill = color.RGBA{150, 150, 150, 255}
+	} else if inRect(qui.hoverX, qui.hoverY, x, y, paintSwatchWidth, paintSwatchHeight) {
+		fill = brighten(fill)
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), paintSwatchWidth, paintSwatchHeight, fill, false)
+	border, width := color.RGBA{100, 100, 100, 255}, float32(1)
+	if chosen {
+		border, width = color.RGBA{255, 215, 0, 255}, 3
+	}
+	vector.StrokeRect(screen, float32(x), float32(y), paintSwatchWidth, paintSwatchHeight, width, border, false)
+	ebitenutil.DebugPrintAt(screen, truncateText(label, 9), x+4, y+paintSwatchHeight+2)
+}
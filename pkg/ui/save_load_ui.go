@@ -1,34 +1,96 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
 	"image/color"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/capture"
+	"github.com/ponyo877/island-merge/pkg/events"
 	"github.com/ponyo877/island-merge/pkg/storage"
+	"github.com/ponyo877/island-merge/pkg/systems"
+)
+
+// settingsTabs are indexed by SaveLoadUI.selectedTab
+var settingsTabs = []string{"Save/Load", "Settings", "Data", "Controls", "Graphics", "Modes"}
+
+const tabWidth = 63
+
+// aiSkillLabels are indexed by ai.Skill
+var aiSkillLabels = []string{"Off", "Random", "Easy", "Medium", "Hard"}
+
+// aiPersonalityLabels are indexed by ai.Personality
+var aiPersonalityLabels = []string{"Steady", "Planner", "Rusher", "Chaotic"}
+
+// idlePauseChoices are the idle pause times the settings button cycles
+// through, in seconds; -1 turns idle pausing off
+var idlePauseChoices = []struct {
+	seconds int
+	label   string
+}{
+	{30, "30s"},
+	{storage.DefaultIdlePause, "1m"},
+	{120, "2m"},
+	{-1, "Off"},
+}
+
+// autoSaveMoveChoices are the move counts between auto-saves that the Save/Load
+// tab cycles through
+var autoSaveMoveChoices = []int{1, storage.DefaultAutoSaveMoves, 10}
+
+// clearUndoPeriod is how long Clear All Data can be undone
+const clearUndoPeriod = 10 * time.Second
+
+// Storage meter on the Data tab
+const (
+	usageX        = 220 // From the panel's left edge
+	usageWidth    = 160
+	usageRows     = 8
+	freeSpaceY    = 280 // From the panel's top edge
+	freeSpaceSize = 30
+	
+	transferWidth = 75 // Export and Import share a row
 )
 
 type SaveLoadUI struct {
 	saveSystem    *storage.SaveSystem
 	showPanel     bool
-	selectedTab   int // 0: Save/Load, 1: Settings, 2: Import/Export
+	selectedTab   int // 0: Save/Load, 1: Settings, 2: Import/Export, 3: Controls, 4: Graphics, 5: Modes
 	settings      *storage.GameSettings
 	statusMessage string
 	statusTime    time.Time
-	OnSaveGame    func()
-	OnLoadGame    func()
+	events        *events.Bus
+	hoverX        int
+	hoverY        int
+	bindings      systems.Bindings
+	capturing     systems.Control // Control waiting for a new input; "" when not rebinding
+	confirm       *ConfirmDialog
+	cleared       storage.ClearedData // Data removed by Clear All Data, kept for undo
+	clearedAt     time.Time
+	
+	// Modes are the game modes the Modes tab sets a launch preference and defaults for
+	Modes []ModeOption
+	// OnSettingsChanged is called after settings are saved so the game can apply them
+	OnSettingsChanged func(*storage.GameSettings)
+	// AnalyticsPending reports how many statistics events wait to be uploaded
+	AnalyticsPending func() int
 }
 
-func NewSaveLoadUI(saveSystem *storage.SaveSystem) *SaveLoadUI {
+func NewSaveLoadUI(saveSystem *storage.SaveSystem, bus *events.Bus) *SaveLoadUI {
 	settings, _ := saveSystem.LoadSettings()
 	return &SaveLoadUI{
 		saveSystem:  saveSystem,
 		showPanel:   false,
 		selectedTab: 0,
 		settings:    settings,
+		events:      bus,
+		bindings:    systems.BindingsFromSettings(settings.KeyBindings),
+		confirm:     NewConfirmDialog(),
 	}
 }
 
@@ -38,6 +100,16 @@ func (slui *SaveLoadUI) TogglePanel() {
 		// Refresh settings when opening
 		settings, _ := slui.saveSystem.LoadSettings()
 		slui.settings = settings
+		slui.bindings = systems.BindingsFromSettings(settings.KeyBindings)
+	}
+	slui.capturing = ""
+}
+
+// saveSettings persists the current settings and notifies OnSettingsChanged
+func (slui *SaveLoadUI) saveSettings() {
+	slui.saveSystem.SaveSettings(slui.settings)
+	if slui.OnSettingsChanged != nil {
+		slui.OnSettingsChanged(slui.settings)
 	}
 }
 
@@ -45,18 +117,38 @@ func (slui *SaveLoadUI) IsOpen() bool {
 	return slui.showPanel
 }
 
+// OpenDataTab opens the panel on the Data tab, where storage can be freed
+func (slui *SaveLoadUI) OpenDataTab() {
+	if !slui.showPanel {
+		slui.TogglePanel()
+	}
+	slui.selectedTab = 2
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (slui *SaveLoadUI) UpdateHover(x, y int) {
+	slui.hoverX, slui.hoverY = x, y
+	slui.confirm.UpdateHover(x, y)
+}
+
 func (slui *SaveLoadUI) Update() {
 	// Clear status message after 3 seconds
 	if !slui.statusTime.IsZero() && time.Since(slui.statusTime) > 3*time.Second {
 		slui.statusMessage = ""
 		slui.statusTime = time.Time{}
 	}
+	if slui.cleared != nil && !slui.canUndoClear() {
+		slui.cleared = nil
+	}
 }
 
 func (slui *SaveLoadUI) HandleClick(x, y int) bool {
 	if !slui.showPanel {
 		return false
 	}
+	if slui.confirm.HandleClick(x, y) {
+		return true
+	}
 	
 	// Panel bounds
 	panelX, panelY := 120, 60
@@ -75,9 +167,8 @@ func (slui *SaveLoadUI) HandleClick(x, y int) bool {
 	}
 	
 	// Tab buttons
-	tabWidth := 120
 	tabY := panelY + 40
-	for i := 0; i < 3; i++ {
+	for i := 0; i < len(settingsTabs); i++ {
 		tabX := panelX + 20 + i*tabWidth
 		if x >= tabX && x <= tabX+tabWidth-10 && y >= tabY && y <= tabY+30 {
 			slui.selectedTab = i
@@ -93,11 +184,81 @@ func (slui *SaveLoadUI) HandleClick(x, y int) bool {
 		return slui.handleSettingsClick(x, y, panelX, panelY)
 	case 2:
 		return slui.handleImportExportClick(x, y, panelX, panelY)
+	case 3:
+		return slui.handleControlsClick(x, y, panelX, panelY)
+	case 4:
+		return slui.handleGraphicsClick(x, y, panelX, panelY)
+	case 5:
+		return slui.handleModesClick(x, y, panelX, panelY)
 	}
 	
 	return true
 }
 
+// TooltipRegions describes the controls of the open tab for hover tooltips
+func (slui *SaveLoadUI) TooltipRegions() []TooltipRegion {
+	if !slui.showPanel {
+		return nil
+	}
+	
+	panelX, panelY := 120, 60
+	regions := []TooltipRegion{
+		{X: panelX + 20, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "Save, load or delete your game"},
+		{X: panelX + 20 + tabWidth, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "Sound, tutorial, animation\nand AI opponent options"},
+		{X: panelX + 20 + tabWidth*2, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "Export or clear stored data"},
+		{X: panelX + 20 + tabWidth*3, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "Rebind build, demolish, hint,\npause, zoom and pan"},
+		{X: panelX + 20 + tabWidth*4, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "Frame rate, effects, power\nsaving and do not disturb"},
+		{X: panelX + 20 + tabWidth*5, Y: panelY + 40, Width: tabWidth - 10, Height: 30, Text: "What to open on launch and\ntimers or feedback per mode"},
+	}
+	
+	switch slui.selectedTab {
+	case 0:
+		regions = append(regions,
+			TooltipRegion{X: panelX + 30, Y: panelY + 120, Width: 160, Height: 40, Text: "Save the game in progress"},
+			TooltipRegion{X: panelX + 210, Y: panelY + 120, Width: 160, Height: 40, Text: "Continue the last saved game"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 180, Width: 160, Height: 40, Text: "Delete the saved game"},
+			TooltipRegion{X: panelX + 210, Y: panelY + 180, Width: 160, Height: 40, Text: "Change player; each profile keeps\nits own progress, settings and saves"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 240, Width: 20, Height: 20, Text: "Save automatically while playing"},
+			TooltipRegion{X: panelX + 210, Y: panelY + 240, Width: 160, Height: 20, Text: "Moves between auto-saves. The game\nalso saves on winning, before the next\ngame and when its window loses focus"},
+		)
+	case 1:
+		regions = append(regions,
+			TooltipRegion{X: panelX + 30, Y: panelY + 120, Width: 20, Height: 20, Text: "Play sound effects"},
+			TooltipRegion{X: panelX + 200, Y: panelY + 120, Width: 20, Height: 20, Text: "Learning aid: each bridge flashes green\n(optimal), yellow (okay) or red (wasted).\nGames played with it earn no stars"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 150, Width: 20, Height: 20, Text: "Play background music"},
+			TooltipRegion{X: panelX + 200, Y: panelY + 150, Width: 150, Height: 20, Text: "Pause timed levels after this long\nwithout input; click to change"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 180, Width: 20, Height: 20, Text: "Show the tutorial on next launch"},
+			TooltipRegion{X: panelX + 200, Y: panelY + 180, Width: 120, Height: 20, Text: "Play the interactive tutorial now"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 210, Width: 20, Height: 20, Text: "Save automatically while playing"},
+			TooltipRegion{X: panelX + 200, Y: panelY + 210, Width: 20, Height: 20, Text: "No time limits or clock; stars come\nfrom moves only. Scores are ranked\nseparately from timed play"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 260, Width: 150, Height: 20, Text: "How fast animations play"},
+			TooltipRegion{X: panelX + 200, Y: panelY + 260, Width: 20, Height: 20, Text: "Time Attack goes on past the limit;\neach second over costs 10 points\nand the win earns one star at most"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 310, Width: 320, Height: 20, Text: "Race an AI opponent on the same\nboard in Time Attack"},
+		)
+	case 2:
+		regions = append(regions,
+			TooltipRegion{X: panelX + 30, Y: panelY + 120, Width: transferWidth, Height: 40, Text: "Save all data to a JSON file"},
+			TooltipRegion{X: panelX + 115, Y: panelY + 120, Width: transferWidth, Height: 40, Text: "Load data from an exported file;\nyou can also drop the file\non the window"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 180, Width: 160, Height: 40, Text: "Delete all saved data; it can be\nundone for 10 seconds"},
+			TooltipRegion{X: panelX + 30, Y: panelY + 240, Width: 20, Height: 20, Text: "Help level designers: record levels\nstarted, won and lost, moves and hints.\nNo names or profiles are included"},
+			TooltipRegion{X: panelX + usageX, Y: panelY + freeSpaceY, Width: usageWidth, Height: freeSpaceSize, Text: "Remove leaderboard entries older than\n30 days except each level's best, the\nold crash report and the cached\nlevel of the week"},
+		)
+	case 4:
+		regions = append(regions,
+			TooltipRegion{X: panelX + graphicsVSyncX, Y: panelY + graphicsAmbientY, Width: 20, Height: 20, Text: "Hold achievement notifications\nuntil the level is won or lost"},
+		)
+	case 5:
+		regions = append(regions,
+			TooltipRegion{X: panelX + modesChoiceX, Y: panelY + modesLaunchY, Width: modesChoiceW, Height: 20, Text: "Open on the main menu, start your\npreferred mode or replay the last level"},
+			TooltipRegion{X: panelX + modesChoiceX, Y: panelY + modesPreferredY, Width: modesChoiceW, Height: 20, Text: "The mode started on launch"},
+			TooltipRegion{X: panelX + modesTimersX, Y: panelY + modesHeaderY, Width: modesCellW, Height: 12, Text: "Default follows relaxed mode\non the Settings tab"},
+			TooltipRegion{X: panelX + modesFeedbackX, Y: panelY + modesHeaderY, Width: modesCellW, Height: 12, Text: "Default follows move feedback\non the Settings tab"},
+		)
+	}
+	
+	return regions
+}
+
 func (slui *SaveLoadUI) handleSaveLoadClick(x, y, panelX, panelY int) bool {
 	buttonY := panelY + 120
 	buttonWidth, buttonHeight := 160, 40
@@ -120,7 +281,18 @@ func (slui *SaveLoadUI) handleSaveLoadClick(x, y, panelX, panelY int) bool {
 	// Delete Save button
 	deleteY := buttonY + buttonHeight + 20
 	if x >= saveX && x <= saveX+buttonWidth && y >= deleteY && y <= deleteY+buttonHeight {
-		slui.deleteSave()
+		if slui.saveSystem.HasSavedGame() {
+			slui.confirm.Confirm("Delete the saved game?", "Delete", slui.deleteSave)
+		} else {
+			slui.showStatus("No saved game found!")
+		}
+		return true
+	}
+	
+	// Switch Profile button
+	if x >= loadX && x <= loadX+buttonWidth && y >= deleteY && y <= deleteY+buttonHeight {
+		slui.showPanel = false
+		slui.events.Publish(events.ProfileSelectRequested{})
 		return true
 	}
 	
@@ -128,15 +300,43 @@ func (slui *SaveLoadUI) handleSaveLoadClick(x, y, panelX, panelY int) bool {
 	autoSaveY := deleteY + buttonHeight + 20
 	if x >= saveX && x <= saveX+20 && y >= autoSaveY && y <= autoSaveY+20 {
 		slui.settings.AutoSave = !slui.settings.AutoSave
-		slui.saveSystem.SaveSettings(slui.settings)
+		slui.saveSettings()
+		return true
+	}
+	
+	// Moves between auto-saves
+	if x >= loadX && x <= loadX+buttonWidth && y >= autoSaveY && y <= autoSaveY+20 {
+		next := autoSaveMoveChoices[(slui.autoSaveMoveChoice()+1)%len(autoSaveMoveChoices)]
+		slui.settings.AutoSaveMoves = next
+		slui.saveSettings()
+		slui.showStatus("Auto-save: " + autoSaveMovesLabel(next))
 		return true
 	}
 	
 	return true
 }
 
+// autoSaveMoveChoice finds the current move count in autoSaveMoveChoices, or
+// the default's place when it was set some other way
+func (slui *SaveLoadUI) autoSaveMoveChoice() int {
+	every := slui.settings.AutoSaveEvery()
+	for i, moves := range autoSaveMoveChoices {
+		if moves == every {
+			return i
+		}
+	}
+	return 1
+}
+
+func autoSaveMovesLabel(moves int) string {
+	if moves == 1 {
+		return "every move"
+	}
+	return fmt.Sprintf("every %d moves", moves)
+}
+
 func (slui *SaveLoadUI) handleSettingsClick(x, y, panelX, panelY int) bool {
-	startY := panelY + 100
+	startY := panelY + 120
 	checkboxSize := 20
 	spacing := 30
 	
@@ -151,36 +351,116 @@ func (slui *SaveLoadUI) handleSettingsClick(x, y, panelX, panelY int) bool {
 	}
 	
 	checkboxX := panelX + 30
+	
+	// Move feedback sits beside sound effects
+	feedbackX := panelX + 200
+	if x >= feedbackX && x <= feedbackX+checkboxSize && y >= startY && y <= startY+checkboxSize {
+		slui.settings.MoveFeedback = !slui.settings.MoveFeedback
+		slui.saveSettings()
+		if slui.settings.MoveFeedback {
+			slui.showStatus("Move feedback on: games earn no stars")
+		} else {
+			slui.showStatus("Move feedback off")
+		}
+		return true
+	}
+	
+	// Relaxed mode sits beside auto-save
+	relaxedX := panelX + 200
+	if x >= relaxedX && x <= relaxedX+checkboxSize && y >= startY+spacing*3 && y <= startY+spacing*3+checkboxSize {
+		slui.settings.RelaxedMode = !slui.settings.RelaxedMode
+		slui.saveSettings()
+		if slui.settings.RelaxedMode {
+			slui.showStatus("Relaxed mode: no timers from the next game")
+		} else {
+			slui.showStatus("Relaxed mode off")
+		}
+		return true
+	}
+	
+	// Idle pause time sits beside background music
+	idleX := panelX + 200
+	if x >= idleX && x <= idleX+150 && y >= startY+spacing && y <= startY+spacing+checkboxSize {
+		next := idlePauseChoices[(slui.idlePauseChoice()+1)%len(idlePauseChoices)]
+		slui.settings.IdlePause = next.seconds
+		slui.saveSettings()
+		slui.showStatus("Idle pause: " + next.label)
+		return true
+	}
+	
+	// Replay tutorial button next to the tutorial checkbox
+	replayX := panelX + 200
+	if x >= replayX && x <= replayX+120 && y >= startY+spacing*2 && y <= startY+spacing*2+checkboxSize {
+		slui.showPanel = false
+		slui.events.Publish(events.TutorialRequested{})
+		return true
+	}
+	
 	for _, checkbox := range checkboxes {
 		if x >= checkboxX && x <= checkboxX+checkboxSize && 
 		   y >= checkbox.y && y <= checkbox.y+checkboxSize {
 			*checkbox.setting = !*checkbox.setting
-			slui.saveSystem.SaveSettings(slui.settings)
+			slui.saveSettings()
 			slui.showStatus("Settings saved!")
 			return true
 		}
 	}
 	
 	// Animation speed slider (simplified - just buttons)
-	sliderY := startY + spacing*4
+	sliderY := startY + spacing*4 + 20
 	slowButtonX := checkboxX
 	fastButtonX := checkboxX + 100
 	
+	// Time Attack overtime sits beside the speed buttons
+	overtimeX := panelX + 200
+	if x >= overtimeX && x <= overtimeX+checkboxSize && y >= sliderY && y <= sliderY+checkboxSize {
+		slui.settings.TimeAttackOvertime = !slui.settings.TimeAttackOvertime
+		slui.saveSettings()
+		if slui.settings.TimeAttackOvertime {
+			slui.showStatus("Overtime: play on past the limit for fewer points")
+		} else {
+			slui.showStatus("Overtime off: games end when time is up")
+		}
+		return true
+	}
+	
 	if y >= sliderY && y <= sliderY+20 {
 		if x >= slowButtonX && x <= slowButtonX+40 {
 			slui.settings.AnimationSpeed = 0.5
-			slui.saveSystem.SaveSettings(slui.settings)
+			slui.saveSettings()
 			slui.showStatus("Animation speed: Slow")
 			return true
 		}
 		if x >= fastButtonX && x <= fastButtonX+40 {
 			slui.settings.AnimationSpeed = 2.0
-			slui.saveSystem.SaveSettings(slui.settings)
+			slui.saveSettings()
 			slui.showStatus("Animation speed: Fast")
 			return true
 		}
 	}
 	
+	// AI opponent skill buttons, with its personality cycled by a button above them
+	aiY := panelY + 310
+	personalityX := panelX + 200
+	if x >= personalityX && x <= personalityX+150 && y >= aiY-26 && y <= aiY-6 {
+		next := (slui.settings.AIPersonality + 1) % len(aiPersonalityLabels)
+		slui.settings.AIPersonality = next
+		slui.saveSettings()
+		slui.showStatus("Time Attack AI personality: " + aiPersonalityLabels[next])
+		return true
+	}
+	if y >= aiY && y <= aiY+20 {
+		for i := range aiSkillLabels {
+			buttonX := checkboxX + i*65
+			if x >= buttonX && x <= buttonX+60 {
+				slui.settings.AIOpponent = i
+				slui.saveSettings()
+				slui.showStatus("Time Attack AI: " + aiSkillLabels[i])
+				return true
+			}
+		}
+	}
+	
 	return true
 }
 
@@ -189,17 +469,44 @@ func (slui *SaveLoadUI) handleImportExportClick(x, y, panelX, panelY int) bool {
 	buttonWidth, buttonHeight := 160, 40
 	spacing := 20
 	
-	// Export button
+	// Export and Import buttons
 	exportX := panelX + 30
-	if x >= exportX && x <= exportX+buttonWidth && y >= buttonY && y <= buttonY+buttonHeight {
+	if inRect(x, y, exportX, buttonY, transferWidth, buttonHeight) {
 		slui.exportData()
 		return true
 	}
+	if inRect(x, y, panelX+115, buttonY, transferWidth, buttonHeight) {
+		slui.requestImport()
+		return true
+	}
 	
 	// Clear Data button
 	clearY := buttonY + buttonHeight + spacing
 	if x >= exportX && x <= exportX+buttonWidth && y >= clearY && y <= clearY+buttonHeight {
-		slui.clearAllData()
+		if slui.canUndoClear() {
+			slui.undoClear()
+		} else {
+			slui.confirm.Confirm("Clear all data?", "Clear", slui.clearAllData, "Progress, settings, saves and custom", "levels are deleted. Undo stays", "available for 10 seconds.")
+		}
+		return true
+	}
+	
+	// Free Space button under the storage meter
+	if inRect(x, y, panelX+usageX, panelY+freeSpaceY, usageWidth, freeSpaceSize) {
+		slui.freeSpace()
+		return true
+	}
+	
+	// Anonymous statistics opt-in
+	analyticsY := clearY + buttonHeight + spacing
+	if x >= exportX && x <= exportX+20 && y >= analyticsY && y <= analyticsY+20 {
+		slui.settings.AnalyticsEnabled = !slui.settings.AnalyticsEnabled
+		slui.saveSettings()
+		if slui.settings.AnalyticsEnabled {
+			slui.showStatus("Thanks! Anonymous statistics on")
+		} else {
+			slui.showStatus("Statistics off; unsent data deleted")
+		}
 		return true
 	}
 	
@@ -208,18 +515,14 @@ func (slui *SaveLoadUI) handleImportExportClick(x, y, panelX, panelY int) bool {
 
 func (slui *SaveLoadUI) saveGame() {
 	// Signal to main game to save
-	if slui.OnSaveGame != nil {
-		slui.OnSaveGame()
-	}
+	slui.events.Publish(events.SaveRequested{})
 	slui.showStatus("Game saved!")
 }
 
 func (slui *SaveLoadUI) loadGame() {
 	if slui.saveSystem.HasSavedGame() {
 		// Signal to main game to load
-		if slui.OnLoadGame != nil {
-			slui.OnLoadGame()
-		}
+		slui.events.Publish(events.LoadRequested{})
 		slui.showStatus("Game loaded!")
 	} else {
 		slui.showStatus("No saved game found!")
@@ -232,17 +535,131 @@ func (slui *SaveLoadUI) deleteSave() {
 }
 
 func (slui *SaveLoadUI) exportData() {
-	// In a real implementation, this would create a download or copy to clipboard
-	slui.showStatus("Data exported to console!")
-	fmt.Println("Exporting save data...")
-	// This is where we'd implement actual export functionality
+	saveData, err := slui.saveSystem.ExportSaveData()
+	if err == nil {
+		var data []byte
+		if data, err = json.MarshalIndent(saveData, "", "  "); err == nil {
+			name := capture.FileName("json", time.Now())
+			if err = slui.saveSystem.ExportFile(name, data); err == nil {
+				slui.showStatus("Exported " + name)
+				return
+			}
+		}
+	}
+	fmt.Println("Export failed:", err)
+	slui.showStatus("Export failed")
+}
+
+// requestImport opens the file picker; where there is none, files are dropped on the window
+func (slui *SaveLoadUI) requestImport() {
+	if err := slui.saveSystem.RequestSaveDataUpload(); err != nil {
+		slui.showStatus("Drop an exported file on the window")
+		return
+	}
+	slui.showStatus("Choose an exported save data file...")
+}
+
+// PreviewImport checks a save data file and asks before it overwrites anything
+func (slui *SaveLoadUI) PreviewImport(data []byte) {
+	slui.OpenDataTab()
+	saveData, err := storage.ParseSaveData(data)
+	if err != nil {
+		slui.showStatus("Import failed: " + err.Error())
+		return
+	}
+	title := "Import save data?"
+	if !saveData.SavedAt.IsZero() {
+		title = "Import save data from " + saveData.SavedAt.Format("2 Jan 2006") + "?"
+	}
+	slui.confirm.Confirm(title, "Import", func() {
+		slui.importData(saveData)
+	}, slui.importSummary(saveData)...)
+}
+
+// importSummary describes what importing the save data overwrites
+func (slui *SaveLoadUI) importSummary(saveData *storage.GameSaveData) []string {
+	var replaced []string
+	if saveData.CurrentGame != nil {
+		replaced = append(replaced, "saved game")
+	}
+	if saveData.Achievements != nil {
+		replaced = append(replaced, "achievements")
+	}
+	if saveData.Settings != nil {
+		replaced = append(replaced, "settings")
+	}
+	if saveData.Collections != nil {
+		replaced = append(replaced, "folders")
+	}
+	
+	var lines []string
+	if len(replaced) > 0 {
+		lines = append(lines, "Replaces: "+strings.Join(replaced, ", "))
+	}
+	if saveData.Progress != nil {
+		current := 0
+		if progress, err := slui.saveSystem.LoadProgress(); err == nil {
+			current = len(progress.CompletedLevels)
+		}
+		lines = append(lines, fmt.Sprintf("Progress: %d levels won (now %d)", len(saveData.Progress.CompletedLevels), current))
+	}
+	if len(saveData.CustomLevels) > 0 {
+		lines = append(lines, fmt.Sprintf("Adds or updates %d custom levels", len(saveData.CustomLevels)))
+	}
+	return lines
+}
+
+// importData writes the save data over the active profile's and has the game reload it
+func (slui *SaveLoadUI) importData(saveData *storage.GameSaveData) {
+	if err := slui.saveSystem.ImportSaveData(saveData); err != nil {
+		slui.showStatus("Import failed: " + err.Error())
+		return
+	}
+	slui.settings, _ = slui.saveSystem.LoadSettings()
+	slui.bindings = systems.BindingsFromSettings(slui.settings.KeyBindings)
+	if slui.OnSettingsChanged != nil {
+		slui.OnSettingsChanged(slui.settings)
+	}
+	slui.events.Publish(events.SaveDataImported{})
+	slui.showStatus("Save data imported!")
 }
 
 func (slui *SaveLoadUI) clearAllData() {
-	slui.saveSystem.ClearAllData()
+	slui.cleared = slui.saveSystem.ClearAllData()
+	slui.clearedAt = time.Now()
 	slui.showStatus("All data cleared!")
 }
 
+// freeSpace removes saved data that is safe to lose
+func (slui *SaveLoadUI) freeSpace() {
+	cleanup, err := slui.saveSystem.FreeSpace()
+	if err != nil {
+		slui.showStatus("Cleanup failed: " + err.Error())
+		return
+	}
+	slui.showStatus(fmt.Sprintf("Freed %s; %d old scores removed", formatBytes(cleanup.Bytes), cleanup.Scores))
+}
+
+// canUndoClear reports whether the last Clear All Data is within its grace period
+func (slui *SaveLoadUI) canUndoClear() bool {
+	return slui.cleared != nil && time.Since(slui.clearedAt) < clearUndoPeriod
+}
+
+// undoClear puts back the data removed by the last Clear All Data
+func (slui *SaveLoadUI) undoClear() {
+	if err := slui.saveSystem.RestoreData(slui.cleared); err != nil {
+		slui.showStatus("Undo failed: " + err.Error())
+		return
+	}
+	slui.cleared = nil
+	slui.settings, _ = slui.saveSystem.LoadSettings()
+	slui.bindings = systems.BindingsFromSettings(slui.settings.KeyBindings)
+	if slui.OnSettingsChanged != nil {
+		slui.OnSettingsChanged(slui.settings)
+	}
+	slui.showStatus("Data restored!")
+}
+
 func (slui *SaveLoadUI) showStatus(message string) {
 	slui.statusMessage = message
 	slui.statusTime = time.Now()
@@ -298,6 +715,12 @@ func (slui *SaveLoadUI) Draw(screen *ebiten.Image) {
 		slui.drawSettingsTab(screen, panelX, panelY)
 	case 2:
 		slui.drawImportExportTab(screen, panelX, panelY)
+	case 3:
+		slui.drawControlsTab(screen, panelX, panelY)
+	case 4:
+		slui.drawGraphicsTab(screen, panelX, panelY)
+	case 5:
+		slui.drawModesTab(screen, panelX, panelY)
 	}
 	
 	// Status message
@@ -305,15 +728,15 @@ func (slui *SaveLoadUI) Draw(screen *ebiten.Image) {
 		statusY := panelY + panelHeight - 30
 		ebitenutil.DebugPrintAt(screen, slui.statusMessage, panelX+20, statusY)
 	}
+	
+	slui.confirm.Draw(screen)
 }
 
 func (slui *SaveLoadUI) drawTabs(screen *ebiten.Image, panelX, panelY int) {
-	tabs := []string{"Save/Load", "Settings", "Data"}
-	tabWidth := 120
 	tabHeight := 30
 	tabY := panelY + 40
 	
-	for i, tabName := range tabs {
+	for i, tabName := range settingsTabs {
 		tabX := panelX + 20 + i*tabWidth
 		
 		// Tab background
@@ -341,7 +764,7 @@ func (slui *SaveLoadUI) drawTabs(screen *ebiten.Image, panelX, panelY int) {
 		)
 		
 		// Tab text
-		textX := tabX + (tabWidth-len(tabName)*6)/2
+		textX := tabX + (tabWidth-10-len(tabName)*6)/2
 		textY := tabY + tabHeight/2 - 4
 		ebitenutil.DebugPrintAt(screen, tabName, textX, textY)
 	}
@@ -359,6 +782,7 @@ func (slui *SaveLoadUI) drawSaveLoadTab(screen *ebiten.Image, panelX, panelY int
 		saveStatus = "Saved game available"
 	}
 	ebitenutil.DebugPrintAt(screen, saveStatus, panelX+20, startY+20)
+	ebitenutil.DebugPrintAt(screen, "Profile: "+slui.saveSystem.CurrentProfile().Name, panelX+210, startY)
 	
 	// Buttons
 	buttonY := panelY + 120
@@ -383,9 +807,17 @@ func (slui *SaveLoadUI) drawSaveLoadTab(screen *ebiten.Image, panelX, panelY int
 	}
 	slui.drawButton(screen, panelX+30, deleteY, buttonWidth, buttonHeight, "Delete Save", deleteColor)
 	
+	// Switch Profile button
+	slui.drawButton(screen, panelX+30+buttonWidth+spacing, deleteY, buttonWidth, buttonHeight, "Switch Profile", color.RGBA{100, 200, 200, 255})
+	
 	// Auto-save checkbox
 	autoSaveY := deleteY + buttonHeight + 20
 	slui.drawCheckbox(screen, panelX+30, autoSaveY, slui.settings.AutoSave, "Auto-save enabled")
+	everyColor := color.RGBA{150, 150, 250, 255}
+	if !slui.settings.AutoSave {
+		everyColor = color.RGBA{150, 150, 150, 255} // Disabled
+	}
+	slui.drawButton(screen, panelX+30+buttonWidth+spacing, autoSaveY, buttonWidth, 20, "Save "+autoSaveMovesLabel(slui.settings.AutoSaveEvery()), everyColor)
 }
 
 func (slui *SaveLoadUI) drawSettingsTab(screen *ebiten.Image, panelX, panelY int) {
@@ -398,9 +830,14 @@ func (slui *SaveLoadUI) drawSettingsTab(screen *ebiten.Image, panelX, panelY int
 	
 	// Sound settings
 	slui.drawCheckbox(screen, panelX+30, checkboxY, slui.settings.SoundEnabled, "Sound Effects")
+	slui.drawCheckbox(screen, panelX+200, checkboxY, slui.settings.MoveFeedback, "Move feedback")
 	slui.drawCheckbox(screen, panelX+30, checkboxY+spacing, slui.settings.MusicEnabled, "Background Music")
+	idleLabel := "Idle pause: " + idlePauseChoices[slui.idlePauseChoice()].label
+	slui.drawButton(screen, panelX+200, checkboxY+spacing, 150, 20, idleLabel, color.RGBA{150, 200, 220, 255})
 	slui.drawCheckbox(screen, panelX+30, checkboxY+spacing*2, slui.settings.ShowTutorial, "Show Tutorial")
+	slui.drawButton(screen, panelX+200, checkboxY+spacing*2, 120, 20, "Replay Tutorial", color.RGBA{255, 215, 0, 255})
 	slui.drawCheckbox(screen, panelX+30, checkboxY+spacing*3, slui.settings.AutoSave, "Auto-save")
+	slui.drawCheckbox(screen, panelX+200, checkboxY+spacing*3, slui.settings.RelaxedMode, "Relaxed (no timers)")
 	
 	// Animation speed
 	speedY := checkboxY + spacing*4
@@ -424,6 +861,39 @@ func (slui *SaveLoadUI) drawSettingsTab(screen *ebiten.Image, panelX, panelY int
 		fastColor = color.RGBA{100, 200, 100, 255}
 	}
 	slui.drawButton(screen, panelX+140, speedY+20, 40, 20, "Fast", fastColor)
+	slui.drawCheckbox(screen, panelX+200, speedY+20, slui.settings.TimeAttackOvertime, "Time Attack overtime")
+	
+	// AI opponent for Time Attack races
+	aiY := speedY + 50
+	ebitenutil.DebugPrintAt(screen, "Time Attack AI Opponent:", panelX+30, aiY)
+	personality := aiPersonalityLabels[0]
+	if p := slui.settings.AIPersonality; p >= 0 && p < len(aiPersonalityLabels) {
+		personality = aiPersonalityLabels[p]
+	}
+	slui.drawButton(screen, panelX+200, aiY-6, 150, 20, "Personality: "+personality, color.RGBA{220, 170, 200, 255})
+	for i, label := range aiSkillLabels {
+		aiColor := color.RGBA{150, 150, 150, 255}
+		if slui.settings.AIOpponent == i {
+			aiColor = color.RGBA{100, 200, 100, 255}
+		}
+		slui.drawButton(screen, panelX+30+i*65, aiY+20, 60, 20, label, aiColor)
+	}
+}
+
+// idlePauseChoice is the index of the current idle pause time in idlePauseChoices
+func (slui *SaveLoadUI) idlePauseChoice() int {
+	seconds := slui.settings.IdlePause
+	if seconds == 0 {
+		seconds = storage.DefaultIdlePause
+	} else if seconds < 0 {
+		seconds = -1
+	}
+	for i, choice := range idlePauseChoices {
+		if choice.seconds == seconds {
+			return i
+		}
+	}
+	return 1
 }
 
 func (slui *SaveLoadUI) drawImportExportTab(screen *ebiten.Image, panelX, panelY int) {
@@ -431,31 +901,95 @@ func (slui *SaveLoadUI) drawImportExportTab(screen *ebiten.Image, panelX, panelY
 	
 	ebitenutil.DebugPrintAt(screen, "Data Management", panelX+20, startY)
 	
-	// Storage usage
-	usage := slui.saveSystem.GetStorageUsage()
-	infoY := startY + 30
-	for key, exists := range usage {
-		status := "❌"
-		if exists {
-			status = "✅"
-		}
-		text := fmt.Sprintf("%s %s", status, key)
-		ebitenutil.DebugPrintAt(screen, text, panelX+30, infoY)
-		infoY += 15
-	}
+	// Storage usage, beside the buttons
+	slui.drawStorageMeter(screen, panelX+usageX, startY)
+	slui.drawButton(screen, panelX+usageX, panelY+freeSpaceY, usageWidth, freeSpaceSize, "Free Space", color.RGBA{100, 200, 200, 255})
 	
 	// Buttons
 	buttonY := panelY + 120
 	buttonWidth, buttonHeight := 160, 40
 	spacing := 20
 	
-	slui.drawButton(screen, panelX+30, buttonY, buttonWidth, buttonHeight, "Export Data", color.RGBA{100, 200, 200, 255})
+	slui.drawButton(screen, panelX+30, buttonY, transferWidth, buttonHeight, "Export", color.RGBA{100, 200, 200, 255})
+	slui.drawButton(screen, panelX+115, buttonY, transferWidth, buttonHeight, "Import", color.RGBA{100, 150, 220, 255})
 	
 	clearY := buttonY + buttonHeight + spacing
-	slui.drawButton(screen, panelX+30, clearY, buttonWidth, buttonHeight, "Clear All Data", color.RGBA{200, 100, 100, 255})
+	if slui.canUndoClear() {
+		left := int((clearUndoPeriod - time.Since(slui.clearedAt) + time.Second - 1) / time.Second)
+		slui.drawButton(screen, panelX+30, clearY, buttonWidth, buttonHeight, fmt.Sprintf("Undo Clear (%ds)", left), color.RGBA{100, 200, 100, 255})
+	} else {
+		slui.drawButton(screen, panelX+30, clearY, buttonWidth, buttonHeight, "Clear All Data", color.RGBA{200, 100, 100, 255})
+	}
+	
+	// Anonymous statistics opt-in
+	analyticsY := clearY + buttonHeight + spacing
+	slui.drawCheckbox(screen, panelX+30, analyticsY, slui.settings.AnalyticsEnabled, "Share anonymous play statistics")
+	if slui.settings.AnalyticsEnabled && slui.AnalyticsPending != nil {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d events waiting to upload", slui.AnalyticsPending()), panelX+60, analyticsY+24)
+	}
+}
+
+// drawStorageMeter shows the space used against the quota, then the largest
+// kinds of saved data, each over a bar sized against the largest
+func (slui *SaveLoadUI) drawStorageMeter(screen *ebiten.Image, x, y int) {
+	usage := slui.saveSystem.StorageUsage()
+	total := 0
+	for _, entry := range usage {
+		total += entry.Bytes
+	}
+	
+	quota := slui.saveSystem.StorageQuota()
+	if quota > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s of %s", formatBytes(total), formatBytes(quota)), x, y)
+		fraction := min(float32(total)/float32(quota), 1)
+		meterColor := color.RGBA{100, 200, 100, 255}
+		if fraction >= 0.9 {
+			meterColor = color.RGBA{220, 80, 80, 255}
+		} else if fraction >= 0.7 {
+			meterColor = color.RGBA{230, 170, 50, 255}
+		}
+		vector.DrawFilledRect(screen, float32(x), float32(y+18), usageWidth, 8, color.RGBA{200, 200, 200, 255}, false)
+		vector.DrawFilledRect(screen, float32(x), float32(y+18), usageWidth*fraction, 8, meterColor, false)
+	} else {
+		ebitenutil.DebugPrintAt(screen, formatBytes(total)+" used", x, y)
+	}
+	
+	rowY := y + 32
+	for i, entry := range usage {
+		if i == usageRows {
+			break
+		}
+		if usage[0].Bytes > 0 {
+			width := float32(usageWidth * entry.Bytes / usage[0].Bytes)
+			vector.DrawFilledRect(screen, float32(x), float32(rowY+1), width, 13, color.RGBA{190, 215, 240, 255}, false)
+		}
+		size := formatBytes(entry.Bytes)
+		ebitenutil.DebugPrintAt(screen, entry.Name, x+2, rowY)
+		ebitenutil.DebugPrintAt(screen, size, x+usageWidth-len(size)*6-2, rowY)
+		rowY += 15
+	}
+	
+	if slui.saveSystem.StorageFull() {
+		ebitenutil.DebugPrintAt(screen, "Storage full!", x, rowY+2)
+	}
+}
+
+// formatBytes writes a size in B, KB or MB
+func formatBytes(bytes int) string {
+	switch {
+	case bytes < 1<<10:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1<<20:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	}
+	return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
 }
 
 func (slui *SaveLoadUI) drawButton(screen *ebiten.Image, x, y, width, height int, text string, bgColor color.Color) {
+	if slui.hoverX >= x && slui.hoverX <= x+width && slui.hoverY >= y && slui.hoverY <= y+height {
+		bgColor = brighten(bgColor)
+	}
+	
 	vector.DrawFilledRect(
 		screen,
 		float32(x), float32(y),
@@ -522,4 +1056,13 @@ func (slui *SaveLoadUI) DrawSettingsButton(screen *ebiten.Image, x, y float64) {
 
 func (slui *SaveLoadUI) IsSettingsButtonClicked(x, y int) bool {
 	return x >= 10 && x <= 110 && y >= 10 && y <= 40
-}
\ No newline at end of file
+}
+
+// brighten lightens a color for hover feedback
+func brighten(c color.Color) color.Color {
+	r, g, b, a := c.RGBA()
+	lift := func(v uint32) uint8 {
+		return uint8(min(255, int(v>>8)+30))
+	}
+	return color.RGBA{lift(r), lift(g), lift(b), uint8(a >> 8)}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Save toast timing and placement, in the bottom-right corner clear of the HUD
+const (
+	saveToastDuration = 1500 * time.Millisecond
+	saveToastFade     = 500 * time.Millisecond
+	saveToastX        = 584
+	saveToastY        = 456
+	saveToastWidth    = 46
+	saveToastHeight   = 16
+)
+
+// SaveToast briefly shows "Saved" after the game saves itself
+type SaveToast struct {
+	shownAt time.Time
+}
+
+func NewSaveToast() *SaveToast {
+	return &SaveToast{}
+}
+
+// Show starts the toast over, so saves in quick succession keep it up
+func (st *SaveToast) Show() {
+	st.shownAt = time.Now()
+}
+
+// Draw shows the toast while it is up, fading out at the end
+func (st *SaveToast) Draw(screen *ebiten.Image) {
+	if st.shownAt.IsZero() {
+		return
+	}
+	left := saveToastDuration - time.Since(st.shownAt)
+	if left <= 0 {
+		return
+	}
+	alpha := 1.0
+	if left < saveToastFade {
+		alpha = float64(left) / float64(saveToastFade)
+	}
+
+	vector.DrawFilledRect(screen, saveToastX, saveToastY, saveToastWidth, saveToastHeight, color.RGBA{0, 0, 0, uint8(120 * alpha)}, false)
+	printLarge(screen, "Saved", saveToastX+8, saveToastY+2, 1, color.RGBA{uint8(200 * alpha), uint8(230 * alpha), uint8(200 * alpha), uint8(255 * alpha)})
+}
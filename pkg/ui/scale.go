@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// uiScale enlarges text and touch targets. It is 1 on the desktop; phones shrink
+// the 640x480 screen to fit theirs, so mobile builds raise it.
+var uiScale = 1.0
+
+// maxCachedText bounds the rendered strings kept for scaled text
+const maxCachedText = 256
+
+// textCache holds debug-font strings rendered once for drawing at uiScale
+var textCache = make(map[string]*ebiten.Image)
+
+// SetScale sets the UI scale factor applied to the menu, HUD and hit areas.
+// Call it before creating the UI; values below 1 are treated as 1.
+func SetScale(scale float64) {
+	uiScale = math.Max(1, scale)
+}
+
+// Scale returns the UI scale factor
+func Scale() float64 {
+	return uiScale
+}
+
+// scaled multiplies a size in pixels by the UI scale
+func scaled(v int) int {
+	return int(math.Round(float64(v) * uiScale))
+}
+
+// touchSlop widens hit areas at larger scales so fingers find small buttons
+func touchSlop() int {
+	return int((uiScale - 1) * 8)
+}
+
+// printAt draws debug-font text at the UI scale
+func printAt(screen *ebiten.Image, text string, x, y int) {
+	if uiScale == 1 {
+		ebitenutil.DebugPrintAt(screen, text, x, y)
+		return
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(uiScale, uiScale)
+	op.GeoM.Translate(float64(x), float64(y))
+	op.Filter = ebiten.FilterLinear
+	screen.DrawImage(textImage(text), op)
+}
+
+// printLarge draws debug-font text size times larger than printAt, tinted
+func printLarge(screen *ebiten.Image, text string, x, y int, size float64, col color.Color) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(uiScale*size, uiScale*size)
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(col)
+	screen.DrawImage(textImage(text), op)
+}
+
+// textImage renders debug-font text once and keeps it for later frames
+func textImage(text string) *ebiten.Image {
+	img, ok := textCache[text]
+	if !ok {
+		if len(textCache) >= maxCachedText {
+			for cached, old := range textCache {
+				old.Deallocate()
+				delete(textCache, cached)
+			}
+		}
+		lines := strings.Split(text, "\n")
+		longest := 0
+		for _, line := range lines {
+			longest = max(longest, len(line))
+		}
+		img = ebiten.NewImage(max(1, longest*hudCharWidth), len(lines)*hudLineHeight)
+		ebitenutil.DebugPrint(img, text)
+		textCache[text] = img
+	}
+	return img
+}
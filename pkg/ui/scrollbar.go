@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const scrollStep = 20
+
+// clampScroll keeps a scroll offset within the scrollable range of the content
+func clampScroll(offset, contentHeight, visibleHeight float64) float64 {
+	maxScroll := math.Max(0, contentHeight-visibleHeight)
+	return math.Max(0, math.Min(offset, maxScroll))
+}
+
+// drawScrollbar draws a vertical track with a thumb sized to the visible fraction
+// of the content. Nothing is drawn when everything fits.
+func drawScrollbar(screen *ebiten.Image, x, y, height, contentHeight, visibleHeight, offset float64) {
+	if contentHeight <= visibleHeight {
+		return
+	}
+
+	width := 6.0
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), color.RGBA{210, 210, 210, 255}, false)
+
+	thumbHeight := math.Max(20, height*visibleHeight/contentHeight)
+	maxScroll := contentHeight - visibleHeight
+	thumbY := y + (height-thumbHeight)*offset/maxScroll
+
+	vector.DrawFilledRect(screen, float32(x), float32(thumbY), float32(width), float32(thumbHeight), color.RGBA{120, 120, 120, 255}, false)
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// GridSize is a board size offered for Classic games
+type GridSize struct {
+	Name          string
+	Width, Height int
+}
+
+// GridSizePresets are the sizes offered before the custom row
+var GridSizePresets = []GridSize{
+	{"Small", 5, 5},
+	{"Medium", 8, 8},
+	{"Large", 12, 12},
+}
+
+// Custom sizes are limited to boards that fit the screen and generate quickly
+const (
+	MinGridSize = 3
+	MaxGridSize = 20
+)
+
+const (
+	pickerX, pickerY          = 140, 100
+	pickerWidth, pickerHeight = 360, 260
+	pickerRowX                = pickerX + 20
+	pickerRowWidth            = pickerWidth - 40
+	pickerRowHeight           = 30
+	pickerRowTop              = pickerY + 45
+	pickerRowSpacing          = 38
+	pickerStepSize            = 20
+	pickerButtonY             = pickerY + pickerHeight - dialogButtonHeight - 15
+)
+
+// SizePicker is a modal that picks the board size for a Classic game. It takes
+// every click while open; OnPick runs after it closes with the chosen size.
+type SizePicker struct {
+	OnPick func(width, height int)
+
+	selected         int // Index into GridSizePresets, or len(GridSizePresets) for custom
+	customW, customH int
+	open             bool
+	hoverX, hoverY   int
+}
+
+func NewSizePicker() *SizePicker {
+	return &SizePicker{selected: 1, customW: 10, customH: 10}
+}
+
+// Select highlights the preset matching a size, or the custom row set to it
+func (sp *SizePicker) Select(width, height int) {
+	for i, preset := range GridSizePresets {
+		if preset.Width == width && preset.Height == height {
+			sp.selected = i
+			return
+		}
+	}
+	if width < MinGridSize || height < MinGridSize {
+		return
+	}
+	sp.selected = len(GridSizePresets)
+	sp.customW = min(width, MaxGridSize)
+	sp.customH = min(height, MaxGridSize)
+}
+
+// Size returns the size currently selected
+func (sp *SizePicker) Size() (int, int) {
+	if sp.selected < len(GridSizePresets) {
+		preset := GridSizePresets[sp.selected]
+		return preset.Width, preset.Height
+	}
+	return sp.customW, sp.customH
+}
+
+func (sp *SizePicker) Show() {
+	sp.open = true
+}
+
+func (sp *SizePicker) IsOpen() bool {
+	return sp.open
+}
+
+// UpdateHover records the pointer position so rows and buttons can highlight under it
+func (sp *SizePicker) UpdateHover(x, y int) {
+	sp.hoverX, sp.hoverY = x, y
+}
+
+func pickerRowY(index int) int {
+	return pickerRowTop + index*pickerRowSpacing
+}
+
+// pickerSteppers are the -/+ buttons of the custom row: width down, width up, height down, height up
+func pickerSteppers() [4]int {
+	x := pickerRowX + 140
+	return [4]int{x, x + 40, x + 90, x + 130}
+}
+
+func (sp *SizePicker) HandleClick(x, y int) bool {
+	if !sp.open {
+		return false
+	}
+
+	custom := len(GridSizePresets)
+	stepY := pickerRowY(custom) + (pickerRowHeight-pickerStepSize)/2
+	for i, stepX := range pickerSteppers() {
+		if inRect(x, y, stepX, stepY, pickerStepSize, pickerStepSize) {
+			sp.selected = custom
+			delta := 1
+			if i%2 == 0 {
+				delta = -1
+			}
+			if i < 2 {
+				sp.customW = max(MinGridSize, min(MaxGridSize, sp.customW+delta))
+			} else {
+				sp.customH = max(MinGridSize, min(MaxGridSize, sp.customH+delta))
+			}
+			return true
+		}
+	}
+
+	for i := 0; i <= custom; i++ {
+		if inRect(x, y, pickerRowX, pickerRowY(i), pickerRowWidth, pickerRowHeight) {
+			sp.selected = i
+			return true
+		}
+	}
+
+	if inRect(x, y, sp.playX(), pickerButtonY, dialogButtonWidth, dialogButtonHeight) {
+		sp.open = false
+		if sp.OnPick != nil {
+			sp.OnPick(sp.Size())
+		}
+	} else if inRect(x, y, sp.cancelX(), pickerButtonY, dialogButtonWidth, dialogButtonHeight) {
+		sp.open = false
+	}
+	return true
+}
+
+func (sp *SizePicker) playX() int {
+	return pickerX + pickerWidth/2 - dialogButtonWidth - 10
+}
+
+func (sp *SizePicker) cancelX() int {
+	return pickerX + pickerWidth/2 + 10
+}
+
+func (sp *SizePicker) Draw(screen *ebiten.Image) {
+	if !sp.open {
+		return
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	vector.DrawFilledRect(screen, pickerX, pickerY, pickerWidth, pickerHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, pickerX, pickerY, pickerWidth, pickerHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Classic - Board Size", pickerX+20, pickerY+15)
+
+	for i, preset := range GridSizePresets {
+		sp.drawRow(screen, i, fmt.Sprintf("%s  %dx%d", preset.Name, preset.Width, preset.Height))
+	}
+	custom := len(GridSizePresets)
+	sp.drawRow(screen, custom, "Custom")
+
+	// Width and height steppers on the custom row
+	stepY := pickerRowY(custom) + (pickerRowHeight-pickerStepSize)/2
+	steps := pickerSteppers()
+	for i, stepX := range steps {
+		label := "+"
+		if i%2 == 0 {
+			label = "-"
+		}
+		bg := color.Color(color.RGBA{210, 210, 210, 255})
+		if inRect(sp.hoverX, sp.hoverY, stepX, stepY, pickerStepSize, pickerStepSize) {
+			bg = brighten(bg)
+		}
+		vector.DrawFilledRect(screen, float32(stepX), float32(stepY), pickerStepSize, pickerStepSize, bg, false)
+		vector.StrokeRect(screen, float32(stepX), float32(stepY), pickerStepSize, pickerStepSize, 1, color.RGBA{100, 100, 100, 255}, false)
+		ebitenutil.DebugPrintAt(screen, label, stepX+7, stepY+2)
+	}
+	ebitenutil.DebugPrintAt(screen, "W", steps[0]-12, stepY+2)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%2d", sp.customW), steps[0]+pickerStepSize+4, stepY+2)
+	ebitenutil.DebugPrintAt(screen, "H", steps[2]-12, stepY+2)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%2d", sp.customH), steps[2]+pickerStepSize+4, stepY+2)
+
+	sp.drawButton(screen, sp.playX(), "Play", color.RGBA{100, 200, 100, 255})
+	sp.drawButton(screen, sp.cancelX(), "Cancel", color.RGBA{200, 200, 200, 255})
+}
+
+func (sp *SizePicker) drawRow(screen *ebiten.Image, index int, label string) {
+	y := pickerRowY(index)
+	bg := color.Color(color.RGBA{225, 225, 225, 255})
+	if index == sp.selected {
+		bg = color.RGBA{150, 200, 240, 255}
+	}
+	if inRect(sp.hoverX, sp.hoverY, pickerRowX, y, pickerRowWidth, pickerRowHeight) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, pickerRowX, float32(y), pickerRowWidth, pickerRowHeight, bg, false)
+	vector.StrokeRect(screen, pickerRowX, float32(y), pickerRowWidth, pickerRowHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, pickerRowX+10, y+pickerRowHeight/2-8)
+}
+
+func (sp *SizePicker) drawButton(screen *ebiten.Image, x int, label string, bgColor color.Color) {
+	if inRect(sp.hoverX, sp.hoverY, x, pickerButtonY, dialogButtonWidth, dialogButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(x), pickerButtonY, dialogButtonWidth, dialogButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, float32(x), pickerButtonY, dialogButtonWidth, dialogButtonHeight, 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, x+(dialogButtonWidth-len(label)*6)/2, pickerButtonY+dialogButtonHeight/2-4)
+}
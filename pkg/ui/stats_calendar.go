@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/achievements"
+)
+
+// Stats view of the achievements panel: a year of play as a calendar heatmap,
+// one column per week from Monday down to Sunday, with totals underneath
+const (
+	calendarWeeks   = 53
+	calendarX       = 136
+	calendarY       = 136
+	calendarCell    = 6
+	calendarStep    = 7
+	statsToggleX    = 430
+	statsToggleY    = 62
+	statsToggleW    = 90
+	statsToggleH    = 20
+	statsLinesY     = 216
+	statsLineHeight = 16
+)
+
+// calendarShades fill days by how much was played: none, games without a
+// completed level, then more levels completed
+var calendarShades = []color.RGBA{
+	{210, 210, 210, 255},
+	{198, 228, 139, 255},
+	{123, 201, 111, 255},
+	{35, 154, 59, 255},
+	{25, 97, 39, 255},
+}
+
+func calendarShade(day achievements.DayActivity) color.RGBA {
+	switch {
+	case day.Games == 0 && day.Wins == 0:
+		return calendarShades[0]
+	case day.Wins == 0:
+		return calendarShades[1]
+	case day.Wins == 1:
+		return calendarShades[2]
+	case day.Wins <= 3:
+		return calendarShades[3]
+	default:
+		return calendarShades[4]
+	}
+}
+
+// calendar returns the days the heatmap shows, starting on the Monday 52
+// weeks before this week's
+func (aui *AchievementsUI) calendar() []achievements.CalendarDay {
+	thisWeek := aui.achievementSystem.Calendar(1)[0].Date
+	todayRow := (int(thisWeek.Weekday()) + 6) % 7 // Monday first
+	return aui.achievementSystem.Calendar((calendarWeeks-1)*7 + todayRow + 1)
+}
+
+// calendarCellAt places a day of the calendar by its index
+func calendarCellAt(index int) (x, y int) {
+	return calendarX + index/7*calendarStep, calendarY + index%7*calendarStep
+}
+
+// handleStatsToggle switches between the achievement list and the stats view
+func (aui *AchievementsUI) handleStatsToggle(x, y int) bool {
+	if !inRect(x, y, statsToggleX, statsToggleY, statsToggleW, statsToggleH) {
+		return false
+	}
+	aui.showStats = !aui.showStats
+	aui.panelScroll = 0
+	return true
+}
+
+func (aui *AchievementsUI) drawStatsToggle(screen *ebiten.Image) {
+	text := "Stats"
+	if aui.showStats {
+		text = "Achievements"
+	}
+	btnColor := color.Color(color.RGBA{210, 210, 230, 255})
+	if inRect(aui.hoverX, aui.hoverY, statsToggleX, statsToggleY, statsToggleW, statsToggleH) {
+		btnColor = brighten(btnColor)
+	}
+	vector.DrawFilledRect(screen, statsToggleX, statsToggleY, statsToggleW, statsToggleH, btnColor, false)
+	vector.StrokeRect(screen, statsToggleX, statsToggleY, statsToggleW, statsToggleH, 1, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, text, statsToggleX+(statsToggleW-len(text)*6)/2, statsToggleY+3)
+}
+
+// drawStats draws the calendar heatmap and the statistics behind it
+func (aui *AchievementsUI) drawStats(screen *ebiten.Image) {
+	days := aui.calendar()
+	ebitenutil.DebugPrintAt(screen, "Days played, last 12 months", 120, 106)
+
+	var hovered *achievements.CalendarDay
+	daysPlayed, completed := 0, 0
+	for i := range days {
+		day := &days[i]
+		x, y := calendarCellAt(i)
+		if day.Date.Day() == 1 {
+			ebitenutil.DebugPrintAt(screen, day.Date.Format("Jan"), x, calendarY-16)
+		}
+		vector.DrawFilledRect(screen, float32(x), float32(y), calendarCell, calendarCell, calendarShade(day.DayActivity), false)
+		if inRect(aui.hoverX, aui.hoverY, x, y, calendarCell, calendarCell) {
+			hovered = day
+		}
+		if day.Games > 0 {
+			daysPlayed++
+		}
+		completed += day.Wins
+	}
+	for row, label := range []string{"M", "W", "F"} {
+		ebitenutil.DebugPrintAt(screen, label, calendarX-12, calendarY+row*2*calendarStep-5)
+	}
+
+	legendY := calendarY + 7*calendarStep + 6
+	ebitenutil.DebugPrintAt(screen, "Less", 400, legendY-4)
+	for i, shade := range calendarShades {
+		vector.DrawFilledRect(screen, float32(430+i*calendarStep), float32(legendY), calendarCell, calendarCell, shade, false)
+	}
+	ebitenutil.DebugPrintAt(screen, "More", 470, legendY-4)
+	if hovered != nil {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s: %d games, %d levels completed", hovered.Date.Format("Mon Jan 2"), hovered.Games, hovered.Wins), 120, legendY-4)
+	}
+
+	stats := aui.achievementSystem.GetStatistics()
+	left := []string{
+		fmt.Sprintf("Days played: %d", daysPlayed),
+		fmt.Sprintf("Current streak: %d days", currentStreak(days)),
+		fmt.Sprintf("Longest streak: %d days", aui.achievementSystem.LongestStreak()),
+		fmt.Sprintf("Levels completed: %d", completed),
+	}
+	right := []string{
+		fmt.Sprintf("Games played: %d", stats.GamesPlayed),
+		fmt.Sprintf("Games won: %d", stats.GamesWon),
+		fmt.Sprintf("Bridges built: %d", stats.BridgesBuilt),
+		fmt.Sprintf("Perfect games: %d", stats.PerfectGames),
+	}
+	if stats.BestTime > 0 {
+		right = append(right, fmt.Sprintf("Best time: %s", stats.BestTime.Round(time.Second)))
+	}
+	for i, line := range left {
+		ebitenutil.DebugPrintAt(screen, line, 120, statsLinesY+i*statsLineHeight)
+	}
+	for i, line := range right {
+		ebitenutil.DebugPrintAt(screen, line, 330, statsLinesY+i*statsLineHeight)
+	}
+	aui.drawRatingChart(screen)
+}
+
+// currentStreak counts the days played in a row up to today, or up to
+// yesterday when today has not been played yet
+func currentStreak(days []achievements.CalendarDay) int {
+	end := len(days) - 1
+	if end >= 0 && days[end].Games == 0 {
+		end--
+	}
+	streak := 0
+	for i := end; i >= 0 && days[i].Games > 0; i-- {
+		streak++
+	}
+	return streak
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/storage"
+)
+
+// The race rating chart sits under the statistics, one point per rated race
+// from the oldest on the left
+const (
+	ratingChartX      = 120
+	ratingChartY      = 324
+	ratingChartWidth  = 360
+	ratingChartHeight = 84
+	ratingChartMinGap = 50 // Smallest rating range the chart spans, so small changes stay small
+)
+
+// SetRatings gives the stats view the race rating history to chart
+func (aui *AchievementsUI) SetRatings(entries []storage.RatingEntry) {
+	aui.ratings = entries
+}
+
+// ratingPoint places a race on the chart
+func ratingPoint(index, count, rating, low, high int) (x, y float32) {
+	x = ratingChartX + ratingChartWidth/2
+	if count > 1 {
+		x = ratingChartX + float32(index)*ratingChartWidth/float32(count-1)
+	}
+	y = ratingChartY + ratingChartHeight - float32(rating-low)*ratingChartHeight/float32(high-low)
+	return x, y
+}
+
+// drawRatingChart charts the rating after each race, wins in green and losses
+// in red; hovering a race shows how it went
+func (aui *AchievementsUI) drawRatingChart(screen *ebiten.Image) {
+	entries := aui.ratings
+	if len(entries) == 0 {
+		ebitenutil.DebugPrintAt(screen, "Race rating: race the AI in Time Attack to get one", ratingChartX, ratingChartY-20)
+		return
+	}
+
+	low, high, best := entries[0].Rating, entries[0].Rating, entries[0].Rating
+	for _, entry := range entries {
+		low, high, best = min(low, entry.Rating), max(high, entry.Rating), max(best, entry.Rating)
+	}
+	if gap := ratingChartMinGap - (high - low); gap > 0 {
+		low -= gap / 2
+		high += gap - gap/2
+	}
+
+	current := entries[len(entries)-1].Rating
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Race rating: %d (best %d)", current, best), ratingChartX, ratingChartY-20)
+	vector.DrawFilledRect(screen, ratingChartX, ratingChartY, ratingChartWidth, ratingChartHeight, color.RGBA{235, 235, 240, 255}, false)
+	vector.StrokeRect(screen, ratingChartX, ratingChartY, ratingChartWidth, ratingChartHeight, 1, color.RGBA{180, 180, 190, 255}, false)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprint(high), ratingChartX+ratingChartWidth+6, ratingChartY-6)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprint(low), ratingChartX+ratingChartWidth+6, ratingChartY+ratingChartHeight-10)
+
+	lineColor := color.RGBA{70, 110, 180, 255}
+	var hovered *storage.RatingEntry
+	for i := range entries {
+		x, y := ratingPoint(i, len(entries), entries[i].Rating, low, high)
+		if i > 0 {
+			px, py := ratingPoint(i-1, len(entries), entries[i-1].Rating, low, high)
+			vector.StrokeLine(screen, px, py, x, y, 1.5, lineColor, true)
+		}
+		dot := color.RGBA{210, 70, 70, 255}
+		if entries[i].Won {
+			dot = color.RGBA{60, 160, 70, 255}
+		}
+		vector.DrawFilledCircle(screen, x, y, 2.5, dot, true)
+		if inRect(aui.hoverX, aui.hoverY, int(x)-4, int(y)-4, 8, 8) {
+			hovered = &entries[i]
+		}
+	}
+
+	if hovered != nil {
+		result := "Lost to"
+		if hovered.Won {
+			result = "Beat"
+		}
+		text := fmt.Sprintf("%s %s (%d), %s: %d (%+d)", result, hovered.Opponent, hovered.OpponentRating,
+			hovered.At.Format("Jan 2"), hovered.Rating, hovered.Change)
+		ebitenutil.DebugPrintAt(screen, text, ratingChartX, ratingChartY+ratingChartHeight+2)
+	}
+}
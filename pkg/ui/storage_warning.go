@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	storageWarningX, storageWarningY          = 100, 436
+	storageWarningWidth, storageWarningHeight = 440, 36
+	storageWarningButtonWidth                 = 84
+	storageWarningButtonHeight                = 24
+)
+
+// StorageWarning is a banner along the bottom of the screen saying that saved
+// data no longer fits in storage. Unlike dialogs it does not block the game;
+// it takes only clicks on itself.
+type StorageWarning struct {
+	OnFreeSpace func()
+
+	open           bool
+	hoverX, hoverY int
+}
+
+// NewStorageWarning creates a hidden storage warning
+func NewStorageWarning() *StorageWarning {
+	return &StorageWarning{}
+}
+
+func (sw *StorageWarning) Show() {
+	sw.open = true
+}
+
+func (sw *StorageWarning) Hide() {
+	sw.open = false
+}
+
+func (sw *StorageWarning) IsOpen() bool {
+	return sw.open
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (sw *StorageWarning) UpdateHover(x, y int) {
+	sw.hoverX, sw.hoverY = x, y
+}
+
+func (sw *StorageWarning) freeSpaceX() int {
+	return storageWarningX + storageWarningWidth - storageWarningButtonWidth - 40
+}
+
+func (sw *StorageWarning) closeX() int {
+	return storageWarningX + storageWarningWidth - 30
+}
+
+func (sw *StorageWarning) buttonY() int {
+	return storageWarningY + (storageWarningHeight-storageWarningButtonHeight)/2
+}
+
+func (sw *StorageWarning) HandleClick(x, y int) bool {
+	if !sw.open || !inRect(x, y, storageWarningX, storageWarningY, storageWarningWidth, storageWarningHeight) {
+		return false
+	}
+
+	if inRect(x, y, sw.freeSpaceX(), sw.buttonY(), storageWarningButtonWidth, storageWarningButtonHeight) {
+		sw.open = false
+		if sw.OnFreeSpace != nil {
+			sw.OnFreeSpace()
+		}
+	} else if inRect(x, y, sw.closeX(), sw.buttonY(), 20, storageWarningButtonHeight) {
+		sw.open = false
+	}
+	return true
+}
+
+func (sw *StorageWarning) Draw(screen *ebiten.Image) {
+	if !sw.open {
+		return
+	}
+
+	vector.DrawFilledRect(screen, storageWarningX, storageWarningY, storageWarningWidth, storageWarningHeight, color.RGBA{230, 170, 50, 240}, false)
+	vector.StrokeRect(screen, storageWarningX, storageWarningY, storageWarningWidth, storageWarningHeight, 2, color.RGBA{150, 100, 20, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Storage is full - progress may not be saved", storageWarningX+10, storageWarningY+storageWarningHeight/2-8)
+
+	buttonY := sw.buttonY()
+	bgColor := color.Color(color.RGBA{240, 240, 240, 255})
+	if inRect(sw.hoverX, sw.hoverY, sw.freeSpaceX(), buttonY, storageWarningButtonWidth, storageWarningButtonHeight) {
+		bgColor = brighten(bgColor)
+	}
+	vector.DrawFilledRect(screen, float32(sw.freeSpaceX()), float32(buttonY), storageWarningButtonWidth, storageWarningButtonHeight, bgColor, false)
+	vector.StrokeRect(screen, float32(sw.freeSpaceX()), float32(buttonY), storageWarningButtonWidth, storageWarningButtonHeight, 1, color.RGBA{150, 100, 20, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Free Space", sw.freeSpaceX()+(storageWarningButtonWidth-10*hudCharWidth)/2, buttonY+storageWarningButtonHeight/2-8)
+	ebitenutil.DebugPrintAt(screen, "X", sw.closeX()+7, buttonY+storageWarningButtonHeight/2-8)
+}
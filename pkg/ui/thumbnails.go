@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/assets"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// Thumbnails draws boards as miniatures on offscreen images and keeps them, so
+// each board is drawn once instead of tile by tile every frame. Images are
+// kept by key and size; a key names one layout, such as a level ID.
+type Thumbnails struct {
+	images map[thumbnailKey]*ebiten.Image
+	theme  assets.Theme
+}
+
+type thumbnailKey struct {
+	name string
+	size int
+}
+
+// LevelThumbnailKey names an installed level's layout
+func LevelThumbnailKey(levelID string) string {
+	return "level:" + levelID
+}
+
+// NewThumbnails creates an empty cache drawing in the given theme
+func NewThumbnails(theme assets.Theme) *Thumbnails {
+	return &Thumbnails{
+		images: make(map[thumbnailKey]*ebiten.Image),
+		theme:  theme,
+	}
+}
+
+// SetTheme recolours the thumbnails, redrawing each the next time it is shown
+func (t *Thumbnails) SetTheme(theme assets.Theme) {
+	t.theme = theme
+	t.Clear()
+}
+
+// Forget drops the images kept for a key, e.g. after its layout changed
+func (t *Thumbnails) Forget(key string) {
+	for cached, img := range t.images {
+		if cached.name == key {
+			img.Deallocate()
+			delete(t.images, cached)
+		}
+	}
+}
+
+// Clear drops every kept image
+func (t *Thumbnails) Clear() {
+	for _, img := range t.images {
+		img.Deallocate()
+	}
+	t.images = make(map[thumbnailKey]*ebiten.Image)
+}
+
+// Draw puts the miniature of a board at x, y in a square of the given size.
+// board is only called when nothing is kept for the key yet.
+func (t *Thumbnails) Draw(screen *ebiten.Image, key string, board func() *island.Board, x, y, size int) {
+	cached := thumbnailKey{name: key, size: size}
+	img, ok := t.images[cached]
+	if !ok {
+		img = ebiten.NewImage(size, size)
+		drawThumbnail(img, board(), size, t.theme)
+		t.images[cached] = img
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(img, op)
+}
+
+// drawThumbnail draws a board as flat tiles, as large as fits the image and centred in it
+func drawThumbnail(img *ebiten.Image, board *island.Board, size int, theme assets.Theme) {
+	img.Fill(theme.Grid)
+	if board == nil || board.Width == 0 || board.Height == 0 {
+		return
+	}
+
+	tileSize := float32(size) / float32(max(board.Width, board.Height))
+	left := (float32(size) - tileSize*float32(board.Width)) / 2
+	top := (float32(size) - tileSize*float32(board.Height)) / 2
+	colors := map[island.TileType]color.Color{
+		island.TileSea:    theme.Sea,
+		island.TileLand:   theme.Land,
+		island.TileBridge: theme.Bridge,
+	}
+	for y := 0; y < board.Height; y++ {
+		for x := 0; x < board.Width; x++ {
+			col, ok := colors[board.GetTile(x, y).Type]
+			if !ok {
+				continue
+			}
+			vector.DrawFilledRect(img, left+float32(x)*tileSize, top+float32(y)*tileSize, tileSize, tileSize, col, false)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const tooltipDelay = time.Millisecond * 500
+
+// TooltipRegion is a hoverable screen area with explanatory text
+type TooltipRegion struct {
+	X, Y          int
+	Width, Height int
+	Text          string
+}
+
+func (r TooltipRegion) Contains(x, y int) bool {
+	return x >= r.X && x <= r.X+r.Width && y >= r.Y && y <= r.Y+r.Height
+}
+
+// Tooltip shows the text of the hovered region after the mouse rests on it briefly.
+// Any UI can offer tooltips by returning regions for the caller to feed into Update.
+type Tooltip struct {
+	text       string
+	mouseX     int
+	mouseY     int
+	hoverStart time.Time
+}
+
+func NewTooltip() *Tooltip {
+	return &Tooltip{}
+}
+
+// Update tracks the region under the mouse; pass nil regions to hide the tooltip
+func (t *Tooltip) Update(mouseX, mouseY int, regions []TooltipRegion) {
+	text := ""
+	for _, region := range regions {
+		if region.Contains(mouseX, mouseY) {
+			text = region.Text
+			break
+		}
+	}
+
+	if text != t.text {
+		t.text = text
+		t.hoverStart = time.Now()
+	}
+	t.mouseX, t.mouseY = mouseX, mouseY
+}
+
+func (t *Tooltip) IsVisible() bool {
+	return t.text != "" && time.Since(t.hoverStart) >= tooltipDelay
+}
+
+func (t *Tooltip) Draw(screen *ebiten.Image) {
+	if !t.IsVisible() {
+		return
+	}
+	DrawTooltipBox(screen, t.text, t.mouseX+12, t.mouseY+16)
+}
+
+// DrawTooltipBox draws a text box near (x, y), kept inside the screen bounds.
+// Lines are separated by "\n".
+func DrawTooltipBox(screen *ebiten.Image, text string, x, y int) {
+	lines := strings.Split(text, "\n")
+	longest := 0
+	for _, line := range lines {
+		if len(line) > longest {
+			longest = len(line)
+		}
+	}
+
+	width := longest*6 + 12
+	height := len(lines)*14 + 8
+
+	bounds := screen.Bounds()
+	if x+width > bounds.Dx() {
+		x = bounds.Dx() - width - 2
+	}
+	if y+height > bounds.Dy() {
+		y = bounds.Dy() - height - 2
+	}
+	if x < 0 {
+		x = 0
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), color.RGBA{40, 40, 40, 230}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(width), float32(height), 1, color.RGBA{200, 200, 200, 255}, false)
+
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, x+6, y+4+i*14)
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/island"
+)
+
+// TutorialStep is one scripted instruction. Steps with a target tile wait for the
+// player to build a bridge there; other steps continue on any click.
+type TutorialStep struct {
+	Lines     []string
+	HasTarget bool
+	TargetX   int
+	TargetY   int
+}
+
+type TutorialUI struct {
+	Board      *island.Board
+	Moves      int
+	steps      []TutorialStep
+	current    int
+	active     bool
+	startTime  time.Time
+	OnFinished func()
+}
+
+func NewTutorialUI() *TutorialUI {
+	return &TutorialUI{
+		steps: []TutorialStep{
+			{Lines: []string{
+				"Welcome to Island Merge!",
+				"Your goal is to connect every island with bridges.",
+				"Click anywhere to continue.",
+			}},
+			{Lines: []string{
+				"Bridges are built on sea tiles next to land or other bridges.",
+				"Click the highlighted sea tile to build your first bridge.",
+			}, HasTarget: true, TargetX: 2, TargetY: 1},
+			{Lines: []string{
+				"A bridge joins everything it touches.",
+				"The two top islands are now connected!",
+				"Click anywhere to continue.",
+			}},
+			{Lines: []string{
+				"Every bridge costs one move - watch the Moves counter.",
+				"Finishing in fewer moves earns more stars.",
+				"Click anywhere to continue.",
+			}},
+			{Lines: []string{
+				"Bridges can also extend from other bridges.",
+				"Click the highlighted tile to reach the last island.",
+			}, HasTarget: true, TargetX: 2, TargetY: 2},
+			{Lines: []string{
+				"All islands are connected - that's a win!",
+				"Levels may add objectives such as time limits or move targets.",
+				"Click anywhere to start playing.",
+			}},
+		},
+	}
+}
+
+// Start resets the tutorial board and begins from the first step
+func (tui *TutorialUI) Start() {
+	board := island.NewBoard(5, 5)
+	board.SetupLevel1()
+
+	tui.Board = board
+	tui.Moves = 0
+	tui.current = 0
+	tui.active = true
+	tui.startTime = time.Now()
+}
+
+func (tui *TutorialUI) IsActive() bool {
+	return tui.active
+}
+
+// CurrentTarget returns the tile the player must build on, if the step has one
+func (tui *TutorialUI) CurrentTarget() (int, int, bool) {
+	if !tui.active {
+		return 0, 0, false
+	}
+	step := tui.steps[tui.current]
+	return step.TargetX, step.TargetY, step.HasTarget
+}
+
+// HandleClick processes a click in screen space; gridX/gridY are the board tile under it
+func (tui *TutorialUI) HandleClick(x, y, gridX, gridY int) {
+	if !tui.active {
+		return
+	}
+
+	if tui.isSkipClicked(x, y) {
+		tui.finish()
+		return
+	}
+
+	step := tui.steps[tui.current]
+	if step.HasTarget {
+		if gridX != step.TargetX || gridY != step.TargetY || !tui.Board.CanBuildBridge(gridX, gridY) {
+			return
+		}
+		tui.Board.BuildBridge(gridX, gridY)
+		tui.Moves++
+	}
+
+	tui.advance()
+}
+
+func (tui *TutorialUI) advance() {
+	tui.current++
+	if tui.current >= len(tui.steps) {
+		tui.finish()
+	}
+}
+
+func (tui *TutorialUI) finish() {
+	tui.active = false
+	if tui.OnFinished != nil {
+		tui.OnFinished()
+	}
+}
+
+func (tui *TutorialUI) isSkipClicked(x, y int) bool {
+	return x >= 560 && x <= 620 && y >= 75 && y <= 95
+}
+
+// Draw renders the instruction panel; the board itself is drawn by the render system
+func (tui *TutorialUI) Draw(screen *ebiten.Image) {
+	if !tui.active {
+		return
+	}
+
+	// Leave the top-left HUD stats visible so the move counter can be explained
+	panelX, panelY := 150.0, 10.0
+	panelWidth, panelHeight := 480.0, 95.0
+
+	vector.DrawFilledRect(
+		screen,
+		float32(panelX), float32(panelY),
+		float32(panelWidth), float32(panelHeight),
+		color.RGBA{255, 248, 220, 245},
+		false,
+	)
+
+	vector.StrokeRect(
+		screen,
+		float32(panelX), float32(panelY),
+		float32(panelWidth), float32(panelHeight),
+		2,
+		color.RGBA{200, 170, 0, 255},
+		false,
+	)
+
+	title := fmt.Sprintf("Tutorial %d/%d", tui.current+1, len(tui.steps))
+	ebitenutil.DebugPrintAt(screen, title, int(panelX+10), int(panelY+8))
+
+	for i, line := range tui.steps[tui.current].Lines {
+		ebitenutil.DebugPrintAt(screen, line, int(panelX+10), int(panelY+28)+i*15)
+	}
+
+	// Skip button
+	vector.DrawFilledRect(screen, 560, 75, 60, 20, color.RGBA{200, 200, 200, 255}, false)
+	vector.StrokeRect(screen, 560, 75, 60, 20, 1, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, "Skip", 578, 79)
+}
+
+// HighlightAlpha returns a pulsing alpha for the target tile highlight
+func (tui *TutorialUI) HighlightAlpha() uint8 {
+	elapsed := time.Since(tui.startTime).Seconds()
+	return uint8(120 + 100*math.Sin(elapsed*math.Pi*2))
+}
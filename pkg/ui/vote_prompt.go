@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	voteX, voteY          = 140, 120
+	voteWidth, voteHeight = 360, 220
+	voteThumbWidth        = 100
+	voteThumbHeight       = 30
+	voteThumbY            = voteY + 55
+	voteLevelSize         = 30
+	voteLevelY            = voteY + 125
+	voteButtonY           = voteY + voteHeight - dialogButtonHeight - 15
+)
+
+// difficultyLabels name the difficulty estimates, easiest first
+var difficultyLabels = []string{"1", "2", "3", "4", "5"}
+
+// VotePrompt asks for a thumbs up or down on a finished level and, optionally,
+// how hard it was. It takes every click while open; OnSubmit runs after it
+// closes with the vote, and nothing runs when it is skipped.
+type VotePrompt struct {
+	OnSubmit func(up bool, difficulty int)
+
+	levelName      string
+	vote           int // 1 up, -1 down, 0 not chosen yet
+	difficulty     int // 1 to 5, 0 when not estimated
+	open           bool
+	hoverX, hoverY int
+}
+
+func NewVotePrompt() *VotePrompt {
+	return &VotePrompt{}
+}
+
+// Show opens the prompt for the named level with nothing chosen
+func (vp *VotePrompt) Show(levelName string) {
+	vp.levelName = levelName
+	vp.vote, vp.difficulty = 0, 0
+	vp.open = true
+}
+
+func (vp *VotePrompt) IsOpen() bool {
+	return vp.open
+}
+
+// UpdateHover records the pointer position so buttons can highlight under it
+func (vp *VotePrompt) UpdateHover(x, y int) {
+	vp.hoverX, vp.hoverY = x, y
+}
+
+func (vp *VotePrompt) thumbX(up bool) int {
+	if up {
+		return voteX + voteWidth/2 - voteThumbWidth - 10
+	}
+	return voteX + voteWidth/2 + 10
+}
+
+func (vp *VotePrompt) levelX(index int) int {
+	gap := 10
+	total := len(difficultyLabels)*voteLevelSize + (len(difficultyLabels)-1)*gap
+	return voteX + (voteWidth-total)/2 + index*(voteLevelSize+gap)
+}
+
+func (vp *VotePrompt) submitX() int {
+	return voteX + voteWidth/2 - dialogButtonWidth - 10
+}
+
+func (vp *VotePrompt) skipX() int {
+	return voteX + voteWidth/2 + 10
+}
+
+func (vp *VotePrompt) HandleClick(x, y int) bool {
+	if !vp.open {
+		return false
+	}
+
+	switch {
+	case inRect(x, y, vp.thumbX(true), voteThumbY, voteThumbWidth, voteThumbHeight):
+		vp.vote = 1
+	case inRect(x, y, vp.thumbX(false), voteThumbY, voteThumbWidth, voteThumbHeight):
+		vp.vote = -1
+	case inRect(x, y, vp.submitX(), voteButtonY, dialogButtonWidth, dialogButtonHeight):
+		if vp.vote != 0 {
+			vp.open = false
+			if vp.OnSubmit != nil {
+				vp.OnSubmit(vp.vote > 0, vp.difficulty)
+			}
+		}
+	case inRect(x, y, vp.skipX(), voteButtonY, dialogButtonWidth, dialogButtonHeight):
+		vp.open = false
+	default:
+		for i := range difficultyLabels {
+			if inRect(x, y, vp.levelX(i), voteLevelY, voteLevelSize, voteLevelSize) {
+				// Clicking the chosen estimate again clears it
+				if vp.difficulty == i+1 {
+					vp.difficulty = 0
+				} else {
+					vp.difficulty = i + 1
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (vp *VotePrompt) Draw(screen *ebiten.Image) {
+	if !vp.open {
+		return
+	}
+
+	vector.DrawFilledRect(screen, 0, 0, 640, 480, color.RGBA{0, 0, 0, 128}, false)
+	vector.DrawFilledRect(screen, voteX, voteY, voteWidth, voteHeight, color.RGBA{240, 240, 240, 255}, false)
+	vector.StrokeRect(screen, voteX, voteY, voteWidth, voteHeight, 3, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Did you enjoy %q?", vp.levelName), voteX+20, voteY+15)
+
+	vp.drawButton(screen, vp.thumbX(true), voteThumbY, voteThumbWidth, voteThumbHeight, "Thumbs up", chosenColor(vp.vote > 0, color.RGBA{100, 200, 100, 255}))
+	vp.drawButton(screen, vp.thumbX(false), voteThumbY, voteThumbWidth, voteThumbHeight, "Thumbs down", chosenColor(vp.vote < 0, color.RGBA{220, 110, 100, 255}))
+
+	ebitenutil.DebugPrintAt(screen, "How hard was it? (optional, 1 = easy)", voteX+20, voteLevelY-22)
+	for i, label := range difficultyLabels {
+		vp.drawButton(screen, vp.levelX(i), voteLevelY, voteLevelSize, voteLevelSize, label, chosenColor(vp.difficulty == i+1, color.RGBA{150, 200, 240, 255}))
+	}
+
+	// Submit stays grey until a thumb is chosen
+	vp.drawButton(screen, vp.submitX(), voteButtonY, dialogButtonWidth, dialogButtonHeight, "Submit", chosenColor(vp.vote != 0, color.RGBA{100, 200, 100, 255}))
+	vp.drawButton(screen, vp.skipX(), voteButtonY, dialogButtonWidth, dialogButtonHeight, "Skip", color.RGBA{200, 200, 200, 255})
+}
+
+// chosenColor is c for a chosen button and grey otherwise
+func chosenColor(chosen bool, c color.Color) color.Color {
+	if chosen {
+		return c
+	}
+	return color.RGBA{210, 210, 210, 255}
+}
+
+func (vp *VotePrompt) drawButton(screen *ebiten.Image, x, y, width, height int, label string, bg color.Color) {
+	if inRect(vp.hoverX, vp.hoverY, x, y, width, height) {
+		bg = brighten(bg)
+	}
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), bg, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(width), float32(height), 2, color.RGBA{100, 100, 100, 255}, false)
+	ebitenutil.DebugPrintAt(screen, label, x+(width-len(label)*6)/2, y+height/2-8)
+}
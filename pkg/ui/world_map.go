@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/ponyo877/island-merge/pkg/levels"
+)
+
+// The campaign map fills the level select panel below the tabs and filters
+const (
+	mapX, mapY          = 70, 142
+	mapWidth, mapHeight = 500, 273
+	mapNodeRadius       = 8
+	mapNodeStep         = 24 // Preferred distance between neighbouring levels on an island
+	mapIslandRadius     = 20 // Land drawn around each level node
+)
+
+var (
+	mapSeaColor      = color.RGBA{90, 150, 210, 255}
+	mapSandColor     = color.RGBA{230, 210, 150, 255}
+	mapGrassColor    = color.RGBA{120, 180, 90, 255}
+	mapLockedColor   = color.RGBA{160, 160, 160, 255}
+	mapPathColor     = color.RGBA{250, 240, 210, 255}
+	mapLitPathColor  = color.RGBA{255, 215, 0, 255}
+	mapNodeBorder    = color.RGBA{80, 80, 80, 255}
+	mapCompletedNode = color.RGBA{255, 215, 0, 255}
+	mapOpenNode      = color.RGBA{255, 255, 255, 255}
+)
+
+// mapRegion is a built-in level set drawn as one island of the archipelago
+type mapRegion struct {
+	set   *levels.LevelSet
+	x, y  float32 // Centre of the island
+	nodes []mapNode
+}
+
+// mapNode is a level's stop on the campaign map
+type mapNode struct {
+	level *levels.LevelData
+	x, y  float32
+}
+
+// campaignMap lays the built-in sets out left to right, alternating low and
+// high so the route between them zigzags across the sea. Levels on an island
+// zigzag the same way, in play order.
+func (lsui *LevelSelectUI) campaignMap() []mapRegion {
+	sets := lsui.builtinSets()
+	regions := make([]mapRegion, len(sets))
+	cellWidth := float32(mapWidth) / float32(max(len(sets), 1))
+	for i, set := range sets {
+		region := mapRegion{
+			set: set,
+			x:   mapX + (float32(i)+0.5)*cellWidth,
+			y:   mapY + mapHeight*0.6,
+		}
+		if i%2 == 1 {
+			region.y = mapY + mapHeight*0.4
+		}
+
+		count := len(set.Levels)
+		step := float32(mapNodeStep)
+		if count > 1 {
+			step = float32(math.Min(mapNodeStep, float64(cellWidth-2*mapIslandRadius)/float64(count-1)))
+		}
+		for j, level := range set.Levels {
+			offset := float32(j) - float32(count-1)/2
+			y := region.y
+			if count > 1 {
+				y += float32(10 - 20*(j%2))
+			}
+			region.nodes = append(region.nodes, mapNode{level: level, x: region.x + offset*step, y: y})
+		}
+		regions[i] = region
+	}
+	return regions
+}
+
+// builtinSets returns the level sets of the main campaign, in order
+func (lsui *LevelSelectUI) builtinSets() []*levels.LevelSet {
+	var sets []*levels.LevelSet
+	for _, set := range lsui.levelManager.LevelSets {
+		if set.PackID == "" {
+			sets = append(sets, set)
+		}
+	}
+	return sets
+}
+
+// starTotals returns the stars earned in a set and the most it can give
+func starTotals(set *levels.LevelSet) (earned, possible int) {
+	for _, level := range set.Levels {
+		if level.Completed && level.BestScore != nil {
+			earned += level.BestScore.Stars
+		}
+	}
+	return earned, 3 * len(set.Levels)
+}
+
+// campaignStars totals the stars of every built-in set
+func (lsui *LevelSelectUI) campaignStars() (earned, possible int) {
+	for _, set := range lsui.builtinSets() {
+		setEarned, setPossible := starTotals(set)
+		earned, possible = earned+setEarned, possible+setPossible
+	}
+	return earned, possible
+}
+
+// nodeAt returns the level under a point of the map
+func (lsui *LevelSelectUI) nodeAt(x, y int) *levels.LevelData {
+	for _, region := range lsui.campaignMap() {
+		for _, node := range region.nodes {
+			dx, dy := float64(x)-float64(node.x), float64(y)-float64(node.y)
+			if math.Hypot(dx, dy) <= mapNodeRadius+3 {
+				return node.level
+			}
+		}
+	}
+	return nil
+}
+
+func (lsui *LevelSelectUI) handleMapClick(x, y int) {
+	level := lsui.nodeAt(x, y)
+	if level != nil && level.Unlocked && lsui.OnLevelSelected != nil {
+		lsui.OnLevelSelected(level)
+		lsui.Hide()
+	}
+}
+
+// mapTooltipRegions describes each island and level node for hover tooltips
+func (lsui *LevelSelectUI) mapTooltipRegions() []TooltipRegion {
+	var regions []TooltipRegion
+	for _, region := range lsui.campaignMap() {
+		earned, possible := starTotals(region.set)
+		text := fmt.Sprintf("%s\n%s\nStars: %d/%d", region.set.Name, region.set.Description, earned, possible)
+		if !lsui.isDifficultyUnlocked(region.set) {
+			text += fmt.Sprintf("\nComplete %d levels to unlock", region.set.UnlockLevel)
+		}
+		label := difficultyNames[region.set.Difficulty]
+		regions = append(regions, TooltipRegion{
+			X: int(region.x) - len(label)*3, Y: int(region.y) - 52, Width: len(label) * 6, Height: 14, Text: text,
+		})
+		for _, node := range region.nodes {
+			regions = append(regions, TooltipRegion{
+				X: int(node.x) - mapNodeRadius, Y: int(node.y) - mapNodeRadius,
+				Width: 2 * mapNodeRadius, Height: 2 * mapNodeRadius, Text: levelTooltip(node.level),
+			})
+		}
+	}
+	return regions
+}
+
+func (lsui *LevelSelectUI) drawCampaignMap(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, mapX, mapY, mapWidth, mapHeight, mapSeaColor, false)
+	vector.StrokeRect(screen, mapX, mapY, mapWidth, mapHeight, 1, color.RGBA{100, 100, 100, 255}, false)
+
+	regions := lsui.campaignMap()
+	for _, region := range regions {
+		lsui.drawMapIsland(screen, region)
+	}
+
+	// The route runs through every level in play order; the leg out of a
+	// completed level lights up
+	var prev *mapNode
+	for _, region := range regions {
+		for i := range region.nodes {
+			node := &region.nodes[i]
+			if prev != nil {
+				pathColor := mapPathColor
+				if prev.level.Completed {
+					pathColor = mapLitPathColor
+				}
+				// Legs between islands cross the sea as a dashed line
+				drawMapPath(screen, prev.x, prev.y, node.x, node.y, pathColor, i == 0)
+			}
+			prev = node
+		}
+	}
+
+	for _, region := range regions {
+		for _, node := range region.nodes {
+			lsui.drawMapNode(screen, node)
+		}
+		lsui.drawRegionLabel(screen, region)
+	}
+
+	earned, possible := lsui.campaignStars()
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Campaign stars: %d/%d", earned, possible), mapX, mapY+mapHeight+10)
+}
+
+// drawMapIsland draws land around each of a region's levels, sand under grass
+func (lsui *LevelSelectUI) drawMapIsland(screen *ebiten.Image, region mapRegion) {
+	sand, grass := color.Color(mapSandColor), color.Color(mapGrassColor)
+	if !lsui.isDifficultyUnlocked(region.set) {
+		sand, grass = mapLockedColor, color.RGBA{130, 130, 130, 255}
+	}
+	for _, node := range region.nodes {
+		vector.DrawFilledCircle(screen, node.x, node.y, mapIslandRadius, sand, true)
+	}
+	for _, node := range region.nodes {
+		vector.DrawFilledCircle(screen, node.x, node.y, mapIslandRadius-5, grass, true)
+	}
+}
+
+func drawMapPath(screen *ebiten.Image, x1, y1, x2, y2 float32, c color.Color, dashed bool) {
+	if !dashed {
+		vector.StrokeLine(screen, x1, y1, x2, y2, 3, c, true)
+		return
+	}
+	length := math.Hypot(float64(x2-x1), float64(y2-y1))
+	dash := 6.0
+	for start := 0.0; start < length; start += 2 * dash {
+		end := math.Min(start+dash, length)
+		t1, t2 := float32(start/length), float32(end/length)
+		vector.StrokeLine(screen, x1+(x2-x1)*t1, y1+(y2-y1)*t1, x1+(x2-x1)*t2, y1+(y2-y1)*t2, 2, c, true)
+	}
+}
+
+func (lsui *LevelSelectUI) drawMapNode(screen *ebiten.Image, node mapNode) {
+	fill := color.Color(mapLockedColor)
+	if node.level.Completed {
+		fill = mapCompletedNode
+	} else if node.level.Unlocked {
+		fill = mapOpenNode
+	}
+	border, borderWidth := color.Color(mapNodeBorder), float32(1.5)
+	hovered := math.Hypot(float64(lsui.hoverX)-float64(node.x), float64(lsui.hoverY)-float64(node.y)) <= mapNodeRadius+3
+	if hovered && node.level.Unlocked {
+		border, borderWidth = color.RGBA{100, 100, 250, 255}, 3
+	}
+	vector.DrawFilledCircle(screen, node.x, node.y, mapNodeRadius, fill, true)
+	vector.StrokeCircle(screen, node.x, node.y, mapNodeRadius, borderWidth, border, true)
+}
+
+// drawRegionLabel names a region above its island and totals its stars below
+func (lsui *LevelSelectUI) drawRegionLabel(screen *ebiten.Image, region mapRegion) {
+	label := difficultyNames[region.set.Difficulty]
+	ebitenutil.DebugPrintAt(screen, label, int(region.x)-len(label)*3, int(region.y)-52)
+
+	stars := "locked"
+	if lsui.isDifficultyUnlocked(region.set) {
+		earned, possible := starTotals(region.set)
+		stars = fmt.Sprintf("%d/%d stars", earned, possible)
+	}
+	ebitenutil.DebugPrintAt(screen, stars, int(region.x)-len(stars)*3, int(region.y)+36)
+}
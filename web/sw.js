@@ -0,0 +1,42 @@
+// Service worker that lets the installed game start without a connection.
+// Requests go to the network first so a new build is picked up as soon as it
+// is deployed; the cached copy is only used when the network fails.
+const CACHE = "island-merge";
+
+// Everything the game needs to start; levels are compiled into game.wasm
+const PRECACHE = [
+    "./",
+    "index.html",
+    "manifest.webmanifest",
+    "wasm_exec.js",
+    "wasm/game.wasm",
+    "icons/icon-192.png",
+    "icons/icon-512.png",
+];
+
+self.addEventListener("install", (event) => {
+    event.waitUntil(caches.open(CACHE).then((cache) => cache.addAll(PRECACHE)));
+    self.skipWaiting();
+});
+
+self.addEventListener("activate", (event) => {
+    event.waitUntil(self.clients.claim());
+});
+
+self.addEventListener("fetch", (event) => {
+    const request = event.request;
+    // Only the game's own files are cached; the level of the week and analytics
+    // uploads go straight to the network
+    if (request.method !== "GET" || new URL(request.url).origin !== self.location.origin) {
+        return;
+    }
+    event.respondWith(
+        fetch(request).then((response) => {
+            if (response.ok) {
+                const copy = response.clone();
+                caches.open(CACHE).then((cache) => cache.put(request, copy));
+            }
+            return response;
+        }).catch(() => caches.match(request, { ignoreSearch: true }))
+    );
+});